@@ -5,26 +5,51 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"database/sql"
 	"errors"
+	"flag"
 	"fmt"
-	"image/color"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
-	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 
 	csstatstracker "csstatstracker"
+	"csstatstracker/internal/bugreport"
 	"csstatstracker/internal/config"
 	"csstatstracker/internal/database"
+	"csstatstracker/internal/faceit"
+	"csstatstracker/internal/gameprocess"
+	"csstatstracker/internal/gsi"
+	"csstatstracker/internal/hooks"
+	"csstatstracker/internal/hotkey"
+	"csstatstracker/internal/options"
+	"csstatstracker/internal/plugins"
+	"csstatstracker/internal/retention"
 	"csstatstracker/internal/singleinstance"
+	"csstatstracker/internal/snapshot"
+	"csstatstracker/internal/summary"
+	"csstatstracker/internal/toast"
 	"csstatstracker/internal/tracker"
+	"csstatstracker/internal/trayicon"
 	"csstatstracker/internal/ui"
+	"csstatstracker/internal/voice"
+	"csstatstracker/internal/webhooks"
 )
 
 // singleInstancePort is a fixed loopback port used as a cross-platform mutex.
@@ -44,14 +69,33 @@ func (f *logFilter) Write(p []byte) (int, error) {
 	return f.inner.Write(p)
 }
 
+// recentLog keeps the end of the log for bug reports.
+var recentLog = bugreport.NewLog(1000)
+
 func init() {
-	log.SetOutput(&logFilter{inner: os.Stderr})
+	log.SetOutput(&logFilter{inner: io.MultiWriter(os.Stderr, recentLog)})
 }
 
 func main() {
+	opts, err := options.Resolve(os.Args[1:], os.Getenv, os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
 	lock, err := singleinstance.Acquire(singleInstancePort)
 	if err != nil {
 		if errors.Is(err, singleinstance.ErrAlreadyRunning) {
+			// Launched by a notification button: pass its URL on to the
+			// running instance instead of complaining.
+			if opts.URL != "" {
+				if err := singleinstance.Send(singleInstancePort, opts.URL); err == nil {
+					os.Exit(0)
+				}
+			}
 			// Show a GUI dialog because the binary uses -H=windowsgui and has
 			// no console — a silent exit would leave the user wondering why.
 			notifyAlreadyRunning()
@@ -61,178 +105,431 @@ func main() {
 	}
 	defer lock.Release()
 
-	ctx := context.Background()
+	// ctx is cancelled when the app stops, abandoning any database work
+	// still in flight.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// Load configuration
-	cfg, err := config.Load(config.DefaultConfigFile)
+	cfg, err := config.Load(opts.ConfigPath)
 	if err != nil {
 		panic(fmt.Errorf("failed to load config: %w", err))
 	}
 
-	db, err := database.Init(ctx, database.DefaultDBFile, csstatstracker.MigrationsFS)
+	cfgProblems, err := config.Validate(opts.ConfigPath, hotkey.IsKnownKey)
 	if err != nil {
-		panic(fmt.Errorf("failed to initialize database: %w", err))
+		log.Printf("config validation skipped: %v", err)
+	}
+	for _, p := range cfgProblems {
+		log.Printf("config: %s", p)
 	}
-	defer func() { _ = db.Close() }()
 
-	a := app.New()
-	w := a.NewWindow("CS Stats Tracker")
+	// All config writes go through the manager so they're serialised,
+	// debounced and atomic.
+	cfgManager := config.NewManager(opts.ConfigPath, cfg, func(err error) {
+		fyne.LogError("Failed to save config", err)
+	})
+	defer func() {
+		if err := cfgManager.Flush(); err != nil {
+			fyne.LogError("Failed to save config", err)
+		}
+	}()
+
+	// A throwaway database for demos, screenshots and UI tests, in a
+	// directory of its own so the WAL files go with it.
+	if opts.TempDB {
+		dir, err := os.MkdirTemp("", "csstatstracker-")
+		if err != nil {
+			panic(fmt.Errorf("failed to create temporary database: %w", err))
+		}
+		defer func() { _ = os.RemoveAll(dir) }()
+		opts.DBPath = filepath.Join(dir, filepath.Base(database.DefaultDBFile))
+	}
 
-	// Create counter labels
-	ctLabel := canvas.NewText("0", color.RGBA{R: 100, G: 149, B: 237, A: 255})
-	ctLabel.TextSize = 72
-	ctLabel.Alignment = fyne.TextAlignCenter
+	// Viewing a database another PC records to: none of the recording
+	// machinery below.
+	if opts.ReadOnly {
+		if err := runReadOnly(ctx, opts, cfg, cfgManager.Save); err != nil {
+			panic(err)
+		}
+		return
+	}
 
-	tLabel := canvas.NewText("0", color.RGBA{R: 255, G: 140, B: 0, A: 255})
-	tLabel.TextSize = 72
-	tLabel.Alignment = fyne.TextAlignCenter
+	a := app.New()
+	w := a.NewWindow("CS Stats Tracker")
+	if opts.TempDB {
+		w.SetTitle("CS Stats Tracker (temporary database)")
+	}
+	a.Lifecycle().SetOnStopped(cancel)
 
-	t := tracker.New(db, w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	// Schema upgrades back the database up first and run behind a splash,
+	// so the tracker is only set up once they're done. A failed upgrade puts
+	// the backup back, and the app shows why and quits.
+	upgrade, err := database.CheckUpgrade(ctx, opts.DBPath, csstatstracker.MigrationsFS)
+	if err != nil {
+		panic(fmt.Errorf("failed to initialize database: %w", err))
+	}
+	var stop func()
+	defer func() {
+		if stop != nil {
+			stop()
+		}
+	}()
+	start := func(db *sql.DB) {
+		stop = run(ctx, a, w, lock, opts, cfg, cfgManager, cfgProblems, db)
+		if !opts.Headless {
+			// Otherwise tray only; the window can be opened from the tray
+			// menu.
+			w.Show()
+		}
+	}
+	if !upgrade.Pending() {
+		db, err := database.Init(ctx, opts.DBPath, csstatstracker.MigrationsFS)
+		if err != nil {
+			panic(fmt.Errorf("failed to initialize database: %w", err))
+		}
+		start(db)
+	} else {
+		splash := ui.ShowUpgradeSplash(a, upgrade)
+		go func() {
+			db, err := database.InitWithBackup(ctx, opts.DBPath, csstatstracker.MigrationsFS)
+			fyne.Do(func() {
+				splash.Close()
+				if err != nil {
+					failed := dialog.NewError(fmt.Errorf("failed to upgrade database: %w", err), w)
+					failed.SetOnClosed(a.Quit)
+					w.Resize(fyne.Size{Width: 600, Height: 450})
+					w.Show()
+					failed.Show()
+					return
+				}
+				start(db)
+			})
+		}()
+	}
+	a.Run()
+}
 
-	// Create CT side (left)
-	ctTitle := canvas.NewText("CT", color.RGBA{R: 100, G: 149, B: 237, A: 255})
-	ctTitle.TextSize = 32
-	ctTitle.Alignment = fyne.TextAlignCenter
+// run sets up the tracker on db, which is closed by the returned stop
+// function along with everything else run started.
+func run(ctx context.Context, a fyne.App, w fyne.Window, lock *singleinstance.Lock, opts *options.Options,
+	cfg *config.Config, cfgManager *config.Manager, cfgProblems []config.Problem, db *sql.DB) (stop func()) {
+	cleanups := []func(){func() { _ = db.Close() }}
+	stop = func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
 
-	ctPlusButton := widget.NewButton("+", func() {
-		t.IncrementCT()
-	})
-	ctPlusButton.Importance = widget.HighImportance
+	ctLabel, tLabel := ui.NewCounterLabels()
+	t := tracker.New(ctx, db, w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
 
-	ctMinusButton := widget.NewButton("-", func() {
-		t.DecrementCT()
+	// Recent-form sparkline; clicking it jumps to the History tab, which is
+	// wired up once the tabs exist.
+	var showHistory func()
+	sparkline := ui.NewSparkline(ctx, db, func() {
+		if showHistory != nil {
+			showHistory()
+		}
 	})
-	ctMinusButton.Importance = widget.WarningImportance
+	goalRing := ui.NewGoalRing(ctx, db, cfg)
+	// The tray icon shows the active match's score, see refreshTray below.
+	var refreshTray func()
+	onRoundsChange := func() {
+		sparkline.Reload()
+		goalRing.Reload()
+		if refreshTray != nil {
+			refreshTray()
+		}
+	}
+	t.SetOnRoundsChange(onRoundsChange)
+
+	// Each match gets its own tab with independent counters and team, so a
+	// caster can follow two games at once. Hotkeys drive the selected one.
+	matchViews := map[*container.TabItem]*ui.TrackerView{}
+	matchCount := 0
+	var matchTabs *container.DocTabs
+	// The Tracker tab shrinks to just the counters when the window is small.
+	var trackerMini *ui.MiniMode
+	newMatchTab := func(mt *tracker.Tracker, ct, tt *canvas.Text) *container.TabItem {
+		matchCount++
+		view := ui.NewTrackerView(mt, w, ct, tt)
+		if trackerMini != nil {
+			view.SetMini(trackerMini.Mini())
+		}
+		item := container.NewTabItem(fmt.Sprintf("Match %d", matchCount), view.Container())
+		// Renamed sides make a more useful tab title than the match number.
+		view.OnRenamed = func(ctName, tName string) {
+			item.Text = ctName + " vs " + tName
+			matchTabs.Refresh()
+		}
+		matchViews[item] = view
+		return item
+	}
 
-	ctButtonsContainer := container.NewGridWithColumns(2,
-		ctPlusButton,
-		ctMinusButton,
-	)
+	matchTabs = container.NewDocTabs(newMatchTab(t, ctLabel, tLabel))
+	matchTabs.CreateTab = func() *container.TabItem {
+		ct, tt := ui.NewCounterLabels()
+		sibling := t.NewSibling(ct, tt)
+		sibling.SetOnRoundsChange(onRoundsChange)
+		return newMatchTab(sibling, ct, tt)
+	}
+	matchTabs.OnSelected = func(item *container.TabItem) {
+		if view, ok := matchViews[item]; ok {
+			view.Tracker().Activate()
+			if refreshTray != nil {
+				refreshTray()
+			}
+		}
+	}
+	matchTabs.CloseIntercept = func(item *container.TabItem) {
+		if len(matchTabs.Items) <= 1 {
+			return // always keep one match to track
+		}
+		matchTabs.Remove(item)
+		delete(matchViews, item)
+		if view, ok := matchViews[matchTabs.Selected()]; ok {
+			view.Tracker().Activate()
+			if refreshTray != nil {
+				refreshTray()
+			}
+		}
+	}
 
-	ctContainer := container.NewBorder(
-		ctTitle,
-		ctButtonsContainer,
+	// Tracker tab content. Stats is created below, so recording a rank
+	// refreshes it through this closure.
+	var onRankRecorded func()
+	recordRankBtn := widget.NewButton("Record Rank...", func() {
+		ui.ShowRecordRankDialog(ctx, db, w, cfg, onRankRecorded)
+	})
+	trackerBar := container.NewBorder(nil, nil, goalRing, recordRankBtn, container.NewCenter(sparkline))
+	trackerMini = ui.NewMiniMode(container.NewBorder(
 		nil,
+		trackerBar,
 		nil,
-		container.NewCenter(ctLabel),
-	)
-
-	// Create T side (right)
-	tTitle := canvas.NewText("T", color.RGBA{R: 255, G: 140, B: 0, A: 255})
-	tTitle.TextSize = 32
-	tTitle.Alignment = fyne.TextAlignCenter
-
-	tPlusButton := widget.NewButton("+", func() {
-		t.IncrementT()
+		nil,
+		matchTabs,
+	), func(mini bool) {
+		if mini {
+			trackerBar.Hide()
+		} else {
+			trackerBar.Show()
+		}
+		for _, view := range matchViews {
+			view.SetMini(mini)
+		}
 	})
-	tPlusButton.Importance = widget.HighImportance
 
-	tMinusButton := widget.NewButton("-", func() {
-		t.DecrementT()
+	// Create history tab
+	statsTab := ui.NewStatsTab(ctx, db, w, cfg, snapshot.KeyPath(opts.ConfigPath), cfgManager.Save)
+	onRankRecorded = statsTab.Refresh
+	helpTab := ui.NewHelpTab(cfg)
+	historyTab := ui.NewHistoryTab(ctx, db, w, cfg, func() {
+		statsTab.Refresh()
+		sparkline.Reload()
+		goalRing.Reload()
+	})
+	statsTab.SetOnRoundsEdited(func() {
+		historyTab.Refresh()
+		sparkline.Reload()
+		goalRing.Reload()
 	})
-	tMinusButton.Importance = widget.WarningImportance
 
-	tButtonsContainer := container.NewGridWithColumns(2,
-		tPlusButton,
-		tMinusButton,
-	)
+	// The game state listener is restarted whenever its settings change.
+	var gsiServer *gsi.Server
+	var gsiSettings config.GSI
+	restartGSI := func() {
+		if gsiServer != nil {
+			_ = gsiServer.Close()
+			gsiServer = nil
+		}
+		gsiSettings = cfg.GSI
+		if !cfg.GSI.Enabled {
+			return
+		}
+		server := gsi.NewServer(cfg.GSI.Port, cfg.GSI.Token, t.HandleGSI)
+		if err := server.Start(); err != nil {
+			fyne.LogError("Game state integration disabled", err)
+			return
+		}
+		gsiServer = server
+	}
+	cleanups = append(cleanups, func() {
+		if gsiServer != nil {
+			_ = gsiServer.Close()
+		}
+	})
 
-	tContainer := container.NewBorder(
-		tTitle,
-		tButtonsContainer,
-		nil,
-		nil,
-		container.NewCenter(tLabel),
-	)
+	// Enabled plugins get every tracker event; they're started and stopped
+	// as they're switched on and off in Settings. Webhooks get them through a
+	// queue in the database so they're retried while the receiver is down.
+	pluginManager := plugins.NewManager(plugins.DirPath(opts.ConfigPath))
+	cleanups = append(cleanups, pluginManager.Stop)
+	webhookDispatcher := webhooks.NewDispatcher(ctx, db, cfg)
+	go webhookDispatcher.Run()
+	t.SetOnEvent(func(data hooks.Data) {
+		pluginManager.Send(data)
+		webhookDispatcher.Enqueue(data)
+	})
 
-	// Create side-by-side layout
-	countersContainer := container.NewGridWithColumns(2,
-		ctContainer,
-		tContainer,
-	)
+	// Duck the sound effects while a voice app has the microphone.
+	var ducking atomic.Bool
+	go voice.Watch(ctx, ducking.Load, t.Sound().SetDucked)
 
-	// Create team selection
-	teamSelect := widget.NewSelect([]string{"None", "CT", "T"}, func(selected string) {
-		if selected == "None" {
-			t.SetTeam(database.TeamNone)
-		} else {
-			t.SetTeam(database.Team(selected))
+	// applyConfig pushes the current config into the running components.
+	applyConfig := func() {
+		t.UpdateHotkeys()
+		t.UpdateBreakLimit()
+		t.UpdateSoundTiming()
+		t.Sound().SetEnabled(cfg.SoundEnabled && !opts.NoSound)
+		t.Sound().SetVolume(cfg.SoundVolume)
+		t.Sound().SetDuckVolume(cfg.Ducking.Volume)
+		t.Sound().SetStingerVolume(cfg.Stingers.Volume)
+		ducking.Store(cfg.Ducking.Enabled)
+		t.SetAccount(cfg.ActiveAccount)
+		for _, view := range matchViews {
+			view.ApplySideColors()
+			view.ApplySideLogos()
+			view.ApplyTouchMode()
 		}
-	})
-	teamSelect.SetSelected("None")
-
-	// Wire up hotkey team selection to update UI
-	t.SetOnTeamChange(func(team database.Team) {
-		switch team {
-		case database.TeamCT:
-			teamSelect.SetSelected("CT")
-		case database.TeamT:
-			teamSelect.SetSelected("T")
-		default:
-			teamSelect.SetSelected("None")
+		if refreshTray != nil {
+			refreshTray()
+		}
+		if ui.SetColorVision(cfg.ColorVision) {
+			statsTab.Refresh()
+			sparkline.Refresh()
+			historyTab.Refresh()
+		}
+		if cfg.GSI != gsiSettings {
+			restartGSI()
 		}
+		pluginManager.Sync(cfg.Plugins)
+		helpTab.Refresh()
+		goalRing.Reload()
+	}
+	applyConfig()
+
+	// Create settings tab
+	settingsTab := ui.NewSettingsTab(t.Config, w, func(*config.Config) {
+		cfgManager.Save()
+		applyConfig()
+	})
+	settingsTab.SetPlugins(pluginManager)
+	refreshRounds := func() {
+		statsTab.Refresh()
+		historyTab.Refresh()
+		sparkline.Reload()
+		goalRing.Reload()
+	}
+	settingsTab.SetDatabase(ctx, db, refreshRounds)
+	settingsTab.SetBugReport(func(w io.Writer) error {
+		ctx, cancel := database.WithTimeout(ctx)
+		defer cancel()
+		return bugreport.Write(ctx, w, db, cfg, recentLog.Bytes())
 	})
 
-	// Team selector row.
-	teamRow := container.NewHBox(
-		layout.NewSpacer(),
-		widget.NewLabel("Team:"),
-		teamSelect,
-		layout.NewSpacer(),
-	)
+	// Prune round detail past the retention period, archiving it first.
+	if cfg.Retention.Enabled {
+		cutoff := retention.Cutoff(time.Now(), cfg.Retention.KeepMonths)
+		go func() {
+			path, err := retention.Run(ctx, db, retention.DirPath(opts.ConfigPath), cutoff)
+			if err != nil {
+				fyne.LogError("Failed to prune old rounds", err)
+				return
+			}
+			if path != "" {
+				log.Printf("pruned rounds recorded before %s, archived to %s", cutoff.Format(time.DateOnly), path)
+				fyne.Do(refreshRounds)
+			}
+		}()
+	}
 
-	// Action buttons row.
-	swapButton := widget.NewButton("Swap Teams", func() {
-		t.SwapTeams()
+	// Email last month's summary once it's over, if switched on. Not from
+	// a temporary database: its summary would be empty, and marking the
+	// month sent in the real config would skip the real one.
+	summaries := summary.NewScheduler(ctx, db, cfg, func(month string) {
+		fyne.Do(func() {
+			cfg.Email.LastSent = month
+			cfgManager.Save()
+		})
+	})
+	if !opts.TempDB {
+		go summaries.Run()
+	}
+	settingsTab.SetSendTestEmail(func() error {
+		return summaries.Send(summary.PreviousMonth(time.Now()))
 	})
-	actionButtonsContainer := container.NewHBox(
-		layout.NewSpacer(),
-		swapButton,
-		layout.NewSpacer(),
-	)
 
-	// Tracker tab content
-	trackerContent := container.NewBorder(
-		nil,
-		container.NewVBox(
-			teamRow,
-			actionButtonsContainer,
-		),
-		nil,
-		nil,
-		countersContainer,
-	)
+	// Record a session for each stretch of time the game runs.
+	go gameprocess.NewRecorder(ctx, db, gameprocess.Running).Run()
 
-	// Create history tab
-	statsTab := ui.NewStatsTab(db, w, cfg, func() {
-		if err := config.Save(cfg, config.DefaultConfigFile); err != nil {
-			fyne.LogError("Failed to save config", err)
-		}
-	})
-	historyTab := ui.NewHistoryTab(db, w, func() {
-		statsTab.Refresh()
+	// Sync finished FACEIT matches into History, if switched on; a
+	// temporary database only syncs when asked to.
+	faceitSyncer := faceit.NewSyncer(ctx, db, cfg, func(int) { fyne.Do(refreshRounds) })
+	if !opts.TempDB {
+		go faceitSyncer.Run()
+	}
+	settingsTab.SetSyncFACEIT(faceitSyncer.Sync)
+
+	// Game state switched to another configured Steam account: remember it
+	// as the account rounds are recorded against, and switch to its own
+	// hotkeys, sounds and appearance if it has them.
+	t.SetOnAccountChange(func(name string) {
+		cfg.SwitchAccount(name)
+		cfgManager.Save()
+		applyConfig()
+		settingsTab.Reload()
 	})
 
-	// Create settings tab
-	settingsTab := ui.NewSettingsTab(t.Config, w, func(cfg *config.Config) {
-		if err := config.Save(cfg, config.DefaultConfigFile); err != nil {
-			fyne.LogError("Failed to save config", err)
-		}
-		t.UpdateHotkeys()
-		t.Sound().SetEnabled(cfg.SoundEnabled)
-		t.Sound().SetVolume(cfg.SoundVolume)
+	// Pick up edits made outside the app (e.g. dotfile sync). Our own saves
+	// fire the watcher too and are skipped.
+	watcher, err := config.Watch(cfgManager.Path(), func(loaded *config.Config) {
+		fyne.Do(func() {
+			if cfgManager.IsOwnWrite(loaded) || reflect.DeepEqual(loaded, cfg) {
+				return
+			}
+			reload := func() {
+				cfgManager.Discard()
+				*cfg = *loaded
+				applyConfig()
+				settingsTab.Reload()
+				statsTab.Refresh()
+			}
+			if !cfgManager.Pending() {
+				reload()
+				return
+			}
+			dialog.ShowConfirm("Settings Changed on Disk",
+				"The settings file was changed outside the app while you have unsaved changes.\n"+
+					"Load the file's version and discard your changes?",
+				func(load bool) {
+					if load {
+						reload()
+					}
+				}, w)
+		})
+	}, func(err error) {
+		fyne.LogError("Failed to reload config", err)
 	})
+	if err != nil {
+		fyne.LogError("Config hot-reload disabled", err)
+	} else {
+		cleanups = append(cleanups, func() { _ = watcher.Close() })
+	}
 
 	// Create tabs
 	historyTabItem := container.NewTabItem("History", historyTab.Container())
 	statsTabItem := container.NewTabItem("Stats", statsTab.Container())
 	tabs := container.NewAppTabs(
-		container.NewTabItem("Tracker", trackerContent),
+		container.NewTabItem("Tracker", trackerMini),
 		historyTabItem,
 		statsTabItem,
 		container.NewTabItem("Settings", settingsTab.Container()),
+		container.NewTabItem("Help", helpTab.Container()),
 	)
 
+	showHistory = func() { tabs.Select(historyTabItem) }
+
 	// Auto-refresh tabs when switching to them
 	tabs.OnSelected = func(tab *container.TabItem) {
 		switch tab {
@@ -243,9 +540,172 @@ func main() {
 		}
 	}
 
-	w.SetContent(tabs)
+	// Warn above the tabs if sound effects are on but the audio device
+	// can't be opened, instead of staying silent.
+	audioBanner := widget.NewLabel("")
+	audioBanner.Importance = widget.WarningImportance
+	audioBanner.Wrapping = fyne.TextWrapWord
+	var audioWarning *fyne.Container
+	audioWarning = container.NewVBox(
+		container.NewBorder(nil, nil, nil, widget.NewButton("Dismiss", func() { audioWarning.Hide() }), audioBanner),
+		widget.NewSeparator(),
+	)
+	audioWarning.Hide()
+	content := container.NewBorder(audioWarning, nil, nil, nil, tabs)
+	settingsTab.SetTestSound(t.Sound().Test)
+	settingsTab.SetTestStinger(t.Sound().TestStinger)
+	if cfg.SoundEnabled && !opts.NoSound {
+		go func() {
+			if err := t.Sound().Init(); err != nil {
+				log.Printf("sound: %v", err)
+				fyne.Do(func() {
+					audioBanner.SetText(fmt.Sprintf("Sound effects won't play: %v.", err))
+					audioWarning.Show()
+				})
+			}
+		}()
+	}
+
+	w.SetContent(content)
 	w.Resize(fyne.Size{Width: 600, Height: 450})
 
+	// F11 toggles presentation mode: the selected match's scoreboard full
+	// screen, for when the tracker doubles as a scoreboard on a TV. Escape
+	// also leaves it.
+	presentation := ui.NewPresentation()
+	var presented *tracker.Tracker
+	togglePresentation := func() {
+		if presented != nil {
+			presented.SetOnScoreChange(nil)
+			presented = nil
+			w.SetFullScreen(false)
+			w.SetContent(content)
+			return
+		}
+		view, ok := matchViews[matchTabs.Selected()]
+		if !ok {
+			return
+		}
+		presented = view.Tracker()
+		presentation.Present(view)
+		presented.SetOnScoreChange(presentation.SetScore)
+		w.SetContent(presentation)
+		w.SetFullScreen(true)
+	}
+	w.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) {
+		if ev.Name == fyne.KeyF11 || (ev.Name == fyne.KeyEscape && presented != nil) {
+			togglePresentation()
+		}
+	})
+
+	// Flash the score on screen for hotkey actions while the window is hidden
+	// in the tray, since there's nothing else to confirm them but the sound.
+	var hidden atomic.Bool
+	hidden.Store(opts.Headless)
+	osd := ui.NewOSD(cfg)
+	t.SetOnHotkey(func(target *tracker.Tracker) {
+		if !hidden.Load() || !cfg.OSD.Enabled {
+			return
+		}
+		if until, ok := target.BreakUntil(); ok {
+			osd.Flash(ui.BreakText(until))
+			return
+		}
+		osd.Flash(ui.OSDText(target.Match().State(), cfg.CTName, cfg.TName))
+	})
+
+	// Round notifications: their buttons launch a second instance with a
+	// csstatstracker: URL, which it sends here through the lock. Anything
+	// can send one, so rounds are only recorded from URLs carrying this
+	// run's nonce, which only its notifications know.
+	toastNonce := rand.Text()
+	var registerToasts sync.Once
+	askRound := func() {
+		if _, onBreak := t.Active().BreakUntil(); onBreak {
+			return
+		}
+		registerToasts.Do(func() {
+			if err := toast.Register(); err != nil {
+				fyne.LogError("Failed to register notification actions", err)
+			}
+		})
+		state := t.Active().Match().State()
+		prompt := toast.Prompt{CT: state.CTWins, T: state.TWins, CTName: cfg.CTName, TName: cfg.TName, Nonce: toastNonce}
+		go func() {
+			if err := toast.Show(prompt); err != nil {
+				fyne.LogError("Failed to ask for the round result", err)
+			}
+		}()
+	}
+	handleURL := func(raw string) {
+		action, err := toast.ParseURL(raw)
+		if err != nil {
+			log.Printf("ignoring %v", err)
+			return
+		}
+		switch {
+		case action.Show:
+			hidden.Store(false)
+			w.Show()
+			w.RequestFocus()
+		case subtle.ConstantTimeCompare([]byte(action.Nonce), []byte(toastNonce)) != 1:
+			log.Printf("ignoring %s: not from this run's notifications", raw)
+		case action.Winner == database.TeamCT:
+			t.Active().IncrementCT()
+		case action.Winner == database.TeamT:
+			t.Active().IncrementT()
+		}
+	}
+	lock.Serve(func(msg string) { fyne.Do(func() { handleURL(msg) }) })
+	t.SetOnRoundOver(func() {
+		if cfg.Toasts.Enabled {
+			askRound()
+		}
+	})
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		lastAsked := time.Now()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				fyne.Do(func() {
+					// Catches the daily goal's rollover hour.
+					goalRing.Reload()
+					interval := time.Duration(cfg.Toasts.IntervalMinutes) * time.Minute
+					if toast.Supported && interval > 0 && now.Sub(lastAsked) >= interval {
+						lastAsked = now
+						askRound()
+					}
+				})
+			}
+		}
+	}()
+
+	// Suggest a break once the break limit is reached. While the window is
+	// hidden the OSD says so instead.
+	breakPrompt := ui.NewBreakPrompt(w, t.OverrideBreak)
+	t.SetOnBreak(func(until time.Time, started bool) {
+		if !hidden.Load() {
+			breakPrompt.Show(until, started)
+		}
+	})
+
+	// A round after the match was decided can't belong to it: ask whether
+	// it starts a new match.
+	matchOverPrompt := ui.NewMatchOverPrompt(w)
+	t.SetOnMatchOver(func(target *tracker.Tracker, side database.Team) {
+		ctName, tName := cfg.CTName, cfg.TName
+		for _, view := range matchViews {
+			if view.Tracker() == target {
+				ctName, tName = view.SideNames()
+			}
+		}
+		matchOverPrompt.Show(target, side, ctName, tName)
+	})
+
 	// Setup system tray. Also set the icon as the app's main icon so the
 	// systray has a fallback if the first SetSystemTrayIcon call races with
 	// the systray backend starting up.
@@ -254,8 +714,30 @@ func main() {
 	if desk, ok := a.(desktop.App); ok {
 		desk.SetSystemTrayIcon(trayIcon)
 
+		// Draw the active match's score onto the tray icon, updated on every
+		// change, so it can be read without opening the window.
+		if badge, err := trayicon.New(csstatstracker.IconData); err != nil {
+			fyne.LogError("Tray score disabled", err)
+		} else {
+			refreshTray = func() {
+				state := t.Active().Match().State()
+				ctColor, tColor := ui.SideColors(cfg)
+				icon, err := badge.Render(state.CTWins, state.TWins, ctColor, tColor)
+				if err != nil {
+					fyne.LogError("Failed to draw tray score", err)
+					return
+				}
+				desk.SetSystemTrayIcon(fyne.NewStaticResource(
+					fmt.Sprintf("tray-%d-%d.png", state.CTWins, state.TWins), icon))
+			}
+			refreshTray()
+		}
+
 		trayMenu := fyne.NewMenu("CS Stats Tracker",
-			fyne.NewMenuItem("Show", func() { w.Show() }),
+			fyne.NewMenuItem("Show", func() {
+				hidden.Store(false)
+				w.Show()
+			}),
 			fyne.NewMenuItemSeparator(),
 			fyne.NewMenuItem("Quit", func() { a.Quit() }),
 		)
@@ -265,14 +747,43 @@ func main() {
 	// Intercept window close to minimize to tray if enabled
 	w.SetCloseIntercept(func() {
 		if cfg.MinimizeToTray {
+			hidden.Store(true)
 			w.Hide()
 		} else {
 			a.Quit()
 		}
 	})
 
+	// Offer to repair config problems found at load rather than silently
+	// misbehaving, e.g. a hotkey bound to a key the hook can never report.
+	if len(cfgProblems) > 0 {
+		lines := make([]string, len(cfgProblems))
+		for i, p := range cfgProblems {
+			lines[i] = "• " + p.String()
+		}
+		msg := widget.NewLabel(fmt.Sprintf("Problems were found in %s:\n\n%s\n\n"+
+			"Repair resets invalid values to their defaults and drops unknown settings.",
+			cfgManager.Path(), strings.Join(lines, "\n")))
+		msg.Wrapping = fyne.TextWrapWord
+		dialog.ShowCustomConfirm("Config Problems", "Repair", "Ignore", msg, func(repair bool) {
+			if !repair {
+				return
+			}
+			config.Repair(cfg, hotkey.IsKnownKey)
+			cfgManager.Save()
+			applyConfig()
+			settingsTab.Reload()
+		}, w)
+	}
+
 	// Start hotkey handling
-	t.StartHotkeys()
+	if !opts.NoHotkeys {
+		t.StartHotkeys()
+	}
 
-	w.ShowAndRun()
+	// Started by a notification button with no instance running.
+	if opts.URL != "" {
+		handleURL(opts.URL)
+	}
+	return stop
 }
@@ -0,0 +1,73 @@
+//go:build linux || windows
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/app"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	csstatstracker "csstatstracker"
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/options"
+	"csstatstracker/internal/snapshot"
+	"csstatstracker/internal/ui"
+)
+
+// runReadOnly shows History and Stats for a database another PC records to,
+// typically on a network share. Nothing that writes to the database runs:
+// no tracker, hotkeys, retention or webhooks, and editing is switched off.
+func runReadOnly(ctx context.Context, opts *options.Options, cfg *config.Config, save func()) error {
+	db, err := database.OpenReadOnly(ctx, opts.DBPath, csstatstracker.MigrationsFS)
+	if err != nil {
+		return fmt.Errorf("failed to open %s read-only: %w", opts.DBPath, err)
+	}
+	defer func() { _ = db.Close() }()
+
+	a := app.New()
+	w := a.NewWindow("CS Stats Tracker (read-only)")
+	a.SetIcon(fyne.NewStaticResource("icon.png", csstatstracker.IconData))
+	ui.SetColorVision(cfg.ColorVision)
+
+	statsTab := ui.NewStatsTab(ctx, db, w, cfg, snapshot.KeyPath(opts.ConfigPath), save)
+	statsTab.SetReadOnly()
+	historyTab := ui.NewHistoryTab(ctx, db, w, cfg, nil)
+	historyTab.SetReadOnly()
+
+	historyTabItem := container.NewTabItem("History", historyTab.Container())
+	statsTabItem := container.NewTabItem("Stats", statsTab.Container())
+	tabs := container.NewAppTabs(historyTabItem, statsTabItem)
+	tabs.OnSelected = func(tab *container.TabItem) {
+		switch tab {
+		case historyTabItem:
+			historyTab.Refresh()
+		case statsTabItem:
+			statsTab.Refresh()
+		}
+	}
+
+	// Rounds recorded on the other PC only show up on a refresh, so say so
+	// next to the button that does it.
+	banner := widget.NewLabel("Read-only: viewing " + opts.DBPath + ". Recording and editing are disabled.")
+	banner.Importance = widget.WarningImportance
+	banner.TextStyle = fyne.TextStyle{Bold: true}
+	banner.Wrapping = fyne.TextWrapWord
+	refreshBtn := widget.NewButton("Refresh", func() {
+		historyTab.Refresh()
+		statsTab.Refresh()
+	})
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, nil, refreshBtn, banner),
+		widget.NewSeparator(),
+	)
+
+	w.SetContent(container.NewBorder(top, nil, nil, nil, tabs))
+	w.Resize(fyne.Size{Width: 600, Height: 450})
+	w.ShowAndRun()
+	return nil
+}
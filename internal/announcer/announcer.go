@@ -0,0 +1,91 @@
+// Package announcer spots the moments in a session worth an announcer's
+// sound: a run of wins reaching a milestone, and a reverse sweep, taking the
+// lead in a match after trailing far behind.
+package announcer
+
+import (
+	"slices"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/records"
+)
+
+// Moment is something worth announcing.
+type Moment string
+
+const (
+	None      Moment = ""
+	WinStreak Moment = "win_streak"
+	Comeback  Moment = "comeback"
+)
+
+// Rules says which moments are announced.
+type Rules struct {
+	Streaks  []int // win streak lengths announced, e.g. 3 and 5
+	Comeback int   // deficit that has to be overturned for a reverse sweep, 0 for none
+}
+
+// Detector follows the results of the rounds recorded for one match. The
+// win streak carries on across matches for the rest of the session; the
+// deficit behind a comeback starts over with each match. The zero value is
+// ready to use.
+type Detector struct {
+	streak int       // wins in a row
+	leads  []int     // won minus lost after each round of this match since the last comeback
+	last   time.Time // when the last round was recorded
+}
+
+// lead returns rounds won minus rounds lost in this match so far.
+func (d *Detector) lead() int {
+	if len(d.leads) == 0 {
+		return 0
+	}
+	return d.leads[len(d.leads)-1]
+}
+
+// Record counts a round with result res recorded at now and returns the
+// moment it completes, if any. A reverse sweep outranks a streak reached by
+// the same round.
+func (d *Detector) Record(res database.Result, now time.Time, rules Rules) Moment {
+	// A pause longer than a session gap starts a new session.
+	if !d.last.IsZero() && now.Sub(d.last) > records.SessionGap {
+		d.streak = 0
+	}
+	d.last = now
+
+	switch res {
+	case database.ResultWin:
+		d.streak++
+		d.leads = append(d.leads, d.lead()+1)
+	case database.ResultLoss:
+		d.streak = 0
+		d.leads = append(d.leads, d.lead()-1)
+		return None
+	default:
+		return None
+	}
+
+	if rules.Comeback > 0 && d.lead() == 1 && slices.Min(d.leads) <= -rules.Comeback {
+		d.leads = []int{1} // announced once per comeback
+		return Comeback
+	}
+	if slices.Contains(rules.Streaks, d.streak) {
+		return WinStreak
+	}
+	return None
+}
+
+// Undo takes back the last round, whose result was res. The streak before
+// it isn't known, so it starts over.
+func (d *Detector) Undo(res database.Result) {
+	d.streak = 0
+	if res != database.ResultDraw && len(d.leads) > 0 {
+		d.leads = d.leads[:len(d.leads)-1]
+	}
+}
+
+// NewMatch starts counting a new match's lead from 0–0.
+func (d *Detector) NewMatch() {
+	d.leads = nil
+}
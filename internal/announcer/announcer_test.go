@@ -0,0 +1,76 @@
+package announcer_test
+
+import (
+	"testing"
+	"time"
+
+	"csstatstracker/internal/announcer"
+	"csstatstracker/internal/database"
+)
+
+func TestDetector(t *testing.T) {
+	rules := announcer.Rules{Streaks: []int{3, 5}, Comeback: 3}
+	start := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		results string // W, L or D per round, a minute apart
+		want    []announcer.Moment
+	}{
+		{"streak milestones", "WWWWW", []announcer.Moment{4: announcer.WinStreak, 2: announcer.WinStreak}},
+		{"loss breaks streak", "WWLWW", nil},
+		{"draws keep streak", "WWDW", []announcer.Moment{3: announcer.WinStreak}},
+		{"reverse sweep", "LLLWWWW", []announcer.Moment{5: announcer.WinStreak, 6: announcer.Comeback}},
+		{"comeback outranks streak", "LLLLWWWWW", []announcer.Moment{6: announcer.WinStreak, 8: announcer.Comeback}},
+		{"too small a deficit", "LLWWW", []announcer.Moment{4: announcer.WinStreak}},
+		{"only once", "LLLWWWWLW", []announcer.Moment{5: announcer.WinStreak, 6: announcer.Comeback}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d announcer.Detector
+			for i, r := range tt.results {
+				res := map[rune]database.Result{'W': database.ResultWin, 'L': database.ResultLoss, 'D': database.ResultDraw}[r]
+				var want announcer.Moment
+				if i < len(tt.want) {
+					want = tt.want[i]
+				}
+				if got := d.Record(res, start.Add(time.Duration(i)*time.Minute), rules); got != want {
+					t.Errorf("round %d (%c) = %q; want %q", i+1, r, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectorSessionAndMatch(t *testing.T) {
+	rules := announcer.Rules{Streaks: []int{3}, Comeback: 2}
+	now := time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC)
+	var d announcer.Detector
+
+	d.Record(database.ResultWin, now, rules)
+	d.Record(database.ResultWin, now, rules)
+	// An hour later is a new session: the streak starts over.
+	now = now.Add(time.Hour)
+	if got := d.Record(database.ResultWin, now, rules); got != announcer.None {
+		t.Errorf("third win after a break = %q; want nothing", got)
+	}
+
+	// Trailing in one match doesn't carry into the next.
+	d.Record(database.ResultLoss, now, rules)
+	d.Record(database.ResultLoss, now, rules)
+	d.Record(database.ResultLoss, now, rules)
+	d.NewMatch()
+	if got := d.Record(database.ResultWin, now, rules); got != announcer.None {
+		t.Errorf("first win of a new match = %q; want nothing", got)
+	}
+
+	// An undone loss doesn't count towards the deficit.
+	d.NewMatch()
+	d.Record(database.ResultLoss, now, rules)
+	d.Record(database.ResultLoss, now, rules)
+	d.Undo(database.ResultLoss)
+	d.Record(database.ResultWin, now, rules)
+	if got := d.Record(database.ResultWin, now, rules); got != announcer.None {
+		t.Errorf("taking the lead after the undo = %q; want nothing, the deficit was 1 then", got)
+	}
+}
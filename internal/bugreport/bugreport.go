@@ -0,0 +1,210 @@
+// Package bugreport builds a zip to attach to bug reports: an anonymized
+// copy of the database, the config with personal details and secrets
+// redacted, and the recent log.
+package bugreport
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+)
+
+// redacted replaces secrets and personal details that are set, so it's
+// still visible that they were.
+const redacted = "[redacted]"
+
+// Shift bounds, in days, for moving the database's timestamps back.
+const (
+	minShiftDays = 30
+	maxShiftDays = 730
+)
+
+// FileName is the suggested name for a bug report saved at now.
+func FileName(now time.Time) string {
+	return "csstatstracker-bug-report-" + now.Format("2006-01-02") + ".zip"
+}
+
+// Write writes the bug report zip to w. logs is the recent log output,
+// which is scrubbed of the same details as the config.
+func Write(ctx context.Context, w io.Writer, db *sql.DB, cfg *config.Config, logs []byte) error {
+	return write(ctx, w, db, cfg, logs, minShiftDays+rand.IntN(maxShiftDays-minShiftDays+1))
+}
+
+func write(ctx context.Context, w io.Writer, db *sql.DB, cfg *config.Config, logs []byte, shiftDays int) error {
+	names, err := database.GetAccountNames(ctx, db)
+	if err != nil {
+		return err
+	}
+	accounts := accountMap(cfg, names)
+
+	dir, err := os.MkdirTemp("", "csstatstracker-report-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	dbPath := filepath.Join(dir, "csstatstracker.db")
+	if err := database.AnonymizedCopy(ctx, db, dbPath, shiftDays, accounts); err != nil {
+		return err
+	}
+	dbData, err := os.ReadFile(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to read database copy: %w", err)
+	}
+
+	clean, scrub := sanitize(cfg, accounts)
+	cfgData, err := json.MarshalIndent(clean, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"README.txt", []byte(readme(shiftDays))},
+		{"csstatstracker.db", dbData},
+		{"csstatstracker.json", cfgData},
+		{"log.txt", []byte(scrub.Replace(string(logs)))},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return fmt.Errorf("failed to write bug report: %w", err)
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return fmt.Errorf("failed to write bug report: %w", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to write bug report: %w", err)
+	}
+	return nil
+}
+
+// accountMap assigns "Account 1", "Account 2", ... to the configured
+// accounts in order, then to any others only found in the database.
+func accountMap(cfg *config.Config, dbNames []string) map[string]string {
+	m := make(map[string]string)
+	add := func(name string) {
+		if _, ok := m[name]; !ok && name != "" {
+			m[name] = fmt.Sprintf("Account %d", len(m)+1)
+		}
+	}
+	for _, a := range cfg.Accounts {
+		add(a.Name)
+	}
+	for _, name := range dbNames {
+		add(name)
+	}
+	return m
+}
+
+// sanitize returns a copy of cfg with personal details and secrets
+// redacted, and a replacer that scrubs the same values from text.
+func sanitize(cfg *config.Config, accounts map[string]string) (*config.Config, *strings.Replacer) {
+	var pairs []string
+	scrub := func(s *string) {
+		if *s != "" {
+			pairs = append(pairs, *s, redacted)
+			*s = redacted
+		}
+	}
+
+	// Deep copy through JSON so slices aren't shared with the live config.
+	data, _ := json.Marshal(cfg)
+	c := &config.Config{}
+	_ = json.Unmarshal(data, c)
+
+	for old, name := range accounts {
+		pairs = append(pairs, old, name)
+	}
+	for i := range c.Accounts {
+		c.Accounts[i].Name = accounts[c.Accounts[i].Name]
+		scrub(&c.Accounts[i].SteamID)
+	}
+	c.ActiveAccount = accounts[c.ActiveAccount]
+	c.StatsAccount = accounts[c.StatsAccount]
+	scrub(&c.ShareName)
+	scrub(&c.GSI.Token)
+	scrub(&c.FACEIT.APIKey)
+	scrub(&c.FACEIT.Nickname)
+	scrub(&c.PINHash)
+	scrub(&c.Email.Host)
+	scrub(&c.Email.Username)
+	scrub(&c.Email.Password)
+	scrub(&c.Email.From)
+	scrub(&c.Email.To)
+	for i := range c.Webhooks {
+		scrub(&c.Webhooks[i].URL)
+		scrub(&c.Webhooks[i].Secret)
+	}
+	for i := range c.Hooks {
+		scrub(&c.Hooks[i].Command)
+		for j := range c.Hooks[i].Args {
+			scrub(&c.Hooks[i].Args[j])
+		}
+	}
+
+	// Logos and sound files are kept by name only: their folders give away
+	// the user's name, as in C:\Users\<name>\Pictures.
+	files := []*string{&c.CTLogo, &c.TLogo, &c.Announcer.StreakSound, &c.Announcer.ComebackSound,
+		&c.Stingers.WinFile, &c.Stingers.LoseFile}
+	for _, a := range c.Accounts {
+		if p := a.Settings; p != nil {
+			files = append(files, p.CTLogo, p.TLogo)
+			if p.Announcer != nil {
+				files = append(files, &p.Announcer.StreakSound, &p.Announcer.ComebackSound)
+			}
+			if p.Stingers != nil {
+				files = append(files, &p.Stingers.WinFile, &p.Stingers.LoseFile)
+			}
+		}
+	}
+	for _, f := range files {
+		if f != nil && *f != "" {
+			name := filepath.Base(*f)
+			pairs = append(pairs, *f, name)
+			*f = name
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		pairs = append(pairs, home, "~")
+	}
+	return c, strings.NewReplacer(pairs...)
+}
+
+// readme describes the report and what was changed in it.
+func readme(shiftDays int) string {
+	version := "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version = info.Main.Version
+	}
+	return fmt.Sprintf(`CS Stats Tracker bug report
+
+App version: %s
+Platform:    %s/%s (%s)
+
+Personal details were removed before this was saved:
+- csstatstracker.db: every timestamp is moved back by %d days (order and
+  gaps are unchanged), account names are replaced by "Account N", and
+  queued webhook deliveries are dropped.
+- csstatstracker.json: Steam IDs, the share name, the GSI token, email
+  settings, webhook URLs and secrets and hook commands are redacted, and
+  accounts renamed as in the database.
+- log.txt: the same values, and your home directory, are replaced.
+`, version, runtime.GOOS, runtime.GOARCH, runtime.Version(), shiftDays)
+}
@@ -0,0 +1,187 @@
+package bugreport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestWrite(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	rounds := dbtest.Series(time.Date(2025, 3, 14, 20, 0, 0, 0, time.UTC), time.Minute, "WL")
+	rounds[0].Account = "alt"
+	rounds[1].Account = "old-main"
+	dbtest.Insert(t, db, rounds...)
+
+	cfg := config.Default()
+	cfg.Accounts = []config.Account{{Name: "main", SteamID: "76561198000000001"}, {Name: "alt"}}
+	cfg.ActiveAccount = "alt"
+	cfg.GSI.Token = "s3cret-token"
+	cfg.Email.Password = "hunter2"
+	cfg.Webhooks = []config.Webhook{{URL: "https://hooks.example.com/abc", Events: []string{"streak"}}}
+
+	logs := []byte("gsi: token s3cret-token rejected\nswitched to alt (76561198000000001)\n")
+	var buf bytes.Buffer
+	if err := write(ctx, &buf, db, cfg, logs, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		files[f.Name] = string(b)
+	}
+	for _, name := range []string{"README.txt", "csstatstracker.db", "csstatstracker.json", "log.txt"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing %s", name)
+		}
+	}
+
+	var got config.Config
+	if err := json.Unmarshal([]byte(files["csstatstracker.json"]), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Accounts[0].Name != "Account 1" || got.Accounts[0].SteamID != redacted || got.ActiveAccount != "Account 2" {
+		t.Errorf("accounts not anonymized: %+v, active %q", got.Accounts, got.ActiveAccount)
+	}
+	if got.GSI.Token != redacted || got.Email.Password != redacted || got.Webhooks[0].URL != redacted {
+		t.Errorf("secrets not redacted: %+v %+v %+v", got.GSI, got.Email, got.Webhooks)
+	}
+	if cfg.GSI.Token != "s3cret-token" || cfg.Accounts[0].Name != "main" {
+		t.Error("the live config was modified")
+	}
+
+	log := files["log.txt"]
+	for _, secret := range []string{"s3cret-token", "76561198000000001", " alt "} {
+		if strings.Contains(log, secret) {
+			t.Errorf("log still contains %q:\n%s", secret, log)
+		}
+	}
+	if !strings.Contains(log, "switched to Account 2") {
+		t.Errorf("log = %q", log)
+	}
+	if !strings.Contains(files["README.txt"], "100 days") {
+		t.Errorf("README doesn't mention the shift:\n%s", files["README.txt"])
+	}
+}
+
+func TestWriteRedactsSecrets(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	rounds := dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WL")
+	rounds[0].Account = "secret-account-main"
+	rounds[1].Account = "secret-account-gone"
+	dbtest.Insert(t, db, rounds...)
+
+	cfg := config.Default()
+	secrets := map[string]*string{}
+	set := func(field string, s *string) {
+		*s = "secret-" + strings.ReplaceAll(field, ".", "-")
+		secrets[field] = s
+	}
+	cfg.Accounts = []config.Account{{Name: "secret-account-main"}}
+	cfg.ActiveAccount = "secret-account-main"
+	cfg.StatsAccount = "secret-account-main"
+	set("Accounts.SteamID", &cfg.Accounts[0].SteamID)
+	set("ShareName", &cfg.ShareName)
+	set("GSI.Token", &cfg.GSI.Token)
+	set("FACEIT.APIKey", &cfg.FACEIT.APIKey)
+	set("FACEIT.Nickname", &cfg.FACEIT.Nickname)
+	set("Email.Host", &cfg.Email.Host)
+	set("Email.Username", &cfg.Email.Username)
+	set("Email.Password", &cfg.Email.Password)
+	set("Email.From", &cfg.Email.From)
+	set("Email.To", &cfg.Email.To)
+	cfg.Webhooks = []config.Webhook{{}}
+	set("Webhooks.URL", &cfg.Webhooks[0].URL)
+	set("Webhooks.Secret", &cfg.Webhooks[0].Secret)
+	cfg.Hooks = []config.Hook{{Args: []string{""}}}
+	set("Hooks.Command", &cfg.Hooks[0].Command)
+	set("Hooks.Args", &cfg.Hooks[0].Args[0])
+	if err := cfg.SetPIN("1234"); err != nil {
+		t.Fatal(err)
+	}
+	secrets["PINHash"] = &cfg.PINHash
+
+	// File paths may only keep the file's name, not the folders that can
+	// give away the user's, so the secret is the folder.
+	folders := map[string]string{}
+	setPath := func(field string, s *string) {
+		folders[field] = "secret-" + strings.ReplaceAll(field, ".", "-")
+		*s = filepath.Join(string(filepath.Separator)+"home", folders[field], "file.png")
+		secrets[field] = s
+	}
+	setPath("CTLogo", &cfg.CTLogo)
+	setPath("TLogo", &cfg.TLogo)
+	setPath("Announcer.StreakSound", &cfg.Announcer.StreakSound)
+	setPath("Stingers.WinFile", &cfg.Stingers.WinFile)
+	profile := &config.Profile{CTLogo: new(string), TLogo: new(string), Stingers: &config.Stingers{}}
+	cfg.Accounts[0].Settings = profile
+	setPath("Accounts.Settings.CTLogo", profile.CTLogo)
+	setPath("Accounts.Settings.TLogo", profile.TLogo)
+	setPath("Accounts.Settings.Stingers.LoseFile", &profile.Stingers.LoseFile)
+
+	// The log mentions every one of them, as a careless log line might.
+	var logs strings.Builder
+	for field, s := range secrets {
+		logs.WriteString(field + ": " + *s + "\n")
+	}
+	logs.WriteString("recorded to secret-account-main\n")
+	for field, folder := range folders {
+		secrets[field] = &folder
+	}
+
+	var buf bytes.Buffer
+	if err := write(ctx, &buf, db, cfg, []byte(logs.String()), 100); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(rc)
+		_ = rc.Close()
+		for field, s := range secrets {
+			if bytes.Contains(b, []byte(*s)) {
+				t.Errorf("%s contains %s", f.Name, field)
+			}
+		}
+		if bytes.Contains(b, []byte("secret-account")) {
+			t.Errorf("%s contains an account name", f.Name)
+		}
+	}
+}
+
+func TestLog(t *testing.T) {
+	l := NewLog(2)
+	for _, s := range []string{"one\n", "two\nthr", "ee\n", "four"} {
+		_, _ = l.Write([]byte(s))
+	}
+	if got := string(l.Bytes()); got != "two\nthree\nfour" {
+		t.Errorf("Bytes = %q", got)
+	}
+}
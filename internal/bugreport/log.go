@@ -0,0 +1,47 @@
+package bugreport
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Log keeps the most recent lines written to it, for including in bug
+// reports. The app has no log file, so it's tee'd from the log output.
+type Log struct {
+	mu    sync.Mutex
+	max   int
+	lines [][]byte
+	part  []byte // an unterminated last line
+}
+
+// NewLog creates a Log keeping the last max lines.
+func NewLog(max int) *Log {
+	return &Log{max: max}
+}
+
+// Write stores p's lines. It never fails.
+func (l *Log) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rest := append(l.part, p...)
+	for {
+		i := bytes.IndexByte(rest, '\n')
+		if i < 0 {
+			break
+		}
+		l.lines = append(l.lines, bytes.Clone(rest[:i+1]))
+		rest = rest[i+1:]
+	}
+	l.part = bytes.Clone(rest)
+	if n := len(l.lines) - l.max; n > 0 {
+		l.lines = append(l.lines[:0:0], l.lines[n:]...)
+	}
+	return len(p), nil
+}
+
+// Bytes returns the kept lines.
+func (l *Log) Bytes() []byte {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append(bytes.Join(l.lines, nil), l.part...)
+}
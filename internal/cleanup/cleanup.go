@@ -0,0 +1,120 @@
+// Package cleanup scans the round history for data that's probably wrong,
+// such as a hotkey pressed twice, so it can be fixed in bulk.
+package cleanup
+
+import (
+	"slices"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/records"
+)
+
+// MinRoundGap is the shortest time a real round can take to follow the one
+// before it. CS2's freeze time alone is 15 seconds.
+const MinRoundGap = 10 * time.Second
+
+// Kind is a kind of anomaly.
+type Kind int
+
+const (
+	Duplicate Kind = iota // same second, winner and team as the round before
+	TooFast               // recorded less than MinRoundGap after the round before
+	Stray                 // a lone round, more than a session gap from any other (but not the newest)
+	NoTeam                // recorded with no team selected, so it counts as a draw
+)
+
+// Kinds lists the kinds in the order they're checked and shown.
+var Kinds = []Kind{Duplicate, TooFast, Stray, NoTeam}
+
+// Title is a short name for the kind.
+func (k Kind) Title() string {
+	switch k {
+	case Duplicate:
+		return "Duplicate rounds"
+	case TooFast:
+		return "Impossibly quick rounds"
+	case Stray:
+		return "Stray rounds"
+	default:
+		return "Rounds without a team"
+	}
+}
+
+// Description explains the kind and what fixing it does.
+func (k Kind) Description() string {
+	switch k {
+	case Duplicate:
+		return "Recorded in the same second as an identical round, usually a hotkey pressed twice. Fixing deletes the copies."
+	case TooFast:
+		return "Recorded less than 10 seconds after the previous round, faster than a round can be played. Fixing deletes them."
+	case Stray:
+		return "A single round with no other round within 30 minutes, often a hotkey pressed by accident. Fixing deletes them."
+	default:
+		return "These count as draws. Assign the side you played under Stats → Draws."
+	}
+}
+
+// Deletable reports whether issues of this kind are fixed by deleting the
+// rounds. Other kinds are fixed elsewhere.
+func (k Kind) Deletable() bool { return k != NoTeam }
+
+// Issue is the rounds found with one kind of anomaly, oldest first.
+type Issue struct {
+	Kind   Kind
+	Rounds []database.Round
+}
+
+// IDs returns the IDs of the issue's rounds.
+func (i Issue) IDs() []int {
+	ids := make([]int, len(i.Rounds))
+	for n, r := range i.Rounds {
+		ids[n] = r.ID
+	}
+	return ids
+}
+
+// Scan checks rounds, which may be in any order, and returns an issue for
+// each kind of anomaly found, in the order of Kinds. A round is reported
+// under the first kind it matches only.
+func Scan(rounds []database.Round) []Issue {
+	sorted := slices.Clone(rounds)
+	slices.SortFunc(sorted, func(a, b database.Round) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	})
+
+	found := make(map[Kind][]database.Round)
+	for i, r := range sorted {
+		var prev, next *database.Round
+		if i > 0 {
+			prev = &sorted[i-1]
+		}
+		if i < len(sorted)-1 {
+			next = &sorted[i+1]
+		}
+		switch {
+		case prev != nil && prev.CreatedAt.Truncate(time.Second).Equal(r.CreatedAt.Truncate(time.Second)) &&
+			prev.Winner == r.Winner && prev.Team == r.Team:
+			found[Duplicate] = append(found[Duplicate], r)
+		case prev != nil && r.CreatedAt.Sub(prev.CreatedAt) < MinRoundGap:
+			found[TooFast] = append(found[TooFast], r)
+		case (prev == nil || r.CreatedAt.Sub(prev.CreatedAt) > records.SessionGap) &&
+			next != nil && next.CreatedAt.Sub(r.CreatedAt) > records.SessionGap:
+			// The newest round is left alone: it may start a game in progress.
+			found[Stray] = append(found[Stray], r)
+		case r.Team == database.TeamNone:
+			found[NoTeam] = append(found[NoTeam], r)
+		}
+	}
+
+	var issues []Issue
+	for _, k := range Kinds {
+		if rs := found[k]; len(rs) > 0 {
+			issues = append(issues, Issue{Kind: k, Rounds: rs})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,58 @@
+package cleanup
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestScan(t *testing.T) {
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	rounds := slices.Concat(
+		dbtest.Series(start, time.Minute, "WLW"),                      // 1-3: fine
+		dbtest.Series(start.Add(3*time.Minute), 0, "WW"),              // 4-5: 5 duplicates 4
+		dbtest.Series(start.Add(3*time.Minute+3*time.Second), 0, "L"), // 6: too quick after 5
+		dbtest.Series(start.Add(4*time.Minute), time.Minute, "DW"),    // 7: no team, 8: fine
+		dbtest.Series(start.Add(2*time.Hour), 0, "W"),                 // 9: stray
+		dbtest.Series(start.Add(4*time.Hour), time.Minute, "D"),       // 10: newest, alone but not stray
+	)
+	id := 0
+	rounds = dbtest.With(rounds, func(r *database.Round) {
+		id++
+		r.ID = id
+	})
+	// Scan doesn't depend on the order rounds come in.
+	slices.Reverse(rounds)
+
+	issues := Scan(rounds)
+	want := []struct {
+		kind Kind
+		ids  []int
+	}{
+		{Duplicate, []int{5}},
+		{TooFast, []int{6}},
+		{Stray, []int{9}},
+		{NoTeam, []int{7, 10}},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+	for i, w := range want {
+		if issues[i].Kind != w.kind || !slices.Equal(issues[i].IDs(), w.ids) {
+			t.Errorf("issue %d = %s %v, want %s %v", i, issues[i].Kind.Title(), issues[i].IDs(), w.kind.Title(), w.ids)
+		}
+	}
+}
+
+func TestScanClean(t *testing.T) {
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	if issues := Scan(dbtest.Series(start, time.Minute, "WLWLwlwl")); len(issues) != 0 {
+		t.Errorf("Scan found %+v in clean data", issues)
+	}
+	if issues := Scan(nil); len(issues) != 0 {
+		t.Errorf("Scan(nil) = %+v, want none", issues)
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+// HotkeyAction is an action that can be bound to a hotkey.
+type HotkeyAction struct {
+	Key   string // key under "hotkeys" in the config file, e.g. "increment_ct"
+	Label string // name in the Settings form
+	Help  string // what the action does, for the Help tab
+}
+
+// HotkeyActions lists every hotkey action, in the order Settings shows them
+// and combos are matched in. Adding an action takes an entry here, a
+// handler in the tracker's actionHandlers and, optionally, a default combo
+// in defaults_linux.go and defaults_windows.go; Settings, validation and
+// cooldowns pick it up from this list.
+var HotkeyActions = []HotkeyAction{
+	{"increment_ct", "Increment CT", "Records a round won by CT in the active match."},
+	{"decrement_ct", "Decrement CT", "Takes back CT's most recent round."},
+	{"increment_t", "Increment T", "Records a round won by T in the active match."},
+	{"decrement_t", "Decrement T", "Takes back T's most recent round."},
+	{"amend_last", "Amend Last Round", "Gives the most recent round to the other side, if it was recorded for the wrong one."},
+	{"select_ct", "Select CT Team", "Marks you as playing CT, so CT rounds count as wins."},
+	{"select_t", "Select T Team", "Marks you as playing T, so T rounds count as wins."},
+	{"swap_teams", "Swap Teams", "Switches your side, as at halftime."},
+	{"toggle_sound", "Mute / Unmute Sounds", "Turns the round sounds off or back on."},
+}
+
+// isHotkeyAction reports whether key names an action in HotkeyActions.
+func isHotkeyAction(key string) bool {
+	for _, a := range HotkeyActions {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
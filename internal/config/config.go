@@ -3,42 +3,322 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 )
 
 const DefaultConfigFile = "./csstatstracker.json"
 
-// Hotkeys defines the keyboard shortcuts for each action
-type Hotkeys struct {
-	IncrementCT []string `json:"increment_ct"`
-	DecrementCT []string `json:"decrement_ct"`
-	IncrementT  []string `json:"increment_t"`
-	DecrementT  []string `json:"decrement_t"`
-	SelectCT    []string `json:"select_ct"`
-	SelectT     []string `json:"select_t"`
-	SwapTeams   []string `json:"swap_teams"`
+// CurrentVersion is the config format version written by this build. Bump it
+// when a change needs migrating in Load rather than just a missing-field
+// default.
+const CurrentVersion = 1
+
+// Hotkeys maps each action in HotkeyActions, by key, to its key
+// combination. An action missing from the map gets its default; one mapped
+// to an empty combo is deliberately unbound.
+type Hotkeys map[string][]string
+
+// Hotkey repeat policies, see HotkeyTiming.Repeat.
+const (
+	RepeatIgnore = "ignore" // a held hotkey fires once
+	RepeatDelay  = "delay"  // a held hotkey repeats after repeat_delay_ms
+)
+
+// HotkeyTiming controls how often hotkeys fire.
+type HotkeyTiming struct {
+	// Cooldowns is the minimum time in milliseconds between two firings of
+	// an action, keyed like Hotkeys, e.g. "increment_ct". Actions not listed
+	// use the default of 100ms.
+	Cooldowns   map[string]int `json:"cooldowns_ms"`
+	Repeat      string         `json:"repeat"`          // RepeatIgnore or RepeatDelay
+	RepeatDelay int            `json:"repeat_delay_ms"` // how long a key is held before it repeats
+}
+
+// Hotkey match strategies, see Config.HotkeyMatch.
+const (
+	MatchExact    = "exact"    // only exactly the combo's keys may be down
+	MatchSuperset = "superset" // other held keys are ignored
+	MatchLongest  = "longest"  // other held keys are ignored, the longest matching combo wins
+)
+
+// OSD positions, see OSD.Position.
+const (
+	OSDCenter      = "center"
+	OSDTopLeft     = "top-left"
+	OSDTopRight    = "top-right"
+	OSDBottomLeft  = "bottom-left"
+	OSDBottomRight = "bottom-right"
+)
+
+// OSD configures the on-screen score flashed when a hotkey fires while the
+// window is hidden.
+type OSD struct {
+	Enabled    bool   `json:"enabled"`
+	DurationMs int    `json:"duration_ms"`
+	Position   string `json:"position"` // corner of the screen, or OSDCenter; always centred on Linux
+}
+
+// Announcer plays a sound at the moments of a session worth celebrating: a
+// run of wins reaching one of Streaks, and a reverse sweep, taking the lead
+// in a match after trailing by Comeback rounds or more.
+type Announcer struct {
+	Enabled       bool   `json:"enabled"`
+	Streaks       []int  `json:"streaks"`        // win streak lengths announced
+	Comeback      int    `json:"comeback"`       // deficit overturned for a reverse sweep, 0 for none
+	StreakSound   string `json:"streak_sound"`   // sound file for streaks, "" for the built-in one
+	ComebackSound string `json:"comeback_sound"` // sound file for reverse sweeps, "" for the built-in one
+}
+
+// SoundTiming keeps a sound retriggered quickly, e.g. while mashing +/- to
+// fix a miscount, from piling up.
+type SoundTiming struct {
+	// Cooldown is the minimum time in milliseconds between two plays of the
+	// same sound; one retriggered sooner is skipped. Cooldowns overrides it
+	// for single sounds, keyed like SoundEvents, e.g. "ct_increment".
+	Cooldown  int            `json:"cooldown_ms"`
+	Cooldowns map[string]int `json:"cooldowns_ms"`
+	Restart   bool           `json:"restart"` // a retriggered sound cuts off the one still playing
+}
+
+// SoundEvents lists the events sound effects are played for, the keys of
+// SoundTiming.Cooldowns.
+var SoundEvents = []string{
+	"ct_increment", "ct_decrement", "t_increment", "t_decrement",
+	"ct_select", "t_select", "streak", "comeback",
+}
+
+// Ducking turns sound effects down to Volume (a share of the effect volume)
+// while an app is using the microphone, so they don't play over voice chat.
+type Ducking struct {
+	Enabled bool    `json:"enabled"`
+	Volume  float64 `json:"volume"`
+}
+
+// Stingers are longer pieces of music played when a match is won or lost,
+// at their own Volume rather than the effects'. Win and Lose pick bundled
+// stingers by key; WinFile and LoseFile, if set, play a sound file instead.
+type Stingers struct {
+	Enabled  bool    `json:"enabled"`
+	Volume   float64 `json:"volume"`
+	Win      string  `json:"win"`
+	Lose     string  `json:"lose"`
+	WinFile  string  `json:"win_file"`
+	LoseFile string  `json:"lose_file"`
+}
+
+// BreakLimit locks the increment actions for a break after a run of
+// finished matches, to stop "one more game" spirals. The lock only holds
+// off the next match, never the rest of one. A run ends at a pause longer
+// than a session gap (30 minutes).
+type BreakLimit struct {
+	Enabled      bool `json:"enabled"`
+	Matches      int  `json:"matches"`       // matches in a row before a break
+	BreakMinutes int  `json:"break_minutes"` // how long increments stay locked
+}
+
+// Daily goal kinds, see DailyGoal.Kind.
+const (
+	GoalNetWins  = "net_wins"  // win Target more games than you lose
+	GoalMaxGames = "max_games" // play no more than Target games
+)
+
+// DailyGoal is a target for each day, tracked on the Tracker tab. Days start
+// at RolloverHour local time, so a session past midnight still counts
+// towards the evening it began.
+type DailyGoal struct {
+	Enabled      bool   `json:"enabled"`
+	Kind         string `json:"kind"` // GoalNetWins or GoalMaxGames
+	Target       int    `json:"target"`
+	RolloverHour int    `json:"rollover_hour"` // 0-23
+}
+
+// Retention prunes round-level detail older than KeepMonths at startup,
+// archiving it to CSV first. Per-day counts are kept so totals don't change.
+type Retention struct {
+	Enabled    bool `json:"enabled"`
+	KeepMonths int  `json:"keep_months"`
+}
+
+// Hook runs an external command on a tracker event. Command is run directly,
+// not through a shell; each of Args is a text/template over hooks.Data, e.g.
+// "{{.CTScore}}".
+type Hook struct {
+	Event     string   `json:"event"`  // "round_recorded" or "streak"
+	Streak    int      `json:"streak"` // for "streak": the run of wins or losses to fire at
+	Command   string   `json:"command"`
+	Args      []string `json:"args"`
+	TimeoutMs int      `json:"timeout_ms"` // 0 for the default of 10s
+}
+
+// Webhook posts tracker events as JSON to a URL, retrying with backoff
+// while it's unreachable.
+type Webhook struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"` // "round_recorded" and/or "streak"; empty for all
+	Secret string   `json:"secret"` // signs the body with HMAC-SHA256 if set
+}
+
+// Email configures the monthly summary email, sent over SMTP.
+type Email struct {
+	Enabled  bool   `json:"enabled"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"` // 587 for STARTTLS, 465 for implicit TLS
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	LastSent string `json:"last_sent"` // month of the last summary sent, e.g. "2025-03"
+}
+
+// Toasts configures the Windows notifications that ask who won a round, with
+// buttons to record it without switching windows.
+type Toasts struct {
+	Enabled         bool `json:"enabled"`          // ask when game state reports a round over
+	IntervalMinutes int  `json:"interval_minutes"` // also ask this often, 0 for never
+}
+
+// GSI configures the CS2 Game State Integration listener.
+type GSI struct {
+	Enabled bool   `json:"enabled"`
+	Port    int    `json:"port"`
+	Token   string `json:"token"` // must match the auth token in the game's cfg file
+}
+
+// FACEIT syncs the player's finished FACEIT matches into History through
+// the FACEIT Data API.
+type FACEIT struct {
+	Enabled         bool   `json:"enabled"`
+	Nickname        string `json:"nickname"`
+	APIKey          string `json:"api_key"`          // a server-side key from the FACEIT developer portal
+	IntervalMinutes int    `json:"interval_minutes"` // how often to check for new matches
+}
+
+// Account is a Steam account the player tracks rounds for, e.g. a main and
+// an alt. SteamID (the 64-bit ID) lets game state integration switch to it
+// automatically.
+type Account struct {
+	Name     string   `json:"name"`
+	SteamID  string   `json:"steam_id"`
+	Settings *Profile `json:"settings,omitempty"` // its own hotkeys, sounds and appearance, nil to follow the global ones
 }
 
 // Config holds the application configuration
 type Config struct {
-	SoundEnabled   bool    `json:"sound_enabled"`
-	SoundVolume    float64 `json:"sound_volume"`
-	MinimizeToTray bool    `json:"minimize_to_tray"`
-	Hotkeys        Hotkeys `json:"hotkeys"`
-	StatsPeriod    string  `json:"stats_period"`
-	StatsGroup     string  `json:"stats_group"`
+	Version        int               `json:"version"`
+	SoundEnabled   bool              `json:"sound_enabled"`
+	SoundVolume    float64           `json:"sound_volume"`
+	Announcer      Announcer         `json:"announcer"`
+	SoundTiming    SoundTiming       `json:"sound_timing"`
+	Ducking        Ducking           `json:"ducking"`
+	Stingers       Stingers          `json:"stingers"`
+	MinimizeToTray bool              `json:"minimize_to_tray"`
+	TouchMode      bool              `json:"touch_mode"` // large counter buttons and swipes, for touchscreens
+	Hotkeys        Hotkeys           `json:"hotkeys"`
+	HotkeyTiming   HotkeyTiming      `json:"hotkey_timing"`
+	HotkeyMatch    string            `json:"hotkey_match"` // MatchExact, MatchSuperset or MatchLongest
+	HotkeyFocus    bool              `json:"hotkey_focus"` // hotkeys fire only while CS2 or the tracker has the focus
+	StatsPeriod    string            `json:"stats_period"`
+	StatsPeriods   map[string]string `json:"stats_periods"` // last period picked on each Stats sub-tab, by tab name
+	StatsGroup     string            `json:"stats_group"`
+	MinSampleSize  int               `json:"min_sample_size"`
+	CTName         string            `json:"ct_name"`
+	TName          string            `json:"t_name"`
+	CTColor        string            `json:"ct_color"` // accent colour as #RRGGBB
+	TColor         string            `json:"t_color"`
+	CTLogo         string            `json:"ct_logo"` // image file shown above the CT counter, "" for none
+	TLogo          string            `json:"t_logo"`
+	ColorVision    string            `json:"color_vision"` // win/loss palette: "", "red-green" or "blue-yellow"
+	CopyFormat     string            `json:"copy_format"`  // "text" or "markdown" for copied history rows
+	OSD            OSD               `json:"osd"`
+	BreakLimit     BreakLimit        `json:"break_limit"`
+	DailyGoal      DailyGoal         `json:"daily_goal"`
+	Retention      Retention         `json:"retention"`
+	Hooks          []Hook            `json:"hooks"`
+	Plugins        []string          `json:"plugins"` // file names of the enabled plugins
+	Webhooks       []Webhook         `json:"webhooks"`
+	Email          Email             `json:"email"`
+	Toasts         Toasts            `json:"toasts"`
+	ShareName      string            `json:"share_name"`
+	GSI            GSI               `json:"gsi"`
+	FACEIT         FACEIT            `json:"faceit"`
+	Accounts       []Account         `json:"accounts"`
+	ActiveAccount  string            `json:"active_account"` // account new rounds are recorded against
+	StatsAccount   string            `json:"stats_account"`  // account stats are filtered to, "" for all
+	StatsMap       string            `json:"stats_map"`      // map stats are filtered to, "" for all
+	PINHash        string            `json:"pin_hash"`       // salted hash of the PIN guarding edits to History, "" for none
+
+	// global keeps the global hotkeys, sounds and appearance while the active
+	// account's profile is applied over them; nil while they apply.
+	global *scoped
+}
+
+// AccountBySteamID returns the name of the configured account with the given
+// Steam ID, or "" if there is none.
+func (c *Config) AccountBySteamID(steamID string) string {
+	for _, a := range c.Accounts {
+		if steamID != "" && a.SteamID == steamID {
+			return a.Name
+		}
+	}
+	return ""
 }
 
 // Default returns the default configuration
 // Hotkey defaults are platform-specific (see defaults_linux.go, defaults_windows.go)
 func Default() *Config {
 	return &Config{
+		Version:        CurrentVersion,
 		SoundEnabled:   true,
 		SoundVolume:    1.0,
 		MinimizeToTray: false,
 		Hotkeys:        defaultHotkeys(),
-		StatsPeriod:    "All Time",
-		StatsGroup:     "By Day",
+		HotkeyTiming: HotkeyTiming{
+			Repeat:      RepeatIgnore,
+			RepeatDelay: 500,
+		},
+		HotkeyMatch:   MatchExact,
+		StatsPeriod:   "All Time",
+		StatsGroup:    "By Day",
+		MinSampleSize: 10,
+		CTName:        "CT",
+		TName:         "T",
+		CTColor:       "#6495ED",
+		TColor:        "#FF8C00",
+		CopyFormat:    "text",
+		Announcer: Announcer{
+			Streaks:  []int{3, 5},
+			Comeback: 4,
+		},
+		SoundTiming: SoundTiming{Cooldown: 100},
+		Ducking:     Ducking{Volume: 0.3},
+		Stingers:    Stingers{Volume: 0.6, Win: "fanfare", Lose: "trombone"},
+		FACEIT:      FACEIT{IntervalMinutes: 15},
+		OSD: OSD{
+			Enabled:    true,
+			DurationMs: 1500,
+			Position:   OSDTopRight,
+		},
+		BreakLimit: BreakLimit{
+			Matches:      3,
+			BreakMinutes: 15,
+		},
+		DailyGoal: DailyGoal{
+			Kind:         GoalNetWins,
+			Target:       2,
+			RolloverHour: 4,
+		},
+		Retention: Retention{
+			KeepMonths: 12,
+		},
+		Email: Email{
+			Port: 587,
+		},
+		GSI: GSI{
+			Port: 3000,
+		},
 	}
 }
 
@@ -51,34 +331,37 @@ func Load(path string) (*Config, error) {
 		}
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
+	return parse(data)
+}
 
+// parse decodes config JSON and fills in anything missing with defaults.
+func parse(data []byte) (*Config, error) {
 	var cfg Config
 	if err := json.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	// Configs written before versioning have no version field; they're
+	// compatible with version 1 once the defaults below are filled in.
+	cfg.Version = CurrentVersion
 
 	// Ensure all hotkeys are set if missing (for app upgrades)
 	def := Default()
-	if len(cfg.Hotkeys.IncrementCT) == 0 {
-		cfg.Hotkeys.IncrementCT = def.Hotkeys.IncrementCT
-	}
-	if len(cfg.Hotkeys.DecrementCT) == 0 {
-		cfg.Hotkeys.DecrementCT = def.Hotkeys.DecrementCT
+	if cfg.Hotkeys == nil {
+		cfg.Hotkeys = make(Hotkeys)
 	}
-	if len(cfg.Hotkeys.IncrementT) == 0 {
-		cfg.Hotkeys.IncrementT = def.Hotkeys.IncrementT
-	}
-	if len(cfg.Hotkeys.DecrementT) == 0 {
-		cfg.Hotkeys.DecrementT = def.Hotkeys.DecrementT
+	for key, combo := range def.Hotkeys {
+		if _, ok := cfg.Hotkeys[key]; !ok {
+			cfg.Hotkeys[key] = combo
+		}
 	}
-	if len(cfg.Hotkeys.SelectCT) == 0 {
-		cfg.Hotkeys.SelectCT = def.Hotkeys.SelectCT
+	if cfg.HotkeyTiming.Repeat == "" {
+		cfg.HotkeyTiming.Repeat = def.HotkeyTiming.Repeat
 	}
-	if len(cfg.Hotkeys.SelectT) == 0 {
-		cfg.Hotkeys.SelectT = def.Hotkeys.SelectT
+	if cfg.HotkeyTiming.RepeatDelay <= 0 {
+		cfg.HotkeyTiming.RepeatDelay = def.HotkeyTiming.RepeatDelay
 	}
-	if len(cfg.Hotkeys.SwapTeams) == 0 {
-		cfg.Hotkeys.SwapTeams = def.Hotkeys.SwapTeams
+	if cfg.HotkeyMatch == "" {
+		cfg.HotkeyMatch = def.HotkeyMatch
 	}
 
 	// Ensure sound volume is set if missing (0 means not set in config)
@@ -93,10 +376,162 @@ func Load(path string) (*Config, error) {
 	if cfg.StatsGroup == "" {
 		cfg.StatsGroup = "By Day"
 	}
+	if cfg.MinSampleSize <= 0 {
+		cfg.MinSampleSize = def.MinSampleSize
+	}
+	if cfg.CTName == "" {
+		cfg.CTName = def.CTName
+	}
+	if cfg.TName == "" {
+		cfg.TName = def.TName
+	}
+	if cfg.CTColor == "" {
+		cfg.CTColor = def.CTColor
+	}
+	if cfg.TColor == "" {
+		cfg.TColor = def.TColor
+	}
+	if cfg.CopyFormat == "" {
+		cfg.CopyFormat = def.CopyFormat
+	}
+	if cfg.Announcer.Streaks == nil && cfg.Announcer.Comeback == 0 {
+		// Configs from before the announcer existed get its defaults.
+		cfg.Announcer.Streaks = def.Announcer.Streaks
+		cfg.Announcer.Comeback = def.Announcer.Comeback
+	}
+	if cfg.Ducking.Volume == 0 {
+		// Like the effect volume, 0 means not set.
+		cfg.Ducking.Volume = def.Ducking.Volume
+	}
+	if cfg.Stingers.Volume == 0 {
+		cfg.Stingers.Volume = def.Stingers.Volume
+	}
+	if cfg.Stingers.Win == "" {
+		cfg.Stingers.Win = def.Stingers.Win
+	}
+	if cfg.Stingers.Lose == "" {
+		cfg.Stingers.Lose = def.Stingers.Lose
+	}
+	if cfg.FACEIT.IntervalMinutes <= 0 {
+		cfg.FACEIT.IntervalMinutes = def.FACEIT.IntervalMinutes
+	}
+	if cfg.OSD == (OSD{}) {
+		// Configs from before the OSD existed get it switched on.
+		cfg.OSD = def.OSD
+	}
+	if cfg.OSD.DurationMs <= 0 {
+		cfg.OSD.DurationMs = def.OSD.DurationMs
+	}
+	if cfg.OSD.Position == "" {
+		cfg.OSD.Position = def.OSD.Position
+	}
+	if cfg.BreakLimit.Matches <= 0 {
+		cfg.BreakLimit.Matches = def.BreakLimit.Matches
+	}
+	if cfg.BreakLimit.BreakMinutes <= 0 {
+		cfg.BreakLimit.BreakMinutes = def.BreakLimit.BreakMinutes
+	}
+	if cfg.DailyGoal == (DailyGoal{}) {
+		// Configs from before daily goals existed get the defaults, rather
+		// than a midnight rollover.
+		cfg.DailyGoal = def.DailyGoal
+	}
+	if cfg.DailyGoal.Kind != GoalNetWins && cfg.DailyGoal.Kind != GoalMaxGames {
+		cfg.DailyGoal.Kind = def.DailyGoal.Kind
+	}
+	if cfg.DailyGoal.Target <= 0 {
+		cfg.DailyGoal.Target = def.DailyGoal.Target
+	}
+	if cfg.DailyGoal.RolloverHour < 0 || cfg.DailyGoal.RolloverHour > 23 {
+		cfg.DailyGoal.RolloverHour = def.DailyGoal.RolloverHour
+	}
+	if cfg.Retention.KeepMonths <= 0 {
+		cfg.Retention.KeepMonths = def.Retention.KeepMonths
+	}
+	if cfg.Email.Port <= 0 || cfg.Email.Port > 65535 {
+		cfg.Email.Port = def.Email.Port
+	}
+	if cfg.Toasts.IntervalMinutes < 0 {
+		cfg.Toasts.IntervalMinutes = 0
+	}
+	if cfg.GSI.Port <= 0 || cfg.GSI.Port > 65535 {
+		cfg.GSI.Port = def.GSI.Port
+	}
 
+	cfg.enterProfile()
 	return &cfg, nil
 }
 
+// Export writes cfg as portable settings JSON, e.g. for copying a setup to
+// another PC. The PIN, passwords, keys and tokens are left blank so the file
+// is safe to pass around.
+func Export(cfg *Config, w io.Writer) error {
+	c := *cfg
+	c.GSI.Token = ""
+	c.FACEIT.APIKey = ""
+	c.Email.Password = ""
+	c.PINHash = ""
+	c.Webhooks = slices.Clone(c.Webhooks)
+	for i := range c.Webhooks {
+		c.Webhooks[i].Secret = ""
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write settings: %w", err)
+	}
+	return nil
+}
+
+// Import reads settings JSON produced by Export. Settings from older versions
+// are upgraded; settings from a newer version of the app are rejected. The
+// PIN, passwords, keys and tokens the file leaves blank keep local's values.
+func Import(r io.Reader, local *Config) (*Config, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings: %w", err)
+	}
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to parse settings: %w", err)
+	}
+	if header.Version > CurrentVersion {
+		return nil, fmt.Errorf("settings are from a newer version of the app (format %d, this build supports %d)",
+			header.Version, CurrentVersion)
+	}
+	cfg, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	keepSecrets(cfg, local)
+	return cfg, nil
+}
+
+// keepSecrets fills in the secrets Export blanks out of cfg from local. A
+// webhook's secret is kept if local has a webhook with the same URL.
+func keepSecrets(cfg, local *Config) {
+	keep := func(s *string, old string) {
+		if *s == "" {
+			*s = old
+		}
+	}
+	keep(&cfg.GSI.Token, local.GSI.Token)
+	keep(&cfg.FACEIT.APIKey, local.FACEIT.APIKey)
+	keep(&cfg.Email.Password, local.Email.Password)
+	keep(&cfg.PINHash, local.PINHash)
+	for i := range cfg.Webhooks {
+		for _, h := range local.Webhooks {
+			if h.URL == cfg.Webhooks[i].URL {
+				keep(&cfg.Webhooks[i].Secret, h.Secret)
+			}
+		}
+	}
+}
+
 // Save writes the configuration to the specified file
 func Save(cfg *Config, path string) error {
 	data, err := json.MarshalIndent(cfg, "", "  ")
@@ -104,9 +539,35 @@ func Save(cfg *Config, path string) error {
 		return fmt.Errorf("failed to marshal config: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes data to a temp file next to path and renames it into
+// place, so readers (and a crash mid-write) never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
 		return fmt.Errorf("failed to write config: %w", err)
 	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // no-op once renamed
 
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,55 @@
+package config_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"csstatstracker/internal/config"
+)
+
+func TestExportLeavesOutSecrets(t *testing.T) {
+	cfg := config.Default()
+	cfg.GSI.Token = "gsi-token"
+	cfg.FACEIT.APIKey = "faceit-key"
+	cfg.Email.Password = "smtp-password"
+	cfg.Webhooks = []config.Webhook{{URL: "https://example.com/hook", Secret: "hook-secret"}}
+	if err := cfg.SetPIN("1234"); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := config.Export(cfg, &buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"gsi-token", "faceit-key", "smtp-password", "hook-secret", cfg.PINHash} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("exported settings contain %q", secret)
+		}
+	}
+	if cfg.GSI.Token != "gsi-token" || cfg.Webhooks[0].Secret != "hook-secret" || !cfg.HasPIN() {
+		t.Error("exporting blanked the live config's secrets")
+	}
+
+	// Importing on another PC keeps its own secrets.
+	local := config.Default()
+	local.GSI.Token = "other-token"
+	local.FACEIT.APIKey = "other-key"
+	local.Email.Password = "other-password"
+	local.Webhooks = []config.Webhook{{URL: "https://example.com/hook", Secret: "other-secret"}}
+	if err := local.SetPIN("5678"); err != nil {
+		t.Fatal(err)
+	}
+	imported, err := config.Import(&buf, local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imported.GSI.Token != "other-token" || imported.FACEIT.APIKey != "other-key" ||
+		imported.Email.Password != "other-password" || imported.Webhooks[0].Secret != "other-secret" {
+		t.Errorf("imported token %q, key %q, password %q and webhook secret %q; want the local ones",
+			imported.GSI.Token, imported.FACEIT.APIKey, imported.Email.Password, imported.Webhooks[0].Secret)
+	}
+	if !imported.CheckPIN("5678") {
+		t.Error("importing settings without a PIN dropped the local PIN")
+	}
+}
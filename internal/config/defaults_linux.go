@@ -2,15 +2,16 @@
 
 package config
 
-// defaultHotkeys returns the default hotkey bindings for Linux
+// defaultHotkeys returns the default hotkey bindings for Linux, by action
+// key. Actions without an entry are unbound until the user sets one.
 func defaultHotkeys() Hotkeys {
 	return Hotkeys{
-		IncrementCT: []string{"Numpad1", "NumpadAdd"},
-		DecrementCT: []string{"Numpad1", "NumpadSubtract"},
-		IncrementT:  []string{"Numpad2", "NumpadAdd"},
-		DecrementT:  []string{"Numpad2", "NumpadSubtract"},
-		SelectCT:    []string{"LeftControl", "LeftShift", "c"},
-		SelectT:     []string{"LeftControl", "LeftShift", "t"},
-		SwapTeams:   []string{"NumpadDecimal", "NumpadEnter"},
+		"increment_ct": {"Numpad1", "NumpadAdd"},
+		"decrement_ct": {"Numpad1", "NumpadSubtract"},
+		"increment_t":  {"Numpad2", "NumpadAdd"},
+		"decrement_t":  {"Numpad2", "NumpadSubtract"},
+		"select_ct":    {"LeftControl", "LeftShift", "c"},
+		"select_t":     {"LeftControl", "LeftShift", "t"},
+		"swap_teams":   {"NumpadDecimal", "NumpadEnter"},
 	}
 }
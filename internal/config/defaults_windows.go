@@ -2,16 +2,17 @@
 
 package config
 
-// defaultHotkeys returns the default hotkey bindings for Windows
+// defaultHotkeys returns the default hotkey bindings for Windows, by action
+// key. Actions without an entry are unbound until the user sets one.
 // Windows uses character equivalents for numpad keys to match Fyne's capture
 func defaultHotkeys() Hotkeys {
 	return Hotkeys{
-		IncrementCT: []string{"1", "+"},
-		DecrementCT: []string{"1", "-"},
-		IncrementT:  []string{"2", "+"},
-		DecrementT:  []string{"2", "-"},
-		SelectCT:    []string{"LeftControl", "LeftShift", "C"},
-		SelectT:     []string{"LeftControl", "LeftShift", "T"},
-		SwapTeams:   []string{".", "KP_Enter"},
+		"increment_ct": {"1", "+"},
+		"decrement_ct": {"1", "-"},
+		"increment_t":  {"2", "+"},
+		"decrement_t":  {"2", "-"},
+		"select_ct":    {"LeftControl", "LeftShift", "C"},
+		"select_t":     {"LeftControl", "LeftShift", "T"},
+		"swap_teams":   {".", "KP_Enter"},
 	}
 }
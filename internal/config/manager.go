@@ -0,0 +1,119 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// saveDelay is how long the Manager waits for further changes before writing.
+// Dragging the volume slider, for example, would otherwise write the file on
+// every step.
+const saveDelay = 500 * time.Millisecond
+
+// Manager is the single writer for a config file. UI code mutates the shared
+// *Config and calls Save; the Manager snapshots it straight away and writes
+// the latest snapshot once changes have settled, atomically, so concurrent
+// saves can't interleave or leave a truncated file behind.
+type Manager struct {
+	path string
+	cfg  *Config
+
+	mu          sync.Mutex
+	pending     []byte // snapshot waiting to be written, nil if none
+	lastWritten []byte // what we last wrote, to recognise our own saves
+	timer       *time.Timer
+	onError     func(error)
+}
+
+// NewManager creates a manager for cfg backed by the file at path. onError
+// receives write failures from the background save.
+func NewManager(path string, cfg *Config, onError func(error)) *Manager {
+	return &Manager{path: path, cfg: cfg, onError: onError}
+}
+
+// Config returns the managed config.
+func (m *Manager) Config() *Config { return m.cfg }
+
+// Path returns the config file path.
+func (m *Manager) Path() string { return m.path }
+
+// Save schedules a write of the config's current state. Call it on the
+// goroutine that mutated the config (normally the UI thread).
+func (m *Manager) Save() {
+	data, err := json.MarshalIndent(m.cfg, "", "  ")
+	if err != nil {
+		m.reportError(fmt.Errorf("failed to marshal config: %w", err))
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = data
+	if m.timer != nil {
+		m.timer.Stop()
+	}
+	m.timer = time.AfterFunc(saveDelay, func() {
+		if err := m.Flush(); err != nil {
+			m.reportError(err)
+		}
+	})
+}
+
+// Flush writes any pending changes immediately. Call it on shutdown.
+func (m *Manager) Flush() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	if m.pending == nil {
+		return nil
+	}
+	if err := writeFileAtomic(m.path, m.pending); err != nil {
+		return err
+	}
+	m.lastWritten = m.pending
+	m.pending = nil
+	return nil
+}
+
+// Pending reports whether there are changes that haven't been written yet.
+func (m *Manager) Pending() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pending != nil
+}
+
+// IsOwnWrite reports whether loaded is exactly what this manager last wrote,
+// so a file watcher can ignore the echo of our own saves.
+func (m *Manager) IsOwnWrite(loaded *Config) bool {
+	data, err := json.MarshalIndent(loaded, "", "  ")
+	if err != nil {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return bytes.Equal(data, m.lastWritten)
+}
+
+// Discard drops any pending write, e.g. when an external edit is accepted
+// over unsaved in-app changes.
+func (m *Manager) Discard() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.timer != nil {
+		m.timer.Stop()
+		m.timer = nil
+	}
+	m.pending = nil
+}
+
+func (m *Manager) reportError(err error) {
+	if m.onError != nil {
+		m.onError(err)
+	}
+}
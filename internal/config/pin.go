@@ -0,0 +1,57 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MinPINLength is the shortest PIN or password SetPIN accepts.
+const MinPINLength = 4
+
+// SetPIN sets the PIN that guards deleting and editing rounds, stored as a
+// salted hash. An empty pin removes it.
+func (c *Config) SetPIN(pin string) error {
+	if pin == "" {
+		c.PINHash = ""
+		return nil
+	}
+	if utf8.RuneCountInString(pin) < MinPINLength {
+		return fmt.Errorf("the PIN must be at least %d characters long", MinPINLength)
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to salt PIN: %w", err)
+	}
+	c.PINHash = hex.EncodeToString(salt) + "$" + pinDigest(salt, pin)
+	return nil
+}
+
+// HasPIN reports whether a PIN is set.
+func (c *Config) HasPIN() bool { return c.PINHash != "" }
+
+// CheckPIN reports whether pin is the PIN that was set. Any pin matches
+// when none is set.
+func (c *Config) CheckPIN(pin string) bool {
+	if c.PINHash == "" {
+		return true
+	}
+	saltHex, digest, ok := strings.Cut(c.PINHash, "$")
+	salt, err := hex.DecodeString(saltHex)
+	if !ok || err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(pinDigest(salt, pin)), []byte(digest)) == 1
+}
+
+// pinDigest returns the hex SHA-256 of salt followed by pin.
+func pinDigest(salt []byte, pin string) string {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(pin))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,37 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"csstatstracker/internal/config"
+)
+
+func TestPIN(t *testing.T) {
+	cfg := config.Default()
+	if cfg.HasPIN() || !cfg.CheckPIN("") {
+		t.Fatal("a new config has a PIN")
+	}
+	if err := cfg.SetPIN("123"); err == nil {
+		t.Error("SetPIN accepted a 3 digit PIN")
+	}
+	if err := cfg.SetPIN("2580"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(cfg.PINHash, "2580") {
+		t.Errorf("PIN stored in the clear: %q", cfg.PINHash)
+	}
+	if !cfg.CheckPIN("2580") || cfg.CheckPIN("0000") || cfg.CheckPIN("") {
+		t.Error("CheckPIN doesn't match only the PIN that was set")
+	}
+
+	// The same PIN is salted differently each time it's set.
+	first := cfg.PINHash
+	if err := cfg.SetPIN("2580"); err != nil || cfg.PINHash == first {
+		t.Errorf("setting the PIN again gave hash %q, err %v; want a new salt", cfg.PINHash, err)
+	}
+
+	if err := cfg.SetPIN(""); err != nil || cfg.HasPIN() {
+		t.Errorf("SetPIN(\"\") = %v, HasPIN = %v; want the PIN removed", err, cfg.HasPIN())
+	}
+}
@@ -0,0 +1,231 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// Profile holds the hotkeys, sounds and appearance an account has its own
+// settings for. Nil fields, and hotkey actions not listed, follow the global
+// settings, so a profile only keeps what differs from them.
+type Profile struct {
+	Hotkeys      Hotkeys    `json:"hotkeys,omitempty"`
+	SoundEnabled *bool      `json:"sound_enabled,omitempty"`
+	SoundVolume  *float64   `json:"sound_volume,omitempty"`
+	Announcer    *Announcer `json:"announcer,omitempty"`
+	Stingers     *Stingers  `json:"stingers,omitempty"`
+	TouchMode    *bool      `json:"touch_mode,omitempty"`
+	CTName       *string    `json:"ct_name,omitempty"`
+	TName        *string    `json:"t_name,omitempty"`
+	CTColor      *string    `json:"ct_color,omitempty"`
+	TColor       *string    `json:"t_color,omitempty"`
+	CTLogo       *string    `json:"ct_logo,omitempty"`
+	TLogo        *string    `json:"t_logo,omitempty"`
+	ColorVision  *string    `json:"color_vision,omitempty"`
+}
+
+// scoped is the value of every setting a Profile can override.
+type scoped struct {
+	hotkeys      Hotkeys
+	soundEnabled bool
+	soundVolume  float64
+	announcer    Announcer
+	stingers     Stingers
+	touchMode    bool
+	ctName       string
+	tName        string
+	ctColor      string
+	tColor       string
+	ctLogo       string
+	tLogo        string
+	colorVision  string
+}
+
+// scopedValues returns a copy of c's scoped settings that shares nothing
+// with c.
+func (c *Config) scopedValues() scoped {
+	announcer := c.Announcer
+	announcer.Streaks = slices.Clone(announcer.Streaks)
+	return scoped{
+		hotkeys:      cloneHotkeys(c.Hotkeys),
+		soundEnabled: c.SoundEnabled,
+		soundVolume:  c.SoundVolume,
+		announcer:    announcer,
+		stingers:     c.Stingers,
+		touchMode:    c.TouchMode,
+		ctName:       c.CTName,
+		tName:        c.TName,
+		ctColor:      c.CTColor,
+		tColor:       c.TColor,
+		ctLogo:       c.CTLogo,
+		tLogo:        c.TLogo,
+		colorVision:  c.ColorVision,
+	}
+}
+
+// setScoped sets c's scoped settings to v.
+func (c *Config) setScoped(v scoped) {
+	c.Hotkeys = v.hotkeys
+	c.SoundEnabled = v.soundEnabled
+	c.SoundVolume = v.soundVolume
+	c.Announcer = v.announcer
+	c.Stingers = v.stingers
+	c.TouchMode = v.touchMode
+	c.CTName = v.ctName
+	c.TName = v.tName
+	c.CTColor = v.ctColor
+	c.TColor = v.tColor
+	c.CTLogo = v.ctLogo
+	c.TLogo = v.tLogo
+	c.ColorVision = v.colorVision
+}
+
+// over returns global with p's overrides applied.
+func (p *Profile) over(global scoped) scoped {
+	v := global
+	v.hotkeys = cloneHotkeys(global.hotkeys)
+	for action, combo := range p.Hotkeys {
+		v.hotkeys[action] = slices.Clone(combo)
+	}
+	v.soundEnabled = inherit(global.soundEnabled, p.SoundEnabled)
+	v.soundVolume = inherit(global.soundVolume, p.SoundVolume)
+	v.announcer = inherit(global.announcer, p.Announcer)
+	v.announcer.Streaks = slices.Clone(v.announcer.Streaks)
+	v.stingers = inherit(global.stingers, p.Stingers)
+	v.touchMode = inherit(global.touchMode, p.TouchMode)
+	v.ctName = inherit(global.ctName, p.CTName)
+	v.tName = inherit(global.tName, p.TName)
+	v.ctColor = inherit(global.ctColor, p.CTColor)
+	v.tColor = inherit(global.tColor, p.TColor)
+	v.ctLogo = inherit(global.ctLogo, p.CTLogo)
+	v.tLogo = inherit(global.tLogo, p.TLogo)
+	v.colorVision = inherit(global.colorVision, p.ColorVision)
+	return v
+}
+
+// profileOf returns the profile that makes global into v.
+func profileOf(global, v scoped) *Profile {
+	p := &Profile{
+		SoundEnabled: override(global.soundEnabled, v.soundEnabled),
+		SoundVolume:  override(global.soundVolume, v.soundVolume),
+		Announcer:    override(global.announcer, v.announcer),
+		Stingers:     override(global.stingers, v.stingers),
+		TouchMode:    override(global.touchMode, v.touchMode),
+		CTName:       override(global.ctName, v.ctName),
+		TName:        override(global.tName, v.tName),
+		CTColor:      override(global.ctColor, v.ctColor),
+		TColor:       override(global.tColor, v.tColor),
+		CTLogo:       override(global.ctLogo, v.ctLogo),
+		TLogo:        override(global.tLogo, v.tLogo),
+		ColorVision:  override(global.colorVision, v.colorVision),
+	}
+	for action, combo := range v.hotkeys {
+		if g, ok := global.hotkeys[action]; !ok || !slices.Equal(g, combo) {
+			if p.Hotkeys == nil {
+				p.Hotkeys = make(Hotkeys)
+			}
+			p.Hotkeys[action] = slices.Clone(combo)
+		}
+	}
+	return p
+}
+
+// inherit returns the override if there is one, and global otherwise.
+func inherit[T any](global T, override *T) T {
+	if override == nil {
+		return global
+	}
+	return *override
+}
+
+// override returns nil if v is the global value, and v otherwise.
+func override[T any](global, v T) *T {
+	if reflect.DeepEqual(global, v) {
+		return nil
+	}
+	return &v
+}
+
+func cloneHotkeys(h Hotkeys) Hotkeys {
+	out := make(Hotkeys, len(h))
+	for action, combo := range h {
+		out[action] = slices.Clone(combo)
+	}
+	return out
+}
+
+// profileAccount returns the active account if it has a profile, or nil if
+// the global settings apply.
+func (c *Config) profileAccount() *Account {
+	if c.ActiveAccount == "" {
+		return nil
+	}
+	for i := range c.Accounts {
+		if c.Accounts[i].Name == c.ActiveAccount && c.Accounts[i].Settings != nil {
+			return &c.Accounts[i]
+		}
+	}
+	return nil
+}
+
+// enterProfile applies the active account's profile over the global
+// settings, keeping them aside to save and to return to.
+func (c *Config) enterProfile() {
+	a := c.profileAccount()
+	if a == nil || c.global != nil {
+		return
+	}
+	global := c.scopedValues()
+	c.global = &global
+	c.setScoped(a.Settings.over(global))
+}
+
+// leaveProfile saves the scoped settings into the active account's profile
+// and puts the global settings back.
+func (c *Config) leaveProfile() {
+	if c.global == nil {
+		return
+	}
+	if a := c.profileAccount(); a != nil {
+		a.Settings = profileOf(*c.global, c.scopedValues())
+	}
+	c.setScoped(*c.global)
+	c.global = nil
+}
+
+// SwitchAccount makes name the active account, switching the hotkeys, sounds
+// and appearance to its profile, or back to the global settings, in one go.
+// Changes to those settings made while an account with a profile is active
+// are kept in its profile.
+func (c *Config) SwitchAccount(name string) {
+	c.leaveProfile()
+	c.ActiveAccount = name
+	c.enterProfile()
+}
+
+// SetOwnSettings gives account i a profile of its own, starting from the
+// global settings, or drops its profile so it follows them again.
+func (c *Config) SetOwnSettings(i int, own bool) {
+	c.leaveProfile()
+	switch {
+	case !own:
+		c.Accounts[i].Settings = nil
+	case c.Accounts[i].Settings == nil:
+		c.Accounts[i].Settings = &Profile{}
+	}
+	c.enterProfile()
+}
+
+// MarshalJSON writes c as it's kept on disk: the global settings, and each
+// account's profile with the changes made while it's been active.
+func (c Config) MarshalJSON() ([]byte, error) {
+	type plain Config
+	if c.global == nil {
+		return json.Marshal(plain(c))
+	}
+	out := c
+	out.Accounts = slices.Clone(c.Accounts)
+	out.leaveProfile()
+	return json.Marshal(plain(out))
+}
@@ -0,0 +1,86 @@
+package config_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"csstatstracker/internal/config"
+)
+
+func TestSwitchAccount(t *testing.T) {
+	cfg := config.Default()
+	cfg.Accounts = []config.Account{{Name: "main"}, {Name: "alt"}}
+	cfg.SetOwnSettings(1, true)
+	globalCombo := slices.Clone(cfg.Hotkeys["increment_ct"])
+
+	// Changes made on alt stay with alt.
+	cfg.SwitchAccount("alt")
+	cfg.SoundVolume = 0.4
+	cfg.CTName = "Blue"
+	cfg.Hotkeys["increment_ct"] = []string{"F9"}
+
+	cfg.SwitchAccount("main")
+	if cfg.SoundVolume != 1 || cfg.CTName != "CT" || !slices.Equal(cfg.Hotkeys["increment_ct"], globalCombo) {
+		t.Errorf("main has volume %v, CT name %q and increment_ct %v; want the global 1, CT and %v",
+			cfg.SoundVolume, cfg.CTName, cfg.Hotkeys["increment_ct"], globalCombo)
+	}
+
+	// Global changes reach alt unless alt has its own.
+	cfg.CTName = "Defenders"
+	cfg.TName = "Attackers"
+	cfg.SwitchAccount("alt")
+	if cfg.SoundVolume != 0.4 || cfg.CTName != "Blue" || cfg.TName != "Attackers" ||
+		!slices.Equal(cfg.Hotkeys["increment_ct"], []string{"F9"}) {
+		t.Errorf("alt has volume %v, names %q/%q and increment_ct %v; want 0.4, Blue/Attackers and [F9]",
+			cfg.SoundVolume, cfg.CTName, cfg.TName, cfg.Hotkeys["increment_ct"])
+	}
+
+	// Dropping alt's profile puts the global settings back.
+	cfg.SetOwnSettings(1, false)
+	if cfg.SoundVolume != 1 || cfg.CTName != "Defenders" || cfg.Accounts[1].Settings != nil {
+		t.Errorf("without a profile alt has volume %v and CT name %q, profile %+v; want the global ones",
+			cfg.SoundVolume, cfg.CTName, cfg.Accounts[1].Settings)
+	}
+}
+
+func TestProfileSaved(t *testing.T) {
+	cfg := config.Default()
+	cfg.Accounts = []config.Account{{Name: "alt"}}
+	cfg.SetOwnSettings(0, true)
+	cfg.SwitchAccount("alt")
+	cfg.SoundVolume = 0.4
+	cfg.ColorVision = "red-green"
+
+	var buf bytes.Buffer
+	if err := config.Export(cfg, &buf); err != nil {
+		t.Fatal(err)
+	}
+	var saved struct {
+		SoundVolume float64          `json:"sound_volume"`
+		Accounts    []config.Account `json:"accounts"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.SoundVolume != 1 {
+		t.Errorf("saved global volume = %v, want 1", saved.SoundVolume)
+	}
+	p := saved.Accounts[0].Settings
+	if p == nil || p.SoundVolume == nil || *p.SoundVolume != 0.4 || p.ColorVision == nil || p.CTName != nil || len(p.Hotkeys) != 0 {
+		t.Fatalf("saved profile = %+v, want only the volume and colour vision", p)
+	}
+
+	// Loading the saved settings applies alt's profile straight away.
+	loaded, err := config.Import(&buf, config.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.SoundVolume != 0.4 || loaded.ColorVision != "red-green" {
+		t.Errorf("loaded volume %v and colour vision %q, want alt's 0.4 and red-green", loaded.SoundVolume, loaded.ColorVision)
+	}
+	if cfg.SoundVolume != 0.4 {
+		t.Errorf("saving changed the live volume to %v", cfg.SoundVolume)
+	}
+}
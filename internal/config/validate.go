@@ -0,0 +1,340 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Problem describes one thing wrong with a config file.
+type Problem struct {
+	Field   string // JSON key path, e.g. "hotkeys.increment_ct"
+	Message string
+}
+
+func (p Problem) String() string {
+	return p.Field + ": " + p.Message
+}
+
+// Validate checks the config file at path for unknown keys and invalid values.
+// validKey reports whether a key name can be bound on this platform. A missing
+// file has no problems.
+func Validate(path string, validKey func(string) bool) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	problems := unknownKeys("", raw, reflect.TypeOf(Config{}))
+
+	cfg, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	return append(problems, checkValues(cfg, validKey, false)...), nil
+}
+
+// Repair fixes invalid values in cfg in place — out-of-range numbers are
+// clamped and hotkeys using unbindable keys are reset to their defaults — and
+// returns what it changed. Unknown keys need no repair: they're dropped the
+// next time the config is saved.
+func Repair(cfg *Config, validKey func(string) bool) []Problem {
+	return checkValues(cfg, validKey, true)
+}
+
+// checkValues reports invalid values in cfg, fixing them when fix is set.
+func checkValues(cfg *Config, validKey func(string) bool, fix bool) []Problem {
+	var problems []Problem
+
+	if cfg.SoundVolume < 0 || cfg.SoundVolume > 1 {
+		problems = append(problems, Problem{
+			Field:   "sound_volume",
+			Message: fmt.Sprintf("%g is outside 0–1", cfg.SoundVolume),
+		})
+		if fix {
+			cfg.SoundVolume = min(max(cfg.SoundVolume, 0), 1)
+		}
+	}
+
+	problems = append(problems, checkSoundTiming(cfg, fix)...)
+
+	if cfg.Ducking.Volume < 0 || cfg.Ducking.Volume > 1 {
+		problems = append(problems, Problem{
+			Field:   "ducking.volume",
+			Message: fmt.Sprintf("%g is outside 0–1", cfg.Ducking.Volume),
+		})
+		if fix {
+			cfg.Ducking.Volume = min(max(cfg.Ducking.Volume, 0), 1)
+		}
+	}
+	if cfg.Stingers.Volume < 0 || cfg.Stingers.Volume > 1 {
+		problems = append(problems, Problem{
+			Field:   "stingers.volume",
+			Message: fmt.Sprintf("%g is outside 0–1", cfg.Stingers.Volume),
+		})
+		if fix {
+			cfg.Stingers.Volume = min(max(cfg.Stingers.Volume, 0), 1)
+		}
+	}
+
+	defaults := defaultHotkeys()
+	actions := make([]string, 0, len(cfg.Hotkeys))
+	for action := range cfg.Hotkeys {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+	for _, action := range actions {
+		if !isHotkeyAction(action) {
+			problems = append(problems, Problem{
+				Field:   "hotkeys." + action,
+				Message: "unknown action, ignored",
+			})
+			if fix {
+				delete(cfg.Hotkeys, action)
+			}
+			continue
+		}
+		var bad []string
+		for _, key := range cfg.Hotkeys[action] {
+			if !validKey(key) {
+				bad = append(bad, key)
+			}
+		}
+		if len(bad) == 0 {
+			continue
+		}
+		problems = append(problems, Problem{
+			Field:   "hotkeys." + action,
+			Message: fmt.Sprintf("unknown key name(s) %s", strings.Join(bad, ", ")),
+		})
+		if fix {
+			cfg.Hotkeys[action] = defaults[action]
+		}
+	}
+
+	problems = append(problems, checkHotkeyTiming(cfg, fix)...)
+	problems = append(problems, checkHooks(cfg, fix)...)
+	problems = append(problems, checkWebhooks(cfg, fix)...)
+
+	switch cfg.OSD.Position {
+	case OSDCenter, OSDTopLeft, OSDTopRight, OSDBottomLeft, OSDBottomRight:
+	default:
+		problems = append(problems, Problem{
+			Field:   "osd.position",
+			Message: fmt.Sprintf("unknown position %q", cfg.OSD.Position),
+		})
+		if fix {
+			cfg.OSD.Position = OSDTopRight
+		}
+	}
+
+	switch cfg.HotkeyMatch {
+	case MatchExact, MatchSuperset, MatchLongest:
+	default:
+		problems = append(problems, Problem{
+			Field: "hotkey_match",
+			Message: fmt.Sprintf("unknown strategy %q, expected %q, %q or %q",
+				cfg.HotkeyMatch, MatchExact, MatchSuperset, MatchLongest),
+		})
+		if fix {
+			cfg.HotkeyMatch = MatchExact
+		}
+	}
+
+	return problems
+}
+
+// checkHotkeyTiming reports cooldowns for actions that don't exist or below
+// zero, and unknown repeat policies, fixing them when fix is set.
+func checkHotkeyTiming(cfg *Config, fix bool) []Problem {
+	var problems []Problem
+
+	names := make([]string, 0, len(cfg.HotkeyTiming.Cooldowns))
+	for name := range cfg.HotkeyTiming.Cooldowns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ms := cfg.HotkeyTiming.Cooldowns[name]
+		switch {
+		case !isHotkeyAction(name):
+			problems = append(problems, Problem{
+				Field:   "hotkey_timing.cooldowns_ms." + name,
+				Message: "unknown action, ignored",
+			})
+		case ms < 0:
+			problems = append(problems, Problem{
+				Field:   "hotkey_timing.cooldowns_ms." + name,
+				Message: fmt.Sprintf("%d is negative", ms),
+			})
+		default:
+			continue
+		}
+		if fix {
+			delete(cfg.HotkeyTiming.Cooldowns, name)
+		}
+	}
+
+	if r := cfg.HotkeyTiming.Repeat; r != RepeatIgnore && r != RepeatDelay {
+		problems = append(problems, Problem{
+			Field:   "hotkey_timing.repeat",
+			Message: fmt.Sprintf("unknown policy %q, expected %q or %q", r, RepeatIgnore, RepeatDelay),
+		})
+		if fix {
+			cfg.HotkeyTiming.Repeat = RepeatIgnore
+		}
+	}
+	return problems
+}
+
+// checkSoundTiming reports negative sound cooldowns and cooldowns for
+// unknown sounds, resetting or removing them when fix is set.
+func checkSoundTiming(cfg *Config, fix bool) []Problem {
+	var problems []Problem
+	if cfg.SoundTiming.Cooldown < 0 {
+		problems = append(problems, Problem{
+			Field:   "sound_timing.cooldown_ms",
+			Message: fmt.Sprintf("%d is negative", cfg.SoundTiming.Cooldown),
+		})
+		if fix {
+			cfg.SoundTiming.Cooldown = 0
+		}
+	}
+
+	names := make([]string, 0, len(cfg.SoundTiming.Cooldowns))
+	for name := range cfg.SoundTiming.Cooldowns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ms := cfg.SoundTiming.Cooldowns[name]
+		switch {
+		case !slices.Contains(SoundEvents, name):
+			problems = append(problems, Problem{
+				Field:   "sound_timing.cooldowns_ms." + name,
+				Message: "unknown sound, ignored",
+			})
+		case ms < 0:
+			problems = append(problems, Problem{
+				Field:   "sound_timing.cooldowns_ms." + name,
+				Message: fmt.Sprintf("%d is negative", ms),
+			})
+		default:
+			continue
+		}
+		if fix {
+			delete(cfg.SoundTiming.Cooldowns, name)
+		}
+	}
+	return problems
+}
+
+// checkHooks reports hooks that can never run, removing them when fix is set.
+func checkHooks(cfg *Config, fix bool) []Problem {
+	var problems []Problem
+	kept := cfg.Hooks[:0:0]
+	for i, h := range cfg.Hooks {
+		var msg string
+		switch {
+		case h.Event != "round_recorded" && h.Event != "streak":
+			msg = fmt.Sprintf("unknown event %q", h.Event)
+		case h.Event == "streak" && h.Streak <= 0:
+			msg = "streak must be at least 1"
+		case h.Command == "":
+			msg = "no command"
+		}
+		if msg == "" {
+			kept = append(kept, h)
+			continue
+		}
+		problems = append(problems, Problem{Field: fmt.Sprintf("hooks[%d]", i), Message: msg})
+	}
+	if fix && len(problems) > 0 {
+		cfg.Hooks = kept
+	}
+	return problems
+}
+
+// checkWebhooks reports webhooks that can never be delivered, removing them
+// when fix is set.
+func checkWebhooks(cfg *Config, fix bool) []Problem {
+	var problems []Problem
+	kept := cfg.Webhooks[:0:0]
+	for i, w := range cfg.Webhooks {
+		var msg string
+		if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			msg = fmt.Sprintf("invalid URL %q", w.URL)
+		}
+		for _, event := range w.Events {
+			if msg == "" && event != "round_recorded" && event != "streak" {
+				msg = fmt.Sprintf("unknown event %q", event)
+			}
+		}
+		if msg == "" {
+			kept = append(kept, w)
+			continue
+		}
+		problems = append(problems, Problem{Field: fmt.Sprintf("webhooks[%d]", i), Message: msg})
+	}
+	if fix && len(problems) > 0 {
+		cfg.Webhooks = kept
+	}
+	return problems
+}
+
+// unknownKeys reports keys in raw that don't correspond to a field of t,
+// recursing into nested structs.
+func unknownKeys(prefix string, raw map[string]json.RawMessage, t reflect.Type) []Problem {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).IsExported() {
+			fields[jsonName(t.Field(i))] = t.Field(i).Type
+		}
+	}
+
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var problems []Problem
+	for _, k := range keys {
+		ft, ok := fields[k]
+		if !ok {
+			problems = append(problems, Problem{
+				Field:   prefix + k,
+				Message: "unknown setting, ignored",
+			})
+			continue
+		}
+		if ft.Kind() == reflect.Struct {
+			var nested map[string]json.RawMessage
+			if json.Unmarshal(raw[k], &nested) == nil {
+				problems = append(problems, unknownKeys(prefix+k+".", nested, ft)...)
+			}
+		}
+	}
+	return problems
+}
+
+// jsonName returns the JSON key a struct field is encoded under.
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
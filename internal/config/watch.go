@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is how long the file must stay quiet before it's re-read.
+// Editors and sync tools often write a file in several steps.
+const watchSettle = 250 * time.Millisecond
+
+// Watcher reports external modifications to a config file.
+type Watcher struct {
+	path     string
+	fs       *fsnotify.Watcher
+	onChange func(*Config)
+	onError  func(error)
+
+	mu    sync.Mutex
+	timer *time.Timer
+}
+
+// Watch starts watching the config file at path. onChange receives the freshly
+// parsed config after each modification; onError receives read/parse errors
+// (e.g. a half-edited file) so they can be surfaced without dropping the
+// current config. Both are called from a background goroutine.
+//
+// The parent directory is watched rather than the file itself so that
+// editors and dotfile managers that save via rename are picked up too.
+func Watch(path string, onChange func(*Config), onError func(error)) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config watcher: %w", err)
+	}
+	if err := fsw.Add(filepath.Dir(path)); err != nil {
+		_ = fsw.Close()
+		return nil, fmt.Errorf("failed to watch config directory: %w", err)
+	}
+
+	w := &Watcher{
+		path:     path,
+		fs:       fsw,
+		onChange: onChange,
+		onError:  onError,
+	}
+	go w.run()
+	return w, nil
+}
+
+// Close stops watching.
+func (w *Watcher) Close() error {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+	return w.fs.Close()
+}
+
+func (w *Watcher) run() {
+	target := filepath.Clean(w.path)
+	for {
+		select {
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			if filepath.Clean(ev.Name) != target {
+				continue
+			}
+			if ev.Has(fsnotify.Write) || ev.Has(fsnotify.Create) || ev.Has(fsnotify.Rename) {
+				w.schedule()
+			}
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			if w.onError != nil {
+				w.onError(err)
+			}
+		}
+	}
+}
+
+func (w *Watcher) schedule() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(watchSettle, w.reload)
+}
+
+func (w *Watcher) reload() {
+	// A rename-based save briefly leaves no file behind; Load would treat
+	// that as "use defaults", which must not clobber the running config.
+	if _, err := os.Stat(w.path); err != nil {
+		return
+	}
+	cfg, err := Load(w.path)
+	if err != nil {
+		if w.onError != nil {
+			w.onError(err)
+		}
+		return
+	}
+	w.onChange(cfg)
+}
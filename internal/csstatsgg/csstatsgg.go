@@ -0,0 +1,239 @@
+// Package csstatsgg reads the match table of a csstats.gg profile, saved as
+// CSV or copied from the page (which pastes as tab-separated text), and turns
+// each match into the rounds the tracker would have recorded for it, so
+// long-time players can seed years of history.
+//
+// The table has each match's date, map and final score, and no time of
+// day, order of rounds or starting side, so matches on the same day are
+// placed an hour apart from noon in the order they were played and the
+// rounds are made up the way the imported package does it.
+package csstatsgg
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/imported"
+)
+
+// Source names csstats.gg in the UUIDs of the rounds imported from it.
+const Source = "csstatsgg"
+
+// Row is one row of the table: the match read from it, or why it couldn't
+// be.
+type Row struct {
+	Line  int // line in the export, counting the header as 1
+	Match imported.Match
+	Err   error
+}
+
+// Column names, normalized, that each part of a match is read from.
+var (
+	idFields    = []string{"matchid", "id", "match", "link", "url"}
+	dateFields  = []string{"date", "played"}
+	mapFields   = []string{"map", "mapname"}
+	scoreFields = []string{"score", "result"}
+)
+
+// Parse reads an export. A file that can't be read at all is an error; rows
+// that can't be read are returned with their Err set, so they can be shown
+// as skipped.
+func Parse(r io.Reader) ([]Row, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(4096)
+	first, _, _ := strings.Cut(strings.TrimPrefix(string(head), "\ufeff"), "\n")
+	cr := csv.NewReader(br)
+	if strings.Contains(first, "\t") {
+		cr.Comma = '\t'
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	if len(records) < 2 {
+		return nil, errors.New("the export has no matches")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if key := normalize(name); key != "" {
+			if _, ok := columns[key]; !ok {
+				columns[key] = i
+			}
+		}
+	}
+	column := func(names []string) int {
+		for _, name := range names {
+			if i, ok := columns[name]; ok {
+				return i
+			}
+		}
+		return -1
+	}
+	dateCol, mapCol, scoreCol, idCol := column(dateFields), column(mapFields), column(scoreFields), column(idFields)
+	switch {
+	case dateCol < 0:
+		return nil, errors.New("the export has no Date column")
+	case scoreCol < 0:
+		return nil, errors.New("the export has no Score column")
+	}
+
+	var rows []Row
+	for i, rec := range records[1:] {
+		field := func(col int) string {
+			if col < 0 || col >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[col])
+		}
+		if strings.TrimSpace(strings.Join(rec, "")) == "" {
+			continue
+		}
+		row := Row{Line: i + 2}
+		m := &row.Match
+		m.Map = mapName(field(mapCol))
+		date, err := parseDate(field(dateCol))
+		if err == nil {
+			m.FinishedAt = date
+			m.Score, m.EnemyScore, err = parseScore(field(scoreCol))
+		}
+		row.Err = err
+		m.ID = field(idCol)
+		rows = append(rows, row)
+	}
+	spreadDays(rows)
+	for i := range rows {
+		if m := &rows[i].Match; m.ID == "" {
+			m.ID = fmt.Sprintf("%s/%s/%d-%d", m.FinishedAt.Format(time.RFC3339), m.Map, m.Score, m.EnemyScore)
+		}
+	}
+	return rows, nil
+}
+
+// matchGap is how far apart matches on the same day are placed.
+const matchGap = time.Hour
+
+// spreadDays gives matches played on the same day their own end times, an
+// hour apart from noon. The table lists the newest match first, so the
+// last of a day's rows is the first match played.
+func spreadDays(rows []Row) {
+	byDay := map[time.Time][]int{}
+	for i, row := range rows {
+		if row.Err == nil {
+			byDay[row.Match.FinishedAt] = append(byDay[row.Match.FinishedAt], i)
+		}
+	}
+	for day, idx := range byDay {
+		sort.Sort(sort.Reverse(sort.IntSlice(idx)))
+		for n, i := range idx {
+			rows[i].Match.FinishedAt = day.Add(12*time.Hour + time.Duration(n)*matchGap)
+		}
+	}
+}
+
+// normalize lower-cases a column name and drops everything but letters and
+// digits.
+func normalize(key string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(key) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// dateLayouts are the date formats accepted, after ordinal suffixes are
+// dropped: csstats.gg's own ("Sat 2 Sep 23"), with or without the weekday,
+// and ISO dates.
+var dateLayouts = []string{"Mon 2 Jan 06", "2 Jan 06", "Mon 2 Jan 2006", "2 Jan 2006", "2006-01-02"}
+
+// parseDate reads a match's date as midnight local time.
+func parseDate(s string) (time.Time, error) {
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	for i, f := range fields {
+		fields[i] = dropOrdinal(f)
+	}
+	clean := strings.Join(fields, " ")
+	if len(clean) > len("2006-01-02") && clean[4] == '-' {
+		// An ISO date with a time, which isn't needed.
+		clean = clean[:len("2006-01-02")]
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, clean, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("date %q isn't one the importer knows", s)
+}
+
+// dropOrdinal turns "2nd" into "2".
+func dropOrdinal(s string) string {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if n, found := strings.CutSuffix(s, suffix); found {
+			if _, err := strconv.Atoi(n); err == nil {
+				return n
+			}
+		}
+	}
+	return s
+}
+
+// parseScore reads a score like "13:9", "13 - 9" or "13 : 9", the player's
+// team first.
+func parseScore(s string) (int, int, error) {
+	for _, sep := range []string{":", "-", "–"} {
+		a, b, found := strings.Cut(s, sep)
+		if !found {
+			continue
+		}
+		own, errA := strconv.Atoi(strings.TrimSpace(a))
+		enemy, errB := strconv.Atoi(strings.TrimSpace(b))
+		if errA != nil || errB != nil || own < 0 || enemy < 0 {
+			break
+		}
+		if own+enemy == 0 {
+			return 0, 0, errors.New("no rounds were played")
+		}
+		return own, enemy, nil
+	}
+	return 0, 0, fmt.Errorf("score %q isn't like 13:9", s)
+}
+
+// classicMaps are the maps csstats.gg lists by name alone that are hostage
+// maps; the rest are taken to be bomb defusal maps.
+var classicMaps = map[string]bool{"office": true, "italy": true, "assault": true, "militia": true, "agency": true}
+
+// mapName turns csstats.gg's "Mirage" into the game's "de_mirage". Names
+// that already have a prefix are kept as they are.
+func mapName(s string) string {
+	name := strings.ToLower(strings.TrimSpace(s))
+	if name == "" || strings.Contains(name, "_") {
+		return name
+	}
+	name = strings.ReplaceAll(name, " ", "")
+	if classicMaps[name] {
+		return "cs_" + name
+	}
+	return "de_" + name
+}
+
+// Rounds returns m's rounds, oldest first, each with a UUID made from m's ID
+// so importing the same export again adds nothing new. account is recorded
+// with every round.
+func Rounds(m imported.Match, account string) []database.Round {
+	return imported.Rounds(Source, m, account)
+}
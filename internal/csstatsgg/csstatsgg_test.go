@@ -0,0 +1,109 @@
+package csstatsgg_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/csstatsgg"
+	"csstatstracker/internal/database"
+)
+
+func TestParse(t *testing.T) {
+	day := func(y int, m time.Month, d, hour int) time.Time {
+		return time.Date(y, m, d, hour, 0, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{"copied table", "Date\tMap\tScore\tK\tD\n" +
+			"Sat 2nd Sep 23\tMirage\t13 : 9\t21\t14\n" +
+			"Sat 2nd Sep 23\tOffice\t9 : 13\t12\t18\n" +
+			"Thu 31st Aug 23\tde_anubis\t15 : 15\t30\t25\n"},
+		{"csv with a byte order mark", "\ufeffDate,Map,Score,Rating\n" +
+			"2023-09-02,Mirage,13-9,1.21\n" +
+			"2023-09-02,Office,9-13,0.84\n" +
+			"2023-08-31T21:14:00Z,de_anubis,15-15,1.10\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := csstatsgg.Parse(strings.NewReader(tt.export))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []struct {
+				finished    time.Time
+				mapName     string
+				score, lost int
+			}{
+				// The table is newest first, so Office was played first that day.
+				{day(2023, 9, 2, 13), "de_mirage", 13, 9},
+				{day(2023, 9, 2, 12), "cs_office", 9, 13},
+				{day(2023, 8, 31, 12), "de_anubis", 15, 15},
+			}
+			if len(rows) != len(want) {
+				t.Fatalf("Parse returned %d rows, want %d", len(rows), len(want))
+			}
+			for i, w := range want {
+				row := rows[i]
+				m := row.Match
+				if row.Err != nil || !m.FinishedAt.Equal(w.finished) || m.Map != w.mapName || m.Score != w.score || m.EnemyScore != w.lost {
+					t.Errorf("row %d = %+v, %v; want %s on %s, %d:%d", i, m, row.Err, w.finished, w.mapName, w.score, w.lost)
+				}
+				if row.Line != i+2 {
+					t.Errorf("row %d is on line %d, want %d", i, row.Line, i+2)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSkipsBadRows(t *testing.T) {
+	rows, err := csstatsgg.Parse(strings.NewReader("Date,Map,Score\n" +
+		"yesterday,Mirage,13-9\n" +
+		"2023-09-02,Nuke,won\n" +
+		"2023-09-02,Nuke,0-0\n" +
+		"2023-09-03,Nuke,13-11\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bad []int
+	for _, row := range rows {
+		if row.Err != nil {
+			bad = append(bad, row.Line)
+		}
+	}
+	if len(rows) != 4 || len(bad) != 3 || bad[0] != 2 || bad[2] != 4 {
+		t.Errorf("rows %+v; want lines 2 to 4 skipped", rows)
+	}
+
+	for _, export := range []string{"", "Date,Map\n2023-09-02,Mirage\n", "Map,Score\nMirage,13-9\n"} {
+		if _, err := csstatsgg.Parse(strings.NewReader(export)); err == nil {
+			t.Errorf("Parse(%q) succeeded; want an error", export)
+		}
+	}
+}
+
+func TestRounds(t *testing.T) {
+	rows, err := csstatsgg.Parse(strings.NewReader("Date,Map,Score\n2023-09-02,Mirage,13-9\n"))
+	if err != nil || rows[0].Err != nil {
+		t.Fatal(err, rows)
+	}
+	rounds := csstatsgg.Rounds(rows[0].Match, "main")
+	wins := 0
+	for _, r := range rounds {
+		if r.Result() == database.ResultWin {
+			wins++
+		}
+		if r.Map != "de_mirage" || r.Account != "main" {
+			t.Errorf("round = %+v, want de_mirage on main", r)
+		}
+	}
+	if len(rounds) != 22 || wins != 13 {
+		t.Errorf("%d rounds with %d wins, want 22 with 13", len(rounds), wins)
+	}
+	if again := csstatsgg.Rounds(rows[0].Match, "main"); again[0].UUID != rounds[0].UUID {
+		t.Error("importing the same match again gave its rounds new UUIDs")
+	}
+}
@@ -0,0 +1,80 @@
+// Package dailygoal tracks progress towards the daily goal set in the
+// config: a number of net game wins to reach, or a number of games not to
+// go over.
+package dailygoal
+
+import (
+	"fmt"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/games"
+	"csstatstracker/internal/match"
+)
+
+// DayStart returns when the day containing now began, for days that roll
+// over at hour (0-23) local time.
+func DayStart(now time.Time, hour int) time.Time {
+	start := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if now.Before(start) {
+		start = start.AddDate(0, 0, -1)
+	}
+	return start
+}
+
+// Progress is how far along the day's goal is.
+type Progress struct {
+	Kind   string // config.GoalNetWins or config.GoalMaxGames
+	Value  int    // net wins so far, or games played
+	Target int
+}
+
+// Compute measures goal against the rounds played so far today, as returned
+// by database.GetRoundsSince(DayStart(...)). Net wins only count finished
+// games; games played include the one in progress.
+func Compute(rounds []database.Round, goal config.DailyGoal) Progress {
+	p := Progress{Kind: goal.Kind, Target: goal.Target}
+	for _, g := range games.Rebuild(rounds, match.DefaultRules) {
+		switch goal.Kind {
+		case config.GoalMaxGames:
+			p.Value++
+		default:
+			if !g.Finished {
+				continue
+			}
+			if g.Wins > g.Losses {
+				p.Value++
+			} else if g.Losses > g.Wins {
+				p.Value--
+			}
+		}
+	}
+	return p
+}
+
+// Fraction is how much of the ring to fill, from 0 to 1.
+func (p Progress) Fraction() float64 {
+	if p.Target <= 0 || p.Value <= 0 {
+		return 0
+	}
+	return min(float64(p.Value)/float64(p.Target), 1)
+}
+
+// Met reports whether a net wins goal was reached.
+func (p Progress) Met() bool {
+	return p.Kind != config.GoalMaxGames && p.Value >= p.Target
+}
+
+// Over reports whether more games were played than a max games goal allows.
+func (p Progress) Over() bool {
+	return p.Kind == config.GoalMaxGames && p.Value > p.Target
+}
+
+// String describes the progress, e.g. "+1 / +2 net wins" or "2 / 3 games".
+func (p Progress) String() string {
+	if p.Kind == config.GoalMaxGames {
+		return fmt.Sprintf("%d / %d games", p.Value, p.Target)
+	}
+	return fmt.Sprintf("%+d / %+d net wins", p.Value, p.Target)
+}
@@ -0,0 +1,112 @@
+package dailygoal
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestDayStart(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	tests := []struct {
+		name string
+		now  time.Time
+		hour int
+		want time.Time
+	}{
+		{"after rollover", time.Date(2025, 3, 10, 21, 0, 0, 0, loc), 4, time.Date(2025, 3, 10, 4, 0, 0, 0, loc)},
+		{"before rollover", time.Date(2025, 3, 10, 1, 30, 0, 0, loc), 4, time.Date(2025, 3, 9, 4, 0, 0, 0, loc)},
+		{"at rollover", time.Date(2025, 3, 10, 4, 0, 0, 0, loc), 4, time.Date(2025, 3, 10, 4, 0, 0, 0, loc)},
+		{"midnight", time.Date(2025, 3, 1, 0, 10, 0, 0, loc), 0, time.Date(2025, 3, 1, 0, 0, 0, 0, loc)},
+		{"across a month", time.Date(2025, 3, 1, 2, 0, 0, 0, loc), 4, time.Date(2025, 2, 28, 4, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DayStart(tt.now, tt.hour); !got.Equal(tt.want) {
+				t.Errorf("DayStart(%s, %d) = %s, want %s", tt.now, tt.hour, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompute(t *testing.T) {
+	start := time.Date(2025, 3, 10, 19, 0, 0, 0, time.UTC)
+	rounds := slices.Concat(
+		// Won 13–0.
+		dbtest.Series(start, time.Minute, "WWWWWWWWWWWWW"),
+		// Lost 0–13.
+		dbtest.Series(start.Add(time.Hour), time.Minute, "LLLLLLLLLLLLL"),
+		// Won 13–0.
+		dbtest.Series(start.Add(2*time.Hour), time.Minute, "wwwwwwwwwwwww"),
+		// In progress.
+		dbtest.Series(start.Add(3*time.Hour), time.Minute, "WWL"),
+	)
+
+	tests := []struct {
+		name      string
+		goal      config.DailyGoal
+		want      Progress
+		wantText  string
+		wantMet   bool
+		wantOver  bool
+		wantShare float64
+	}{
+		{
+			name:      "net wins ignore the game in progress",
+			goal:      config.DailyGoal{Kind: config.GoalNetWins, Target: 2},
+			want:      Progress{Kind: config.GoalNetWins, Value: 1, Target: 2},
+			wantText:  "+1 / +2 net wins",
+			wantShare: 0.5,
+		},
+		{
+			name:      "net wins met",
+			goal:      config.DailyGoal{Kind: config.GoalNetWins, Target: 1},
+			want:      Progress{Kind: config.GoalNetWins, Value: 1, Target: 1},
+			wantText:  "+1 / +1 net wins",
+			wantMet:   true,
+			wantShare: 1,
+		},
+		{
+			name:      "max games count the game in progress",
+			goal:      config.DailyGoal{Kind: config.GoalMaxGames, Target: 5},
+			want:      Progress{Kind: config.GoalMaxGames, Value: 4, Target: 5},
+			wantText:  "4 / 5 games",
+			wantShare: 0.8,
+		},
+		{
+			name:      "max games over",
+			goal:      config.DailyGoal{Kind: config.GoalMaxGames, Target: 3},
+			want:      Progress{Kind: config.GoalMaxGames, Value: 4, Target: 3},
+			wantText:  "4 / 3 games",
+			wantOver:  true,
+			wantShare: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compute(rounds, tt.goal)
+			if got != tt.want {
+				t.Fatalf("Compute = %+v, want %+v", got, tt.want)
+			}
+			if s := got.String(); s != tt.wantText {
+				t.Errorf("String() = %q, want %q", s, tt.wantText)
+			}
+			if got.Met() != tt.wantMet || got.Over() != tt.wantOver {
+				t.Errorf("Met, Over = %v, %v; want %v, %v", got.Met(), got.Over(), tt.wantMet, tt.wantOver)
+			}
+			if f := got.Fraction(); f != tt.wantShare {
+				t.Errorf("Fraction() = %v, want %v", f, tt.wantShare)
+			}
+		})
+	}
+}
+
+func TestFractionBelowZero(t *testing.T) {
+	p := Progress{Kind: config.GoalNetWins, Value: -2, Target: 2}
+	if f := p.Fraction(); f != 0 {
+		t.Errorf("Fraction() = %v, want 0", f)
+	}
+}
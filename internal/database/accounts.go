@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// RenameAccount moves everything recorded under the account from to the name
+// to, in one transaction. Summaries of pruned days already kept under to are
+// added to rather than replaced.
+func RenameAccount(ctx context.Context, db *sql.DB, from, to string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Summaries are keyed by account, so merge them into any the new name
+	// already has.
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO round_summaries (created_at, winner, team, party_size, account, rounds)
+		SELECT created_at, winner, team, party_size, ?, rounds FROM round_summaries WHERE account = ?
+		ON CONFLICT (created_at, winner, team, party_size, account)
+		DO UPDATE SET rounds = rounds + excluded.rounds`, to, from)
+	if err != nil {
+		return fmt.Errorf("failed to rename account in round_summaries: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM round_summaries WHERE account = ?`, from); err != nil {
+		return fmt.Errorf("failed to rename account in round_summaries: %w", err)
+	}
+	for _, table := range accountTables {
+		if table == "round_summaries" {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET account = ? WHERE account = ?`, to, from); err != nil {
+			return fmt.Errorf("failed to rename account in %s: %w", table, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit account rename: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package database_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestRenameAccount(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	start := time.Now().AddDate(0, 0, -40)
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(start, time.Minute, "WWL"), func(r *database.Round) { r.Account = "main" })...)
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(start, time.Minute, "W"), func(r *database.Round) { r.Account = "alt" })...)
+	// A pruned day under both names, so the summaries have to be merged.
+	if _, err := database.PruneRoundsBefore(ctx, db, start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(time.Now(), time.Minute, "LL"), func(r *database.Round) { r.Account = "main" })...)
+	if err := database.InsertRating(ctx, db, database.RatingPremier, 14250, "main"); err != nil {
+		t.Fatal(err)
+	}
+	if err := database.InsertMoment(ctx, db, database.MomentAce, "de_mirage", 3, "main"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := database.RenameAccount(ctx, db, "main", "alt"); err != nil {
+		t.Fatalf("RenameAccount: %v", err)
+	}
+	if names, err := database.GetAccountNames(ctx, db); err != nil || !slices.Equal(names, []string{"alt"}) {
+		t.Errorf("GetAccountNames = %v, %v; want [alt]", names, err)
+	}
+	stats, err := database.GetStats(ctx, db, database.Filter{Window: database.WindowAll, Account: "alt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalRounds != 6 {
+		t.Errorf("alt has %d rounds after the rename, want all 6", stats.TotalRounds)
+	}
+	if ratings, err := database.GetRatings(ctx, db, database.RatingPremier, "alt"); err != nil || len(ratings) != 1 {
+		t.Errorf("alt has ratings %v, %v; want the one from main", ratings, err)
+	}
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// accountTables are the tables with an account column.
+var accountTables = []string{"rounds", "round_summaries", "ratings", "round_outcomes", "moments"}
+
+// timestampColumns are the columns, as table.column, whose times are shifted
+// by AnonymizedCopy.
+var timestampColumns = []string{
+	"rounds.created_at", "round_summaries.created_at", "ratings.created_at", "moments.created_at",
+	"round_outcomes.created_at", "sessions.started_at", "sessions.ended_at",
+}
+
+// GetAccountNames returns every account name stored in the database, sorted.
+func GetAccountNames(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT account FROM rounds WHERE account != ''
+		UNION SELECT account FROM round_summaries WHERE account != ''
+		UNION SELECT account FROM ratings WHERE account != ''
+		UNION SELECT account FROM round_outcomes WHERE account != ''
+		UNION SELECT account FROM moments WHERE account != ''
+		ORDER BY account`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan account: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// AnonymizedCopy writes a copy of db to path, which mustn't exist, with
+// personal details removed: every timestamp is moved back by shiftDays,
+// keeping their order and spacing; account names are replaced as given by
+// accounts, or blanked if missing from it; and queued webhook deliveries,
+// which carry URLs and payloads, are dropped. The copy is vacuumed so none
+// of the originals linger in free pages.
+func AnonymizedCopy(ctx context.Context, db *sql.DB, path string, shiftDays int, accounts map[string]string) error {
+	if _, err := db.ExecContext(ctx, `VACUUM INTO ?`, path); err != nil {
+		return fmt.Errorf("failed to copy database: %w", err)
+	}
+
+	cp, err := sql.Open("sqlite", path)
+	if err != nil {
+		return fmt.Errorf("failed to open database copy: %w", err)
+	}
+	defer func() { _ = cp.Close() }()
+	// One connection, so the temporary table is visible to every statement.
+	cp.SetMaxOpenConns(1)
+
+	if _, err := cp.ExecContext(ctx, `CREATE TEMP TABLE account_map (old TEXT PRIMARY KEY, new TEXT NOT NULL)`); err != nil {
+		return fmt.Errorf("failed to anonymize database copy: %w", err)
+	}
+	for old, name := range accounts {
+		if _, err := cp.ExecContext(ctx, `INSERT INTO account_map (old, new) VALUES (?, ?)`, old, name); err != nil {
+			return fmt.Errorf("failed to anonymize database copy: %w", err)
+		}
+	}
+
+	var stmts []string
+	for _, table := range accountTables {
+		stmts = append(stmts, `UPDATE `+table+` SET account = COALESCE(
+			(SELECT new FROM account_map WHERE old = `+table+`.account), '') WHERE account != ''`)
+	}
+	shift := fmt.Sprintf("-%d days", shiftDays)
+	for _, c := range timestampColumns {
+		table, column, _ := strings.Cut(c, ".")
+		stmts = append(stmts, `UPDATE `+table+` SET `+column+` = datetime(`+column+`, '`+shift+`')`)
+	}
+	stmts = append(stmts, `DELETE FROM webhook_deliveries`, `DROP TABLE account_map`)
+	for _, stmt := range stmts {
+		if _, err := cp.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to anonymize database copy: %w", err)
+		}
+	}
+	if _, err := cp.ExecContext(ctx, `VACUUM`); err != nil {
+		return fmt.Errorf("failed to compact database copy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestAnonymizedCopy(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	at := time.Date(2025, 3, 14, 20, 0, 0, 0, time.UTC)
+	rounds := dbtest.Series(at, time.Minute, "WLw")
+	rounds[0].Account = "alice"
+	rounds[1].Account = "smurf"
+	dbtest.Insert(t, db, rounds...)
+	if err := database.EnqueueDelivery(ctx, db, "https://secret.example.com", "round_recorded", []byte("{}"), at); err != nil {
+		t.Fatal(err)
+	}
+	session, err := database.StartSession(ctx, db, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.ExtendSession(ctx, db, session, at.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := database.GetAccountNames(ctx, db)
+	if err != nil || len(names) != 2 || names[0] != "alice" {
+		t.Fatalf("GetAccountNames = %v, %v", names, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "copy.db")
+	if err := database.AnonymizedCopy(ctx, db, path, 10, map[string]string{"alice": "Account 1"}); err != nil {
+		t.Fatal(err)
+	}
+	cp, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = cp.Close() }()
+
+	got, err := database.GetAllRounds(ctx, cp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("got %d rounds, want 3", len(got))
+	}
+	oldest := got[2]
+	if want := at.AddDate(0, 0, -10); !oldest.CreatedAt.Equal(want) || oldest.Account != "Account 1" {
+		t.Errorf("oldest round = %+v, want at %s on Account 1", oldest, want)
+	}
+	if got[1].Account != "" || got[0].CreatedAt.Sub(oldest.CreatedAt) != 2*time.Minute {
+		t.Errorf("rounds = %+v", got)
+	}
+	sessions, err := database.GetSessionsSince(ctx, cp, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := at.AddDate(0, 0, -10); len(sessions) != 1 || !sessions[0].StartedAt.Equal(want) ||
+		!sessions[0].EndedAt.Equal(want.Add(time.Hour)) {
+		t.Errorf("sessions = %+v, want one from %s lasting an hour", sessions, want)
+	}
+	if n, err := database.CountDeliveries(ctx, cp); err != nil || n != 0 {
+		t.Errorf("CountDeliveries = %d, %v, want 0", n, err)
+	}
+
+	// The original is untouched.
+	if names, _ := database.GetAccountNames(ctx, db); len(names) != 2 {
+		t.Errorf("original accounts = %v", names)
+	}
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// MonthStats holds a calendar month's round results.
+type MonthStats struct {
+	Month  time.Time // first day of the month, UTC
+	Wins   int
+	Losses int
+	Draws  int
+}
+
+// GetMonthlyStats rolls every round, including the summaries of pruned
+// days, up into calendar months (UTC, matching the daily buckets), newest
+// month first.
+func GetMonthlyStats(ctx context.Context, db *sql.DB) ([]MonthStats, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT strftime('%Y-%m', created_at) AS month, winner, team, n
+		FROM `+allRounds+`
+		ORDER BY month DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query monthly stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var result []MonthStats
+	for rows.Next() {
+		var month, winner, team string
+		var n int
+		if err := rows.Scan(&month, &winner, &team, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan monthly row: %w", err)
+		}
+		m, err := time.Parse("2006-01", month)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse month %q: %w", month, err)
+		}
+		if n := len(result); n == 0 || !result[n-1].Month.Equal(m) {
+			result = append(result, MonthStats{Month: m})
+		}
+		ms := &result[len(result)-1]
+		switch (Round{Winner: Team(winner), Team: Team(team)}).Result() {
+		case ResultWin:
+			ms.Wins += n
+		case ResultLoss:
+			ms.Losses += n
+		default:
+			ms.Draws += n
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetRoundsInMonth returns the rounds of the UTC calendar month starting at
+// month, newest first.
+func GetRoundsInMonth(ctx context.Context, db *sql.DB, month time.Time) ([]Round, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds
+		WHERE created_at >= ? AND created_at < ?
+		ORDER BY created_at DESC, id DESC`,
+		month.UTC(), month.AddDate(0, 1, 0).UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rounds: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
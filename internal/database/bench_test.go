@@ -0,0 +1,150 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"csstatstracker"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+// benchRounds is how many rounds the benchmarks' database has by default.
+// CSST_BENCH_ROUNDS overrides it, e.g. 2000000 for a long-time player's
+// history (make bench).
+const benchRounds = 100_000
+
+var (
+	benchOnce sync.Once
+	benchDB   *sql.DB
+	benchErr  error
+)
+
+// newBenchDB returns a database with generated history, shared by every
+// benchmark since generating it takes a while. It lives until the test
+// binary exits.
+func newBenchDB(b *testing.B) *sql.DB {
+	b.Helper()
+	benchOnce.Do(func() {
+		n := benchRounds
+		if v := os.Getenv("CSST_BENCH_ROUNDS"); v != "" {
+			if n, benchErr = strconv.Atoi(v); benchErr != nil {
+				return
+			}
+		}
+		ctx := context.Background()
+		path := ":memory:"
+		if dir, err := os.MkdirTemp("", "csst-bench"); err == nil {
+			// A file, like the app's, rather than :memory:'s single
+			// connection. It's removed once written: the open handles keep it.
+			path = dir + "/bench.db"
+			defer func() { _ = os.RemoveAll(dir) }()
+		}
+		if benchDB, benchErr = database.Init(ctx, path, csstatstracker.MigrationsFS); benchErr != nil {
+			return
+		}
+		benchErr = dbtest.Generate(ctx, benchDB, n, time.Now(), 1)
+	})
+	if benchErr != nil {
+		b.Fatalf("failed to create benchmark database: %v", benchErr)
+	}
+	b.ResetTimer()
+	return benchDB
+}
+
+func BenchmarkGetStats(b *testing.B) {
+	db := newBenchDB(b)
+	for _, bm := range []struct {
+		name string
+		f    database.Filter
+	}{
+		{"all", database.Filter{Window: database.WindowAll}},
+		{"month", database.Filter{Window: database.WindowMonth}},
+		{"last20", database.Filter{Window: database.WindowLast20}},
+		{"account", database.Filter{Window: database.WindowAll, Account: dbtest.Accounts[0]}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := database.GetStats(context.Background(), db, bm.f); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetDailyStats(b *testing.B) {
+	db := newBenchDB(b)
+	for b.Loop() {
+		if _, err := database.GetDailyStats(context.Background(), db, database.Filter{Window: database.WindowAll}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetPartyStats(b *testing.B) {
+	db := newBenchDB(b)
+	for b.Loop() {
+		if _, err := database.GetPartyStats(context.Background(), db, database.Filter{Window: database.WindowAll}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetMonthlyStats(b *testing.B) {
+	db := newBenchDB(b)
+	for b.Loop() {
+		if _, err := database.GetMonthlyStats(context.Background(), db); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGetRoundsPage covers History's paging: the first page, and a
+// page deep into the history as after Go to Date.
+func BenchmarkGetRoundsPage(b *testing.B) {
+	db := newBenchDB(b)
+	total, err := database.CountRounds(context.Background(), db)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, bm := range []struct {
+		name   string
+		offset int
+	}{
+		{"first", 0},
+		{"middle", total / 2},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := database.GetRoundsPage(context.Background(), db, bm.offset, 50); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkCountRoundsSince(b *testing.B) {
+	db := newBenchDB(b)
+	since := time.Now().AddDate(0, -6, 0)
+	for b.Loop() {
+		if _, err := database.CountRoundsSince(context.Background(), db, since); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetUnassignedRounds(b *testing.B) {
+	db := newBenchDB(b)
+	for b.Loop() {
+		if _, err := database.GetUnassignedRounds(context.Background(), db); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -3,8 +3,9 @@ package database
 import (
 	"context"
 	"database/sql"
-	"embed"
 	"fmt"
+	"io/fs"
+	"strings"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -22,39 +23,181 @@ const (
 	TeamT    Team = "T"
 )
 
+// PartySize is how many players (including the player) were queued together.
+// PartyUnknown is used for rounds recorded without it.
+type PartySize int
+
+const (
+	PartyUnknown PartySize = iota
+	PartySolo
+	PartyDuo
+	PartyTrio
+	PartyFour
+	PartyFive
+)
+
+// PartySizes lists the party sizes in display order.
+var PartySizes = []PartySize{PartyUnknown, PartySolo, PartyDuo, PartyTrio, PartyFour, PartyFive}
+
+func (p PartySize) String() string {
+	switch p {
+	case PartySolo:
+		return "Solo"
+	case PartyDuo:
+		return "Duo"
+	case PartyTrio:
+		return "Trio"
+	case PartyFour:
+		return "4-stack"
+	case PartyFive:
+		return "5-stack"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParsePartySize is the inverse of PartySize.String. Unrecognised names map
+// to PartyUnknown.
+func ParsePartySize(name string) PartySize {
+	for _, p := range PartySizes {
+		if p.String() == name {
+			return p
+		}
+	}
+	return PartyUnknown
+}
+
+// Mode is the game mode a round was played in. ModeUnknown is used for
+// rounds recorded without it.
+type Mode string
+
+const (
+	ModeUnknown     Mode = ""
+	ModePremier     Mode = "premier"
+	ModeCompetitive Mode = "competitive"
+	ModeWingman     Mode = "wingman"
+	ModeCasual      Mode = "casual"
+)
+
+// Modes lists the game modes in display order.
+var Modes = []Mode{ModeUnknown, ModePremier, ModeCompetitive, ModeWingman, ModeCasual}
+
+func (m Mode) String() string {
+	switch m {
+	case ModePremier:
+		return "Premier"
+	case ModeCompetitive:
+		return "Competitive"
+	case ModeWingman:
+		return "Wingman"
+	case ModeCasual:
+		return "Casual"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseMode is the inverse of Mode.String. Unrecognised names map to
+// ModeUnknown.
+func ParseMode(name string) Mode {
+	for _, m := range Modes {
+		if m.String() == name {
+			return m
+		}
+	}
+	return ModeUnknown
+}
+
 const DefaultDBFile = "./csstatstracker.db"
 
-// Init opens the database and runs migrations using embedded files.
-func Init(ctx context.Context, dbPath string, migrationsFS embed.FS) (*sql.DB, error) {
-	db, err := sql.Open("sqlite", dbPath)
+// busyTimeout is how long a connection waits for another's write lock before
+// failing with SQLITE_BUSY.
+const busyTimeout = 5 * time.Second
+
+// maxOpenConns caps the pool. WAL lets readers run alongside the single
+// writer, so a few connections are enough for stats refreshes to proceed
+// while a hotkey saves a round.
+const maxOpenConns = 4
+
+// dsn adds the connection settings to dbPath. They're given as _pragma
+// parameters so every pooled connection gets them, not just the first:
+//   - WAL journaling, so reads don't block on writes and vice versa
+//   - a busy timeout, so concurrent writers wait instead of erroring
+//   - foreign key enforcement, which SQLite leaves off by default
+//   - immediate transactions, which take the write lock up front instead of
+//     failing when a read transaction tries to upgrade
+func dsn(dbPath string) string {
+	sep := "?"
+	if strings.Contains(dbPath, "?") {
+		sep = "&"
+	}
+	return fmt.Sprintf("%s%s_pragma=journal_mode(WAL)&_pragma=busy_timeout(%d)&_pragma=foreign_keys(1)&_txlock=immediate",
+		dbPath, sep, busyTimeout.Milliseconds())
+}
+
+// OpTimeout bounds a single interactive database operation, such as saving a
+// round or loading a tab, so a hung disk surfaces as an error instead of
+// freezing the caller.
+const OpTimeout = 10 * time.Second
+
+// WithTimeout derives the context for one interactive operation from parent,
+// which is typically cancelled when the app shuts down.
+func WithTimeout(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, OpTimeout)
+}
+
+// Init opens the database and runs the migrations in migrationsFS's
+// migrations directory, e.g. the embedded ones.
+func Init(ctx context.Context, dbPath string, migrationsFS fs.FS) (*sql.DB, error) {
+	db, err := open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrateUp(db, migrationsFS); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// open opens the database at dbPath with the connection settings of dsn.
+func open(dbPath string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dsn(dbPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	if dbPath == ":memory:" {
+		// Each connection to :memory: is a separate, empty database.
+		db.SetMaxOpenConns(1)
+	} else {
+		db.SetMaxOpenConns(maxOpenConns)
+	}
+	db.SetMaxIdleConns(maxOpenConns)
+	db.SetConnMaxLifetime(0)
+	return db, nil
+}
 
+// migrateUp runs every migration in migrationsFS that db hasn't had yet.
+func migrateUp(db *sql.DB, migrationsFS fs.FS) error {
 	source, err := iofs.New(migrationsFS, "migrations")
 	if err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("failed to create migration source: %w", err)
+		return fmt.Errorf("failed to create migration source: %w", err)
 	}
 
 	driver, err := sqlite.WithInstance(db, &sqlite.Config{})
 	if err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+		return fmt.Errorf("failed to create migration driver: %w", err)
 	}
 
 	m, err := migrate.NewWithInstance("iofs", source, "sqlite", driver)
 	if err != nil {
-		_ = db.Close()
-		return nil, fmt.Errorf("failed to create migration instance: %w", err)
+		return fmt.Errorf("failed to create migration instance: %w", err)
 	}
 
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		_ = db.Close()
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
+		return fmt.Errorf("failed to run migrations: %w", err)
 	}
-
-	return db, nil
+	return nil
 }
 
 // TimeWindow represents a time period for filtering statistics.
@@ -66,18 +209,42 @@ const (
 	WindowMonth
 	WindowYear
 	WindowAll
+	WindowLast5
+	WindowLast10
+	WindowLast20
+	WindowToday  // since midnight
+	WindowSeason // the last 3 months, about the length of a Premier season
 )
 
+// RoundLimit returns how many of the most recent rounds a count-based window
+// covers, or 0 for time-based windows.
+func (w TimeWindow) RoundLimit() int {
+	switch w {
+	case WindowLast5:
+		return 5
+	case WindowLast10:
+		return 10
+	case WindowLast20:
+		return 20
+	default:
+		return 0
+	}
+}
+
 // GetWindowStart returns the start time for the given window.
 func GetWindowStart(window TimeWindow) time.Time {
 	now := time.Now()
 	switch window {
+	case WindowToday:
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 	case WindowDay:
 		return now.AddDate(0, 0, -1)
 	case WindowWeek:
 		return now.AddDate(0, 0, -7)
 	case WindowMonth:
 		return now.AddDate(0, -1, 0)
+	case WindowSeason:
+		return now.AddDate(0, -3, 0)
 	case WindowYear:
 		return now.AddDate(-1, 0, 0)
 	default:
@@ -85,6 +252,52 @@ func GetWindowStart(window TimeWindow) time.Time {
 	}
 }
 
+// Filter selects the rounds statistics are computed over.
+type Filter struct {
+	Window  TimeWindow
+	Account string // only rounds played on this account; "" for all
+	Map     string // only rounds played on this map; "" for all
+}
+
+// allRounds is a FROM-clause source of every round plus the summaries of
+// pruned days. Its n column is 1 for a round, or how many rounds a summary
+// row stands for. Summaries don't keep maps, so theirs is "".
+const allRounds = `(SELECT winner, team, party_size, account, map, created_at, 1 AS n FROM rounds
+	UNION ALL
+	SELECT winner, team, party_size, account, '' AS map, created_at, rounds AS n FROM round_summaries)`
+
+// roundSource returns a FROM-clause source selecting the rounds matching f,
+// along with its query arguments. Rows have allRounds' n column, so counts
+// must be weighted by it. Count-based windows use a LIMIT subquery over the
+// newest matching rounds, leaving out the summaries of pruned days.
+func roundSource(f Filter) (string, []any) {
+	var where []string
+	var args []any
+	if f.Account != "" {
+		where = append(where, "account = ?")
+		args = append(args, f.Account)
+	}
+	if f.Map != "" {
+		where = append(where, "map = ?")
+		args = append(args, f.Map)
+	}
+	if n := f.Window.RoundLimit(); n > 0 {
+		clause := ""
+		if len(where) > 0 {
+			clause = " WHERE " + strings.Join(where, " AND ")
+		}
+		return `(SELECT *, 1 AS n FROM rounds` + clause + ` ORDER BY created_at DESC, id DESC LIMIT ?)`, append(args, n)
+	}
+	if f.Window != WindowAll {
+		where = append(where, "created_at >= ?")
+		args = append(args, GetWindowStart(f.Window))
+	}
+	if len(where) == 0 {
+		return allRounds, nil
+	}
+	return `(SELECT * FROM ` + allRounds + ` WHERE ` + strings.Join(where, " AND ") + `)`, args
+}
+
 // Stats holds aggregate round counts for a window.
 type Stats struct {
 	TotalRounds int
@@ -110,19 +323,10 @@ type DailyStats struct {
 	Draws  int
 }
 
-// GetStats returns round-scope aggregate statistics for the given window.
-func GetStats(ctx context.Context, db *sql.DB, window TimeWindow) (*Stats, error) {
-	startTime := GetWindowStart(window)
-	useWindow := window != WindowAll
-
-	var rows *sql.Rows
-	var err error
-	if useWindow {
-		rows, err = db.QueryContext(ctx,
-			`SELECT winner, team FROM rounds WHERE created_at >= ?`, startTime)
-	} else {
-		rows, err = db.QueryContext(ctx, `SELECT winner, team FROM rounds`)
-	}
+// GetStats returns round-scope aggregate statistics for the rounds matching f.
+func GetStats(ctx context.Context, db *sql.DB, f Filter) (*Stats, error) {
+	source, args := roundSource(f)
+	rows, err := db.QueryContext(ctx, `SELECT winner, team, n FROM `+source, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query stats: %w", err)
 	}
@@ -131,15 +335,67 @@ func GetStats(ctx context.Context, db *sql.DB, window TimeWindow) (*Stats, error
 	stats := &Stats{}
 	for rows.Next() {
 		var winner, team string
-		if err := rows.Scan(&winner, &team); err != nil {
+		var n int
+		if err := rows.Scan(&winner, &team, &n); err != nil {
 			return nil, fmt.Errorf("failed to scan round: %w", err)
 		}
-		accumulate(stats, Team(winner), Team(team))
+		accumulate(stats, Team(winner), Team(team), n)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
+	computeRates(stats)
+	return stats, nil
+}
+
+// PartyStats holds the aggregate statistics for rounds played at one party size.
+type PartyStats struct {
+	Party PartySize
+	Stats
+}
+
+// GetPartyStats returns round-scope statistics for the rounds matching f
+// broken down by party size, in PartySizes order. Party sizes with no rounds
+// are omitted.
+func GetPartyStats(ctx context.Context, db *sql.DB, f Filter) ([]PartyStats, error) {
+	source, args := roundSource(f)
+	rows, err := db.QueryContext(ctx, `SELECT party_size, winner, team, n FROM `+source, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query party stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	byParty := make(map[PartySize]*Stats)
+	for rows.Next() {
+		var party, n int
+		var winner, team string
+		if err := rows.Scan(&party, &winner, &team, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan round: %w", err)
+		}
+		stats, ok := byParty[PartySize(party)]
+		if !ok {
+			stats = &Stats{}
+			byParty[PartySize(party)] = stats
+		}
+		accumulate(stats, Team(winner), Team(team), n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var result []PartyStats
+	for _, p := range PartySizes {
+		if stats, ok := byParty[p]; ok {
+			computeRates(stats)
+			result = append(result, PartyStats{Party: p, Stats: *stats})
+		}
+	}
+	return result, nil
+}
+
+// computeRates fills in the win-rate percentages from the counts.
+func computeRates(stats *Stats) {
 	if stats.TotalRounds > 0 {
 		stats.WinRate = float64(stats.Wins) / float64(stats.TotalRounds) * 100
 	}
@@ -149,54 +405,43 @@ func GetStats(ctx context.Context, db *sql.DB, window TimeWindow) (*Stats, error
 	if stats.TRounds > 0 {
 		stats.TWinRate = float64(stats.TWins) / float64(stats.TRounds) * 100
 	}
-	return stats, nil
 }
 
-func accumulate(stats *Stats, winner, playerTeam Team) {
-	stats.TotalRounds++
+// accumulate counts n rounds with the given winner and player team.
+func accumulate(stats *Stats, winner, playerTeam Team, n int) {
+	stats.TotalRounds += n
 	switch playerTeam {
 	case TeamCT:
-		stats.CTRounds++
+		stats.CTRounds += n
 		if winner == TeamCT {
-			stats.Wins++
-			stats.CTWins++
+			stats.Wins += n
+			stats.CTWins += n
 		} else {
-			stats.Losses++
-			stats.CTLosses++
+			stats.Losses += n
+			stats.CTLosses += n
 		}
 	case TeamT:
-		stats.TRounds++
+		stats.TRounds += n
 		if winner == TeamT {
-			stats.Wins++
-			stats.TWins++
+			stats.Wins += n
+			stats.TWins += n
 		} else {
-			stats.Losses++
-			stats.TLosses++
+			stats.Losses += n
+			stats.TLosses += n
 		}
 	default:
-		stats.Draws++
+		stats.Draws += n
 	}
 }
 
-// GetDailyStats returns daily win/loss counts (round-scope).
-func GetDailyStats(ctx context.Context, db *sql.DB, window TimeWindow) ([]DailyStats, error) {
-	startTime := GetWindowStart(window)
-	useWindow := window != WindowAll
-
-	var rows *sql.Rows
-	var err error
-	if useWindow {
-		rows, err = db.QueryContext(ctx, `
-			SELECT date(created_at), winner, team
-			FROM rounds
-			WHERE created_at >= ?
-			ORDER BY created_at ASC`, startTime)
-	} else {
-		rows, err = db.QueryContext(ctx, `
-			SELECT date(created_at), winner, team
-			FROM rounds
-			ORDER BY created_at ASC`)
-	}
+// GetDailyStats returns daily win/loss counts (round-scope) for the rounds
+// matching f.
+func GetDailyStats(ctx context.Context, db *sql.DB, f Filter) ([]DailyStats, error) {
+	source, args := roundSource(f)
+	rows, err := db.QueryContext(ctx, `
+		SELECT date(created_at), winner, team, n
+		FROM `+source+`
+		ORDER BY created_at ASC`, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query daily stats: %w", err)
 	}
@@ -205,7 +450,8 @@ func GetDailyStats(ctx context.Context, db *sql.DB, window TimeWindow) ([]DailyS
 	dailyMap := make(map[string]*DailyStats)
 	for rows.Next() {
 		var day, winner, team string
-		if err := rows.Scan(&day, &winner, &team); err != nil {
+		var n int
+		if err := rows.Scan(&day, &winner, &team, &n); err != nil {
 			return nil, fmt.Errorf("failed to scan daily row: %w", err)
 		}
 		if _, ok := dailyMap[day]; !ok {
@@ -217,18 +463,18 @@ func GetDailyStats(ctx context.Context, db *sql.DB, window TimeWindow) ([]DailyS
 		switch playerTeam {
 		case TeamCT:
 			if winner == string(TeamCT) {
-				ds.Wins++
+				ds.Wins += n
 			} else {
-				ds.Losses++
+				ds.Losses += n
 			}
 		case TeamT:
 			if winner == string(TeamT) {
-				ds.Wins++
+				ds.Wins += n
 			} else {
-				ds.Losses++
+				ds.Losses += n
 			}
 		default:
-			ds.Draws++
+			ds.Draws += n
 		}
 	}
 	if err := rows.Err(); err != nil {
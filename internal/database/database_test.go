@@ -0,0 +1,182 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestGetStats(t *testing.T) {
+	now := time.Now()
+	old := dbtest.Series(now.AddDate(0, 0, -10), time.Minute, "WWLl")
+	recent := dbtest.Series(now.Add(-2*time.Hour), time.Minute, "wWLLD")
+	alt := dbtest.With(dbtest.Series(now.Add(-time.Hour), time.Minute, "WW"), func(r *database.Round) {
+		r.Account, r.Map = "alt", "de_nuke"
+	})
+
+	tests := []struct {
+		name                string
+		filter              database.Filter
+		wins, losses, draws int
+		ctWins, tWins       int
+	}{
+		{name: "all time", filter: database.Filter{Window: database.WindowAll},
+			wins: 6, losses: 4, draws: 1, ctWins: 5, tWins: 1},
+		{name: "last day", filter: database.Filter{Window: database.WindowDay},
+			wins: 4, losses: 2, draws: 1, ctWins: 3, tWins: 1},
+		{name: "last 5 rounds", filter: database.Filter{Window: database.WindowLast5},
+			wins: 2, losses: 2, draws: 1, ctWins: 2, tWins: 0},
+		{name: "one account", filter: database.Filter{Window: database.WindowAll, Account: "alt"},
+			wins: 2, ctWins: 2},
+		{name: "last 5 of one account", filter: database.Filter{Window: database.WindowLast5, Account: "alt"},
+			wins: 2, ctWins: 2},
+		{name: "unknown account", filter: database.Filter{Window: database.WindowAll, Account: "nobody"}},
+		{name: "one map", filter: database.Filter{Window: database.WindowAll, Map: "de_nuke"},
+			wins: 2, ctWins: 2},
+		{name: "last 5 on one map", filter: database.Filter{Window: database.WindowLast5, Map: "de_nuke"},
+			wins: 2, ctWins: 2},
+	}
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, old...)
+	dbtest.Insert(t, db, recent...)
+	dbtest.Insert(t, db, alt...)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := database.GetStats(context.Background(), db, tt.filter)
+			if err != nil {
+				t.Fatalf("GetStats: %v", err)
+			}
+			if stats.Wins != tt.wins || stats.Losses != tt.losses || stats.Draws != tt.draws {
+				t.Errorf("got %d W / %d L / %d D, want %d / %d / %d",
+					stats.Wins, stats.Losses, stats.Draws, tt.wins, tt.losses, tt.draws)
+			}
+			if stats.CTWins != tt.ctWins || stats.TWins != tt.tWins {
+				t.Errorf("got %d CT wins / %d T wins, want %d / %d",
+					stats.CTWins, stats.TWins, tt.ctWins, tt.tWins)
+			}
+			if total := tt.wins + tt.losses + tt.draws; stats.TotalRounds != total {
+				t.Errorf("got %d rounds, want %d", stats.TotalRounds, total)
+			}
+		})
+	}
+}
+
+func TestGetStatsWinRate(t *testing.T) {
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WWWLD")...)
+
+	stats, err := database.GetStats(context.Background(), db, database.Filter{Window: database.WindowAll})
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	// The overall rate is over every round, including those without a team;
+	// the side rates only cover rounds played on that side.
+	if stats.WinRate != 60 {
+		t.Errorf("win rate = %.2f, want 60", stats.WinRate)
+	}
+	if stats.CTWinRate != 75 {
+		t.Errorf("CT win rate = %.2f, want 75", stats.CTWinRate)
+	}
+}
+
+func TestGetPartyStats(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(start, time.Minute, "WWL"), func(r *database.Round) {
+		r.PartySize = database.PartyFive
+	})...)
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(start, time.Minute, "LLw"), func(r *database.Round) {
+		r.PartySize = database.PartySolo
+	})...)
+	dbtest.Insert(t, db, dbtest.Series(start, time.Minute, "W")...)
+
+	parties, err := database.GetPartyStats(context.Background(), db, database.Filter{Window: database.WindowAll})
+	if err != nil {
+		t.Fatalf("GetPartyStats: %v", err)
+	}
+
+	want := []struct {
+		party        database.PartySize
+		wins, losses int
+	}{
+		{database.PartyUnknown, 1, 0},
+		{database.PartySolo, 1, 2},
+		{database.PartyFive, 2, 1},
+	}
+	if len(parties) != len(want) {
+		t.Fatalf("got %d party sizes, want %d: %+v", len(parties), len(want), parties)
+	}
+	for i, w := range want {
+		p := parties[i]
+		if p.Party != w.party || p.Wins != w.wins || p.Losses != w.losses {
+			t.Errorf("parties[%d] = %s %d W / %d L, want %s %d / %d",
+				i, p.Party, p.Wins, p.Losses, w.party, w.wins, w.losses)
+		}
+	}
+}
+
+func TestGetDailyStats(t *testing.T) {
+	day1 := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 2)
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.Series(day2, time.Minute, "LD")...)
+	dbtest.Insert(t, db, dbtest.Series(day1, time.Minute, "WWl")...)
+
+	days, err := database.GetDailyStats(context.Background(), db, database.Filter{Window: database.WindowAll})
+	if err != nil {
+		t.Fatalf("GetDailyStats: %v", err)
+	}
+
+	want := []database.DailyStats{
+		{Date: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), Wins: 2, Losses: 1},
+		{Date: time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), Losses: 1, Draws: 1},
+	}
+	if len(days) != len(want) {
+		t.Fatalf("got %d days, want %d: %+v", len(days), len(want), days)
+	}
+	for i, w := range want {
+		if !days[i].Date.Equal(w.Date) || days[i].Wins != w.Wins || days[i].Losses != w.Losses || days[i].Draws != w.Draws {
+			t.Errorf("days[%d] = %+v, want %+v", i, days[i], w)
+		}
+	}
+}
+
+func TestParsePartySize(t *testing.T) {
+	for _, p := range database.PartySizes {
+		if got := database.ParsePartySize(p.String()); got != p {
+			t.Errorf("ParsePartySize(%q) = %v, want %v", p.String(), got, p)
+		}
+	}
+	if got := database.ParsePartySize("6-stack"); got != database.PartyUnknown {
+		t.Errorf("ParsePartySize(%q) = %v, want PartyUnknown", "6-stack", got)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, m := range database.Modes {
+		if got := database.ParseMode(m.String()); got != m {
+			t.Errorf("ParseMode(%q) = %v, want %v", m.String(), got, m)
+		}
+	}
+	if got := database.ParseMode("Deathmatch"); got != database.ModeUnknown {
+		t.Errorf("ParseMode(%q) = %v, want ModeUnknown", "Deathmatch", got)
+	}
+}
+
+func TestGetWindowStart(t *testing.T) {
+	now := time.Now()
+	today := database.GetWindowStart(database.WindowToday)
+	if today.After(now) || today.Hour() != 0 || today.Minute() != 0 || today.Day() != now.Day() {
+		t.Errorf("GetWindowStart(WindowToday) = %s, want midnight of %s", today, now)
+	}
+	if season := database.GetWindowStart(database.WindowSeason); now.Sub(season) < 88*24*time.Hour || now.Sub(season) > 93*24*time.Hour {
+		t.Errorf("GetWindowStart(WindowSeason) = %s, want about 3 months before %s", season, now)
+	}
+	if all := database.GetWindowStart(database.WindowAll); !all.IsZero() {
+		t.Errorf("GetWindowStart(WindowAll) = %s, want zero", all)
+	}
+}
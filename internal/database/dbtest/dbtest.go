@@ -0,0 +1,99 @@
+// Package dbtest provides databases and fixtures for tests of code that
+// reads or writes the tracker's database.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"csstatstracker"
+	"csstatstracker/internal/database"
+)
+
+// New returns an empty in-memory database with every migration applied. It's
+// closed when the test ends.
+func New(t testing.TB) *sql.DB {
+	t.Helper()
+	db, err := database.Init(context.Background(), ":memory:", csstatstracker.MigrationsFS)
+	if err != nil {
+		t.Fatalf("failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// Insert adds rounds to db as given, including their CreatedAt (unlike
+// database.InsertRound, which always stamps the current time). A zero
+// CreatedAt means now. The rounds' IDs are ignored.
+func Insert(t testing.TB, db *sql.DB, rounds ...database.Round) {
+	t.Helper()
+	for _, r := range rounds {
+		at := r.CreatedAt
+		if at.IsZero() {
+			at = time.Now()
+		}
+		_, err := db.Exec(
+			`INSERT INTO rounds (winner, team, party_size, account, map, mode, ct_name, t_name, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			string(r.Winner), string(r.Team), int(r.PartySize), r.Account, r.Map, string(r.Mode), r.CTName, r.TName, Timestamp(at),
+		)
+		if err != nil {
+			t.Fatalf("failed to insert fixture round: %v", err)
+		}
+	}
+}
+
+// Timestamp formats t the way SQLite's CURRENT_TIMESTAMP does, so fixture
+// rows sort and bucket like rows the app writes.
+func Timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// Series builds rounds from a results string, one round per character,
+// starting at start and step apart:
+//
+//	W  a win on CT
+//	L  a loss on CT
+//	w  a win on T
+//	l  a loss on T
+//	D  a round with no team selected (CT won)
+//
+// Any other character panics, so typos fail loudly.
+func Series(start time.Time, step time.Duration, results string) []database.Round {
+	rounds := make([]database.Round, 0, len(results))
+	at := start
+	for _, c := range results {
+		var r database.Round
+		switch c {
+		case 'W':
+			r = database.Round{Winner: database.TeamCT, Team: database.TeamCT}
+		case 'L':
+			r = database.Round{Winner: database.TeamT, Team: database.TeamCT}
+		case 'w':
+			r = database.Round{Winner: database.TeamT, Team: database.TeamT}
+		case 'l':
+			r = database.Round{Winner: database.TeamCT, Team: database.TeamT}
+		case 'D':
+			r = database.Round{Winner: database.TeamCT}
+		default:
+			panic(fmt.Sprintf("dbtest: unknown result %q in series %q", c, results))
+		}
+		r.CreatedAt = at
+		rounds = append(rounds, r)
+		at = at.Add(step)
+	}
+	return rounds
+}
+
+// With returns a copy of rounds with fn applied to each, e.g. to set the
+// party size or account on a Series.
+func With(rounds []database.Round, fn func(*database.Round)) []database.Round {
+	out := make([]database.Round, len(rounds))
+	for i, r := range rounds {
+		fn(&r)
+		out[i] = r
+	}
+	return out
+}
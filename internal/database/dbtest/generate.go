@@ -0,0 +1,98 @@
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"csstatstracker/internal/database"
+)
+
+// Accounts are the account names Generate spreads rounds over.
+var Accounts = []string{"main", "smurf"}
+
+// Generate adds n rounds of made-up play history to db, ending at end, for
+// benchmarks and for trying the app on a large database. The same seed gives
+// the same history.
+//
+// Rounds come in matches to 13 wins with a side switch at halftime, a couple
+// of minutes apart, and matches in evening sessions of a few each. A small
+// share of rounds has no team, as if the side wasn't selected. It's all
+// written in one transaction.
+func Generate(ctx context.Context, db *sql.DB, n int, end time.Time, seed uint64) error {
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO rounds (winner, team, party_size, account, created_at) VALUES (?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare round insert: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	// Matches are laid out backwards from end, so the generated history
+	// finishes at end whatever n is.
+	at := end
+	for written := 0; written < n; {
+		session := 2 + rng.IntN(4)
+		party := database.PartySizes[rng.IntN(len(database.PartySizes))]
+		account := Accounts[rng.IntN(len(Accounts))]
+		for m := 0; m < session && written < n; m++ {
+			rounds := generateMatch(rng)
+			if len(rounds) > n-written {
+				rounds = rounds[len(rounds)-(n-written):]
+			}
+			at = at.Add(-time.Duration(len(rounds)) * 105 * time.Second)
+			for i, r := range rounds {
+				created := at.Add(time.Duration(i) * 105 * time.Second)
+				if _, err := stmt.ExecContext(ctx, string(r.Winner), string(r.Team), int(party), account, Timestamp(created)); err != nil {
+					return fmt.Errorf("failed to insert round: %w", err)
+				}
+			}
+			written += len(rounds)
+			at = at.Add(-time.Duration(5+rng.IntN(20)) * time.Minute)
+		}
+		// The previous session was on an earlier day.
+		at = at.Add(-time.Duration(16+rng.IntN(48)) * time.Hour)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit generated rounds: %w", err)
+	}
+	return nil
+}
+
+// generateMatch plays one match: the player starts on a random side and
+// switches after 12 rounds, and it ends when a side has 13 wins or at 12-12.
+// The player wins a little over half their rounds.
+func generateMatch(rng *rand.Rand) []database.Round {
+	side := database.TeamCT
+	if rng.IntN(2) == 0 {
+		side = database.TeamT
+	}
+	other := map[database.Team]database.Team{database.TeamCT: database.TeamT, database.TeamT: database.TeamCT}
+	var rounds []database.Round
+	won, lost := 0, 0
+	for won < 13 && lost < 13 && won+lost < 24 {
+		if won+lost == 12 {
+			side = other[side]
+		}
+		r := database.Round{Winner: other[side], Team: side}
+		if rng.Float64() < 0.52 {
+			r.Winner = side
+			won++
+		} else {
+			lost++
+		}
+		if rng.Float64() < 0.02 {
+			r.Team = database.TeamNone
+		}
+		rounds = append(rounds, r)
+	}
+	return rounds
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// MomentKind names a notable in-round event.
+type MomentKind string
+
+const (
+	MomentBombPlanted  MomentKind = "bomb_planted"
+	MomentBombDefused  MomentKind = "bomb_defused"
+	MomentBombExploded MomentKind = "bomb_exploded"
+	MomentAce          MomentKind = "ace"
+	MomentMVP          MomentKind = "mvp"
+)
+
+// MomentKinds lists the moment kinds in display order.
+var MomentKinds = []MomentKind{MomentBombPlanted, MomentBombDefused, MomentBombExploded, MomentAce, MomentMVP}
+
+// Label returns a human-readable name for the moment kind.
+func (k MomentKind) Label() string {
+	switch k {
+	case MomentBombPlanted:
+		return "Bomb plants"
+	case MomentBombDefused:
+		return "Bomb defuses"
+	case MomentBombExploded:
+		return "Bomb explosions"
+	case MomentAce:
+		return "Aces"
+	case MomentMVP:
+		return "Round MVPs"
+	default:
+		return string(k)
+	}
+}
+
+// InsertMoment records a moment on the given map and round number, played on
+// account ("" if unknown).
+func InsertMoment(ctx context.Context, db *sql.DB, kind MomentKind, mapName string, round int, account string) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO moments (kind, map, round, account) VALUES (?, ?, ?, ?)`,
+		string(kind), mapName, round, account,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert moment: %w", err)
+	}
+	return nil
+}
+
+// GetMomentCounts returns how many of each kind of moment matching f
+// happened. For count-based windows the moments since the oldest matching
+// round in the window are counted.
+func GetMomentCounts(ctx context.Context, db *sql.DB, f Filter) (map[MomentKind]int, error) {
+	var where []string
+	var args []any
+	if f.Account != "" {
+		where = append(where, "account = ?")
+		args = append(args, f.Account)
+	}
+	if f.Map != "" {
+		where = append(where, "map = ?")
+		args = append(args, f.Map)
+	}
+	switch {
+	case f.Window.RoundLimit() > 0:
+		source, sourceArgs := roundSource(f)
+		where = append(where, `created_at >= (SELECT MIN(created_at) FROM `+source+`)`)
+		args = append(args, sourceArgs...)
+	case f.Window != WindowAll:
+		where = append(where, "created_at >= ?")
+		args = append(args, GetWindowStart(f.Window))
+	}
+	query := `SELECT kind, COUNT(*) FROM moments`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` GROUP BY kind`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query moments: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[MomentKind]int)
+	for rows.Next() {
+		var kind string
+		var n int
+		if err := rows.Scan(&kind, &n); err != nil {
+			return nil, fmt.Errorf("failed to scan moment count: %w", err)
+		}
+		counts[MomentKind(kind)] = n
+	}
+	return counts, rows.Err()
+}
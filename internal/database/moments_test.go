@@ -0,0 +1,53 @@
+package database_test
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestGetMomentCounts(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	for _, m := range []struct {
+		kind    database.MomentKind
+		mapName string
+		account string
+	}{
+		{database.MomentBombPlanted, "de_mirage", "main"},
+		{database.MomentBombPlanted, "de_nuke", "main"},
+		{database.MomentAce, "de_mirage", "main"},
+		{database.MomentBombPlanted, "de_mirage", "alt"},
+	} {
+		if err := database.InsertMoment(ctx, db, m.kind, m.mapName, 1, m.account); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter database.Filter
+		want   map[database.MomentKind]int
+	}{
+		{"all", database.Filter{Window: database.WindowAll},
+			map[database.MomentKind]int{database.MomentBombPlanted: 3, database.MomentAce: 1}},
+		{"account", database.Filter{Window: database.WindowAll, Account: "alt"},
+			map[database.MomentKind]int{database.MomentBombPlanted: 1}},
+		{"account and map", database.Filter{Window: database.WindowAll, Account: "main", Map: "de_mirage"},
+			map[database.MomentKind]int{database.MomentBombPlanted: 1, database.MomentAce: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := database.GetMomentCounts(ctx, db, tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("GetMomentCounts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RatingKind is the ranking system a rating belongs to.
+type RatingKind string
+
+const (
+	RatingPremier     RatingKind = "premier"     // CS Rating, e.g. 14250
+	RatingCompetitive RatingKind = "competitive" // skill group index into CompetitiveRanks
+)
+
+// CompetitiveRanks lists the competitive skill groups from lowest to highest.
+// A competitive rating's value is its 1-based index in this list.
+var CompetitiveRanks = []string{
+	"Silver I", "Silver II", "Silver III", "Silver IV", "Silver Elite", "Silver Elite Master",
+	"Gold Nova I", "Gold Nova II", "Gold Nova III", "Gold Nova Master",
+	"Master Guardian I", "Master Guardian II", "Master Guardian Elite", "Distinguished Master Guardian",
+	"Legendary Eagle", "Legendary Eagle Master", "Supreme Master First Class", "Global Elite",
+}
+
+// Rating is one recorded rank or rating.
+type Rating struct {
+	ID        int
+	Kind      RatingKind
+	Value     int
+	Account   string
+	CreatedAt time.Time
+}
+
+// Label returns the rating as players write it: "14,250" for Premier, the
+// skill group name for competitive.
+func (r Rating) Label() string {
+	if r.Kind == RatingCompetitive {
+		if r.Value >= 1 && r.Value <= len(CompetitiveRanks) {
+			return CompetitiveRanks[r.Value-1]
+		}
+		return "Unranked"
+	}
+	if r.Value >= 1000 {
+		return fmt.Sprintf("%d,%03d", r.Value/1000, r.Value%1000)
+	}
+	return fmt.Sprintf("%d", r.Value)
+}
+
+// PremierTier returns the colour tier of a Premier rating, 0 (grey, below
+// 5,000) to 6 (gold, 30,000 and up), in steps of 5,000.
+func PremierTier(rating int) int {
+	return min(max(rating/5000, 0), 6)
+}
+
+// InsertRating records a rating, timestamped now.
+func InsertRating(ctx context.Context, db *sql.DB, kind RatingKind, value int, account string) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO ratings (kind, value, account) VALUES (?, ?, ?)`,
+		string(kind), value, account,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert rating: %w", err)
+	}
+	return nil
+}
+
+// DeleteRating removes a single rating by id.
+func DeleteRating(ctx context.Context, db *sql.DB, id int) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM ratings WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete rating: %w", err)
+	}
+	return nil
+}
+
+// GetRatings returns every rating of the given kind in chronological order,
+// restricted to one account unless account is "".
+func GetRatings(ctx context.Context, db *sql.DB, kind RatingKind, account string) ([]Rating, error) {
+	query := `SELECT id, kind, value, account, created_at FROM ratings WHERE kind = ?`
+	args := []any{string(kind)}
+	if account != "" {
+		query += ` AND account = ?`
+		args = append(args, account)
+	}
+	query += ` ORDER BY created_at ASC, id ASC`
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ratings: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []Rating
+	for rows.Next() {
+		var r Rating
+		var kind string
+		if err := rows.Scan(&r.ID, &kind, &r.Value, &r.Account, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		r.Kind = RatingKind(kind)
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
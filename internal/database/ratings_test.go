@@ -0,0 +1,87 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestRatingLabel(t *testing.T) {
+	tests := []struct {
+		rating database.Rating
+		want   string
+	}{
+		{database.Rating{Kind: database.RatingPremier, Value: 14250}, "14,250"},
+		{database.Rating{Kind: database.RatingPremier, Value: 999}, "999"},
+		{database.Rating{Kind: database.RatingPremier, Value: 30005}, "30,005"},
+		{database.Rating{Kind: database.RatingCompetitive, Value: 1}, "Silver I"},
+		{database.Rating{Kind: database.RatingCompetitive, Value: 18}, "Global Elite"},
+		{database.Rating{Kind: database.RatingCompetitive, Value: 0}, "Unranked"},
+		{database.Rating{Kind: database.RatingCompetitive, Value: 19}, "Unranked"},
+	}
+	for _, tt := range tests {
+		if got := tt.rating.Label(); got != tt.want {
+			t.Errorf("%s %d: Label() = %q, want %q", tt.rating.Kind, tt.rating.Value, got, tt.want)
+		}
+	}
+}
+
+func TestPremierTier(t *testing.T) {
+	tests := []struct{ rating, want int }{
+		{-5, 0}, {0, 0}, {4999, 0}, {5000, 1}, {14250, 2}, {29999, 5}, {30000, 6}, {45000, 6},
+	}
+	for _, tt := range tests {
+		if got := database.PremierTier(tt.rating); got != tt.want {
+			t.Errorf("PremierTier(%d) = %d, want %d", tt.rating, got, tt.want)
+		}
+	}
+}
+
+func TestGetRatings(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	inserts := []struct {
+		kind    database.RatingKind
+		value   int
+		account string
+	}{
+		{database.RatingPremier, 10000, "main"},
+		{database.RatingCompetitive, 5, "main"},
+		{database.RatingPremier, 11000, "alt"},
+		{database.RatingPremier, 12000, "main"},
+	}
+	for _, in := range inserts {
+		if err := database.InsertRating(ctx, db, in.kind, in.value, in.account); err != nil {
+			t.Fatalf("InsertRating: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		kind    database.RatingKind
+		account string
+		want    []int
+	}{
+		{name: "premier, all accounts", kind: database.RatingPremier, want: []int{10000, 11000, 12000}},
+		{name: "premier, one account", kind: database.RatingPremier, account: "main", want: []int{10000, 12000}},
+		{name: "competitive", kind: database.RatingCompetitive, want: []int{5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ratings, err := database.GetRatings(ctx, db, tt.kind, tt.account)
+			if err != nil {
+				t.Fatalf("GetRatings: %v", err)
+			}
+			if len(ratings) != len(tt.want) {
+				t.Fatalf("got %d ratings, want %d", len(ratings), len(tt.want))
+			}
+			for i, v := range tt.want {
+				if ratings[i].Value != v {
+					t.Errorf("ratings[%d] = %d, want %d", i, ratings[i].Value, v)
+				}
+			}
+		})
+	}
+}
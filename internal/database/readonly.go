@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// OpenReadOnly opens the database at dbPath for viewing only, e.g. a file on
+// a network share that another PC records to. Migrations aren't run, so the
+// database must already be at the schema version of migrationsFS.
+//
+// A read-only connection to a database in WAL mode still needs the -shm
+// file next to it, which can't be created or locked on some shares. If the
+// normal read-only open fails, the file is opened as immutable instead:
+// SQLite then takes no locks at all, so it's read as a snapshot and writes
+// made by the other PC meanwhile may be missed until it's reopened.
+func OpenReadOnly(ctx context.Context, dbPath string, migrationsFS fs.FS) (*sql.DB, error) {
+	want, err := latestMigration(migrationsFS)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	for _, params := range []string{"mode=ro", "mode=ro&immutable=1"} {
+		db, err := sql.Open("sqlite", readOnlyDSN(dbPath, params))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		db.SetMaxOpenConns(maxOpenConns)
+		db.SetMaxIdleConns(maxOpenConns)
+
+		err = checkVersion(ctx, db, want)
+		if err == nil {
+			return db, nil
+		}
+		_ = db.Close()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// readOnlyDSN builds a URI filename for dbPath with the given query
+// parameters, plus a busy timeout and query_only as a second guard against
+// writes.
+func readOnlyDSN(dbPath, params string) string {
+	p := filepath.ToSlash(dbPath)
+	p = strings.NewReplacer("%", "%25", "?", "%3f", "#", "%23").Replace(p)
+	switch {
+	case strings.HasPrefix(p, "//"):
+		// A UNC path, \\server\share\file: an empty authority keeps the
+		// server name in the path.
+		p = "//" + p
+	case filepath.VolumeName(dbPath) != "":
+		p = "///" + p
+	}
+	return fmt.Sprintf("file:%s?%s&_pragma=busy_timeout(%d)&_pragma=query_only(1)",
+		p, params, busyTimeout.Milliseconds())
+}
+
+// checkVersion fails unless db's schema is at migration version want.
+func checkVersion(ctx context.Context, db *sql.DB, want int) error {
+	version, dirty, err := schemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	switch {
+	case dirty:
+		return fmt.Errorf("database is mid-upgrade (version %d); open it normally on the PC that records to it", version)
+	case version < want:
+		return fmt.Errorf("database is from an older version of the app (%d, want %d); open it normally on the PC that records to it to upgrade it", version, want)
+	case version > want:
+		return fmt.Errorf("database is from a newer version of the app (%d, want %d); update the app", version, want)
+	}
+	return nil
+}
+
+// schemaVersion returns the migration version db's schema is at, and
+// whether a migration to it failed part way.
+func schemaVersion(ctx context.Context, db *sql.DB) (version int, dirty bool, err error) {
+	err = db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations`).Scan(&version, &dirty)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read database version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// latestMigration returns the version of the newest migration in
+// migrationsFS, from file names like "000009_round_summaries.up.sql".
+func latestMigration(migrationsFS fs.FS) (int, error) {
+	entries, err := fs.ReadDir(migrationsFS, "migrations")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	latest := 0
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.Atoi(prefix); err == nil && v > latest {
+			latest = v
+		}
+	}
+	return latest, nil
+}
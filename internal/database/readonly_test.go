@@ -0,0 +1,47 @@
+package database_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"csstatstracker"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestOpenReadOnly(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "shared.db")
+	db, err := database.Init(ctx, path, csstatstracker.MigrationsFS)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	dbtest.Insert(t, db, dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WLW")...)
+
+	// The recording PC keeps its connection open while the viewer reads.
+	t.Cleanup(func() { _ = db.Close() })
+
+	ro, err := database.OpenReadOnly(ctx, path, csstatstracker.MigrationsFS)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer func() { _ = ro.Close() }()
+
+	n, err := database.CountRounds(ctx, ro)
+	if err != nil || n != 3 {
+		t.Fatalf("CountRounds = %d, %v; want 3", n, err)
+	}
+	if _, err := database.InsertRound(ctx, ro, database.Round{Winner: database.TeamCT}); err == nil {
+		t.Error("InsertRound on a read-only database succeeded")
+	}
+}
+
+func TestOpenReadOnlyMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.db")
+	if db, err := database.OpenReadOnly(context.Background(), path, csstatstracker.MigrationsFS); err == nil {
+		_ = db.Close()
+		t.Error("OpenReadOnly of a missing file succeeded")
+	}
+}
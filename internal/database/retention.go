@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// GetRoundsBefore returns the rounds recorded before t, oldest first, e.g.
+// to archive them before they're pruned.
+func GetRoundsBefore(ctx context.Context, db *sql.DB, t time.Time) ([]Round, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds
+		WHERE created_at < ?
+		ORDER BY created_at, id`, t.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rounds: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
+
+// PruneRoundsBefore deletes the rounds recorded before t, keeping per-day
+// counts of them in round_summaries so totals and charts are unchanged.
+// Returns how many rounds were deleted.
+func PruneRoundsBefore(ctx context.Context, db *sql.DB, t time.Time) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO round_summaries (created_at, winner, team, party_size, account, rounds)
+		SELECT date(created_at) || ' 00:00:00', winner, team, party_size, account, COUNT(*)
+		FROM rounds
+		WHERE created_at < ?
+		GROUP BY 1, 2, 3, 4, 5
+		ON CONFLICT (created_at, winner, team, party_size, account)
+		DO UPDATE SET rounds = rounds + excluded.rounds`, t.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to summarise rounds: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, `DELETE FROM rounds WHERE created_at < ?`, t.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune rounds: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune rounds: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit pruning: %w", err)
+	}
+	return int(n), nil
+}
@@ -0,0 +1,83 @@
+package database_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestPruneRoundsBefore(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	old := time.Date(2023, 5, 10, 20, 0, 0, 0, time.UTC)
+	cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	dbtest.Insert(t, db, slices.Concat(
+		dbtest.Series(old, time.Minute, "WWLwlD"),
+		dbtest.Series(old.AddDate(0, 0, 1), time.Minute, "WL"),
+		dbtest.Series(cutoff.Add(time.Hour), time.Minute, "WWl"),
+	)...)
+
+	before, err := database.GetStats(ctx, db, database.Filter{Window: database.WindowAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	beforeMonths, err := database.GetMonthlyStats(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archived, err := database.GetRoundsBefore(ctx, db, cutoff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archived) != 8 || !archived[0].CreatedAt.Equal(old) {
+		t.Fatalf("GetRoundsBefore = %d rounds starting %v, want 8 starting %v", len(archived), archived[0].CreatedAt, old)
+	}
+
+	n, err := database.PruneRoundsBefore(ctx, db, cutoff)
+	if err != nil || n != 8 {
+		t.Fatalf("PruneRoundsBefore = %d, %v, want 8", n, err)
+	}
+	if left, _ := database.CountRounds(ctx, db); left != 3 {
+		t.Errorf("%d rounds left, want 3", left)
+	}
+
+	after, err := database.GetStats(ctx, db, database.Filter{Window: database.WindowAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *after != *before {
+		t.Errorf("stats changed by pruning:\n before %+v\n after  %+v", before, after)
+	}
+	afterMonths, err := database.GetMonthlyStats(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(afterMonths, beforeMonths) {
+		t.Errorf("monthly stats changed by pruning: %+v, want %+v", afterMonths, beforeMonths)
+	}
+	daily, err := database.GetDailyStats(ctx, db, database.Filter{Window: database.WindowAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(daily) != 3 || daily[0].Wins != 3 || daily[0].Losses != 2 || daily[0].Draws != 1 {
+		t.Errorf("GetDailyStats = %+v", daily)
+	}
+
+	// Pruning again merges into the same day's summaries.
+	dbtest.Insert(t, db, dbtest.Series(old.Add(time.Hour), time.Minute, "W")...)
+	if _, err := database.PruneRoundsBefore(ctx, db, cutoff); err != nil {
+		t.Fatal(err)
+	}
+	again, err := database.GetStats(ctx, db, database.Filter{Window: database.WindowAll})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Wins != before.Wins+1 || again.TotalRounds != before.TotalRounds+1 {
+		t.Errorf("after a second prune: %+v", again)
+	}
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// WinReason is why a round was won, game state's reason without the side,
+// e.g. "bomb" for "t_win_bomb".
+type WinReason string
+
+const (
+	WinElimination WinReason = "elimination"
+	WinBomb        WinReason = "bomb"
+	WinDefuse      WinReason = "defuse"
+	WinTime        WinReason = "time"
+)
+
+// RoundOutcome is how a round ended, as reported by game state.
+type RoundOutcome struct {
+	ID         int
+	Map        string
+	Round      int  // 1 for a match's first round
+	Winner     Team // side that won the round
+	Reason     WinReason
+	Bomb       string // "planted", "defused", "exploded", or "" if it wasn't planted
+	Side       Team   // side the player was on, if known
+	Money      int    // the player's money when the round went live
+	EquipValue int    // value of the player's equipment when the round went live
+	Account    string
+	CreatedAt  time.Time
+}
+
+// InsertRoundOutcome records how a round ended.
+func InsertRoundOutcome(ctx context.Context, db *sql.DB, o RoundOutcome) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO round_outcomes (map, round, winner, reason, bomb, side, money, equip_value, account)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		o.Map, o.Round, string(o.Winner), string(o.Reason), o.Bomb, string(o.Side), o.Money, o.EquipValue, o.Account,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert round outcome: %w", err)
+	}
+	return nil
+}
+
+// GetRoundOutcomesSince returns the round outcomes recorded at or after t,
+// oldest first.
+func GetRoundOutcomesSince(ctx context.Context, db *sql.DB, t time.Time) ([]RoundOutcome, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, map, round, winner, reason, bomb, side, money, equip_value, account, created_at
+		FROM round_outcomes WHERE created_at >= ? ORDER BY created_at ASC, id ASC`,
+		t.UTC().Format(time.DateTime))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query round outcomes: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var outcomes []RoundOutcome
+	for rows.Next() {
+		var o RoundOutcome
+		var winner, reason, side string
+		if err := rows.Scan(&o.ID, &o.Map, &o.Round, &winner, &reason, &o.Bomb, &side,
+			&o.Money, &o.EquipValue, &o.Account, &o.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan round outcome: %w", err)
+		}
+		o.Winner, o.Reason, o.Side = Team(winner), WinReason(reason), Team(side)
+		outcomes = append(outcomes, o)
+	}
+	return outcomes, rows.Err()
+}
@@ -0,0 +1,49 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestRoundOutcomes(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	start := time.Now().UTC().Add(-time.Minute)
+
+	want := []database.RoundOutcome{
+		{Map: "de_mirage", Round: 1, Winner: database.TeamT, Reason: database.WinBomb, Bomb: "exploded",
+			Side: database.TeamT, Money: 800, EquipValue: 200, Account: "main"},
+		{Map: "de_mirage", Round: 2, Winner: database.TeamCT, Reason: database.WinElimination,
+			Side: database.TeamT, Money: 3250, EquipValue: 1000, Account: "main"},
+	}
+	for _, o := range want {
+		if err := database.InsertRoundOutcome(ctx, db, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := database.GetRoundOutcomesSince(ctx, db, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("GetRoundOutcomesSince returned %d outcomes, want %d", len(got), len(want))
+	}
+	for i, o := range got {
+		if o.CreatedAt.Before(start) {
+			t.Errorf("outcome %d recorded at %s, before %s", i, o.CreatedAt, start)
+		}
+		o.ID, o.CreatedAt = 0, time.Time{}
+		if o != want[i] {
+			t.Errorf("outcome %d = %+v, want %+v", i, o, want[i])
+		}
+	}
+
+	if got, err := database.GetRoundOutcomesSince(ctx, db, time.Now().Add(time.Hour)); err != nil || len(got) != 0 {
+		t.Errorf("GetRoundOutcomesSince(future) = %v, %v; want none", got, err)
+	}
+}
@@ -4,23 +4,31 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // Round represents a single round recorded by the tracker.
 type Round struct {
 	ID        int
+	UUID      string // identifies the round across databases, e.g. when merging
 	Winner    Team
 	Team      Team
+	PartySize PartySize
+	Account   string // name of the Steam account it was played on, "" if unknown
+	Map       string // map from game state integration, e.g. "de_dust2"; "" if unknown
+	Mode      Mode
+	CTName    string // the CT side's name when it was recorded, "" if not stored
+	TName     string // the T side's name when it was recorded, "" if not stored
 	CreatedAt time.Time
 }
 
-// InsertRound records a round with the given winner and player's team.
-// Returns the new row id.
-func InsertRound(ctx context.Context, db *sql.DB, winner, team Team) (int64, error) {
+// InsertRound records r, timestamped now; r.ID and r.CreatedAt are ignored,
+// and so is r.UUID: the round gets a new one. Returns the new row id.
+func InsertRound(ctx context.Context, db *sql.DB, r Round) (int64, error) {
 	res, err := db.ExecContext(ctx,
-		`INSERT INTO rounds (winner, team) VALUES (?, ?)`,
-		string(winner), string(team),
+		`INSERT INTO rounds (winner, team, party_size, account, map, mode, ct_name, t_name) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		string(r.Winner), string(r.Team), int(r.PartySize), r.Account, r.Map, string(r.Mode), r.CTName, r.TName,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert round: %w", err)
@@ -32,28 +40,68 @@ func InsertRound(ctx context.Context, db *sql.DB, winner, team Team) (int64, err
 	return id, nil
 }
 
-// DeleteLastRoundForWinner removes the most recent round whose winner matches,
-// used by the tracker's decrement buttons.
-func DeleteLastRoundForWinner(ctx context.Context, db *sql.DB, winner Team) (bool, error) {
-	res, err := db.ExecContext(ctx, `
-		DELETE FROM rounds
-		WHERE id = (
-			SELECT id FROM rounds
-			WHERE winner = ?
-			ORDER BY id DESC LIMIT 1
-		)`, string(winner))
+// MergeRounds adds rounds recorded in another database, keeping their UUID
+// and CreatedAt; their IDs are ignored. Rounds whose UUID is already here are
+// skipped, so merging the same rounds twice adds them once. It runs in one
+// transaction and returns how many rounds were added.
+func MergeRounds(ctx context.Context, db *sql.DB, rounds []Round) (int, error) {
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
-		return false, fmt.Errorf("failed to delete last round: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	n, _ := res.RowsAffected()
-	return n > 0, nil
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO rounds (uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (uuid) DO NOTHING`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare round merge: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+	added := 0
+	for _, r := range rounds {
+		if r.UUID == "" {
+			return 0, fmt.Errorf("round recorded %s has no UUID", r.CreatedAt.Format(time.DateTime))
+		}
+		res, err := stmt.ExecContext(ctx, r.UUID, string(r.Winner), string(r.Team), int(r.PartySize), r.Account, r.Map,
+			string(r.Mode), r.CTName, r.TName, r.CreatedAt.UTC().Format(time.DateTime))
+		if err != nil {
+			return 0, fmt.Errorf("failed to merge round %s: %w", r.UUID, err)
+		}
+		n, _ := res.RowsAffected()
+		added += int(n)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit merged rounds: %w", err)
+	}
+	return added, nil
 }
 
-// UpdateRound mutates a round's winner and/or team.
-func UpdateRound(ctx context.Context, db *sql.DB, id int, winner, team Team) error {
+// GetRoundByUUID returns the round with the given UUID, or sql.ErrNoRows.
+func GetRoundByUUID(ctx context.Context, db *sql.DB, uuid string) (Round, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds WHERE uuid = ?`, uuid)
+	if err != nil {
+		return Round{}, fmt.Errorf("failed to query round: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	rounds, err := scanRounds(rows)
+	if err != nil {
+		return Round{}, err
+	}
+	if len(rounds) == 0 {
+		return Round{}, sql.ErrNoRows
+	}
+	return rounds[0], nil
+}
+
+// UpdateRound saves r's winner, team, party size, account, map, mode and side
+// names over the round with r.ID. The timestamp is left as recorded.
+func UpdateRound(ctx context.Context, db *sql.DB, r Round) error {
 	_, err := db.ExecContext(ctx,
-		`UPDATE rounds SET winner = ?, team = ? WHERE id = ?`,
-		string(winner), string(team), id,
+		`UPDATE rounds SET winner = ?, team = ?, party_size = ?, account = ?, map = ?, mode = ?, ct_name = ?, t_name = ? WHERE id = ?`,
+		string(r.Winner), string(r.Team), int(r.PartySize), r.Account, r.Map, string(r.Mode), r.CTName, r.TName, r.ID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to update round: %w", err)
@@ -61,6 +109,84 @@ func UpdateRound(ctx context.Context, db *sql.DB, id int, winner, team Team) err
 	return nil
 }
 
+// RoundChange describes a bulk edit. Nil fields are left as they are.
+type RoundChange struct {
+	Team      *Team
+	PartySize *PartySize
+	Account   *string
+	Map       *string
+	Mode      *Mode
+}
+
+// UpdateRounds applies change to every round in ids in one transaction, so
+// either all of them are updated or none are.
+func UpdateRounds(ctx context.Context, db *sql.DB, ids []int, change RoundChange) error {
+	var sets []string
+	var args []any
+	if change.Team != nil {
+		sets = append(sets, "team = ?")
+		args = append(args, string(*change.Team))
+	}
+	if change.PartySize != nil {
+		sets = append(sets, "party_size = ?")
+		args = append(args, int(*change.PartySize))
+	}
+	if change.Account != nil {
+		sets = append(sets, "account = ?")
+		args = append(args, *change.Account)
+	}
+	if change.Map != nil {
+		sets = append(sets, "map = ?")
+		args = append(args, *change.Map)
+	}
+	if change.Mode != nil {
+		sets = append(sets, "mode = ?")
+		args = append(args, string(*change.Mode))
+	}
+	if len(sets) == 0 || len(ids) == 0 {
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `UPDATE rounds SET `+strings.Join(sets, ", ")+` WHERE id = ?`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare round update: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+	for _, id := range ids {
+		if _, err := stmt.ExecContext(ctx, append(args, id)...); err != nil {
+			return fmt.Errorf("failed to update round %d: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit round updates: %w", err)
+	}
+	return nil
+}
+
+// GetMapNames returns every map a round was recorded on, sorted.
+func GetMapNames(ctx context.Context, db *sql.DB) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT DISTINCT map FROM rounds WHERE map != '' ORDER BY map`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query maps: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan map: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
 // DeleteRound removes a single round by id.
 func DeleteRound(ctx context.Context, db *sql.DB, id int) error {
 	_, err := db.ExecContext(ctx, `DELETE FROM rounds WHERE id = ?`, id)
@@ -70,24 +196,166 @@ func DeleteRound(ctx context.Context, db *sql.DB, id int) error {
 	return nil
 }
 
+// DeleteRounds removes the rounds in ids in one transaction, so either all
+// of them are deleted or none are.
+func DeleteRounds(ctx context.Context, db *sql.DB, ids []int) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt, err := tx.PrepareContext(ctx, `DELETE FROM rounds WHERE id = ?`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare round delete: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+	for _, id := range ids {
+		if _, err := stmt.ExecContext(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete round %d: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit round deletes: %w", err)
+	}
+	return nil
+}
+
 // GetAllRounds returns every round in reverse-chronological order.
 func GetAllRounds(ctx context.Context, db *sql.DB) ([]Round, error) {
 	rows, err := db.QueryContext(ctx,
-		`SELECT id, winner, team, created_at FROM rounds ORDER BY created_at DESC, id DESC`)
+		`SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds ORDER BY created_at DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rounds: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
+
+// Result is the outcome of a round from the player's point of view.
+type Result int
+
+const (
+	ResultDraw Result = iota // no team selected, so the round can't be scored
+	ResultWin
+	ResultLoss
+)
+
+// Result reports whether the round was won, lost or drawn by the player.
+func (r Round) Result() Result {
+	switch r.Team {
+	case TeamCT, TeamT:
+		if r.Winner == r.Team {
+			return ResultWin
+		}
+		return ResultLoss
+	default:
+		return ResultDraw
+	}
+}
+
+// CountRounds returns the number of recorded rounds.
+func CountRounds(ctx context.Context, db *sql.DB) (int, error) {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM rounds`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count rounds: %w", err)
+	}
+	return n, nil
+}
+
+// CountRoundsSince returns the number of rounds recorded at or after t. In
+// the newest-first order of GetRoundsPage, that's the offset of the first
+// round before t.
+func CountRoundsSince(ctx context.Context, db *sql.DB, t time.Time) (int, error) {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM rounds WHERE created_at >= ?`, t).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count rounds: %w", err)
+	}
+	return n, nil
+}
+
+// GetRoundsSince returns the rounds recorded at or after t, oldest first.
+func GetRoundsSince(ctx context.Context, db *sql.DB, t time.Time) ([]Round, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds
+		WHERE created_at >= ?
+		ORDER BY created_at, id`, t.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rounds: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
+
+// GetRoundsBetween returns the rounds recorded from start to end, both
+// included, oldest first: e.g. the rounds of a game the games package
+// rebuilt, to break it down by half or find the runs within it. Times are
+// compared to the second, however the round's time was written.
+func GetRoundsBetween(ctx context.Context, db *sql.DB, start, end time.Time) ([]Round, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds
+		WHERE datetime(created_at) BETWEEN datetime(?) AND datetime(?)
+		ORDER BY created_at, id`, start.UTC().Format(time.DateTime), end.UTC().Format(time.DateTime))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rounds: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
+
+// GetUnassignedRounds returns the rounds recorded with no team selected,
+// which count as draws, oldest first.
+func GetUnassignedRounds(ctx context.Context, db *sql.DB) ([]Round, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds
+		WHERE team = ''
+		ORDER BY created_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rounds: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
+
+// GetRoundsPage returns up to limit rounds newest first, skipping the offset
+// newest.
+func GetRoundsPage(ctx context.Context, db *sql.DB, offset, limit int) ([]Round, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`,
+		limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query rounds: %w", err)
 	}
 	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
+
+// GetRecentRounds returns up to limit of the most recent rounds, newest first.
+func GetRecentRounds(ctx context.Context, db *sql.DB, limit int) ([]Round, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, uuid, winner, team, party_size, account, map, mode, ct_name, t_name, created_at FROM rounds ORDER BY created_at DESC, id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent rounds: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return scanRounds(rows)
+}
 
+// scanRounds reads id, uuid, winner, team, party_size, account, map, mode,
+// ct_name, t_name, created_at rows into Rounds.
+func scanRounds(rows *sql.Rows) ([]Round, error) {
 	var out []Round
 	for rows.Next() {
 		var r Round
-		var winner, team string
-		if err := rows.Scan(&r.ID, &winner, &team, &r.CreatedAt); err != nil {
+		var winner, team, mode string
+		var party int
+		if err := rows.Scan(&r.ID, &r.UUID, &winner, &team, &party, &r.Account, &r.Map, &mode, &r.CTName, &r.TName, &r.CreatedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan round: %w", err)
 		}
 		r.Winner = Team(winner)
 		r.Team = Team(team)
+		r.PartySize = PartySize(party)
+		r.Mode = Mode(mode)
 		out = append(out, r)
 	}
 	return out, rows.Err()
@@ -0,0 +1,367 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestRoundResult(t *testing.T) {
+	tests := []struct {
+		name         string
+		winner, team database.Team
+		want         database.Result
+	}{
+		{name: "CT win", winner: database.TeamCT, team: database.TeamCT, want: database.ResultWin},
+		{name: "CT loss", winner: database.TeamT, team: database.TeamCT, want: database.ResultLoss},
+		{name: "T win", winner: database.TeamT, team: database.TeamT, want: database.ResultWin},
+		{name: "T loss", winner: database.TeamCT, team: database.TeamT, want: database.ResultLoss},
+		{name: "no team", winner: database.TeamCT, team: database.TeamNone, want: database.ResultDraw},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := database.Round{Winner: tt.winner, Team: tt.team}
+			if got := r.Result(); got != tt.want {
+				t.Errorf("Result() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertRound(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+
+	want := database.Round{Winner: database.TeamT, Team: database.TeamCT, PartySize: database.PartyDuo, Account: "main", Map: "de_mirage", Mode: database.ModeWingman,
+		CTName: "Us", TName: "Them"}
+	id, err := database.InsertRound(ctx, db, want)
+	if err != nil {
+		t.Fatalf("InsertRound: %v", err)
+	}
+
+	rounds, err := database.GetAllRounds(ctx, db)
+	if err != nil {
+		t.Fatalf("GetAllRounds: %v", err)
+	}
+	if len(rounds) != 1 {
+		t.Fatalf("got %d rounds, want 1", len(rounds))
+	}
+	got := rounds[0]
+	if int64(got.ID) != id || got.Winner != want.Winner || got.Team != want.Team ||
+		got.PartySize != want.PartySize || got.Account != want.Account || got.Map != want.Map || got.Mode != want.Mode ||
+		got.CTName != want.CTName || got.TName != want.TName {
+		t.Errorf("got %+v, want %+v with id %d", got, want, id)
+	}
+	if time.Since(got.CreatedAt) > time.Minute {
+		t.Errorf("CreatedAt = %v, want about now", got.CreatedAt)
+	}
+}
+
+func TestUpdateRounds(t *testing.T) {
+	team := database.TeamT
+	party := database.PartyTrio
+	account := "alt"
+	mapName := "de_ancient"
+	mode := database.ModePremier
+
+	tests := []struct {
+		name   string
+		change database.RoundChange
+		want   func(before database.Round) database.Round
+	}{
+		{
+			name:   "nothing",
+			change: database.RoundChange{},
+			want:   func(r database.Round) database.Round { return r },
+		},
+		{
+			name:   "team only",
+			change: database.RoundChange{Team: &team},
+			want: func(r database.Round) database.Round {
+				r.Team = team
+				return r
+			},
+		},
+		{
+			name:   "party and account",
+			change: database.RoundChange{PartySize: &party, Account: &account},
+			want: func(r database.Round) database.Round {
+				r.PartySize = party
+				r.Account = account
+				return r
+			},
+		},
+		{
+			name:   "map",
+			change: database.RoundChange{Map: &mapName},
+			want: func(r database.Round) database.Round {
+				r.Map = mapName
+				return r
+			},
+		},
+		{
+			name:   "mode",
+			change: database.RoundChange{Mode: &mode},
+			want: func(r database.Round) database.Round {
+				r.Mode = mode
+				return r
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := dbtest.New(t)
+			dbtest.Insert(t, db, dbtest.With(dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WLD"), func(r *database.Round) {
+				r.Account = "main"
+			})...)
+
+			before, err := database.GetAllRounds(ctx, db)
+			if err != nil {
+				t.Fatalf("GetAllRounds: %v", err)
+			}
+			// Change the two newest rounds, leave the oldest alone.
+			ids := []int{before[0].ID, before[1].ID}
+			if err := database.UpdateRounds(ctx, db, ids, tt.change); err != nil {
+				t.Fatalf("UpdateRounds: %v", err)
+			}
+
+			after, err := database.GetAllRounds(ctx, db)
+			if err != nil {
+				t.Fatalf("GetAllRounds: %v", err)
+			}
+			for i := range after {
+				want := before[i]
+				if i < 2 {
+					want = tt.want(before[i])
+				}
+				if after[i] != want {
+					t.Errorf("round %d = %+v, want %+v", i, after[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMapNames(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	maps := []string{"de_nuke", "", "de_anubis", "de_nuke"}
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WLWL"), func(r *database.Round) {
+		r.Map, maps = maps[0], maps[1:]
+	})...)
+
+	names, err := database.GetMapNames(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"de_anubis", "de_nuke"}; !slices.Equal(names, want) {
+		t.Errorf("GetMapNames() = %v, want %v", names, want)
+	}
+}
+
+func TestGetRoundsPage(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	dbtest.Insert(t, db, dbtest.Series(start, 24*time.Hour, "WWWWWLLLLL")...) // Jan 1 to Jan 10
+
+	total, err := database.CountRounds(ctx, db)
+	if err != nil || total != 10 {
+		t.Fatalf("CountRounds = %d, %v; want 10", total, err)
+	}
+
+	tests := []struct {
+		name          string
+		offset, limit int
+		wantFirstDay  int // day of January of the first round returned
+		wantLen       int
+	}{
+		{name: "first page", offset: 0, limit: 4, wantFirstDay: 10, wantLen: 4},
+		{name: "middle page", offset: 4, limit: 4, wantFirstDay: 6, wantLen: 4},
+		{name: "short last page", offset: 8, limit: 4, wantFirstDay: 2, wantLen: 2},
+		{name: "past the end", offset: 10, limit: 4, wantLen: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := database.GetRoundsPage(ctx, db, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatalf("GetRoundsPage: %v", err)
+			}
+			if len(page) != tt.wantLen {
+				t.Fatalf("got %d rounds, want %d", len(page), tt.wantLen)
+			}
+			if len(page) > 0 && page[0].CreatedAt.UTC().Day() != tt.wantFirstDay {
+				t.Errorf("first round on Jan %d, want Jan %d", page[0].CreatedAt.UTC().Day(), tt.wantFirstDay)
+			}
+		})
+	}
+
+	since, err := database.CountRoundsSince(ctx, db, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+	if err != nil || since != 3 {
+		t.Errorf("CountRoundsSince(Jan 8) = %d, %v; want 3", since, err)
+	}
+	rounds, err := database.GetRoundsSince(ctx, db, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("GetRoundsSince: %v", err)
+	}
+	if len(rounds) != 3 {
+		t.Fatalf("GetRoundsSince(Jan 8) = %d rounds, want 3", len(rounds))
+	}
+	if day := rounds[0].CreatedAt.UTC().Day(); day != 8 {
+		t.Errorf("GetRoundsSince(Jan 8) starts on Jan %d, want Jan 8 (oldest first)", day)
+	}
+}
+
+func TestGetMonthlyStats(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.Series(time.Date(2023, 12, 30, 12, 0, 0, 0, time.UTC), 24*time.Hour, "WLWD")...)
+
+	months, err := database.GetMonthlyStats(ctx, db)
+	if err != nil {
+		t.Fatalf("GetMonthlyStats: %v", err)
+	}
+	want := []database.MonthStats{
+		{Month: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Wins: 1, Draws: 1},
+		{Month: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), Wins: 1, Losses: 1},
+	}
+	if len(months) != len(want) {
+		t.Fatalf("got %d months, want %d: %+v", len(months), len(want), months)
+	}
+	for i, w := range want {
+		if !months[i].Month.Equal(w.Month) || months[i].Wins != w.Wins ||
+			months[i].Losses != w.Losses || months[i].Draws != w.Draws {
+			t.Errorf("months[%d] = %+v, want %+v", i, months[i], w)
+		}
+	}
+
+	jan, err := database.GetRoundsInMonth(ctx, db, want[0].Month)
+	if err != nil {
+		t.Fatalf("GetRoundsInMonth: %v", err)
+	}
+	if len(jan) != 2 {
+		t.Errorf("got %d rounds in January, want 2", len(jan))
+	}
+}
+
+func TestGetRoundsBetween(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	dbtest.Insert(t, db, dbtest.Series(start, time.Minute, "WLWWL")...)
+	// A synced round's time is written by the driver rather than SQLite.
+	synced := database.Round{UUID: "synced", Winner: database.TeamT, Team: database.TeamT, CreatedAt: start.Add(3 * time.Minute)}
+	if _, err := database.MergeRounds(ctx, db, []database.Round{synced}); err != nil {
+		t.Fatalf("MergeRounds: %v", err)
+	}
+
+	rounds, err := database.GetRoundsBetween(ctx, db, start.Add(time.Minute), start.Add(3*time.Minute))
+	if err != nil {
+		t.Fatalf("GetRoundsBetween: %v", err)
+	}
+	var got []database.Result
+	for _, r := range rounds {
+		got = append(got, r.Result())
+	}
+	if want := []database.Result{database.ResultLoss, database.ResultWin, database.ResultWin, database.ResultWin}; !slices.Equal(got, want) {
+		t.Errorf("rounds between the 2nd and 4th = %v, want %v, both ends included", got, want)
+	}
+}
+
+func TestGetUnassignedRounds(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	dbtest.Insert(t, db, dbtest.Series(start, time.Minute, "WDLDw")...)
+
+	rounds, err := database.GetUnassignedRounds(ctx, db)
+	if err != nil {
+		t.Fatalf("GetUnassignedRounds: %v", err)
+	}
+	if len(rounds) != 2 {
+		t.Fatalf("got %d rounds, want 2", len(rounds))
+	}
+	for i, r := range rounds {
+		if r.Team != database.TeamNone {
+			t.Errorf("round %d has team %q, want none", i, r.Team)
+		}
+	}
+	if !rounds[0].CreatedAt.Before(rounds[1].CreatedAt) {
+		t.Errorf("rounds not oldest first: %v, %v", rounds[0].CreatedAt, rounds[1].CreatedAt)
+	}
+}
+
+func TestDeleteRounds(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WLWL")...)
+	rounds, err := database.GetAllRounds(ctx, db)
+	if err != nil {
+		t.Fatalf("GetAllRounds: %v", err)
+	}
+
+	if err := database.DeleteRounds(ctx, db, []int{rounds[0].ID, rounds[2].ID}); err != nil {
+		t.Fatalf("DeleteRounds: %v", err)
+	}
+	after, err := database.GetAllRounds(ctx, db)
+	if err != nil {
+		t.Fatalf("GetAllRounds: %v", err)
+	}
+	if len(after) != 2 || after[0].ID != rounds[1].ID || after[1].ID != rounds[3].ID {
+		t.Errorf("after delete got %+v, want rounds %d and %d", after, rounds[1].ID, rounds[3].ID)
+	}
+}
+
+func TestMergeRounds(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	// Two PCs record rounds with overlapping row ids.
+	other := dbtest.New(t)
+	dbtest.Insert(t, other, dbtest.Series(start, time.Minute, "WLw")...)
+	theirs, err := database.GetAllRounds(ctx, other)
+	if err != nil {
+		t.Fatalf("GetAllRounds: %v", err)
+	}
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.Series(start.Add(time.Hour), time.Minute, "LL")...)
+
+	seen := map[string]bool{}
+	for _, r := range theirs {
+		if len(r.UUID) != 36 || r.UUID[14] != '4' || seen[r.UUID] {
+			t.Errorf("round %d has UUID %q, want a unique version 4 UUID", r.ID, r.UUID)
+		}
+		seen[r.UUID] = true
+	}
+
+	for _, want := range []int{3, 0} {
+		added, err := database.MergeRounds(ctx, db, theirs)
+		if err != nil {
+			t.Fatalf("MergeRounds: %v", err)
+		}
+		if added != want {
+			t.Errorf("MergeRounds added %d rounds, want %d", added, want)
+		}
+	}
+	if n, _ := database.CountRounds(ctx, db); n != 5 {
+		t.Errorf("CountRounds = %d after merging, want 5", n)
+	}
+
+	got, err := database.GetRoundByUUID(ctx, db, theirs[0].UUID)
+	if err != nil {
+		t.Fatalf("GetRoundByUUID: %v", err)
+	}
+	if got.Winner != theirs[0].Winner || got.Team != theirs[0].Team || !got.CreatedAt.Equal(theirs[0].CreatedAt) {
+		t.Errorf("merged round = %+v, want %+v", got, theirs[0])
+	}
+	if _, err := database.GetRoundByUUID(ctx, db, "missing"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetRoundByUUID(missing) error = %v, want sql.ErrNoRows", err)
+	}
+}
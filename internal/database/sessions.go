@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Session is a stretch of time the game was running.
+type Session struct {
+	ID        int
+	StartedAt time.Time
+	EndedAt   time.Time
+}
+
+// Duration returns how long the session lasted.
+func (s Session) Duration() time.Duration {
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// StartSession records a session starting, and for now ending, at the given
+// time. Returns the new row id.
+func StartSession(ctx context.Context, db *sql.DB, at time.Time) (int64, error) {
+	stamp := at.UTC().Format(time.DateTime)
+	res, err := db.ExecContext(ctx, `INSERT INTO sessions (started_at, ended_at) VALUES (?, ?)`, stamp, stamp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert session: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read session id: %w", err)
+	}
+	return id, nil
+}
+
+// ExtendSession moves the end of the session with the given id to at.
+func ExtendSession(ctx context.Context, db *sql.DB, id int64, at time.Time) error {
+	_, err := db.ExecContext(ctx, `UPDATE sessions SET ended_at = ? WHERE id = ?`, at.UTC().Format(time.DateTime), id)
+	if err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	return nil
+}
+
+// GetSessionsSince returns the sessions that started at or after t, oldest
+// first.
+func GetSessionsSince(ctx context.Context, db *sql.DB, t time.Time) ([]Session, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, started_at, ended_at FROM sessions WHERE started_at >= ? ORDER BY started_at ASC, id ASC`,
+		t.UTC().Format(time.DateTime))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var sessions []Session
+	for rows.Next() {
+		var s Session
+		if err := rows.Scan(&s.ID, &s.StartedAt, &s.EndedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
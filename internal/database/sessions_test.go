@@ -0,0 +1,43 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestSessions(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	start := time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC)
+
+	old, err := database.StartSession(ctx, db, start.Add(-48*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := database.StartSession(ctx, db, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id == old {
+		t.Fatalf("both sessions got id %d", id)
+	}
+	if err := database.ExtendSession(ctx, db, id, start.Add(95*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	sessions, err := database.GetSessionsSince(ctx, db, start.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("GetSessionsSince returned %d sessions, want 1", len(sessions))
+	}
+	s := sessions[0]
+	if !s.StartedAt.Equal(start) || s.Duration() != 95*time.Minute {
+		t.Errorf("session = %s for %s, want %s for 1h35m", s.StartedAt, s.Duration(), start)
+	}
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// Upgrade describes the migrations waiting to run on a database.
+type Upgrade struct {
+	From int // the database's schema version, 0 for a new database
+	To   int // the newest version there are migrations for
+}
+
+// Pending reports whether an existing database needs migrating. New
+// databases are simply created.
+func (u Upgrade) Pending() bool {
+	return u.From > 0 && u.From < u.To
+}
+
+// String describes the upgrade like "v3→v5".
+func (u Upgrade) String() string {
+	return fmt.Sprintf("v%d→v%d", u.From, u.To)
+}
+
+// CheckUpgrade reports which migrations Init would run on the database at
+// dbPath, without running them. A missing file isn't created.
+func CheckUpgrade(ctx context.Context, dbPath string, migrationsFS fs.FS) (Upgrade, error) {
+	want, err := latestMigration(migrationsFS)
+	if err != nil {
+		return Upgrade{}, err
+	}
+	up := Upgrade{To: want}
+	if _, err := os.Stat(dbPath); errors.Is(err, fs.ErrNotExist) || dbPath == ":memory:" {
+		return up, nil
+	}
+
+	db, err := open(dbPath)
+	if err != nil {
+		return Upgrade{}, err
+	}
+	defer func() { _ = db.Close() }()
+	up.From, err = currentVersion(ctx, db)
+	if err != nil {
+		return Upgrade{}, err
+	}
+	return up, nil
+}
+
+// currentVersion is schemaVersion, except that a database that's never been
+// migrated is at version 0.
+func currentVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var tables int
+	err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`).Scan(&tables)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read database version: %w", err)
+	}
+	if tables == 0 {
+		return 0, nil
+	}
+	version, _, err := schemaVersion(ctx, db)
+	return version, err
+}
+
+// BackupPath is where InitWithBackup copies the database at dbPath before
+// upgrading it from schema version from.
+func BackupPath(dbPath string, from int) string {
+	return fmt.Sprintf("%s.v%d.bak", dbPath, from)
+}
+
+// InitWithBackup is Init for a database that may need upgrading. Before any
+// migration runs, the database is copied to BackupPath; if one then fails,
+// the copy is put back so the database is left as it was. The copy is kept
+// after a successful upgrade.
+func InitWithBackup(ctx context.Context, dbPath string, migrationsFS fs.FS) (*sql.DB, error) {
+	up, err := CheckUpgrade(ctx, dbPath, migrationsFS)
+	if err != nil {
+		return nil, err
+	}
+	if !up.Pending() {
+		return Init(ctx, dbPath, migrationsFS)
+	}
+
+	db, err := open(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	backup := BackupPath(dbPath, up.From)
+	// VACUUM INTO won't overwrite, so replace a backup from an earlier
+	// attempt at the same upgrade.
+	if err := os.Remove(backup); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to replace database backup: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `VACUUM INTO ?`, backup); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to back up database before upgrading it: %w", err)
+	}
+
+	if err := migrateUp(db, migrationsFS); err != nil {
+		_ = db.Close()
+		if rerr := restore(dbPath, backup); rerr != nil {
+			return nil, fmt.Errorf("%w; restoring the backup %s also failed: %v", err, backup, rerr)
+		}
+		return nil, fmt.Errorf("%w; the database was restored from %s", err, backup)
+	}
+	return db, nil
+}
+
+// restore replaces the database at dbPath with the copy at backup. The
+// database must be closed.
+func restore(dbPath, backup string) error {
+	src, err := os.Open(backup)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+
+	tmp := dbPath + ".restore"
+	dst, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	// The write-ahead log belongs to the failed upgrade.
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			_ = os.Remove(tmp)
+			return err
+		}
+	}
+	return os.Rename(tmp, dbPath)
+}
@@ -0,0 +1,151 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"csstatstracker"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+// oldDatabase creates a database file with three rounds, then undoes the
+// newest migration so it's one schema version behind.
+func oldDatabase(t *testing.T) (path string, up database.Upgrade) {
+	t.Helper()
+	ctx := context.Background()
+	path = filepath.Join(t.TempDir(), "stats.db")
+	db, err := database.Init(ctx, path, csstatstracker.MigrationsFS)
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	dbtest.Insert(t, db, dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WLW")...)
+
+	up, err = database.CheckUpgrade(ctx, path, csstatstracker.MigrationsFS)
+	if err != nil || up.Pending() {
+		t.Fatalf("CheckUpgrade of a current database = %v, %v; want nothing pending", up, err)
+	}
+	entries, err := csstatstracker.MigrationsFS.ReadDir("migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var down string
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".down.sql") {
+			down = e.Name()
+		}
+	}
+	downSQL, err := csstatstracker.MigrationsFS.ReadFile("migrations/" + down)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{string(downSQL), `UPDATE schema_migrations SET version = version - 1`} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("failed to downgrade test database: %v", err)
+		}
+	}
+	return path, database.Upgrade{From: up.To - 1, To: up.To}
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	ctx := context.Background()
+	path, want := oldDatabase(t)
+	up, err := database.CheckUpgrade(ctx, path, csstatstracker.MigrationsFS)
+	if err != nil || up != want || !up.Pending() {
+		t.Errorf("CheckUpgrade = %v, %v; want pending %v", up, err, want)
+	}
+
+	missing := filepath.Join(t.TempDir(), "new.db")
+	if up, err := database.CheckUpgrade(ctx, missing, csstatstracker.MigrationsFS); err != nil || up.Pending() {
+		t.Errorf("CheckUpgrade of a new database = %v, %v; want nothing pending", up, err)
+	}
+	if _, err := os.Stat(missing); err == nil {
+		t.Error("CheckUpgrade created the database")
+	}
+}
+
+func TestInitWithBackup(t *testing.T) {
+	ctx := context.Background()
+	path, up := oldDatabase(t)
+	db, err := database.InitWithBackup(ctx, path, csstatstracker.MigrationsFS)
+	if err != nil {
+		t.Fatalf("InitWithBackup: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if n, err := database.CountRounds(ctx, db); err != nil || n != 3 {
+		t.Errorf("CountRounds = %d, %v after upgrading; want 3", n, err)
+	}
+
+	backup := database.BackupPath(path, up.From)
+	if got, err := database.CheckUpgrade(ctx, backup, csstatstracker.MigrationsFS); err != nil || got != up {
+		t.Errorf("backup is at %v, %v; want %v", got, err, up)
+	}
+}
+
+// brokenMigrations returns the app's migrations followed by a newer one that
+// fails part way through.
+func brokenMigrations(t *testing.T) fstest.MapFS {
+	t.Helper()
+	fsys := fstest.MapFS{
+		"migrations/999999_broken.up.sql":   {Data: []byte("CREATE TABLE broken (id INTEGER);\nNOT SQL;")},
+		"migrations/999999_broken.down.sql": {Data: []byte("DROP TABLE IF EXISTS broken;")},
+	}
+	entries, err := csstatstracker.MigrationsFS.ReadDir("migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		data, err := csstatstracker.MigrationsFS.ReadFile("migrations/" + e.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys["migrations/"+e.Name()] = &fstest.MapFile{Data: data}
+	}
+	return fsys
+}
+
+func TestInitWithBackupRestores(t *testing.T) {
+	ctx := context.Background()
+	path, _ := oldDatabase(t)
+	migrations := brokenMigrations(t)
+	up, err := database.CheckUpgrade(ctx, path, migrations)
+	if err != nil || !up.Pending() {
+		t.Fatalf("CheckUpgrade = %v, %v; want an upgrade pending", up, err)
+	}
+	if db, err := database.InitWithBackup(ctx, path, migrations); err == nil {
+		_ = db.Close()
+		t.Fatal("InitWithBackup succeeded; want the migration to fail")
+	} else if !strings.Contains(err.Error(), "restored") {
+		t.Errorf("InitWithBackup error = %v; want it to say the database was restored", err)
+	}
+
+	// Restored to where it was, not left dirty part way.
+	got, err := database.CheckUpgrade(ctx, path, migrations)
+	if err != nil || got != up {
+		t.Fatalf("CheckUpgrade after a failed upgrade = %v, %v; want %v", got, err, up)
+	}
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	var dirty bool
+	var rounds, broken int
+	if err := db.QueryRow(`
+		SELECT dirty, (SELECT COUNT(*) FROM rounds),
+			(SELECT COUNT(*) FROM sqlite_master WHERE name = 'broken')
+		FROM schema_migrations`).Scan(&dirty, &rounds, &broken); err != nil {
+		t.Fatal(err)
+	}
+	if dirty || rounds != 3 || broken != 0 {
+		t.Errorf("restored database dirty = %v with %d rounds and %d broken tables; want clean with 3 and none",
+			dirty, rounds, broken)
+	}
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Delivery is a queued webhook request.
+type Delivery struct {
+	ID          int
+	URL         string
+	Event       string
+	Payload     []byte // request body, JSON
+	Attempts    int    // failed attempts so far
+	NextAttempt time.Time
+	LastError   string
+}
+
+// EnqueueDelivery queues a webhook request to be sent at or after next.
+func EnqueueDelivery(ctx context.Context, db *sql.DB, url, event string, payload []byte, next time.Time) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO webhook_deliveries (url, event, payload, next_attempt) VALUES (?, ?, ?, ?)`,
+		url, event, string(payload), next.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// DueDeliveries returns up to limit queued deliveries due at now, oldest
+// first.
+func DueDeliveries(ctx context.Context, db *sql.DB, now time.Time, limit int) ([]Delivery, error) {
+	rows, err := db.QueryContext(ctx,
+		`SELECT id, url, event, payload, attempts, next_attempt, last_error FROM webhook_deliveries
+		 WHERE next_attempt <= ? ORDER BY next_attempt ASC, id ASC LIMIT ?`,
+		now.Unix(), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var out []Delivery
+	for rows.Next() {
+		var d Delivery
+		var payload string
+		var next int64
+		if err := rows.Scan(&d.ID, &d.URL, &d.Event, &payload, &d.Attempts, &next, &d.LastError); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		d.Payload = []byte(payload)
+		d.NextAttempt = time.Unix(next, 0)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// CountDeliveries returns the number of queued deliveries.
+func CountDeliveries(ctx context.Context, db *sql.DB) (int, error) {
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM webhook_deliveries`).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	return n, nil
+}
+
+// RetryDelivery records a failed attempt and schedules the next one.
+func RetryDelivery(ctx context.Context, db *sql.DB, id int, next time.Time, lastError string) error {
+	_, err := db.ExecContext(ctx,
+		`UPDATE webhook_deliveries SET attempts = attempts + 1, next_attempt = ?, last_error = ? WHERE id = ?`,
+		next.Unix(), lastError, id,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// DeleteDelivery removes a delivery from the queue, once it's been sent or
+// given up on.
+func DeleteDelivery(ctx context.Context, db *sql.DB, id int) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook delivery: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestDeliveryQueue(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	now := time.Unix(1_700_000_000, 0)
+
+	for i, next := range []time.Time{now.Add(time.Minute), now, now.Add(-time.Minute)} {
+		if err := database.EnqueueDelivery(ctx, db, "http://example.com", "round_recorded", []byte{'0' + byte(i)}, next); err != nil {
+			t.Fatalf("EnqueueDelivery: %v", err)
+		}
+	}
+
+	due, err := database.DueDeliveries(ctx, db, now, 10)
+	if err != nil {
+		t.Fatalf("DueDeliveries: %v", err)
+	}
+	if len(due) != 2 || string(due[0].Payload) != "2" || string(due[1].Payload) != "1" {
+		t.Fatalf("DueDeliveries = %+v, want payloads 2 and 1", due)
+	}
+
+	if err := database.RetryDelivery(ctx, db, due[0].ID, now.Add(time.Hour), "503 Service Unavailable"); err != nil {
+		t.Fatalf("RetryDelivery: %v", err)
+	}
+	if err := database.DeleteDelivery(ctx, db, due[1].ID); err != nil {
+		t.Fatalf("DeleteDelivery: %v", err)
+	}
+
+	due, err = database.DueDeliveries(ctx, db, now.Add(2*time.Hour), 10)
+	if err != nil {
+		t.Fatalf("DueDeliveries: %v", err)
+	}
+	if len(due) != 2 {
+		t.Fatalf("got %d due deliveries, want 2", len(due))
+	}
+	retried := due[1]
+	if retried.Attempts != 1 || retried.LastError != "503 Service Unavailable" || !retried.NextAttempt.Equal(now.Add(time.Hour)) {
+		t.Errorf("retried delivery = %+v", retried)
+	}
+	if n, err := database.CountDeliveries(ctx, db); err != nil || n != 2 {
+		t.Errorf("CountDeliveries = %d, %v, want 2", n, err)
+	}
+}
@@ -0,0 +1,51 @@
+// Package draws groups rounds recorded with no team selected, so they can be
+// assigned a team in bulk. Such rounds can't be scored as a win or a loss
+// until they are.
+package draws
+
+import (
+	"slices"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/records"
+)
+
+// Run is a stretch of unassigned rounds likely played on one side: it ends at
+// a pause longer than a session gap, and after half a regulation game's
+// worth of rounds, since sides swap at halftime.
+type Run struct {
+	Start time.Time // when its first round was recorded
+	End   time.Time // when its last round was recorded
+	IDs   []int     // its rounds
+	CTWon int       // rounds won by CT
+	TWon  int       // rounds won by T
+}
+
+// Runs groups the rounds with no team in rounds, which may be in any order,
+// into runs of at most half rounds each, newest first.
+func Runs(rounds []database.Round, half int) []Run {
+	sorted := slices.Clone(rounds)
+	slices.SortFunc(sorted, func(a, b database.Round) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	var runs []Run
+	for _, r := range sorted {
+		if r.Team != database.TeamNone {
+			continue
+		}
+		n := len(runs)
+		if n == 0 || len(runs[n-1].IDs) >= half || r.CreatedAt.Sub(runs[n-1].End) > records.SessionGap {
+			runs = append(runs, Run{Start: r.CreatedAt})
+		}
+		run := &runs[len(runs)-1]
+		run.End = r.CreatedAt
+		run.IDs = append(run.IDs, r.ID)
+		if r.Winner == database.TeamCT {
+			run.CTWon++
+		} else {
+			run.TWon++
+		}
+	}
+	slices.Reverse(runs)
+	return runs
+}
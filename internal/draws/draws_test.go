@@ -0,0 +1,50 @@
+package draws
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestRuns(t *testing.T) {
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	rounds := slices.Concat(
+		// A full half with no team, then one more round on the other side.
+		dbtest.Series(start, time.Minute, "DDDDDDDDDDDDD"),
+		// Scored rounds in between are skipped.
+		dbtest.Series(start.Add(20*time.Minute), time.Minute, "WLwl"),
+		// After a pause: another run, with T winning one round.
+		dbtest.Series(start.Add(2*time.Hour), time.Minute, "DD"),
+	)
+	id := 0
+	rounds = dbtest.With(rounds, func(r *database.Round) {
+		id++
+		r.ID = id
+	})
+	rounds[len(rounds)-1].Winner = database.TeamT
+
+	runs := Runs(rounds, 12)
+	want := []struct {
+		ids         []int
+		ctWon, tWon int
+	}{
+		{[]int{18, 19}, 1, 1},
+		{[]int{13}, 1, 0},
+		{[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 12, 0},
+	}
+	if len(runs) != len(want) {
+		t.Fatalf("got %d runs, want %d: %+v", len(runs), len(want), runs)
+	}
+	for i, w := range want {
+		r := runs[i]
+		if !slices.Equal(r.IDs, w.ids) || r.CTWon != w.ctWon || r.TWon != w.tWon {
+			t.Errorf("run %d = IDs %v, CT %d, T %d; want IDs %v, CT %d, T %d", i, r.IDs, r.CTWon, r.TWon, w.ids, w.ctWon, w.tWon)
+		}
+		if r.End.Before(r.Start) {
+			t.Errorf("run %d runs from %s to %s", i, r.Start, r.End)
+		}
+	}
+}
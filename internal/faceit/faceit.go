@@ -0,0 +1,332 @@
+// Package faceit keeps History up to date with the player's finished FACEIT
+// matches, fetched from the FACEIT Data API.
+//
+// The API gives each map's final score but not the order its rounds were
+// won in, or which side the player started on, so the rounds are made up
+// the way the imported package does it.
+package faceit
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/imported"
+)
+
+// Source names FACEIT in the UUIDs of the rounds synced from it.
+const Source = "faceit"
+
+// BaseURL is where the FACEIT Data API is served.
+const BaseURL = "https://open.faceit.com/data/v4"
+
+const (
+	checkInterval  = time.Minute // how often Run checks whether a sync is due
+	requestTimeout = 15 * time.Second
+	historyLimit   = 100 // most recent matches looked at in each sync, the API's maximum
+)
+
+// ErrNoStats is returned by Client.Maps for a match FACEIT has no usable
+// stats for, such as a forfeit, which fetching again won't change.
+var ErrNoStats = errors.New("FACEIT has no stats for the match")
+
+// statusError is a response from the API with an unexpected HTTP status.
+type statusError struct {
+	status string // e.g. "404 Not Found"
+	code   int
+}
+
+func (e *statusError) Error() string { return "FACEIT returned " + e.status }
+
+// Client calls the FACEIT Data API with a server-side API key.
+type Client struct {
+	baseURL string
+	apiKey  string
+	http    *http.Client
+}
+
+// NewClient creates a Client for the API at baseURL, normally BaseURL.
+func NewClient(baseURL, apiKey string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		apiKey:  apiKey,
+		http:    &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// get fetches path with query and decodes the JSON response into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "csstatstracker")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return errors.New("FACEIT rejected the API key")
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		return &statusError{status: resp.Status, code: resp.StatusCode}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse FACEIT response: %w", err)
+	}
+	return nil
+}
+
+// PlayerID returns the FACEIT player ID of the player with the given
+// nickname.
+func (c *Client) PlayerID(ctx context.Context, nickname string) (string, error) {
+	var player struct {
+		PlayerID string `json:"player_id"`
+	}
+	err := c.get(ctx, "/players", url.Values{"nickname": {nickname}}, &player)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up FACEIT player %s: %w", nickname, err)
+	}
+	if player.PlayerID == "" {
+		return "", fmt.Errorf("no FACEIT player is called %s", nickname)
+	}
+	return player.PlayerID, nil
+}
+
+// Finished is a finished match from a player's history.
+type Finished struct {
+	ID         string
+	FinishedAt time.Time
+}
+
+// History returns the player's most recent finished CS2 matches, newest
+// first.
+func (c *Client) History(ctx context.Context, playerID string) ([]Finished, error) {
+	var history struct {
+		Items []struct {
+			MatchID    string `json:"match_id"`
+			Status     string `json:"status"`
+			FinishedAt int64  `json:"finished_at"`
+		} `json:"items"`
+	}
+	query := url.Values{"game": {"cs2"}, "limit": {strconv.Itoa(historyLimit)}}
+	if err := c.get(ctx, "/players/"+url.PathEscape(playerID)+"/history", query, &history); err != nil {
+		return nil, fmt.Errorf("failed to fetch FACEIT match history: %w", err)
+	}
+	var matches []Finished
+	for _, item := range history.Items {
+		if strings.EqualFold(item.Status, "finished") && item.FinishedAt > 0 {
+			matches = append(matches, Finished{ID: item.MatchID, FinishedAt: time.Unix(item.FinishedAt, 0)})
+		}
+	}
+	return matches, nil
+}
+
+// Maps returns the maps of a finished match as the player played them, in
+// the order they were played, the last one ending when the match did. It
+// returns an error wrapping ErrNoStats if FACEIT has no stats for the match
+// or they don't include the player's score.
+func (c *Client) Maps(ctx context.Context, playerID string, f Finished) ([]imported.Match, error) {
+	var stats struct {
+		Rounds []struct {
+			RoundStats map[string]string `json:"round_stats"`
+			Teams      []struct {
+				TeamStats map[string]string `json:"team_stats"`
+				Players   []struct {
+					PlayerID string `json:"player_id"`
+				} `json:"players"`
+			} `json:"teams"`
+		} `json:"rounds"`
+	}
+	err := c.get(ctx, "/matches/"+url.PathEscape(f.ID)+"/stats", nil, &stats)
+	var se *statusError
+	if errors.As(err, &se) && se.code == http.StatusNotFound {
+		return nil, fmt.Errorf("%w %s", ErrNoStats, f.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch FACEIT match %s: %w", f.ID, err)
+	}
+
+	maps := make([]imported.Match, 0, len(stats.Rounds))
+	for i, played := range stats.Rounds {
+		m := imported.Match{ID: mapID(f.ID, i), Map: played.RoundStats["Map"]}
+		found := false
+		for _, team := range played.Teams {
+			score, err := strconv.Atoi(strings.TrimSpace(team.TeamStats["Final Score"]))
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: no final score", ErrNoStats, f.ID)
+			}
+			own := false
+			for _, p := range team.Players {
+				own = own || p.PlayerID == playerID
+			}
+			if own {
+				m.Score, found = score, true
+			} else {
+				m.EnemyScore = score
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w %s: the player isn't in it", ErrNoStats, f.ID)
+		}
+		maps = append(maps, m)
+	}
+
+	// Each map ends a round before the next one starts.
+	end := f.FinishedAt
+	for i := len(maps) - 1; i >= 0; i-- {
+		maps[i].FinishedAt = end
+		end = end.Add(-time.Duration(maps[i].Score+maps[i].EnemyScore) * imported.RoundLength)
+	}
+	return maps, nil
+}
+
+// mapID returns the ID the rounds of map i of a match are made from; the
+// first map has the match's.
+func mapID(matchID string, i int) string {
+	if i == 0 {
+		return matchID
+	}
+	return fmt.Sprintf("%s/%d", matchID, i+1)
+}
+
+// Sync adds the rounds of the player's recent finished matches that aren't
+// in db yet, recorded against account, and returns how many it added.
+//
+// A match whose stats can't be fetched is logged and skipped, so it doesn't
+// hold back the others. Those FACEIT has no stats for are added to noStats,
+// if it isn't nil, and matches already in it aren't fetched again; others
+// are retried by the next sync.
+func Sync(ctx context.Context, db *sql.DB, c *Client, nickname, account string, noStats map[string]bool) (int, error) {
+	playerID, err := c.PlayerID(ctx, nickname)
+	if err != nil {
+		return 0, err
+	}
+	history, err := c.History(ctx, playerID)
+	if err != nil {
+		return 0, err
+	}
+	var rounds []database.Round
+	for _, f := range history {
+		if noStats[f.ID] {
+			continue
+		}
+		dbCtx, cancel := database.WithTimeout(ctx)
+		_, err := database.GetRoundByUUID(dbCtx, db, imported.RoundUUID(Source, mapID(f.ID, 0), 0))
+		cancel()
+		if err == nil {
+			continue // synced before
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+		maps, err := c.Maps(ctx, playerID, f)
+		if ctx.Err() != nil {
+			return 0, ctx.Err()
+		}
+		if err != nil {
+			log.Printf("FACEIT sync: skipping match: %v", err)
+			if errors.Is(err, ErrNoStats) && noStats != nil {
+				noStats[f.ID] = true
+			}
+			continue
+		}
+		for _, m := range maps {
+			rounds = append(rounds, imported.Rounds(Source, m, account)...)
+		}
+	}
+	if len(rounds) == 0 {
+		return 0, nil
+	}
+	dbCtx, cancel := database.WithTimeout(ctx)
+	defer cancel()
+	return database.MergeRounds(dbCtx, db, rounds)
+}
+
+// Syncer syncs cfg's FACEIT matches every cfg.FACEIT.IntervalMinutes while
+// the integration is switched on.
+type Syncer struct {
+	ctx      context.Context // cancelled on app shutdown
+	db       *sql.DB
+	cfg      *config.Config
+	onSynced func(added int)
+	mu       sync.Mutex      // one sync at a time
+	last     time.Time       // when the last sync ran
+	noStats  map[string]bool // matches FACEIT has no stats for, not fetched again until restart
+}
+
+// NewSyncer creates a Syncer for cfg's FACEIT settings. onSynced is called,
+// from the syncer's goroutine, after a sync that added rounds.
+func NewSyncer(ctx context.Context, db *sql.DB, cfg *config.Config, onSynced func(added int)) *Syncer {
+	return &Syncer{ctx: ctx, db: db, cfg: cfg, onSynced: onSynced, noStats: make(map[string]bool)}
+}
+
+// Run syncs whenever a sync is due, until the context is cancelled.
+func (s *Syncer) Run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		s.check(time.Now())
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check syncs if the integration is on and the interval has passed since
+// the last sync. Failures are logged and retried at the next interval.
+func (s *Syncer) check(now time.Time) {
+	f := s.cfg.FACEIT
+	if !f.Enabled || f.APIKey == "" || f.Nickname == "" {
+		return
+	}
+	s.mu.Lock()
+	due := now.Sub(s.last) >= time.Duration(f.IntervalMinutes)*time.Minute
+	s.mu.Unlock()
+	if !due {
+		return
+	}
+	if _, err := s.Sync(); err != nil {
+		log.Printf("FACEIT sync: %v", err)
+	}
+}
+
+// Sync syncs now, e.g. to test the settings, and returns how many rounds it
+// added.
+func (s *Syncer) Sync() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.last = time.Now()
+	f := s.cfg.FACEIT
+	if f.APIKey == "" || f.Nickname == "" {
+		return 0, errors.New("set a FACEIT nickname and API key first")
+	}
+	added, err := Sync(s.ctx, s.db, NewClient(BaseURL, f.APIKey), f.Nickname, s.cfg.ActiveAccount, s.noStats)
+	if added > 0 {
+		s.onSynced(added)
+	}
+	return added, err
+}
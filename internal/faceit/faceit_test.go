@@ -0,0 +1,202 @@
+package faceit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/faceit"
+)
+
+// api is a FACEIT Data API with one player and two finished matches, the
+// second a best of two, counting the match stats it's asked for. With broken
+// set, the history also has a forfeit FACEIT has no stats for and a match
+// whose stats fail to load, between the two.
+type api struct {
+	broken bool
+	mu     sync.Mutex
+	stats  int
+}
+
+func (a *api) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer key" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/players":
+		if r.URL.Query().Get("nickname") != "s1mple" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"player_id": "me"}`))
+	case "/players/me/history":
+		broken := ""
+		if a.broken {
+			broken = `{"match_id": "forfeit", "status": "FINISHED", "finished_at": 1700008000},
+				{"match_id": "down", "status": "FINISHED", "finished_at": 1700006000},`
+		}
+		_, _ = w.Write([]byte(`{"items": [
+			{"match_id": "bo2", "status": "FINISHED", "finished_at": 1700010000},
+			{"match_id": "live", "status": "ONGOING", "finished_at": 0},
+			` + broken + `
+			{"match_id": "bo1", "status": "FINISHED", "finished_at": 1700000000}
+		]}`))
+	case "/matches/forfeit/stats":
+		a.count()
+		w.WriteHeader(http.StatusNotFound)
+	case "/matches/down/stats":
+		a.count()
+		w.WriteHeader(http.StatusBadGateway)
+	case "/matches/bo1/stats":
+		a.count()
+		_, _ = w.Write([]byte(`{"rounds": [{
+			"round_stats": {"Map": "de_mirage", "Score": "13 / 9"},
+			"teams": [
+				{"team_stats": {"Final Score": "13"}, "players": [{"player_id": "them"}]},
+				{"team_stats": {"Final Score": "9"}, "players": [{"player_id": "me"}]}
+			]
+		}]}`))
+	case "/matches/bo2/stats":
+		a.count()
+		_, _ = w.Write([]byte(`{"rounds": [
+			{"round_stats": {"Map": "de_nuke"}, "teams": [
+				{"team_stats": {"Final Score": "13"}, "players": [{"player_id": "me"}]},
+				{"team_stats": {"Final Score": "2"}, "players": [{"player_id": "them"}]}
+			]},
+			{"round_stats": {"Map": "de_inferno"}, "teams": [
+				{"team_stats": {"Final Score": "13"}, "players": [{"player_id": "me"}]},
+				{"team_stats": {"Final Score": "11"}, "players": [{"player_id": "them"}]}
+			]}
+		]}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (a *api) count() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stats++
+}
+
+func (a *api) fetched() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.stats
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	a := &api{}
+	server := httptest.NewServer(a)
+	defer server.Close()
+	client := faceit.NewClient(server.URL+"/", "key")
+
+	added, err := faceit.Sync(ctx, db, client, "s1mple", "main", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 22 + 15 + 24; added != want {
+		t.Fatalf("Sync added %d rounds, want %d", added, want)
+	}
+	rounds, err := database.GetAllRounds(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	maps := map[string][2]int{}
+	var last time.Time
+	for _, r := range rounds {
+		if r.Account != "main" {
+			t.Errorf("round %s recorded against %q, want main", r.UUID, r.Account)
+		}
+		score := maps[r.Map]
+		if r.Result() == database.ResultWin {
+			score[0]++
+		} else {
+			score[1]++
+		}
+		maps[r.Map] = score
+		if r.CreatedAt.After(last) {
+			last = r.CreatedAt
+		}
+	}
+	for name, want := range map[string][2]int{"de_mirage": {9, 13}, "de_nuke": {13, 2}, "de_inferno": {13, 11}} {
+		if maps[name] != want {
+			t.Errorf("%s score = %v, want %v", name, maps[name], want)
+		}
+	}
+	if want := time.Unix(1700010000, 0); !last.Equal(want) {
+		t.Errorf("last round recorded at %s, want %s when the match finished", last, want)
+	}
+
+	// Matches already synced aren't fetched again.
+	fetched := a.fetched()
+	if added, err := faceit.Sync(ctx, db, client, "s1mple", "main", nil); err != nil || added != 0 {
+		t.Errorf("second Sync = %d, %v; want 0, nil", added, err)
+	}
+	if n := a.fetched() - fetched; n != 0 {
+		t.Errorf("second Sync fetched %d match stats, want none", n)
+	}
+}
+
+func TestSyncSkipsBrokenMatches(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	a := &api{broken: true}
+	server := httptest.NewServer(a)
+	defer server.Close()
+	client := faceit.NewClient(server.URL, "key")
+
+	noStats := map[string]bool{}
+	added, err := faceit.Sync(ctx, db, client, "s1mple", "main", noStats)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 22 + 15 + 24; added != want {
+		t.Errorf("Sync added %d rounds, want the %d of the matches around the broken ones", added, want)
+	}
+	if !noStats["forfeit"] || noStats["down"] {
+		t.Errorf("Sync remembered %v as having no stats, want just the forfeit", noStats)
+	}
+
+	// The forfeit isn't fetched again; the match that failed to load is.
+	fetched := a.fetched()
+	if added, err := faceit.Sync(ctx, db, client, "s1mple", "main", noStats); err != nil || added != 0 {
+		t.Errorf("second Sync = %d, %v; want 0, nil", added, err)
+	}
+	if n := a.fetched() - fetched; n != 1 {
+		t.Errorf("second Sync fetched %d match stats, want just the one that failed", n)
+	}
+}
+
+func TestSyncErrors(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	server := httptest.NewServer(&api{})
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		key      string
+		nickname string
+		want     string
+	}{
+		{"bad key", "wrong", "s1mple", "rejected the API key"},
+		{"unknown player", "key", "nobody", "404"},
+	}
+	for _, tt := range tests {
+		_, err := faceit.Sync(ctx, db, faceit.NewClient(server.URL, tt.key), tt.nickname, "", nil)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Sync() = %v, want error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
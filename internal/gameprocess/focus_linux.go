@@ -0,0 +1,67 @@
+//go:build linux
+
+package gameprocess
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Focused reports whether the focused window belongs to the game or to this
+// app, going by the X11 active window and its _NET_WM_PID, read with xprop.
+// It reports true when it can't tell, e.g. without xprop or under Wayland,
+// so nothing that depends on it is locked out.
+func Focused() bool {
+	out, err := exec.Command("xprop", "-root", "_NET_ACTIVE_WINDOW").Output()
+	if err != nil {
+		return true
+	}
+	id, ok := activeWindow(string(out))
+	if !ok {
+		return true
+	}
+	out, err = exec.Command("xprop", "-id", id, "_NET_WM_PID").Output()
+	if err != nil {
+		return true
+	}
+	pid, ok := windowPID(string(out))
+	if !ok {
+		return true
+	}
+	if pid == os.Getpid() {
+		return true
+	}
+	comm, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return true
+	}
+	return isGame(strings.TrimSpace(string(comm)))
+}
+
+// activeWindow reads the window ID from xprop's _NET_ACTIVE_WINDOW line,
+// e.g. "_NET_ACTIVE_WINDOW(WINDOW): window id # 0x3a00007", which some
+// window managers follow with ", 0x0". No window focused is reported as
+// 0x0.
+func activeWindow(out string) (string, bool) {
+	_, ids, found := strings.Cut(out, "#")
+	id, _, _ := strings.Cut(ids, ",")
+	id = strings.TrimSpace(id)
+	if !found || id == "" || id == "0x0" {
+		return "", false
+	}
+	return id, true
+}
+
+// windowPID reads the process ID from xprop's _NET_WM_PID line, e.g.
+// "_NET_WM_PID(CARDINAL) = 12345".
+func windowPID(out string) (int, bool) {
+	_, v, found := strings.Cut(out, "=")
+	if !found {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(v))
+	return pid, err == nil && pid > 0
+}
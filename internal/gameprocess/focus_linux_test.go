@@ -0,0 +1,40 @@
+//go:build linux
+
+package gameprocess
+
+import "testing"
+
+func TestActiveWindow(t *testing.T) {
+	tests := []struct {
+		out    string
+		want   string
+		wantOK bool
+	}{
+		{"_NET_ACTIVE_WINDOW(WINDOW): window id # 0x3a00007\n", "0x3a00007", true},
+		{"_NET_ACTIVE_WINDOW(WINDOW): window id # 0x1c00007, 0x0\n", "0x1c00007", true},
+		{"_NET_ACTIVE_WINDOW(WINDOW): window id # 0x0\n", "", false},
+		{"_NET_ACTIVE_WINDOW:  not found.\n", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := activeWindow(tt.out); got != tt.want || ok != tt.wantOK {
+			t.Errorf("activeWindow(%q) = %q, %v; want %q, %v", tt.out, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestWindowPID(t *testing.T) {
+	tests := []struct {
+		out    string
+		want   int
+		wantOK bool
+	}{
+		{"_NET_WM_PID(CARDINAL) = 12345\n", 12345, true},
+		{"_NET_WM_PID:  not found.\n", 0, false},
+		{"_NET_WM_PID(CARDINAL) = 0\n", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := windowPID(tt.out); got != tt.want || ok != tt.wantOK {
+			t.Errorf("windowPID(%q) = %d, %v; want %d, %v", tt.out, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
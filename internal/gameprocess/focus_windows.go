@@ -0,0 +1,38 @@
+//go:build windows
+
+package gameprocess
+
+import (
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/windows"
+)
+
+// Focused reports whether the foreground window belongs to the game or to
+// this app. It reports true when it can't tell, so nothing that depends on
+// it is locked out.
+func Focused() bool {
+	hwnd := windows.GetForegroundWindow()
+	if hwnd == 0 {
+		return true
+	}
+	var pid uint32
+	if _, err := windows.GetWindowThreadProcessId(hwnd, &pid); err != nil || pid == 0 {
+		return true
+	}
+	if pid == windows.GetCurrentProcessId() {
+		return true
+	}
+	proc, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return true
+	}
+	defer func() { _ = windows.CloseHandle(proc) }()
+	buf := make([]uint16, windows.MAX_PATH)
+	size := uint32(len(buf))
+	if err := windows.QueryFullProcessImageName(proc, 0, &buf[0], &size); err != nil {
+		return true
+	}
+	return strings.EqualFold(filepath.Base(windows.UTF16ToString(buf[:size])), processName)
+}
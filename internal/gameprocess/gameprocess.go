@@ -0,0 +1,97 @@
+// Package gameprocess watches for CS2 running and records each stretch of
+// time it runs as a session, giving play time measured instead of estimated
+// from round counts. It also tells whether the game has the keyboard focus.
+package gameprocess
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	"csstatstracker/internal/database"
+)
+
+const (
+	pollInterval   = 15 * time.Second // how often the process list is checked
+	extendInterval = time.Minute      // how often a running session's end is saved
+)
+
+// Recorder records a session for every stretch of time the game runs.
+type Recorder struct {
+	ctx     context.Context // cancelled on app shutdown
+	db      *sql.DB
+	running func() bool
+
+	session  int64     // the open session's id, 0 while the game isn't running
+	seen     time.Time // when the game was last seen running
+	extended time.Time // when the open session's end was last saved
+}
+
+// NewRecorder creates a Recorder for db, which checks whether the game is
+// running with running, normally Running.
+func NewRecorder(ctx context.Context, db *sql.DB, running func() bool) *Recorder {
+	return &Recorder{ctx: ctx, db: db, running: running}
+}
+
+// Run checks for the game every poll interval until the context is
+// cancelled. A session open then keeps the end last saved, at most
+// extendInterval early.
+func (r *Recorder) Run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		r.check(time.Now())
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check starts a session when the game has started, saves how long it's
+// been going every extendInterval, and ends it once the game is gone.
+// Failures are logged and retried at the next check.
+func (r *Recorder) check(now time.Time) {
+	if !r.running() {
+		r.end()
+		return
+	}
+	r.seen = now
+	if r.session == 0 {
+		ctx, cancel := database.WithTimeout(r.ctx)
+		id, err := database.StartSession(ctx, r.db, now)
+		cancel()
+		if err != nil {
+			log.Printf("game sessions: %v", err)
+			return
+		}
+		r.session, r.extended = id, now
+		return
+	}
+	if now.Sub(r.extended) >= extendInterval {
+		r.extend()
+	}
+}
+
+// end saves the open session as ending when the game was last seen.
+func (r *Recorder) end() {
+	if r.session == 0 {
+		return
+	}
+	if r.extended != r.seen {
+		r.extend()
+	}
+	r.session = 0
+}
+
+func (r *Recorder) extend() {
+	ctx, cancel := database.WithTimeout(r.ctx)
+	defer cancel()
+	if err := database.ExtendSession(ctx, r.db, r.session, r.seen); err != nil {
+		log.Printf("game sessions: %v", err)
+		return
+	}
+	r.extended = r.seen
+}
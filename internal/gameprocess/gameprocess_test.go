@@ -0,0 +1,52 @@
+package gameprocess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	running := false
+	r := NewRecorder(ctx, db, func() bool { return running })
+	start := time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC)
+
+	// Game open for 40 minutes, polled every 15 seconds, closed, then
+	// opened again briefly.
+	at := start
+	poll := func(d time.Duration, game bool) {
+		running = game
+		for end := at.Add(d); at.Before(end); at = at.Add(pollInterval) {
+			r.check(at)
+		}
+	}
+	poll(5*time.Minute, false)
+	poll(40*time.Minute, true)
+	poll(10*time.Minute, false)
+	poll(30*time.Second, true)
+	poll(time.Minute, false)
+
+	sessions, err := database.GetSessionsSince(ctx, db, start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("recorded %d sessions, want 2", len(sessions))
+	}
+	first, second := sessions[0], sessions[1]
+	if want := start.Add(5 * time.Minute); !first.StartedAt.Equal(want) {
+		t.Errorf("first session started %s, want %s", first.StartedAt, want)
+	}
+	// Last seen one poll before the game was found gone.
+	if want := 40*time.Minute - pollInterval; first.Duration() != want {
+		t.Errorf("first session lasted %s, want %s", first.Duration(), want)
+	}
+	if want := 30*time.Second - pollInterval; second.Duration() != want {
+		t.Errorf("second session lasted %s, want %s", second.Duration(), want)
+	}
+}
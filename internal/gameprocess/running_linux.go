@@ -0,0 +1,40 @@
+//go:build linux
+
+package gameprocess
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// processNames are the names the game runs under: the native Linux build,
+// and the Windows one under Proton.
+var processNames = []string{"cs2", "cs2.exe"}
+
+// Running reports whether the game is running.
+func Running() bool {
+	return runningIn("/proc")
+}
+
+// runningIn reports whether any process in the proc file system at dir has
+// one of processNames as its command name.
+func runningIn(dir string) bool {
+	comms, _ := filepath.Glob(filepath.Join(dir, "[0-9]*", "comm"))
+	for _, comm := range comms {
+		data, err := os.ReadFile(comm)
+		if err != nil {
+			continue // exited since the glob
+		}
+		if isGame(strings.TrimSpace(string(data))) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGame reports whether a process's command name is one of processNames.
+func isGame(comm string) bool {
+	return slices.Contains(processNames, comm)
+}
@@ -0,0 +1,32 @@
+//go:build linux
+
+package gameprocess
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunningIn(t *testing.T) {
+	proc := t.TempDir()
+	process := func(pid, comm string) {
+		dir := filepath.Join(proc, pid)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "comm"), []byte(comm+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	process("1", "systemd")
+	process("812", "steam")
+	process("self", "cs2") // not a process directory
+	if runningIn(proc) {
+		t.Error("runningIn found the game before it started")
+	}
+	process("4242", "cs2")
+	if !runningIn(proc) {
+		t.Error("runningIn missed the game")
+	}
+}
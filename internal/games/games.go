@@ -0,0 +1,131 @@
+// Package games rebuilds games from the round history. Rounds aren't
+// grouped into games in the database, so they're replayed in order under the
+// match rules of their game mode: a game ends when one side clinches it, at a
+// pause longer than a session gap, or when the mode changes.
+package games
+
+import (
+	"slices"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/match"
+	"csstatstracker/internal/records"
+)
+
+// Game is a rebuilt game.
+type Game struct {
+	Start      time.Time // when its first round was recorded
+	End        time.Time // when its last round was recorded
+	Wins       int       // final score
+	Losses     int
+	HalfWins   int  // score at halftime, if it got that far
+	HalfLosses int  //
+	Finished   bool // clinched, rather than cut off by a pause
+	Mode       database.Mode
+}
+
+// Rounds is the number of rounds played.
+func (g Game) Rounds() int { return g.Wins + g.Losses }
+
+// Overtime reports whether the game went past regulation.
+func (g Game) Overtime(rules match.Rules) bool { return g.Rounds() > rules.RegulationRounds }
+
+// Pace is the average time between rounds, or 0 with fewer than two rounds.
+// Rounds are recorded as they end, so the first round's length is unknown.
+func (g Game) Pace() time.Duration {
+	if g.Rounds() < 2 {
+		return 0
+	}
+	return g.End.Sub(g.Start) / time.Duration(g.Rounds()-1)
+}
+
+// Rebuild replays rounds, which may be in any order, into games, oldest
+// first. Rounds with no team recorded can't be placed and are skipped.
+// Games with a mode are played under its rules, and the rest under rules.
+func Rebuild(rounds []database.Round, rules match.Rules) []Game {
+	sorted := slices.Clone(rounds)
+	slices.SortFunc(sorted, func(a, b database.Round) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	var games []Game
+	var cur *Game
+	var curRules match.Rules
+	flush := func() {
+		if cur != nil {
+			games = append(games, *cur)
+		}
+		cur = nil
+	}
+	for _, r := range sorted {
+		result := r.Result()
+		if result != database.ResultWin && result != database.ResultLoss {
+			continue
+		}
+		if cur != nil && (r.CreatedAt.Sub(cur.End) > records.SessionGap || r.Mode != cur.Mode) {
+			flush()
+		}
+		if cur == nil {
+			cur = &Game{Start: r.CreatedAt, Mode: r.Mode}
+			curRules = rules
+			if r.Mode != database.ModeUnknown {
+				curRules = match.RulesFor(r.Mode)
+			}
+		}
+		cur.End = r.CreatedAt
+		if result == database.ResultWin {
+			cur.Wins++
+		} else {
+			cur.Losses++
+		}
+		if cur.Rounds() == curRules.RegulationRounds/2 {
+			cur.HalfWins, cur.HalfLosses = cur.Wins, cur.Losses
+		}
+		if curRules.Decided(cur.Wins, cur.Losses) {
+			cur.Finished = true
+			flush()
+		}
+	}
+	flush()
+	return games
+}
+
+// slowFactor is how much slower than the median pace a game has to be to
+// count as slow, e.g. from a long pause or a reconnect.
+const slowFactor = 1.5
+
+// MedianPace is the median Pace of the finished games, or 0 if there are
+// none.
+func MedianPace(all []Game) time.Duration {
+	var paces []time.Duration
+	for _, g := range all {
+		if g.Finished && g.Pace() > 0 {
+			paces = append(paces, g.Pace())
+		}
+	}
+	if len(paces) == 0 {
+		return 0
+	}
+	slices.Sort(paces)
+	mid := len(paces) / 2
+	if len(paces)%2 == 0 {
+		return (paces[mid-1] + paces[mid]) / 2
+	}
+	return paces[mid]
+}
+
+// Slow returns the finished games played well below the median pace, newest
+// first.
+func Slow(all []Game) []Game {
+	median := MedianPace(all)
+	if median == 0 {
+		return nil
+	}
+	var slow []Game
+	for i := len(all) - 1; i >= 0; i-- {
+		g := all[i]
+		if g.Finished && float64(g.Pace()) > float64(median)*slowFactor {
+			slow = append(slow, g)
+		}
+	}
+	return slow
+}
@@ -0,0 +1,104 @@
+package games
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/match"
+)
+
+func TestRebuild(t *testing.T) {
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	rounds := slices.Concat(
+		// 8–4 at half, won 13–7.
+		dbtest.Series(start, time.Minute, "WWWWWWWWLLLL"+"LLLWWWWW"),
+		// 4–8 at half, lost 9–13, with a round without a team skipped.
+		dbtest.Series(start.Add(time.Hour), 2*time.Minute, "WWWWLLLLLLLL"+"WWDWWWLLLLL"),
+		// Cut off before halftime by a pause.
+		dbtest.Series(start.Add(3*time.Hour), time.Minute, "WWWLLL"),
+	)
+
+	games := Rebuild(rounds, match.DefaultRules)
+	want := []Game{
+		{Wins: 13, Losses: 7, HalfWins: 8, HalfLosses: 4, Finished: true},
+		{Wins: 9, Losses: 13, HalfWins: 4, HalfLosses: 8, Finished: true},
+		{Wins: 3, Losses: 3},
+	}
+	if len(games) != len(want) {
+		t.Fatalf("got %d games, want %d: %+v", len(games), len(want), games)
+	}
+	for i, g := range games {
+		if g.Start.IsZero() || g.End.Before(g.Start) {
+			t.Errorf("game %d runs from %s to %s", i, g.Start, g.End)
+		}
+		g.Start, g.End = time.Time{}, time.Time{}
+		if g != want[i] {
+			t.Errorf("game %d = %+v, want %+v", i, g, want[i])
+		}
+	}
+
+	if p := games[0].Pace(); p != time.Minute {
+		t.Errorf("game 0 Pace = %s, want 1m", p)
+	}
+	// 23 rounds two minutes apart with one skipped: the 22 placed rounds
+	// span 44 minutes in 21 gaps.
+	if p := games[1].Pace(); p != 44*time.Minute/21 {
+		t.Errorf("game 1 Pace = %s, want %s", p, 44*time.Minute/21)
+	}
+}
+
+func TestRebuildModes(t *testing.T) {
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	wingman := func(r *database.Round) { r.Mode = database.ModeWingman }
+	rounds := slices.Concat(
+		// Won 8–3 in Wingman, then straight into a Premier match.
+		dbtest.With(dbtest.Series(start, time.Minute, "WWWWWLLLWWW"), wingman),
+		dbtest.With(dbtest.Series(start.Add(11*time.Minute), time.Minute, "WWWW"), func(r *database.Round) {
+			r.Mode = database.ModePremier
+		}),
+	)
+
+	games := Rebuild(rounds, match.DefaultRules)
+	want := []Game{
+		{Wins: 8, Losses: 3, HalfWins: 5, HalfLosses: 2, Finished: true, Mode: database.ModeWingman},
+		{Wins: 4, Mode: database.ModePremier},
+	}
+	if len(games) != len(want) {
+		t.Fatalf("got %d games, want %d: %+v", len(games), len(want), games)
+	}
+	for i, g := range games {
+		g.Start, g.End = time.Time{}, time.Time{}
+		if g != want[i] {
+			t.Errorf("game %d = %+v, want %+v", i, g, want[i])
+		}
+	}
+}
+
+func TestSlow(t *testing.T) {
+	start := time.Date(2025, 3, 1, 20, 0, 0, 0, time.UTC)
+	game := func(day int, pace time.Duration, finished bool) Game {
+		s := start.AddDate(0, 0, day)
+		return Game{Start: s, End: s.Add(19 * pace), Wins: 13, Losses: 7, Finished: finished}
+	}
+	all := []Game{
+		game(0, 2*time.Minute, true),
+		game(1, 5*time.Minute, true), // slow
+		game(2, 2*time.Minute, true),
+		game(3, 2*time.Minute+30*time.Second, true),
+		game(4, 9*time.Minute, false), // unfinished, ignored
+		game(5, 4*time.Minute, true),  // slow
+	}
+	if m := MedianPace(all); m != 2*time.Minute+30*time.Second {
+		t.Errorf("MedianPace = %s, want 2m30s", m)
+	}
+	slow := Slow(all)
+	if len(slow) != 2 || !slow[0].Start.Equal(all[5].Start) || !slow[1].Start.Equal(all[1].Start) {
+		t.Errorf("Slow = %+v, want days 5 and 1", slow)
+	}
+	if Slow(nil) != nil {
+		t.Error("Slow(nil) != nil")
+	}
+}
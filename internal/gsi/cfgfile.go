@@ -0,0 +1,125 @@
+package gsi
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ConfigFileName is the name of the file that tells CS2 to send updates to
+// the tracker. CS2 reads every gamestate_integration_*.cfg in its cfg
+// directory at startup.
+const ConfigFileName = "gamestate_integration_csstatstracker.cfg"
+
+// cfgSubdir is where CS2's cfg directory is within a Steam library.
+var cfgSubdir = filepath.Join("steamapps", "common", "Counter-Strike Global Offensive", "game", "csgo", "cfg")
+
+// ErrNoCfgDir is returned by FindCfgDir when CS2 isn't in any Steam library
+// it knows to look in.
+var ErrNoCfgDir = errors.New("CS2's cfg directory wasn't found in any Steam library")
+
+// ConfigFile returns the contents of a config file that has CS2 post updates
+// to 127.0.0.1:port, with token as the auth token if it isn't empty, asking
+// for the data the tracker uses.
+func ConfigFile(port int, token string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `"CS Stats Tracker"
+{
+    "uri"       "http://127.0.0.1:%d"
+    "timeout"   "5.0"
+    "buffer"    "0.1"
+    "throttle"  "0.5"
+    "heartbeat" "30.0"
+`, port)
+	if token != "" {
+		fmt.Fprintf(&b, `    "auth"
+    {
+        "token" "%s"
+    }
+`, vdfEscape(token))
+	}
+	b.WriteString(`    "data"
+    {
+        "provider"     "1"
+        "map"          "1"
+        "map_round_wins" "1"
+        "round"        "1"
+        "player_id"    "1"
+        "player_state" "1"
+        "player_match_stats" "1"
+    }
+}
+`)
+	return b.Bytes()
+}
+
+// vdfEscape escapes s for a quoted string in a Valve KeyValues file.
+func vdfEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+// WriteConfigFile writes ConfigFile(port, token) into the CS2 cfg directory
+// dir, replacing an earlier one, and returns the file's path.
+func WriteConfigFile(dir string, port int, token string) (string, error) {
+	path := filepath.Join(dir, ConfigFileName)
+	if err := os.WriteFile(path, ConfigFile(port, token), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write game state config: %w", err)
+	}
+	return path, nil
+}
+
+// FindCfgDir returns CS2's cfg directory, looking in every Steam library of
+// the Steam installs found on this machine.
+func FindCfgDir() (string, error) {
+	dirs := CfgDirs(steamRoots())
+	if len(dirs) == 0 {
+		return "", ErrNoCfgDir
+	}
+	return dirs[0], nil
+}
+
+// CfgDirs returns the CS2 cfg directories that exist in the Steam libraries
+// of the Steam installs at roots. A root's own library is checked even if
+// its library list can't be read.
+func CfgDirs(roots []string) []string {
+	var dirs []string
+	seen := map[string]bool{}
+	for _, root := range roots {
+		libraries := []string{root}
+		if data, err := os.ReadFile(filepath.Join(root, "steamapps", "libraryfolders.vdf")); err == nil {
+			libraries = append(libraries, libraryPaths(data)...)
+		}
+		for _, lib := range libraries {
+			dir := filepath.Join(lib, cfgSubdir)
+			if seen[dir] {
+				continue
+			}
+			seen[dir] = true
+			if info, err := os.Stat(dir); err == nil && info.IsDir() {
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// libraryPathLine matches a library's "path" entry in libraryfolders.vdf.
+var libraryPathLine = regexp.MustCompile(`^\s*"path"\s+"(.*)"\s*$`)
+
+// libraryPaths returns the Steam library paths listed in a
+// libraryfolders.vdf file.
+func libraryPaths(vdf []byte) []string {
+	var paths []string
+	scanner := bufio.NewScanner(bytes.NewReader(vdf))
+	for scanner.Scan() {
+		if m := libraryPathLine.FindStringSubmatch(scanner.Text()); m != nil {
+			paths = append(paths, strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(m[1]))
+		}
+	}
+	return paths
+}
@@ -0,0 +1,77 @@
+package gsi_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"csstatstracker/internal/gsi"
+)
+
+func TestConfigFile(t *testing.T) {
+	got := string(gsi.ConfigFile(3001, `se"cr\et`))
+	for _, want := range []string{
+		`"uri"       "http://127.0.0.1:3001"`,
+		`"token" "se\"cr\\et"`,
+		`"player_id"    "1"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("config file is missing %s:\n%s", want, got)
+		}
+	}
+	if got := string(gsi.ConfigFile(3000, "")); strings.Contains(got, "auth") {
+		t.Errorf("config file without a token has an auth block:\n%s", got)
+	}
+}
+
+func TestCfgDirs(t *testing.T) {
+	root := t.TempDir()
+	library := filepath.Join(t.TempDir(), "Steam Library")
+	cfgDir := filepath.Join(library, "steamapps", "common", "Counter-Strike Global Offensive", "game", "csgo", "cfg")
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Steam escapes backslashes in Windows paths.
+	escaped := strings.ReplaceAll(library, `\`, `\\`)
+	vdf := `"libraryfolders"
+{
+	"0"
+	{
+		"path"		"` + strings.ReplaceAll(root, `\`, `\\`) + `"
+		"label"		""
+	}
+	"1"
+	{
+		"path"		"` + escaped + `"
+		"apps"
+		{
+			"730"		"35000000000"
+		}
+	}
+}
+`
+	if err := os.MkdirAll(filepath.Join(root, "steamapps"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "steamapps", "libraryfolders.vdf"), []byte(vdf), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	missing := filepath.Join(t.TempDir(), "no-steam")
+	if got := gsi.CfgDirs([]string{missing, root}); !slices.Equal(got, []string{cfgDir}) {
+		t.Errorf("CfgDirs = %v; want [%s]", got, cfgDir)
+	}
+
+	path, err := gsi.WriteConfigFile(cfgDir, 3000, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != gsi.ConfigFileName {
+		t.Errorf("wrote %s; want %s", path, gsi.ConfigFileName)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != string(gsi.ConfigFile(3000, "token")) {
+		t.Errorf("written file = %q, %v", data, err)
+	}
+}
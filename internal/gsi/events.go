@@ -0,0 +1,78 @@
+package gsi
+
+import "sync"
+
+// EventKind names a notable moment derived from consecutive game states.
+type EventKind string
+
+const (
+	EventBombPlanted  EventKind = "bomb_planted"
+	EventBombDefused  EventKind = "bomb_defused"
+	EventBombExploded EventKind = "bomb_exploded"
+	EventAce          EventKind = "ace" // local player killed 5 in one round
+	EventMVP          EventKind = "mvp" // local player was the round MVP
+)
+
+// Event is a moment spotted by a Detector.
+type Event struct {
+	Kind  EventKind
+	Map   string
+	Round int // rounds completed before this one, as reported by the map section
+}
+
+// Detector turns the stream of game states into discrete events by comparing
+// each state with the previous one. GSI only reports the current state, so a
+// bomb plant, for example, shows up as the bomb field changing to "planted".
+//
+// Clutches aren't detected: the player's own payload doesn't say how many
+// teammates are alive, and the all-players section is only sent to
+// spectators and casters.
+type Detector struct {
+	mu   sync.Mutex
+	prev *State
+	aced bool // an ace was already reported this round
+}
+
+// Observe feeds the next state to the detector and returns any events it
+// completes.
+func (d *Detector) Observe(s *State) []Event {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	prev := d.prev
+	d.prev = s
+	if prev == nil || s.Map.Name != prev.Map.Name {
+		// Nothing to compare against yet, or a new match.
+		d.aced = false
+		return nil
+	}
+
+	var events []Event
+	add := func(kind EventKind) {
+		events = append(events, Event{Kind: kind, Map: s.Map.Name, Round: s.Map.Round})
+	}
+
+	if s.Round.Bomb != prev.Round.Bomb {
+		switch s.Round.Bomb {
+		case "planted":
+			add(EventBombPlanted)
+		case "defused":
+			add(EventBombDefused)
+		case "exploded":
+			add(EventBombExploded)
+		}
+	}
+
+	if s.Round.Phase == "freezetime" {
+		d.aced = false
+	}
+	if s.IsLocalPlayer() && prev.IsLocalPlayer() {
+		if s.Player.State.RoundKills >= 5 && !d.aced {
+			d.aced = true
+			add(EventAce)
+		}
+		if s.Player.MatchStats.MVPs > prev.Player.MatchStats.MVPs {
+			add(EventMVP)
+		}
+	}
+	return events
+}
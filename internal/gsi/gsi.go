@@ -0,0 +1,161 @@
+// Package gsi receives Counter-Strike 2 Game State Integration updates.
+//
+// CS2 POSTs the game state as JSON to every endpoint listed in a
+// gamestate_integration_*.cfg file in its cfg directory whenever something
+// changes. Server listens for those posts on localhost and hands each decoded
+// State to a callback.
+package gsi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+)
+
+// maxBodySize caps a single update. Full payloads are a few KB.
+const maxBodySize = 1 << 20
+
+// State is the subset of a GSI payload the tracker uses.
+type State struct {
+	Provider Provider `json:"provider"`
+	Map      Map      `json:"map"`
+	Round    Round    `json:"round"`
+	Player   Player   `json:"player"`
+	Auth     Auth     `json:"auth"`
+}
+
+// Provider identifies the game client sending the update.
+type Provider struct {
+	Name    string `json:"name"`
+	AppID   int    `json:"appid"`
+	SteamID string `json:"steamid"` // the local player's account
+}
+
+// Map describes the match in progress.
+type Map struct {
+	Mode   string    `json:"mode"`  // e.g. "competitive", "premier"
+	Name   string    `json:"name"`  // e.g. "de_dust2"
+	Phase  string    `json:"phase"` // "warmup", "live", "intermission" or "gameover"
+	Round  int       `json:"round"` // rounds completed so far
+	TeamCT TeamState `json:"team_ct"`
+	TeamT  TeamState `json:"team_t"`
+
+	// RoundWins maps each finished round, numbered from 1, to why it was
+	// won, e.g. "ct_win_elimination".
+	RoundWins map[string]string `json:"round_wins"`
+}
+
+// TeamState holds one side's match score.
+type TeamState struct {
+	Score int `json:"score"`
+}
+
+// Round describes the current round.
+type Round struct {
+	Phase   string `json:"phase"`    // "freezetime", "live" or "over"
+	WinTeam string `json:"win_team"` // "CT" or "T" once the round is over
+	Bomb    string `json:"bomb"`     // "planted", "exploded" or "defused"
+}
+
+// Player describes the player the client is currently following, which is
+// someone else while the local player is dead or spectating.
+type Player struct {
+	SteamID    string      `json:"steamid"`
+	Name       string      `json:"name"`
+	Team       string      `json:"team"` // "CT" or "T"
+	State      PlayerState `json:"state"`
+	MatchStats MatchStats  `json:"match_stats"`
+}
+
+// PlayerState is the followed player's in-round state.
+type PlayerState struct {
+	Health     int `json:"health"`
+	RoundKills int `json:"round_kills"`
+	Money      int `json:"money"`
+	EquipValue int `json:"equip_value"`
+}
+
+// MatchStats is the followed player's scoreboard line.
+type MatchStats struct {
+	Kills   int `json:"kills"`
+	Assists int `json:"assists"`
+	Deaths  int `json:"deaths"`
+	MVPs    int `json:"mvps"`
+	Score   int `json:"score"`
+}
+
+// Auth carries the token from the cfg file's auth block.
+type Auth struct {
+	Token string `json:"token"`
+}
+
+// IsLocalPlayer reports whether the player section describes the local
+// player rather than someone being spectated.
+func (s *State) IsLocalPlayer() bool {
+	return s.Player.SteamID != "" && s.Player.SteamID == s.Provider.SteamID
+}
+
+// Server accepts GSI posts on localhost.
+type Server struct {
+	port    int
+	token   string
+	onState func(*State)
+	srv     *http.Server
+}
+
+// NewServer creates a server for the given port. If token is non-empty,
+// updates whose auth token doesn't match are rejected. onState is called from
+// the HTTP handler goroutine for every accepted update.
+func NewServer(port int, token string, onState func(*State)) *Server {
+	return &Server{port: port, token: token, onState: onState}
+}
+
+// Start begins listening. It returns once the port is bound; requests are
+// served in the background until Close.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", s.port))
+	if err != nil {
+		return fmt.Errorf("failed to listen for game state updates: %w", err)
+	}
+	s.srv = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("game state server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	if s.srv == nil {
+		return nil
+	}
+	return s.srv.Close()
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var state State
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&state); err != nil {
+		http.Error(w, "invalid game state", http.StatusBadRequest)
+		return
+	}
+	if s.token != "" && state.Auth.Token != s.token {
+		http.Error(w, "invalid token", http.StatusUnauthorized)
+		return
+	}
+	s.onState(&state)
+	w.WriteHeader(http.StatusOK)
+}
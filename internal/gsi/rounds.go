@@ -0,0 +1,145 @@
+package gsi
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Outcome is how a round ended.
+type Outcome struct {
+	Map        string
+	Round      int    // 1 for a match's first round
+	Winner     string // "CT" or "T"
+	Reason     string // "elimination", "bomb", "defuse", "time", or "" if unknown
+	Bomb       string // "planted", "defused", "exploded", or "" if it wasn't planted
+	Side       string // "CT" or "T" for the local player, or "" if unknown
+	Money      int    // the local player's money when the round went live
+	EquipValue int    // value of the local player's equipment when the round went live
+}
+
+// RoundDetector turns the stream of game states into an Outcome for each
+// round as it ends. The bomb and the player's side and buy are remembered
+// through the round, since by the time it's over the payload may be about
+// someone else, or the bomb field already cleared.
+type RoundDetector struct {
+	mu      sync.Mutex
+	mapName string
+	phase   string // round phase of the last state
+	last    int    // number of the last round reported
+	bomb    string
+	side    string
+	money   int
+	equip   int
+	bought  bool // money and equip were captured this round
+}
+
+// Observe feeds the next state to the detector and returns the outcome of
+// the round it ends, if it ends one.
+func (d *RoundDetector) Observe(s *State) (Outcome, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if s.Map.Name != d.mapName {
+		// A new match.
+		d.mapName, d.phase, d.last = s.Map.Name, "", 0
+		d.newRound()
+	}
+	prevPhase := d.phase
+	d.phase = s.Round.Phase
+
+	if s.Round.Phase == "freezetime" && prevPhase != "freezetime" {
+		d.newRound()
+	}
+	if bomb := s.Round.Bomb; bomb != "" && bombRank(bomb) > bombRank(d.bomb) {
+		d.bomb = bomb
+	}
+	if s.IsLocalPlayer() {
+		if team := s.Player.Team; team == "CT" || team == "T" {
+			d.side = team
+		}
+		// The buy is what the player has once freeze time ends and the
+		// round goes live.
+		if s.Round.Phase == "live" && !d.bought {
+			d.money, d.equip, d.bought = s.Player.State.Money, s.Player.State.EquipValue, true
+		}
+	}
+
+	if s.Round.Phase != "over" || prevPhase == "over" || prevPhase == "" || s.Map.Phase == "warmup" {
+		return Outcome{}, false
+	}
+	winner := s.Round.WinTeam
+	if winner != "CT" && winner != "T" {
+		return Outcome{}, false
+	}
+	number, reason := lastRoundWin(s.Map.RoundWins)
+	if number == 0 {
+		// Without the round wins the number is worked out from the score,
+		// which already counts this round.
+		number = s.Map.TeamCT.Score + s.Map.TeamT.Score
+	}
+	if number <= d.last {
+		return Outcome{}, false
+	}
+	d.last = number
+	if reason == "" {
+		reason = reasonFromBomb(winner, d.bomb)
+	}
+	return Outcome{
+		Map:        s.Map.Name,
+		Round:      number,
+		Winner:     winner,
+		Reason:     reason,
+		Bomb:       d.bomb,
+		Side:       d.side,
+		Money:      d.money,
+		EquipValue: d.equip,
+	}, true
+}
+
+// newRound forgets what was seen of the last round.
+func (d *RoundDetector) newRound() {
+	d.bomb, d.side, d.money, d.equip, d.bought = "", "", 0, 0, false
+}
+
+// bombRank orders the bomb's states so a later one isn't overwritten by an
+// earlier one arriving out of order.
+func bombRank(bomb string) int {
+	switch bomb {
+	case "planted":
+		return 1
+	case "defused", "exploded":
+		return 2
+	}
+	return 0
+}
+
+// lastRoundWin returns the number of the latest round in wins and why it
+// was won, without the winning side, or 0 if there are none.
+func lastRoundWin(wins map[string]string) (int, string) {
+	number, reason := 0, ""
+	for key, why := range wins {
+		n, err := strconv.Atoi(key)
+		if err != nil || n <= number {
+			continue
+		}
+		number = n
+		_, reason, _ = strings.Cut(why, "_win_")
+	}
+	return number, reason
+}
+
+// reasonFromBomb works out why winner won from the bomb alone: T win by
+// the bomb exploding or by elimination, and once it's planted CT can only
+// win by defusing it. Otherwise CT could have won by elimination or by the
+// time running out.
+func reasonFromBomb(winner, bomb string) string {
+	switch {
+	case winner == "T" && bomb == "exploded":
+		return "bomb"
+	case winner == "CT" && bomb != "":
+		return "defuse"
+	case winner == "T":
+		return "elimination"
+	}
+	return ""
+}
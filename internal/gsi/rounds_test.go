@@ -0,0 +1,73 @@
+package gsi_test
+
+import (
+	"testing"
+
+	"csstatstracker/internal/gsi"
+)
+
+// state builds a game state for the local player on side, in round phase.
+func state(phase, side string, ct, t int) *gsi.State {
+	return &gsi.State{
+		Provider: gsi.Provider{SteamID: "me"},
+		Map:      gsi.Map{Name: "de_nuke", Phase: "live", TeamCT: gsi.TeamState{Score: ct}, TeamT: gsi.TeamState{Score: t}},
+		Round:    gsi.Round{Phase: phase},
+		Player:   gsi.Player{SteamID: "me", Team: side},
+	}
+}
+
+func TestRoundDetector(t *testing.T) {
+	var d gsi.RoundDetector
+
+	steps := []*gsi.State{state("freezetime", "T", 0, 0), state("live", "T", 0, 0)}
+	steps[1].Player.State.Money, steps[1].Player.State.EquipValue = 200, 1000
+	planted := state("live", "T", 0, 0)
+	planted.Round.Bomb = "planted"
+	// Dead, following a teammate on the same side.
+	dead := state("live", "T", 0, 0)
+	dead.Player.SteamID = "mate"
+	dead.Player.State.Money = 9000
+	over := state("over", "T", 0, 1)
+	over.Round.WinTeam, over.Round.Bomb = "T", "exploded"
+	over.Map.RoundWins = map[string]string{"1": "t_win_bomb"}
+	steps = append(steps, planted, dead, over, over)
+
+	var outcomes []gsi.Outcome
+	for _, s := range steps {
+		if o, ok := d.Observe(s); ok {
+			outcomes = append(outcomes, o)
+		}
+	}
+	want := gsi.Outcome{Map: "de_nuke", Round: 1, Winner: "T", Reason: "bomb", Bomb: "exploded", Side: "T", Money: 200, EquipValue: 1000}
+	if len(outcomes) != 1 || outcomes[0] != want {
+		t.Fatalf("outcomes = %+v, want just %+v", outcomes, want)
+	}
+
+	// A round without round wins in the payload is numbered from the score,
+	// and its reason worked out from the bomb.
+	second := state("over", "T", 1, 1)
+	second.Round.WinTeam, second.Round.Bomb = "CT", "planted"
+	for _, s := range []*gsi.State{state("freezetime", "T", 0, 1), state("live", "T", 0, 1), second} {
+		if o, ok := d.Observe(s); ok {
+			outcomes = append(outcomes, o)
+		}
+	}
+	if len(outcomes) != 2 {
+		t.Fatalf("got %d outcomes, want 2", len(outcomes))
+	}
+	if o := outcomes[1]; o.Round != 2 || o.Winner != "CT" || o.Reason != "defuse" {
+		t.Errorf("second outcome = %+v, want round 2 won by CT defusing", o)
+	}
+}
+
+func TestRoundDetectorSkipsWarmup(t *testing.T) {
+	var d gsi.RoundDetector
+	over := state("over", "CT", 1, 0)
+	over.Map.Phase = "warmup"
+	over.Round.WinTeam = "CT"
+	for _, s := range []*gsi.State{state("live", "CT", 0, 0), over} {
+		if o, ok := d.Observe(s); ok {
+			t.Errorf("Observe reported %+v during warmup", o)
+		}
+	}
+}
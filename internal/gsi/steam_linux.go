@@ -0,0 +1,22 @@
+//go:build linux
+
+package gsi
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// steamRoots returns where Steam is usually installed on Linux: the native
+// package's directories and the Flatpak's.
+func steamRoots() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	return []string{
+		filepath.Join(home, ".steam", "steam"),
+		filepath.Join(home, ".local", "share", "Steam"),
+		filepath.Join(home, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam"),
+	}
+}
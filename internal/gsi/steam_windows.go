@@ -0,0 +1,26 @@
+//go:build windows
+
+package gsi
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// steamRoots returns where Steam is installed on Windows: the path Steam
+// records in the registry, then the default install directory.
+func steamRoots() []string {
+	var roots []string
+	if key, err := registry.OpenKey(registry.CURRENT_USER, `Software\Valve\Steam`, registry.QUERY_VALUE); err == nil {
+		if path, _, err := key.GetStringValue("SteamPath"); err == nil && path != "" {
+			roots = append(roots, filepath.Clean(path))
+		}
+		_ = key.Close()
+	}
+	if pf := os.Getenv("ProgramFiles(x86)"); pf != "" {
+		roots = append(roots, filepath.Join(pf, "Steam"))
+	}
+	return roots
+}
@@ -0,0 +1,93 @@
+// Package halftime relates the score at halftime to how games ended, e.g.
+// how often an 8–4 lead is converted, to set expectations and spot leads
+// that keep slipping away.
+package halftime
+
+import (
+	"cmp"
+	"slices"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/games"
+	"csstatstracker/internal/match"
+)
+
+// Row is how games with one halftime score ended.
+type Row struct {
+	HalfWins   int
+	HalfLosses int
+	Games      int
+	Won        int
+	Lost       int
+	Drawn      int
+}
+
+// WinRate is the percentage of the row's games that were won.
+func (r Row) WinRate() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Won) / float64(r.Games) * 100
+}
+
+// ByHalfScore groups the finished games that went past halftime by their
+// halftime score, biggest lead first. Games of a mode played under other
+// rules, whose halves are a different length, are left out.
+func ByHalfScore(all []games.Game, rules match.Rules) []Row {
+	byScore := make(map[[2]int]*Row)
+	for _, g := range all {
+		if !g.Finished || g.Rounds() <= rules.RegulationRounds/2 {
+			continue
+		}
+		if g.Mode != database.ModeUnknown && match.RulesFor(g.Mode) != rules {
+			continue
+		}
+		key := [2]int{g.HalfWins, g.HalfLosses}
+		row, ok := byScore[key]
+		if !ok {
+			row = &Row{HalfWins: g.HalfWins, HalfLosses: g.HalfLosses}
+			byScore[key] = row
+		}
+		row.Games++
+		switch {
+		case g.Wins > g.Losses:
+			row.Won++
+		case g.Losses > g.Wins:
+			row.Lost++
+		default:
+			row.Drawn++
+		}
+	}
+
+	rows := make([]Row, 0, len(byScore))
+	for _, row := range byScore {
+		rows = append(rows, *row)
+	}
+	slices.SortFunc(rows, func(a, b Row) int { return cmp.Compare(b.HalfWins, a.HalfWins) })
+	return rows
+}
+
+// Summary totals the rows by whether the player led, trailed or was level
+// at halftime.
+type Summary struct {
+	Leading, Trailing, Level Row // HalfWins and HalfLosses are unused
+}
+
+// Summarize totals rows into a Summary.
+func Summarize(rows []Row) Summary {
+	var s Summary
+	for _, r := range rows {
+		target := &s.Level
+		switch {
+		case r.HalfWins > r.HalfLosses:
+			target = &s.Leading
+		case r.HalfWins < r.HalfLosses:
+			target = &s.Trailing
+		}
+		target.Games += r.Games
+		target.Won += r.Won
+		target.Lost += r.Lost
+		target.Drawn += r.Drawn
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package halftime
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"csstatstracker/internal/games"
+	"csstatstracker/internal/match"
+)
+
+func TestByHalfScore(t *testing.T) {
+	all := []games.Game{
+		{HalfWins: 8, HalfLosses: 4, Wins: 13, Losses: 7, Finished: true},
+		{HalfWins: 8, HalfLosses: 4, Wins: 10, Losses: 13, Finished: true},
+		{HalfWins: 8, HalfLosses: 4, Wins: 13, Losses: 10, Finished: true},
+		{HalfWins: 4, HalfLosses: 8, Wins: 13, Losses: 11, Finished: true},
+		{HalfWins: 6, HalfLosses: 6, Wins: 12, Losses: 13, Finished: true},
+		{HalfWins: 10, HalfLosses: 2, Wins: 11, Losses: 2},
+	}
+	rows := ByHalfScore(all, match.DefaultRules)
+	var got []string
+	for _, r := range rows {
+		got = append(got, fmt.Sprintf("%d–%d %d/%d", r.HalfWins, r.HalfLosses, r.Won, r.Games))
+	}
+	if want := []string{"8–4 2/3", "6–6 0/1", "4–8 1/1"}; !slices.Equal(got, want) {
+		t.Errorf("ByHalfScore = %v, want %v", got, want)
+	}
+	if rate := rows[0].WinRate(); rate < 66.6 || rate > 66.7 {
+		t.Errorf("8–4 WinRate = %.2f, want 66.67", rate)
+	}
+
+	s := Summarize(rows)
+	if s.Leading.Games != 3 || s.Leading.Lost != 1 || s.Trailing.Won != 1 || s.Level.Lost != 1 {
+		t.Errorf("Summarize = %+v", s)
+	}
+}
@@ -0,0 +1,126 @@
+// Package hooks runs user-configured external commands when something
+// happens in the tracker, e.g. to update a stream overlay or post to a chat.
+//
+// Commands are run directly, never through a shell, and each argument is
+// expanded as a text/template on its own, so a map name or account can't
+// inject extra arguments. Every run is bounded by a timeout.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"text/template"
+	"time"
+
+	"csstatstracker/internal/config"
+)
+
+// Events a hook can run on.
+const (
+	RoundRecorded = "round_recorded" // a round was saved
+	StreakReached = "streak"         // a run of wins or losses reached the hook's Streak
+)
+
+// DefaultTimeout bounds hooks without a timeout of their own.
+const DefaultTimeout = 10 * time.Second
+
+// Data is what hook arguments can refer to, e.g. "{{.CTScore}}". It's also
+// the event plugins receive, as JSON.
+type Data struct {
+	Event   string `json:"event"`
+	Winner  string `json:"winner"` // "CT" or "T"
+	Team    string `json:"team"`   // the player's side, "" if not selected
+	Result  string `json:"result"` // "win", "loss" or "draw" (no side selected)
+	Map     string `json:"map"`    // e.g. "de_dust2", "" without game state integration
+	Account string `json:"account"`
+	CTScore int    `json:"ct_score"`
+	TScore  int    `json:"t_score"`
+	Streak  int    `json:"streak"` // current run of wins (positive) or losses (negative)
+}
+
+// Runner runs the hooks in a config.
+type Runner struct {
+	ctx context.Context // cancelled on app shutdown, killing running hooks
+	cfg *config.Config
+}
+
+// NewRunner creates a Runner for cfg's hooks, which it reads on every event
+// so edits apply immediately.
+func NewRunner(ctx context.Context, cfg *config.Config) *Runner {
+	return &Runner{ctx: ctx, cfg: cfg}
+}
+
+// Fire runs, in the background, every hook for data.Event. Failures are
+// logged.
+func (r *Runner) Fire(data Data) {
+	for _, h := range r.cfg.Hooks {
+		if !matches(h, data) {
+			continue
+		}
+		go func() {
+			if err := Run(r.ctx, h, data); err != nil {
+				log.Printf("hook %q for %s: %v", h.Command, data.Event, err)
+			}
+		}()
+	}
+}
+
+// matches reports whether h should run for data.
+func matches(h config.Hook, data Data) bool {
+	if h.Event != data.Event {
+		return false
+	}
+	if h.Event == StreakReached {
+		return data.Streak == h.Streak || data.Streak == -h.Streak
+	}
+	return true
+}
+
+// Run runs one hook for data and waits for it to finish or time out.
+func Run(ctx context.Context, h config.Hook, data Data) error {
+	args, err := Expand(h.Args, data)
+	if err != nil {
+		return err
+	}
+	timeout := DefaultTimeout
+	if h.TimeoutMs > 0 {
+		timeout = time.Duration(h.TimeoutMs) * time.Millisecond
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, h.Command, args...)
+	cmd.WaitDelay = time.Second // don't wait on pipes held open by children
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		if stderr.Len() > 0 {
+			return fmt.Errorf("%w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return err
+	}
+	return nil
+}
+
+// Expand fills in each argument template with data.
+func Expand(args []string, data Data) ([]string, error) {
+	out := make([]string, len(args))
+	for i, arg := range args {
+		tmpl, err := template.New("arg").Option("missingkey=error").Parse(arg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse argument %q: %w", arg, err)
+		}
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, fmt.Errorf("failed to expand argument %q: %w", arg, err)
+		}
+		out[i] = b.String()
+	}
+	return out, nil
+}
@@ -0,0 +1,82 @@
+package hooks
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"csstatstracker/internal/config"
+)
+
+func TestExpand(t *testing.T) {
+	data := Data{Event: RoundRecorded, Winner: "CT", Map: "de_dust2; rm -rf /", CTScore: 8, TScore: 7}
+	tests := []struct {
+		args    []string
+		want    []string
+		wantErr bool
+	}{
+		{args: []string{"--score", "{{.CTScore}}-{{.TScore}}"}, want: []string{"--score", "8-7"}},
+		// A value stays one argument, whatever it contains.
+		{args: []string{"{{.Map}}"}, want: []string{"de_dust2; rm -rf /"}},
+		{args: []string{"{{.Nope}}"}, wantErr: true},
+		{args: []string{"{{.Map"}, wantErr: true},
+		{args: nil, want: []string{}},
+	}
+	for _, tt := range tests {
+		got, err := Expand(tt.args, data)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Expand(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			continue
+		}
+		if strings.Join(got, "\x00") != strings.Join(tt.want, "\x00") {
+			t.Errorf("Expand(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		hook config.Hook
+		data Data
+		want bool
+	}{
+		{config.Hook{Event: RoundRecorded}, Data{Event: RoundRecorded}, true},
+		{config.Hook{Event: RoundRecorded}, Data{Event: StreakReached, Streak: 3}, false},
+		{config.Hook{Event: StreakReached, Streak: 3}, Data{Event: StreakReached, Streak: 3}, true},
+		{config.Hook{Event: StreakReached, Streak: 3}, Data{Event: StreakReached, Streak: -3}, true},
+		{config.Hook{Event: StreakReached, Streak: 3}, Data{Event: StreakReached, Streak: 4}, false},
+	}
+	for _, tt := range tests {
+		if got := matches(tt.hook, tt.data); got != tt.want {
+			t.Errorf("matches(%+v, %+v) = %v, want %v", tt.hook, tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("needs sh")
+	}
+	tests := []struct {
+		name    string
+		hook    config.Hook
+		wantErr string
+	}{
+		{"success", config.Hook{Command: "sh", Args: []string{"-c", `test "$0" = 8`, "{{.CTScore}}"}}, ""},
+		{"failure with stderr", config.Hook{Command: "sh", Args: []string{"-c", "echo broken >&2; exit 3"}}, "broken"},
+		{"timeout", config.Hook{Command: "sh", Args: []string{"-c", "sleep 5"}, TimeoutMs: 50}, "timed out"},
+		{"missing command", config.Hook{Command: "csst-no-such-command"}, "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Run(context.Background(), tt.hook, Data{CTScore: 8})
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("Run() = %v, want success", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("Run() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
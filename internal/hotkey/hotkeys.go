@@ -3,60 +3,35 @@
 package hotkey
 
 import (
-	"strings"
 	"sync"
 	"time"
 
 	hook "github.com/robotn/gohook"
 )
 
-// ActionType represents the type of action triggered by a hotkey
-type ActionType int
-
-const (
-	ActionNone ActionType = iota
-	ActionIncrementCT
-	ActionDecrementCT
-	ActionIncrementT
-	ActionDecrementT
-	ActionSelectCT
-	ActionSelectT
-	ActionSwapTeams
-)
-
-// Bindings holds the key combinations for each action
-type Bindings struct {
-	IncrementCT []string
-	DecrementCT []string
-	IncrementT  []string
-	DecrementT  []string
-	SelectCT    []string
-	SelectT     []string
-	SwapTeams   []string
-}
-
 // Handler processes keyboard events and triggers actions
 type Handler struct {
-	bindings       *Bindings
-	pressedKeys    map[string]bool
-	keysMutex      sync.Mutex
-	lastActionTime time.Time
-	hookChan       chan hook.Event
-	hookRunning    bool
-	actionChan     chan ActionType
+	bindings    *Bindings
+	timing      Timing
+	keys        *keyState
+	keysMutex   sync.Mutex
+	hookChan    chan hook.Event
+	hookRunning bool
+	actionChan  chan Action
+	focused     func() bool // nil fires hotkeys whatever has the focus
 }
 
 // NewHandler creates a new hotkey handler
 func NewHandler(bindings *Bindings) *Handler {
 	return &Handler{
-		bindings:    bindings,
-		pressedKeys: make(map[string]bool),
-		actionChan:  make(chan ActionType, 10),
+		bindings:   bindings,
+		keys:       newKeyState(),
+		actionChan: make(chan Action, 10),
 	}
 }
 
 // Actions returns the channel for receiving triggered actions
-func (h *Handler) Actions() <-chan ActionType {
+func (h *Handler) Actions() <-chan Action {
 	return h.actionChan
 }
 
@@ -67,6 +42,22 @@ func (h *Handler) UpdateBindings(bindings *Bindings) {
 	h.bindings = bindings
 }
 
+// SetTiming sets the per-action cooldowns and what holding a key does.
+func (h *Handler) SetTiming(timing Timing) {
+	h.keysMutex.Lock()
+	defer h.keysMutex.Unlock()
+	h.timing = timing
+}
+
+// SetFocusCheck makes hotkeys fire only while focused reports true, e.g.
+// only while the game has the focus, so keys typed in other apps don't
+// change the score. Pass nil to fire them whatever has the focus.
+func (h *Handler) SetFocusCheck(focused func() bool) {
+	h.keysMutex.Lock()
+	defer h.keysMutex.Unlock()
+	h.focused = focused
+}
+
 // Start begins listening for global keyboard events
 func (h *Handler) Start() {
 	if h.hookRunning {
@@ -103,40 +94,13 @@ func (h *Handler) Stop() {
 
 func (h *Handler) handleKeyDown(keyName string) {
 	h.keysMutex.Lock()
-	defer h.keysMutex.Unlock()
+	action := h.keys.keyDown(keyName, time.Now(), h.bindings, &h.timing)
+	focused := h.focused
+	h.keysMutex.Unlock()
 
-	// Skip if key is already pressed (avoid repeat events)
-	if h.pressedKeys[keyName] {
-		return
-	}
-
-	h.pressedKeys[keyName] = true
-
-	// Check for action cooldown (prevent rapid-fire)
-	if time.Since(h.lastActionTime) < 100*time.Millisecond {
-		return
-	}
-
-	// Check all hotkey combos
-	var action ActionType
-	if h.matchesCombo(h.bindings.IncrementCT) {
-		action = ActionIncrementCT
-	} else if h.matchesCombo(h.bindings.DecrementCT) {
-		action = ActionDecrementCT
-	} else if h.matchesCombo(h.bindings.IncrementT) {
-		action = ActionIncrementT
-	} else if h.matchesCombo(h.bindings.DecrementT) {
-		action = ActionDecrementT
-	} else if h.matchesCombo(h.bindings.SelectCT) {
-		action = ActionSelectCT
-	} else if h.matchesCombo(h.bindings.SelectT) {
-		action = ActionSelectT
-	} else if h.matchesCombo(h.bindings.SwapTeams) {
-		action = ActionSwapTeams
-	}
-
-	if action != ActionNone {
-		h.lastActionTime = time.Now()
+	// The key is still tracked as held, so releasing it later is matched
+	// up; only the action is dropped.
+	if action != ActionNone && (focused == nil || focused()) {
 		select {
 		case h.actionChan <- action:
 		default:
@@ -148,39 +112,28 @@ func (h *Handler) handleKeyDown(keyName string) {
 func (h *Handler) handleKeyUp(keyName string) {
 	h.keysMutex.Lock()
 	defer h.keysMutex.Unlock()
-	delete(h.pressedKeys, keyName)
+	h.keys.keyUp(keyName, time.Now())
 }
 
-func (h *Handler) matchesCombo(comboKeys []string) bool {
-	// All keys in the combo must be pressed (case-insensitive for letters)
-	for _, key := range comboKeys {
-		found := false
-		keyNorm := normalizeKey(key)
-		for pressedKey := range h.pressedKeys {
-			if normalizeKey(pressedKey) == keyNorm {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+// IsKnownKey reports whether name is a key the global hook can report on
+// this platform, i.e. whether a binding that uses it can ever fire.
+func IsKnownKey(name string) bool {
+	canonical := CanonicalKey(name)
+	if canonical == "" {
+		return false
 	}
-	// And we must have exactly the same number of keys pressed
-	return len(h.pressedKeys) == len(comboKeys)
-}
-
-// normalizeKey normalizes key names to handle platform differences
-// (e.g., Fyne captures "KP_Enter" but gohook returns "Return" on Windows)
-func normalizeKey(key string) string {
-	lower := strings.ToLower(key)
-	// Normalize numpad enter variants to "return"
-	if lower == "kp_enter" || lower == "numpadenter" {
-		return "return"
+	// Printable ASCII keys are reported via their keychar.
+	if len(canonical) == 1 {
+		return true
+	}
+	for _, known := range rawKeyNames {
+		if keyMatches(canonical, known) {
+			return true
+		}
 	}
-	return lower
+	return false
 }
 
-// mapKeyToName is defined in platform-specific files:
+// mapKeyToName and rawKeyNames are defined in platform-specific files:
 // - keymap_linux.go (X11 keysyms)
 // - keymap_windows.go (Windows Virtual Key codes)
@@ -7,167 +7,126 @@ import hook "github.com/robotn/gohook"
 // mapKeyToName converts a gohook event to a key name string (Linux/X11 version)
 func mapKeyToName(ev hook.Event) string {
 	// Map based on rawcode (X11 keysyms)
-	switch ev.Rawcode {
+	if name, ok := rawKeyNames[ev.Rawcode]; ok {
+		return name
+	}
+
+	// For printable characters, use the keychar directly
+	if ev.Keychar >= 32 && ev.Keychar <= 126 {
+		return string(ev.Keychar)
+	}
+
+	// Return empty if we can't map it
+	return ""
+}
+
+// rawKeyNames maps X11 keysyms (gohook rawcodes) to key names.
+var rawKeyNames = map[uint16]string{
 	// Modifier keys (X11 keysyms)
-	case 65505:
-		return "LeftShift"
-	case 65506:
-		return "RightShift"
-	case 65507:
-		return "LeftControl"
-	case 65508:
-		return "RightControl"
-	case 65513:
-		return "LeftAlt"
-	case 65514:
-		return "RightAlt"
-	case 65515:
-		return "LeftSuper"
-	case 65516:
-		return "RightSuper"
+	65505: "LeftShift",
+	65506: "RightShift",
+	65507: "LeftControl",
+	65508: "RightControl",
+	65513: "LeftAlt",
+	65514: "RightAlt",
+	65515: "LeftSuper",
+	65516: "RightSuper",
 
 	// Function keys (X11 keysyms)
-	case 65470:
-		return "F1"
-	case 65471:
-		return "F2"
-	case 65472:
-		return "F3"
-	case 65473:
-		return "F4"
-	case 65474:
-		return "F5"
-	case 65475:
-		return "F6"
-	case 65476:
-		return "F7"
-	case 65477:
-		return "F8"
-	case 65478:
-		return "F9"
-	case 65479:
-		return "F10"
-	case 65480:
-		return "F11"
-	case 65481:
-		return "F12"
+	65470: "F1",
+	65471: "F2",
+	65472: "F3",
+	65473: "F4",
+	65474: "F5",
+	65475: "F6",
+	65476: "F7",
+	65477: "F8",
+	65478: "F9",
+	65479: "F10",
+	65480: "F11",
+	65481: "F12",
 
 	// Special keys
-	case 65293:
-		return "Return"
-	case 65288:
-		return "Backspace"
-	case 65289:
-		return "Tab"
-	case 32:
-		return "Space"
-	case 65307:
-		return "Escape"
+	65293: "Return",
+	65288: "Backspace",
+	65289: "Tab",
+	32:    "Space",
+	65307: "Escape",
+
+	// Navigation and editing keys (X11 keysyms)
+	65362: "Up",
+	65364: "Down",
+	65361: "Left",
+	65363: "Right",
+	65360: "Home",
+	65367: "End",
+	65365: "PageUp",
+	65366: "PageDown",
+	65379: "Insert",
+	65535: "Delete",
+	65377: "PrintScreen",
+
+	// Media keys. Their XF86 keysyms (0x1008FFxx) don't fit gohook's 16-bit
+	// rawcode and arrive truncated to 0xFFxx, which is why Pause, Scroll Lock
+	// and SysRq (0xFF13-0xFF15, the same codes as volume up, play and stop)
+	// can't be bound.
+	65297: "VolumeDown",     // XF86AudioLowerVolume
+	65298: "VolumeMute",     // XF86AudioMute
+	65299: "VolumeUp",       // XF86AudioRaiseVolume
+	65300: "MediaPlayPause", // XF86AudioPlay
+	65301: "MediaStop",      // XF86AudioStop
+	65302: "MediaPrevious",  // XF86AudioPrev
+	65303: "MediaNext",      // XF86AudioNext
 
 	// Numpad keys (X11 keysyms)
-	case 65457:
-		return "Numpad1"
-	case 65458:
-		return "Numpad2"
-	case 65459:
-		return "Numpad3"
-	case 65460:
-		return "Numpad4"
-	case 65461:
-		return "Numpad5"
-	case 65462:
-		return "Numpad6"
-	case 65463:
-		return "Numpad7"
-	case 65464:
-		return "Numpad8"
-	case 65465:
-		return "Numpad9"
-	case 65456:
-		return "Numpad0"
-	case 65454:
-		return "NumpadDecimal"
-	case 65451:
-		return "NumpadAdd"
-	case 65453:
-		return "NumpadSubtract"
-	case 65450:
-		return "NumpadMultiply"
-	case 65455:
-		return "NumpadDivide"
-	case 65421:
-		return "NumpadEnter"
+	65457: "Numpad1",
+	65458: "Numpad2",
+	65459: "Numpad3",
+	65460: "Numpad4",
+	65461: "Numpad5",
+	65462: "Numpad6",
+	65463: "Numpad7",
+	65464: "Numpad8",
+	65465: "Numpad9",
+	65456: "Numpad0",
+	65454: "NumpadDecimal",
+	65451: "NumpadAdd",
+	65453: "NumpadSubtract",
+	65450: "NumpadMultiply",
+	65455: "NumpadDivide",
+	65421: "NumpadEnter",
 
 	// Common symbol keys by their rawcode (unshifted X11 keysyms)
-	case 45: // minus key
-		return "-"
-	case 61: // equals key
-		return "="
-	case 43: // plus (shifted equals)
-		return "="
-	case 95: // underscore (shifted minus)
-		return "-"
+	45: "-", // minus key
+	61: "=", // equals key
+	43: "=", // plus (shifted equals)
+	95: "-", // underscore (shifted minus)
 
 	// Letter keys by rawcode (needed for Ctrl+letter combos where keychar becomes control char)
-	case 97:
-		return "a"
-	case 98:
-		return "b"
-	case 99:
-		return "c"
-	case 100:
-		return "d"
-	case 101:
-		return "e"
-	case 102:
-		return "f"
-	case 103:
-		return "g"
-	case 104:
-		return "h"
-	case 105:
-		return "i"
-	case 106:
-		return "j"
-	case 107:
-		return "k"
-	case 108:
-		return "l"
-	case 109:
-		return "m"
-	case 110:
-		return "n"
-	case 111:
-		return "o"
-	case 112:
-		return "p"
-	case 113:
-		return "q"
-	case 114:
-		return "r"
-	case 115:
-		return "s"
-	case 116:
-		return "t"
-	case 117:
-		return "u"
-	case 118:
-		return "v"
-	case 119:
-		return "w"
-	case 120:
-		return "x"
-	case 121:
-		return "y"
-	case 122:
-		return "z"
-	}
-
-	// For printable characters, use the keychar directly
-	if ev.Keychar >= 32 && ev.Keychar <= 126 {
-		return string(ev.Keychar)
-	}
-
-	// Return empty if we can't map it
-	return ""
+	97:  "a",
+	98:  "b",
+	99:  "c",
+	100: "d",
+	101: "e",
+	102: "f",
+	103: "g",
+	104: "h",
+	105: "i",
+	106: "j",
+	107: "k",
+	108: "l",
+	109: "m",
+	110: "n",
+	111: "o",
+	112: "p",
+	113: "q",
+	114: "r",
+	115: "s",
+	116: "t",
+	117: "u",
+	118: "v",
+	119: "w",
+	120: "x",
+	121: "y",
+	122: "z",
 }
@@ -0,0 +1,18 @@
+//go:build linux || windows
+
+package hotkey
+
+import "testing"
+
+// Every key the hook can report must be in the registry, or bindings using
+// it could never be captured or matched.
+func TestKeymapIsRegistered(t *testing.T) {
+	for code, name := range rawKeyNames {
+		if CanonicalKey(name) == "" {
+			t.Errorf("rawcode %d reports %q, which has no canonical name", code, name)
+		}
+		if !IsKnownKey(name) {
+			t.Errorf("IsKnownKey(%q) = false for a key the hook reports", name)
+		}
+	}
+}
@@ -12,7 +12,7 @@ import (
 // Windows uses Virtual Key codes in rawcode
 func mapKeyToName(ev hook.Event) string {
 	// First try to map based on rawcode (Windows Virtual Key codes)
-	if name := mapRawcode(ev.Rawcode); name != "" {
+	if name, ok := rawKeyNames[ev.Rawcode]; ok {
 		return name
 	}
 
@@ -25,181 +25,123 @@ func mapKeyToName(ev hook.Event) string {
 	return ""
 }
 
-func mapRawcode(rawcode uint16) string {
-	switch rawcode {
+// rawKeyNames maps Windows Virtual Key codes (gohook rawcodes) to key names.
+var rawKeyNames = map[uint16]string{
 	// Modifier keys (Windows VK codes)
-	case 160: // VK_LSHIFT
-		return "LeftShift"
-	case 161: // VK_RSHIFT
-		return "RightShift"
-	case 162: // VK_LCONTROL
-		return "LeftControl"
-	case 163: // VK_RCONTROL
-		return "RightControl"
-	case 164: // VK_LMENU (Left Alt)
-		return "LeftAlt"
-	case 165: // VK_RMENU (Right Alt)
-		return "RightAlt"
-	case 91: // VK_LWIN
-		return "LeftSuper"
-	case 92: // VK_RWIN
-		return "RightSuper"
+	160: "LeftShift",    // VK_LSHIFT
+	161: "RightShift",   // VK_RSHIFT
+	162: "LeftControl",  // VK_LCONTROL
+	163: "RightControl", // VK_RCONTROL
+	164: "LeftAlt",      // VK_LMENU (Left Alt)
+	165: "RightAlt",     // VK_RMENU (Right Alt)
+	91:  "LeftSuper",    // VK_LWIN
+	92:  "RightSuper",   // VK_RWIN
 
 	// Function keys (Windows VK codes)
-	case 112: // VK_F1
-		return "F1"
-	case 113: // VK_F2
-		return "F2"
-	case 114: // VK_F3
-		return "F3"
-	case 115: // VK_F4
-		return "F4"
-	case 116: // VK_F5
-		return "F5"
-	case 117: // VK_F6
-		return "F6"
-	case 118: // VK_F7
-		return "F7"
-	case 119: // VK_F8
-		return "F8"
-	case 120: // VK_F9
-		return "F9"
-	case 121: // VK_F10
-		return "F10"
-	case 122: // VK_F11
-		return "F11"
-	case 123: // VK_F12
-		return "F12"
+	112: "F1",  // VK_F1
+	113: "F2",  // VK_F2
+	114: "F3",  // VK_F3
+	115: "F4",  // VK_F4
+	116: "F5",  // VK_F5
+	117: "F6",  // VK_F6
+	118: "F7",  // VK_F7
+	119: "F8",  // VK_F8
+	120: "F9",  // VK_F9
+	121: "F10", // VK_F10
+	122: "F11", // VK_F11
+	123: "F12", // VK_F12
 
 	// Special keys
-	case 13: // VK_RETURN
-		return "Return"
-	case 8: // VK_BACK
-		return "Backspace"
-	case 9: // VK_TAB
-		return "Tab"
-	case 32: // VK_SPACE
-		return "Space"
-	case 27: // VK_ESCAPE
-		return "Escape"
+	13: "Return",    // VK_RETURN
+	8:  "Backspace", // VK_BACK
+	9:  "Tab",       // VK_TAB
+	32: "Space",     // VK_SPACE
+	27: "Escape",    // VK_ESCAPE
+
+	// Navigation and editing keys (Windows VK codes)
+	38: "Up",          // VK_UP
+	40: "Down",        // VK_DOWN
+	37: "Left",        // VK_LEFT
+	39: "Right",       // VK_RIGHT
+	36: "Home",        // VK_HOME
+	35: "End",         // VK_END
+	33: "PageUp",      // VK_PRIOR
+	34: "PageDown",    // VK_NEXT
+	45: "Insert",      // VK_INSERT
+	46: "Delete",      // VK_DELETE
+	44: "PrintScreen", // VK_SNAPSHOT
+
+	// Media keys (Windows VK codes)
+	173: "VolumeMute",     // VK_VOLUME_MUTE
+	174: "VolumeDown",     // VK_VOLUME_DOWN
+	175: "VolumeUp",       // VK_VOLUME_UP
+	176: "MediaNext",      // VK_MEDIA_NEXT_TRACK
+	177: "MediaPrevious",  // VK_MEDIA_PREV_TRACK
+	178: "MediaStop",      // VK_MEDIA_STOP
+	179: "MediaPlayPause", // VK_MEDIA_PLAY_PAUSE
 
 	// Numpad keys (Windows VK codes)
-	// Fyne on Windows reports numpad keys as their character equivalents
-	case 97: // VK_NUMPAD1
-		return "1"
-	case 98: // VK_NUMPAD2
-		return "2"
-	case 99: // VK_NUMPAD3
-		return "3"
-	case 100: // VK_NUMPAD4
-		return "4"
-	case 101: // VK_NUMPAD5
-		return "5"
-	case 102: // VK_NUMPAD6
-		return "6"
-	case 103: // VK_NUMPAD7
-		return "7"
-	case 104: // VK_NUMPAD8
-		return "8"
-	case 105: // VK_NUMPAD9
-		return "9"
-	case 96: // VK_NUMPAD0
-		return "0"
-	case 110: // VK_DECIMAL
-		return "."
-	case 107: // VK_ADD
-		return "+"
-	case 109: // VK_SUBTRACT
-		return "-"
-	case 106: // VK_MULTIPLY
-		return "*"
-	case 111: // VK_DIVIDE
-		return "/"
+	// Fyne reports most of these as their character equivalents; see
+	// keypadEquivalents for how those captures match.
+	97:  "Numpad1",        // VK_NUMPAD1
+	98:  "Numpad2",        // VK_NUMPAD2
+	99:  "Numpad3",        // VK_NUMPAD3
+	100: "Numpad4",        // VK_NUMPAD4
+	101: "Numpad5",        // VK_NUMPAD5
+	102: "Numpad6",        // VK_NUMPAD6
+	103: "Numpad7",        // VK_NUMPAD7
+	104: "Numpad8",        // VK_NUMPAD8
+	105: "Numpad9",        // VK_NUMPAD9
+	96:  "Numpad0",        // VK_NUMPAD0
+	110: "NumpadDecimal",  // VK_DECIMAL
+	107: "NumpadAdd",      // VK_ADD
+	109: "NumpadSubtract", // VK_SUBTRACT
+	106: "NumpadMultiply", // VK_MULTIPLY
+	111: "NumpadDivide",   // VK_DIVIDE
 	// NumpadEnter shares VK_RETURN (13)
 
 	// Symbol keys
-	case 189: // VK_OEM_MINUS
-		return "-"
-	case 187: // VK_OEM_PLUS (equals/plus key)
-		return "="
+	189: "-", // VK_OEM_MINUS
+	187: "=", // VK_OEM_PLUS (equals/plus key)
 
 	// Letter keys (Windows VK codes are uppercase ASCII)
 	// Return uppercase to match Fyne's key names
-	case 65: // VK_A
-		return "A"
-	case 66: // VK_B
-		return "B"
-	case 67: // VK_C
-		return "C"
-	case 68: // VK_D
-		return "D"
-	case 69: // VK_E
-		return "E"
-	case 70: // VK_F
-		return "F"
-	case 71: // VK_G
-		return "G"
-	case 72: // VK_H
-		return "H"
-	case 73: // VK_I
-		return "I"
-	case 74: // VK_J
-		return "J"
-	case 75: // VK_K
-		return "K"
-	case 76: // VK_L
-		return "L"
-	case 77: // VK_M
-		return "M"
-	case 78: // VK_N
-		return "N"
-	case 79: // VK_O
-		return "O"
-	case 80: // VK_P
-		return "P"
-	case 81: // VK_Q
-		return "Q"
-	case 82: // VK_R
-		return "R"
-	case 83: // VK_S
-		return "S"
-	case 84: // VK_T
-		return "T"
-	case 85: // VK_U
-		return "U"
-	case 86: // VK_V
-		return "V"
-	case 87: // VK_W
-		return "W"
-	case 88: // VK_X
-		return "X"
-	case 89: // VK_Y
-		return "Y"
-	case 90: // VK_Z
-		return "Z"
+	65: "A", // VK_A
+	66: "B", // VK_B
+	67: "C", // VK_C
+	68: "D", // VK_D
+	69: "E", // VK_E
+	70: "F", // VK_F
+	71: "G", // VK_G
+	72: "H", // VK_H
+	73: "I", // VK_I
+	74: "J", // VK_J
+	75: "K", // VK_K
+	76: "L", // VK_L
+	77: "M", // VK_M
+	78: "N", // VK_N
+	79: "O", // VK_O
+	80: "P", // VK_P
+	81: "Q", // VK_Q
+	82: "R", // VK_R
+	83: "S", // VK_S
+	84: "T", // VK_T
+	85: "U", // VK_U
+	86: "V", // VK_V
+	87: "W", // VK_W
+	88: "X", // VK_X
+	89: "Y", // VK_Y
+	90: "Z", // VK_Z
 
 	// Number keys (top row)
-	case 48: // VK_0
-		return "0"
-	case 49: // VK_1
-		return "1"
-	case 50: // VK_2
-		return "2"
-	case 51: // VK_3
-		return "3"
-	case 52: // VK_4
-		return "4"
-	case 53: // VK_5
-		return "5"
-	case 54: // VK_6
-		return "6"
-	case 55: // VK_7
-		return "7"
-	case 56: // VK_8
-		return "8"
-	case 57: // VK_9
-		return "9"
-	}
-
-	return ""
+	48: "0", // VK_0
+	49: "1", // VK_1
+	50: "2", // VK_2
+	51: "3", // VK_3
+	52: "4", // VK_4
+	53: "5", // VK_5
+	54: "6", // VK_6
+	55: "7", // VK_7
+	56: "8", // VK_8
+	57: "9", // VK_9
 }
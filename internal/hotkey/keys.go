@@ -0,0 +1,97 @@
+package hotkey
+
+import "strings"
+
+// Key names come from two places that don't agree: the Settings capture
+// dialog records Fyne key names ("KP_Enter", "BackSpace", "A"), while the
+// global hook reports the names in keymap_*.go ("Return", "Backspace", "a").
+// Both are translated to one canonical name per key before they're compared,
+// so a combo captured in Settings matches what the hook reports at runtime
+// on both platforms.
+
+// canonicalKeys are the canonical names of the non-printable keys. They're
+// the names the keymaps report. Printable keys are named by their character,
+// letters in upper case.
+var canonicalKeys = []string{
+	"LeftShift", "RightShift", "LeftControl", "RightControl",
+	"LeftAlt", "RightAlt", "LeftSuper", "RightSuper",
+	"F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12",
+	"Return", "Backspace", "Tab", "Space", "Escape",
+	"Up", "Down", "Left", "Right", "Home", "End", "PageUp", "PageDown",
+	"Insert", "Delete", "PrintScreen",
+	"VolumeUp", "VolumeDown", "VolumeMute",
+	"MediaPlayPause", "MediaStop", "MediaNext", "MediaPrevious",
+	"Numpad0", "Numpad1", "Numpad2", "Numpad3", "Numpad4",
+	"Numpad5", "Numpad6", "Numpad7", "Numpad8", "Numpad9",
+	"NumpadDecimal", "NumpadAdd", "NumpadSubtract", "NumpadMultiply", "NumpadDivide",
+}
+
+// keyAliases maps other spellings of a key, lower case, to its canonical
+// name.
+var keyAliases = map[string]string{
+	// Fyne names the keypad's Enter "KP_Enter". Neither Fyne nor the Windows
+	// hook can tell the two Enter keys apart, so they're one key everywhere.
+	"kp_enter":    "Return",
+	"numpadenter": "Return",
+	"enter":       "Return",
+
+	// Fyne names keypad keys by their character. "+" and "*" only come from
+	// the keypad (the main row reports "=" and "8"); the rest are ambiguous,
+	// see keypadEquivalents.
+	"+": "NumpadAdd",
+	"*": "NumpadMultiply",
+	" ": "Space",
+
+	// Fyne uses the X11 names for Page Up and Page Down.
+	"prior": "PageUp",
+	"next":  "PageDown",
+}
+
+// keypadEquivalents maps keypad keys to the main keyboard key Fyne reports
+// for them. A combo captured in Settings as "1" can't know which 1 was
+// pressed, so it fires on either; a combo naming "Numpad1" only on the
+// keypad.
+var keypadEquivalents = map[string]string{
+	"Numpad0":        "0",
+	"Numpad1":        "1",
+	"Numpad2":        "2",
+	"Numpad3":        "3",
+	"Numpad4":        "4",
+	"Numpad5":        "5",
+	"Numpad6":        "6",
+	"Numpad7":        "7",
+	"Numpad8":        "8",
+	"Numpad9":        "9",
+	"NumpadDecimal":  ".",
+	"NumpadSubtract": "-",
+	"NumpadDivide":   "/",
+}
+
+func init() {
+	for _, name := range canonicalKeys {
+		keyAliases[strings.ToLower(name)] = name
+	}
+}
+
+// CanonicalKey returns the canonical name of a key given any of its
+// spellings — a Fyne key name, a hook key name or one from an older config —
+// or "" if the key isn't in the registry.
+func CanonicalKey(name string) string {
+	if canonical, ok := keyAliases[strings.ToLower(name)]; ok {
+		return canonical
+	}
+	if len(name) == 1 && name[0] > 32 && name[0] <= 126 {
+		return strings.ToUpper(name)
+	}
+	return ""
+}
+
+// keyMatches reports whether pressing the key the hook calls pressed
+// satisfies the key bound in a combo.
+func keyMatches(bound, pressed string) bool {
+	b, p := CanonicalKey(bound), CanonicalKey(pressed)
+	if b == "" || p == "" {
+		return false
+	}
+	return b == p || keypadEquivalents[p] == b
+}
@@ -0,0 +1,64 @@
+package hotkey
+
+import "testing"
+
+func TestCanonicalKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		// Fyne names from the capture dialog
+		{"KP_Enter", "Return"},
+		{"BackSpace", "Backspace"},
+		{"LeftControl", "LeftControl"},
+		{"C", "C"},
+		{"1", "1"},
+		{"+", "NumpadAdd"},
+		{"*", "NumpadMultiply"},
+		{"Space", "Space"},
+		{"Prior", "PageUp"},
+		{"Next", "PageDown"},
+		{"Delete", "Delete"},
+		{"PrintScreen", "PrintScreen"},
+		// Hook names
+		{"c", "C"},
+		{"NumpadEnter", "Return"},
+		{"Numpad1", "Numpad1"},
+		{"numpadadd", "NumpadAdd"},
+		{"MediaPlayPause", "MediaPlayPause"},
+		// Unknown
+		{"", ""},
+		{"Hyper", ""},
+	}
+	for _, tt := range tests {
+		if got := CanonicalKey(tt.name); got != tt.want {
+			t.Errorf("CanonicalKey(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKeyMatches(t *testing.T) {
+	tests := []struct {
+		bound, pressed string
+		want           bool
+	}{
+		{"C", "c", true},
+		{"KP_Enter", "Return", true},
+		{"Return", "NumpadEnter", true},
+		// A captured "1" can't tell the two 1 keys apart, so it fires on both.
+		{"1", "1", true},
+		{"1", "Numpad1", true},
+		{"-", "NumpadSubtract", true},
+		{".", "NumpadDecimal", true},
+		// A keypad binding stays on the keypad.
+		{"Numpad1", "1", false},
+		{"+", "NumpadAdd", true},
+		{"+", "=", false},
+		{"Hyper", "Hyper", false},
+	}
+	for _, tt := range tests {
+		if got := keyMatches(tt.bound, tt.pressed); got != tt.want {
+			t.Errorf("keyMatches(%q, %q) = %v, want %v", tt.bound, tt.pressed, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package hotkey
+
+import "time"
+
+// Action names a hotkey action by its config key, e.g. "increment_ct". The
+// actions themselves are listed in config.HotkeyActions.
+type Action string
+
+// ActionNone means no action was triggered.
+const ActionNone Action = ""
+
+// Combo binds a key combination to an action.
+type Combo struct {
+	Action Action
+	Keys   []string
+}
+
+// Bindings holds the key combination for each action. Combos are tried in
+// order, so with MatchSuperset the first one whose keys are held wins.
+type Bindings struct {
+	Combos   []Combo
+	Strategy MatchStrategy
+}
+
+// MatchStrategy decides which combo, if any, the pressed keys trigger. Under
+// every strategy the key just pressed must be part of the combo, so a stuck
+// key can't fire its action on every other key press.
+type MatchStrategy int
+
+const (
+	// MatchExact fires a combo only if exactly its keys are pressed. A stuck
+	// modifier blocks every hotkey until it's released.
+	MatchExact MatchStrategy = iota
+	// MatchSuperset fires the first combo (in Bindings order) whose keys are
+	// all pressed, ignoring any other pressed keys.
+	MatchSuperset
+	// MatchLongest is like MatchSuperset but prefers the combo with the most
+	// keys, so Ctrl+Shift+C wins over Shift+C when all three are down.
+	MatchLongest
+)
+
+// RepeatPolicy says what holding down a hotkey does.
+type RepeatPolicy int
+
+const (
+	// RepeatIgnore fires the action once per press, however long it's held.
+	RepeatIgnore RepeatPolicy = iota
+	// RepeatAfterDelay fires once on press and then, once the key has been
+	// held for Timing.RepeatDelay, again on every auto-repeat the action's
+	// cooldown allows.
+	RepeatAfterDelay
+)
+
+// DefaultCooldown is the cooldown of actions Timing doesn't list.
+const DefaultCooldown = 100 * time.Millisecond
+
+// autoRepeatGap is how soon after a release a press of the same key counts as
+// auto-repeat rather than a new press. X11 reports auto-repeat as
+// release/press pairs with no gap; nobody can tap a key this fast.
+const autoRepeatGap = 5 * time.Millisecond
+
+// Timing controls how often hotkeys fire.
+type Timing struct {
+	// Cooldowns is the minimum time between two firings of an action.
+	// Actions without an entry use DefaultCooldown. Cooldowns are per action,
+	// so a quick decrement right after an increment is never swallowed.
+	Cooldowns   map[Action]time.Duration
+	Repeat      RepeatPolicy
+	RepeatDelay time.Duration
+}
+
+func (t *Timing) cooldown(action Action) time.Duration {
+	if d, ok := t.Cooldowns[action]; ok {
+		return d
+	}
+	return DefaultCooldown
+}
+
+// keyState tracks pressed keys and turns key events into actions. It's
+// independent of the keyboard hook, and not safe for concurrent use.
+type keyState struct {
+	pressed   map[string]time.Time // pressed keys and when they went down
+	released  map[string]time.Time // recently released keys and when they went down
+	releaseAt map[string]time.Time
+	lastFired map[Action]time.Time
+}
+
+func newKeyState() *keyState {
+	return &keyState{
+		pressed:   make(map[string]time.Time),
+		released:  make(map[string]time.Time),
+		releaseAt: make(map[string]time.Time),
+		lastFired: make(map[Action]time.Time),
+	}
+}
+
+// keyDown records a press of keyName at now and returns the action it
+// triggers, if any.
+func (s *keyState) keyDown(keyName string, now time.Time, bindings *Bindings, timing *Timing) Action {
+	repeat := false
+	if _, held := s.pressed[keyName]; held {
+		repeat = true
+	} else if at, ok := s.releaseAt[keyName]; ok && now.Sub(at) < autoRepeatGap {
+		// Auto-repeat reported as a release and a press: the key never
+		// really went up.
+		s.pressed[keyName] = s.released[keyName]
+		repeat = true
+	} else {
+		s.pressed[keyName] = now
+	}
+	delete(s.released, keyName)
+	delete(s.releaseAt, keyName)
+
+	if repeat {
+		if timing.Repeat != RepeatAfterDelay || now.Sub(s.pressed[keyName]) < timing.RepeatDelay {
+			return ActionNone
+		}
+	}
+
+	action := s.match(bindings, keyName)
+	if action == ActionNone {
+		return ActionNone
+	}
+	if last, ok := s.lastFired[action]; ok && now.Sub(last) < timing.cooldown(action) {
+		return ActionNone
+	}
+	s.lastFired[action] = now
+	return action
+}
+
+// keyUp records a release of keyName at now.
+func (s *keyState) keyUp(keyName string, now time.Time) {
+	down, ok := s.pressed[keyName]
+	if !ok {
+		return
+	}
+	delete(s.pressed, keyName)
+	// Forget older releases; only the latest can be followed by an
+	// auto-repeat press.
+	clear(s.released)
+	clear(s.releaseAt)
+	s.released[keyName] = down
+	s.releaseAt[keyName] = now
+}
+
+// match returns the action the pressed keys trigger now that keyName went
+// down, according to b.Strategy.
+func (s *keyState) match(b *Bindings, keyName string) Action {
+	best, bestLen := ActionNone, 0
+	for _, c := range b.Combos {
+		if !s.matchesCombo(c.Keys, keyName, b.Strategy == MatchExact) {
+			continue
+		}
+		if b.Strategy != MatchLongest {
+			return c.Action
+		}
+		if len(c.Keys) > bestLen {
+			best, bestLen = c.Action, len(c.Keys)
+		}
+	}
+	return best
+}
+
+// matchesCombo reports whether every key in comboKeys is pressed and
+// keyName is one of them. With exact set, no other key may be pressed.
+func (s *keyState) matchesCombo(comboKeys []string, keyName string, exact bool) bool {
+	if len(comboKeys) == 0 {
+		return false
+	}
+	// All keys in the combo must be pressed, see keyMatches
+	includesKey := false
+	for _, key := range comboKeys {
+		found := false
+		for pressedKey := range s.pressed {
+			if keyMatches(key, pressedKey) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+		if keyMatches(key, keyName) {
+			includesKey = true
+		}
+	}
+	if !includesKey {
+		return false
+	}
+	// And, for an exact match, we must have exactly the same number of keys
+	// pressed
+	return !exact || len(s.pressed) == len(comboKeys)
+}
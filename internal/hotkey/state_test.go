@@ -0,0 +1,266 @@
+package hotkey
+
+import (
+	"testing"
+	"time"
+)
+
+const (
+	incCT Action = "increment_ct"
+	decCT Action = "decrement_ct"
+	selCT Action = "select_ct"
+	selT  Action = "select_t"
+	swap  Action = "swap_teams"
+)
+
+var testBindings = &Bindings{Combos: []Combo{
+	{incCT, []string{"NumpadAdd"}},
+	{decCT, []string{"NumpadSubtract"}},
+	{selCT, []string{"LeftControl", "c"}},
+}}
+
+// key is one simulated key event, at a time relative to the start.
+type key struct {
+	at   time.Duration
+	name string
+	up   bool
+}
+
+// press returns the events of tapping name at at for 50ms.
+func press(at time.Duration, name string) []key {
+	return []key{{at: at, name: name}, {at: at + 50*time.Millisecond, name: name, up: true}}
+}
+
+func sequence(parts ...[]key) []key {
+	var keys []key
+	for _, p := range parts {
+		keys = append(keys, p...)
+	}
+	return keys
+}
+
+func ms(n int) time.Duration { return time.Duration(n) * time.Millisecond }
+
+func TestKeyState(t *testing.T) {
+	repeat := Timing{Repeat: RepeatAfterDelay, RepeatDelay: ms(500)}
+
+	tests := []struct {
+		name   string
+		timing Timing
+		keys   []key
+		want   []Action
+	}{
+		{
+			name: "single press",
+			keys: press(0, "NumpadAdd"),
+			want: []Action{incCT},
+		},
+		{
+			name: "second press inside the cooldown is dropped",
+			keys: sequence(press(0, "NumpadAdd"), press(ms(60), "NumpadAdd")),
+			want: []Action{incCT},
+		},
+		{
+			name: "second press after the cooldown fires",
+			keys: sequence(press(0, "NumpadAdd"), press(ms(150), "NumpadAdd")),
+			want: []Action{incCT, incCT},
+		},
+		{
+			name: "cooldowns are per action",
+			keys: sequence(press(0, "NumpadAdd"), press(ms(60), "NumpadSubtract")),
+			want: []Action{incCT, decCT},
+		},
+		{
+			name:   "configured cooldown",
+			timing: Timing{Cooldowns: map[Action]time.Duration{incCT: ms(500)}},
+			keys:   sequence(press(0, "NumpadAdd"), press(ms(300), "NumpadAdd"), press(ms(600), "NumpadAdd")),
+			want:   []Action{incCT, incCT},
+		},
+		{
+			name:   "zero cooldown",
+			timing: Timing{Cooldowns: map[Action]time.Duration{incCT: 0}},
+			keys:   sequence(press(0, "NumpadAdd"), press(ms(60), "NumpadAdd")),
+			want:   []Action{incCT, incCT},
+		},
+		{
+			name: "held key fires once by default",
+			keys: []key{{at: 0, name: "NumpadAdd"}, {at: ms(600), name: "NumpadAdd"}, {at: ms(700), name: "NumpadAdd"}},
+			want: []Action{incCT},
+		},
+		{
+			name: "X11 auto-repeat is not a new press",
+			keys: []key{
+				{at: 0, name: "NumpadAdd"},
+				{at: ms(600), name: "NumpadAdd", up: true},
+				{at: ms(600), name: "NumpadAdd"},
+			},
+			want: []Action{incCT},
+		},
+		{
+			name:   "held key repeats after the delay",
+			timing: repeat,
+			keys: []key{
+				{at: 0, name: "NumpadAdd"},
+				{at: ms(300), name: "NumpadAdd"},
+				{at: ms(550), name: "NumpadAdd"},
+				{at: ms(600), name: "NumpadAdd"},
+				{at: ms(700), name: "NumpadAdd"},
+			},
+			want: []Action{incCT, incCT, incCT},
+		},
+		{
+			name:   "X11 auto-repeat repeats after the delay",
+			timing: repeat,
+			keys: []key{
+				{at: 0, name: "NumpadAdd"},
+				{at: ms(300), name: "NumpadAdd", up: true},
+				{at: ms(300), name: "NumpadAdd"},
+				{at: ms(550), name: "NumpadAdd", up: true},
+				{at: ms(550), name: "NumpadAdd"},
+			},
+			want: []Action{incCT, incCT},
+		},
+		{
+			name: "combo",
+			keys: []key{{at: 0, name: "LeftControl"}, {at: ms(20), name: "C"}},
+			want: []Action{selCT},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newKeyState()
+			start := time.Now()
+			var got []Action
+			for _, k := range tt.keys {
+				if k.up {
+					s.keyUp(k.name, start.Add(k.at))
+					continue
+				}
+				if a := s.keyDown(k.name, start.Add(k.at), testBindings, &tt.timing); a != ActionNone {
+					got = append(got, a)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("actions = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("actions = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchStrategy(t *testing.T) {
+	bindings := func(strategy MatchStrategy) *Bindings {
+		return &Bindings{
+			Combos: []Combo{
+				{incCT, []string{"NumpadAdd"}},
+				{selCT, []string{"LeftShift", "c"}},
+				{selT, []string{"LeftControl", "LeftShift", "c"}},
+				{swap, []string{"LeftShift"}},
+			},
+			Strategy: strategy,
+		}
+	}
+	down := func(names ...string) []key {
+		keys := make([]key, len(names))
+		for i, n := range names {
+			keys[i] = key{at: ms(200 * i), name: n}
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name     string
+		strategy MatchStrategy
+		keys     []key
+		want     []Action
+	}{
+		{
+			name:     "exact: stuck modifier blocks",
+			strategy: MatchExact,
+			keys:     down("RightAlt", "NumpadAdd"),
+			want:     nil,
+		},
+		{
+			name:     "superset: stuck modifier is ignored",
+			strategy: MatchSuperset,
+			keys:     down("RightAlt", "NumpadAdd"),
+			want:     []Action{incCT},
+		},
+		{
+			name:     "superset: stuck key doesn't fire on other presses",
+			strategy: MatchSuperset,
+			keys:     down("NumpadAdd", "x", "y"),
+			want:     []Action{incCT},
+		},
+		{
+			name:     "exact: three-key combo",
+			strategy: MatchExact,
+			keys:     down("LeftControl", "LeftShift", "C"),
+			want:     []Action{selT},
+		},
+		{
+			name:     "superset: first combo in action order wins",
+			strategy: MatchSuperset,
+			keys:     down("LeftControl", "LeftShift", "C"),
+			want:     []Action{swap, selCT},
+		},
+		{
+			name:     "longest: combo with most keys wins",
+			strategy: MatchLongest,
+			keys:     down("LeftControl", "LeftShift", "C"),
+			want:     []Action{swap, selT},
+		},
+		{
+			name:     "longest: shorter combo when the longer isn't held",
+			strategy: MatchLongest,
+			keys:     down("LeftShift", "C"),
+			want:     []Action{swap, selCT},
+		},
+		{
+			name:     "exact: modifier on its own",
+			strategy: MatchExact,
+			keys:     down("LeftShift", "C"),
+			want:     []Action{swap, selCT},
+		},
+		{
+			name:     "longest: released key no longer counts",
+			strategy: MatchLongest,
+			keys: []key{
+				{at: 0, name: "LeftControl"},
+				{at: ms(100), name: "LeftControl", up: true},
+				{at: ms(200), name: "LeftShift"},
+				{at: ms(400), name: "c"},
+			},
+			want: []Action{swap, selCT},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newKeyState()
+			b := bindings(tt.strategy)
+			start := time.Now()
+			var got []Action
+			for _, k := range tt.keys {
+				if k.up {
+					s.keyUp(k.name, start.Add(k.at))
+					continue
+				}
+				if a := s.keyDown(k.name, start.Add(k.at), b, &Timing{}); a != ActionNone {
+					got = append(got, a)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("actions = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("actions = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
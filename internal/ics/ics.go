@@ -0,0 +1,97 @@
+// Package ics writes play sessions as an iCalendar (RFC 5545) file, so a
+// calendar app can show when the player actually played.
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"csstatstracker/internal/records"
+)
+
+// timeFormat is the iCalendar UTC date-time format.
+const timeFormat = "20060102T150405Z"
+
+// maxLineLen is the longest a content line may be, in octets, before it's
+// folded.
+const maxLineLen = 75
+
+// Write writes sessions as a calendar with one event per session, stamped
+// now. Each event's UID comes from its start time, so importing a newer
+// export updates the events from an older one instead of duplicating them.
+// desc describes which rounds the sessions were built from, e.g. "All
+// rounds"; it's written as the calendar's description if not empty.
+func Write(w io.Writer, sessions []records.Session, now time.Time, desc string) error {
+	bw := bufio.NewWriter(w)
+	line := func(format string, args ...any) {
+		writeLine(bw, fmt.Sprintf(format, args...))
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//csstatstracker//Play Sessions//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:CS2 Sessions")
+	if desc != "" {
+		line("X-WR-CALDESC:%s", escape(desc))
+	}
+	for _, s := range sessions {
+		line("BEGIN:VEVENT")
+		line("UID:session-%d@csstatstracker", s.Start.Unix())
+		line("DTSTAMP:%s", now.UTC().Format(timeFormat))
+		line("DTSTART:%s", s.Start.UTC().Format(timeFormat))
+		line("DTEND:%s", s.End.UTC().Format(timeFormat))
+		line("SUMMARY:%s", escape(Summary(s)))
+		line("DESCRIPTION:%s", escape(formatDuration(s.Duration())+" from the first recorded round to the last"))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("failed to write calendar: %w", err)
+	}
+	return nil
+}
+
+// Summary is an event's title, e.g. "CS2: 24 rounds (14 W – 10 L)".
+func Summary(s records.Session) string {
+	noun := "rounds"
+	if s.Rounds == 1 {
+		noun = "round"
+	}
+	return fmt.Sprintf("CS2: %d %s (%d W – %d L)", s.Rounds, noun, s.Wins, s.Losses)
+}
+
+// formatDuration formats d as e.g. "1h 25m".
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	if d < time.Hour {
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// escape escapes text for a TEXT property value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeLine writes a content line ending in CRLF, folding it onto
+// continuation lines that start with a space if it's too long. Lines are
+// only folded between UTF-8 characters.
+func writeLine(w *bufio.Writer, s string) {
+	limit := maxLineLen
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		_, _ = w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		limit = maxLineLen - 1 // the leading space counts
+	}
+	_, _ = w.WriteString(s + "\r\n")
+}
@@ -0,0 +1,72 @@
+package ics
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/records"
+)
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2025, 3, 14, 19, 5, 0, 0, time.UTC)
+	sessions := []records.Session{
+		{Start: start, End: start.Add(85 * time.Minute), Rounds: 24, Wins: 14, Losses: 10},
+		{Start: start.Add(24 * time.Hour), End: start.Add(24 * time.Hour), Rounds: 1, Wins: 1},
+	}
+	var b strings.Builder
+	if err := Write(&b, sessions, start.Add(48*time.Hour), "3 selected rounds, 2025-03-14 to 2025-03-15"); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALDESC:3 selected rounds\\, 2025-03-14 to 2025-03-15\r\n",
+		"UID:session-1741979100@csstatstracker\r\n",
+		"DTSTAMP:20250316T190500Z\r\n",
+		"DTSTART:20250314T190500Z\r\n",
+		"DTEND:20250314T203000Z\r\n",
+		"SUMMARY:CS2: 24 rounds (14 W – 10 L)\r\n",
+		"DESCRIPTION:1h 25m from the first recorded round to the last\r\n",
+		"SUMMARY:CS2: 1 round (1 W – 0 L)\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar is missing %q:\n%s", want, out)
+		}
+	}
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 2 {
+		t.Errorf("got %d events, want 2", n)
+	}
+}
+
+func TestWriteLineFolds(t *testing.T) {
+	long := "SUMMARY:" + strings.Repeat("é", 60)
+	var b strings.Builder
+	w := bufio.NewWriter(&b)
+	writeLine(w, long)
+	_ = w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+	if len(lines) < 2 {
+		t.Fatalf("line of %d octets wasn't folded", len(long))
+	}
+	var unfolded strings.Builder
+	for i, l := range lines {
+		if len(l) > maxLineLen {
+			t.Errorf("line %d is %d octets, want at most %d", i, len(l), maxLineLen)
+		}
+		if i > 0 {
+			if !strings.HasPrefix(l, " ") {
+				t.Fatalf("continuation line %d doesn't start with a space", i)
+			}
+			l = l[1:]
+		}
+		unfolded.WriteString(l)
+	}
+	if unfolded.String() != long {
+		t.Errorf("unfolded = %q, want %q", unfolded.String(), long)
+	}
+}
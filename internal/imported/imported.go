@@ -0,0 +1,107 @@
+// Package imported turns matches imported from other services, which only
+// know each match's final score and not the order the rounds were won in,
+// into the rounds the tracker would have recorded for them.
+//
+// The rounds are spread evenly through the match with the winner taking the
+// last one, a round length apart up to the time the match finished. Sides
+// follow the starting side through halftime and overtime; a match without
+// one is taken to start on CT, so its wins and losses are right but its
+// per-side split is a guess.
+package imported
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/match"
+)
+
+// RoundLength is how far apart a match's rounds are recorded, the same
+// estimate the Stats tab uses for play time.
+const RoundLength = 105 * time.Second
+
+// Match is one match from another service.
+type Match struct {
+	ID         string    // the service's match id, or one made up from the rest
+	FinishedAt time.Time // when the match ended
+	Map        string    // e.g. "de_dust2"
+	Score      int       // rounds the player's team won
+	EnemyScore int       // rounds the other team won
+	Side       database.Team
+}
+
+// Rounds returns m's rounds, oldest first, each with a UUID made from source
+// (the service's name) and m's ID, so importing the same match again adds
+// nothing new. account is recorded with every round.
+func Rounds(source string, m Match, account string) []database.Round {
+	total := m.Score + m.EnemyScore
+	if total == 0 {
+		return nil
+	}
+	start := m.Side
+	if start == database.TeamNone {
+		start = database.TeamCT
+	}
+	// Bresenham's spread of the winner's rounds, which always gives them
+	// the last one.
+	high, ownHigh := m.Score, true
+	if m.EnemyScore > m.Score {
+		high, ownHigh = m.EnemyScore, false
+	}
+
+	rounds := make([]database.Round, total)
+	for i := range total {
+		team := start
+		if sideSwitches(i)%2 == 1 {
+			team = otherSide(start)
+		}
+		won := (i+1)*high/total > i*high/total
+		if !ownHigh {
+			won = !won
+		}
+		winner := team
+		if !won {
+			winner = otherSide(team)
+		}
+		rounds[i] = database.Round{
+			UUID:      RoundUUID(source, m.ID, i),
+			Winner:    winner,
+			Team:      team,
+			Account:   account,
+			Map:       m.Map,
+			CreatedAt: m.FinishedAt.Add(-time.Duration(total-1-i) * RoundLength),
+		}
+	}
+	return rounds
+}
+
+// sideSwitches returns how many times the teams have switched sides before
+// the round played after the given number of completed rounds: at halftime,
+// then at each overtime's halftime, as overtime starts on the side
+// regulation ended on.
+func sideSwitches(played int) int {
+	p := match.PhaseOf(played, match.DefaultRules)
+	if p.Overtime == 0 {
+		return p.Half - 1
+	}
+	return p.Overtime + p.Half - 1
+}
+
+func otherSide(team database.Team) database.Team {
+	if team == database.TeamCT {
+		return database.TeamT
+	}
+	return database.TeamCT
+}
+
+// RoundUUID derives a name-based (version 5 style) UUID for round i of the
+// match with the given ID from source.
+func RoundUUID(source, matchID string, i int) string {
+	sum := sha1.Sum(fmt.Appendf(nil, "%s/%s/%d", source, matchID, i))
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	b := sum[:16]
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
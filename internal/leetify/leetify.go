@@ -0,0 +1,275 @@
+// Package leetify reads a match history exported from Leetify, as CSV or
+// JSON, and turns each match into the rounds the tracker would have
+// recorded for it, so players moving over keep their history. Leetify only
+// exports each match's final score, so the rounds are made up the way the
+// imported package does it.
+package leetify
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/imported"
+)
+
+// Source names Leetify in the UUIDs of the rounds imported from it.
+const Source = "leetify"
+
+// Match is one match from the export.
+type Match = imported.Match
+
+// Parse reads an export, either a CSV file with a header row or JSON: a list
+// of matches, or an object with one under "games" or "matches". Column and
+// field names are matched loosely, so "mapName", "map_name" and "Map" all
+// work.
+func Parse(r io.Reader) ([]Match, error) {
+	br := bufio.NewReader(r)
+	first, err := firstByte(br)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]any
+	if first == '[' || first == '{' {
+		rows, err = readJSON(br)
+	} else {
+		rows, err = readCSV(br)
+	}
+	if err != nil {
+		return nil, err
+	}
+	matches := make([]Match, 0, len(rows))
+	for i, row := range rows {
+		m, err := parseMatch(row)
+		if err != nil {
+			return nil, fmt.Errorf("match %d: %w", i+1, err)
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// firstByte returns the first byte of br that isn't white space or part of
+// a byte order mark, without consuming it.
+func firstByte(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err == io.EOF {
+			return 0, errors.New("the export is empty")
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read export: %w", err)
+		}
+		if strings.IndexByte(" \t\r\n\xef\xbb\xbf", b) < 0 {
+			return b, br.UnreadByte()
+		}
+	}
+}
+
+func readJSON(r io.Reader) ([]map[string]any, error) {
+	var doc any
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	if obj, ok := doc.(map[string]any); ok {
+		doc = nil
+		for key, v := range obj {
+			if k := normalize(key); k == "games" || k == "matches" {
+				doc = v
+			}
+		}
+	}
+	list, ok := doc.([]any)
+	if !ok {
+		return nil, errors.New("the export has no list of matches")
+	}
+	rows := make([]map[string]any, 0, len(list))
+	for _, item := range list {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, errors.New("the export's matches aren't objects")
+		}
+		row := make(map[string]any, len(obj))
+		for key, v := range obj {
+			row[normalize(key)] = v
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func readCSV(r io.Reader) ([]map[string]any, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse export: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the export is empty")
+	}
+	header := records[0]
+	rows := make([]map[string]any, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make(map[string]any, len(header))
+		for i, v := range rec {
+			if i < len(header) && strings.TrimSpace(v) != "" {
+				row[normalize(header[i])] = strings.TrimSpace(v)
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+// normalize lower-cases a column or field name and drops everything but
+// letters and digits.
+func normalize(key string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(key) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Field names, normalized, that each part of a match is read from.
+var (
+	idFields         = []string{"gameid", "matchid", "id"}
+	dateFields       = []string{"gamefinishedat", "finishedat", "matchdate", "date", "time"}
+	mapFields        = []string{"mapname", "map"}
+	scoreFields      = []string{"teamscore", "ownscore", "myscore"}
+	enemyScoreFields = []string{"enemyscore", "opponentscore", "enemyteamscore"}
+	sideFields       = []string{"startingside", "initialside", "side", "initialteamnumber", "team"}
+)
+
+func parseMatch(row map[string]any) (Match, error) {
+	var m Match
+	field := func(names []string) (any, bool) {
+		for _, name := range names {
+			if v, ok := row[name]; ok && v != nil {
+				return v, true
+			}
+		}
+		return nil, false
+	}
+
+	v, ok := field(dateFields)
+	if !ok {
+		return m, errors.New("no date")
+	}
+	finished, err := parseTime(fmt.Sprint(v))
+	if err != nil {
+		return m, err
+	}
+	m.FinishedAt = finished
+
+	if v, ok := field(mapFields); ok {
+		m.Map = fmt.Sprint(v)
+	}
+
+	if err := parseScores(row, field, &m); err != nil {
+		return m, err
+	}
+
+	if v, ok := field(sideFields); ok {
+		m.Side = parseSide(fmt.Sprint(v))
+	}
+
+	if v, ok := field(idFields); ok {
+		m.ID = fmt.Sprint(v)
+	} else {
+		m.ID = fmt.Sprintf("%s/%s/%d-%d", m.FinishedAt.UTC().Format(time.RFC3339), m.Map, m.Score, m.EnemyScore)
+	}
+	return m, nil
+}
+
+// parseScores reads the score as separate columns, a "scores" pair with
+// the player's team first, or a "score" like "13:9".
+func parseScores(row map[string]any, field func([]string) (any, bool), m *Match) error {
+	own, okOwn := field(scoreFields)
+	enemy, okEnemy := field(enemyScoreFields)
+	var a, b string
+	switch {
+	case okOwn && okEnemy:
+		a, b = fmt.Sprint(own), fmt.Sprint(enemy)
+	case row["scores"] != nil:
+		pair, ok := row["scores"].([]any)
+		if !ok || len(pair) != 2 {
+			return fmt.Errorf("scores %v aren't a pair", row["scores"])
+		}
+		a, b = fmt.Sprint(pair[0]), fmt.Sprint(pair[1])
+	case row["score"] != nil:
+		s := fmt.Sprint(row["score"])
+		var found bool
+		for _, sep := range []string{":", "-", "–"} {
+			if a, b, found = strings.Cut(s, sep); found {
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("score %q isn't like 13:9", s)
+		}
+	default:
+		return errors.New("no score")
+	}
+	var err error
+	if m.Score, err = strconv.Atoi(strings.TrimSpace(a)); err != nil || m.Score < 0 {
+		return fmt.Errorf("score %q isn't a number of rounds", a)
+	}
+	if m.EnemyScore, err = strconv.Atoi(strings.TrimSpace(b)); err != nil || m.EnemyScore < 0 {
+		return fmt.Errorf("score %q isn't a number of rounds", b)
+	}
+	return nil
+}
+
+// timeLayouts are the date formats accepted, most specific first.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if secs, err := strconv.ParseInt(s, 10, 64); err == nil {
+		// Unix seconds, or milliseconds for anything past 2286.
+		if secs > 1e10 {
+			return time.UnixMilli(secs), nil
+		}
+		return time.Unix(secs, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("date %q isn't one the importer knows", s)
+}
+
+// parseSide reads a side as CT or T, by name or by CS2's team number (2 for
+// T, 3 for CT). Anything else is unknown.
+func parseSide(s string) database.Team {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "ct", "counter-terrorist", "counter-terrorists", "counterterrorist", "3":
+		return database.TeamCT
+	case "t", "terrorist", "terrorists", "2":
+		return database.TeamT
+	}
+	return database.TeamNone
+}
+
+// Rounds returns m's rounds, oldest first, each with a UUID made from m's ID
+// so importing the same export again adds nothing new. account is recorded
+// with every round.
+func Rounds(m Match, account string) []database.Round {
+	return imported.Rounds(Source, m, account)
+}
@@ -0,0 +1,109 @@
+package leetify_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/leetify"
+)
+
+func TestParse(t *testing.T) {
+	finished := time.Date(2026, 3, 14, 21, 30, 0, 0, time.UTC)
+	want := []leetify.Match{
+		{ID: "a1", FinishedAt: finished, Map: "de_mirage", Score: 13, EnemyScore: 9, Side: database.TeamT},
+		{ID: "b2", FinishedAt: finished.Add(time.Hour), Map: "de_nuke", Score: 10, EnemyScore: 13, Side: database.TeamCT},
+	}
+	tests := []struct {
+		name   string
+		export string
+	}{
+		{"csv", "\xef\xbb\xbfGame ID,Finished At,Map Name,Team Score,Enemy Score,Starting Side\n" +
+			"a1,2026-03-14T21:30:00Z,de_mirage,13,9,T\n" +
+			"b2,2026-03-14T22:30:00Z,de_nuke,10,13,CT\n"},
+		{"json list", `[
+			{"gameId": "a1", "gameFinishedAt": "2026-03-14T21:30:00Z", "mapName": "de_mirage", "scores": [13, 9], "initialTeamNumber": 2},
+			{"gameId": "b2", "gameFinishedAt": "2026-03-14T22:30:00Z", "mapName": "de_nuke", "scores": [10, 13], "initialTeamNumber": 3}
+		]`},
+		{"json games", `{"games": [
+			{"id": "a1", "finished_at": 1773523800, "map": "de_mirage", "score": "13:9", "side": "terrorist"},
+			{"id": "b2", "finished_at": 1773527400000, "map": "de_nuke", "score": "10 - 13", "side": "ct"}
+		]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := leetify.Parse(strings.NewReader(tt.export))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d matches, want %d", len(got), len(want))
+			}
+			for i := range want {
+				g := got[i]
+				if g.ID != want[i].ID || !g.FinishedAt.Equal(want[i].FinishedAt) || g.Map != want[i].Map ||
+					g.Score != want[i].Score || g.EnemyScore != want[i].EnemyScore || g.Side != want[i].Side {
+					t.Errorf("match %d = %+v, want %+v", i, g, want[i])
+				}
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "date,map\n2026-03-14,de_dust2\n", `{"user": "x"}`, "date,score\nyesterday,13:9\n"} {
+		if _, err := leetify.Parse(strings.NewReader(bad)); err == nil {
+			t.Errorf("Parse(%q) succeeded; want an error", bad)
+		}
+	}
+}
+
+func TestRounds(t *testing.T) {
+	finished := time.Date(2026, 3, 14, 21, 30, 0, 0, time.UTC)
+	m := leetify.Match{ID: "a1", FinishedAt: finished, Map: "de_mirage", Score: 9, EnemyScore: 13, Side: database.TeamT}
+	rounds := leetify.Rounds(m, "main")
+	if len(rounds) != 22 {
+		t.Fatalf("got %d rounds, want 22", len(rounds))
+	}
+
+	wins, losses := 0, 0
+	uuids := map[string]bool{}
+	for i, r := range rounds {
+		switch r.Result() {
+		case database.ResultWin:
+			wins++
+		case database.ResultLoss:
+			losses++
+		}
+		wantTeam := database.TeamT
+		if i >= 12 {
+			wantTeam = database.TeamCT
+		}
+		if r.Team != wantTeam || r.Map != "de_mirage" || r.Account != "main" {
+			t.Errorf("round %d = %+v; want on %s on de_mirage for main", i+1, r, wantTeam)
+		}
+		uuids[r.UUID] = true
+	}
+	if wins != 9 || losses != 13 {
+		t.Errorf("%d wins and %d losses; want 9 and 13", wins, losses)
+	}
+	if last := rounds[len(rounds)-1]; last.Result() != database.ResultLoss || !last.CreatedAt.Equal(finished) {
+		t.Errorf("last round = %+v; want the winner's, at the finish", last)
+	}
+	if !rounds[0].CreatedAt.Before(rounds[1].CreatedAt) {
+		t.Error("rounds aren't oldest first")
+	}
+	if len(uuids) != 22 {
+		t.Errorf("%d distinct UUIDs; want one per round", len(uuids))
+	}
+	if again := leetify.Rounds(m, "main"); again[5].UUID != rounds[5].UUID {
+		t.Error("UUIDs change between imports of the same match")
+	}
+
+	// Overtime starts on the side regulation ended on.
+	ot := leetify.Rounds(leetify.Match{ID: "ot", FinishedAt: finished, Score: 16, EnemyScore: 14, Side: database.TeamCT}, "")
+	for i, want := range map[int]database.Team{0: database.TeamCT, 12: database.TeamT, 24: database.TeamT, 27: database.TeamCT} {
+		if ot[i].Team != want {
+			t.Errorf("overtime match round %d on %s; want %s", i+1, ot[i].Team, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package limiter counts matches finished in a row and calls for a break once
+// there have been enough, so the tracker can hold off the next match until
+// the break is over or the player overrides it.
+package limiter
+
+import (
+	"sync"
+	"time"
+
+	"csstatstracker/internal/records"
+)
+
+// Limiter tracks the current run of matches. It's safe for use from several
+// goroutines. The zero value never calls for a break.
+type Limiter struct {
+	mu      sync.Mutex
+	matches int           // matches in a row before a break, 0 for no limit
+	brk     time.Duration // length of the break
+	run     int           // matches finished in the current run
+	last    time.Time     // when the last round in the run was played
+	until   time.Time     // end of the current break, zero if there is none
+}
+
+// Configure sets how many matches in a row call for a break and how long it
+// lasts. matches <= 0 switches the limit off and ends any break.
+func (l *Limiter) Configure(matches int, brk time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.matches, l.brk = matches, brk
+	if matches <= 0 {
+		l.until = time.Time{}
+	}
+}
+
+// Played notes a round played at now. A pause longer than a session gap
+// before it, in a match or between two, is a break of its own and starts
+// the run over.
+func (l *Limiter) Played(now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() && now.Sub(l.last) > records.SessionGap {
+		l.run = 0
+	}
+	l.last = now
+}
+
+// Finished counts a match finished at now, after its last round was
+// Played. It reports whether that match ended the run, starting a break,
+// and when the break ends.
+func (l *Limiter) Finished(now time.Time) (until time.Time, started bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.matches <= 0 {
+		return time.Time{}, false
+	}
+	l.run++
+	if l.run < l.matches {
+		return time.Time{}, false
+	}
+	l.run = 0
+	l.until = now.Add(l.brk)
+	return l.until, true
+}
+
+// Locked reports whether a break is on at now, and when it ends.
+func (l *Limiter) Locked(now time.Time) (until time.Time, locked bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.matches <= 0 || !now.Before(l.until) {
+		return time.Time{}, false
+	}
+	return l.until, true
+}
+
+// Override ends the current break early. The next run starts from zero.
+func (l *Limiter) Override() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.until = time.Time{}
+	l.run = 0
+}
@@ -0,0 +1,70 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	start := time.Date(2025, 3, 14, 19, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	tests := []struct {
+		name       string
+		matches    int
+		played     []int // minutes each round is played at
+		finished   []int // minutes each match finishes at, after its last round
+		override   bool  // override after playing
+		checkAt    int
+		wantLocked bool
+	}{
+		{name: "under the limit", matches: 2, played: []int{0, 30}, finished: []int{30}, checkAt: 31},
+		{name: "rounds alone don't count", matches: 2, played: []int{0, 10, 20, 30, 40, 50}, checkAt: 51},
+		{name: "limit reached", matches: 2, played: []int{0, 20, 40}, finished: []int{20, 40}, checkAt: 41, wantLocked: true},
+		{name: "break over", matches: 2, played: []int{0, 20, 40}, finished: []int{20, 40}, checkAt: 55},
+		{name: "long pause resets the run", matches: 2, played: []int{0, 20, 60}, finished: []int{20, 60}, checkAt: 61},
+		{name: "overridden", matches: 2, played: []int{0, 20, 40}, finished: []int{20, 40}, override: true, checkAt: 41},
+		{name: "no limit", matches: 0, played: []int{0, 20, 40}, finished: []int{20, 40}, checkAt: 41},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var l Limiter
+			l.Configure(tt.matches, 15*time.Minute)
+			finished := tt.finished
+			for _, m := range tt.played {
+				l.Played(at(m))
+				if len(finished) > 0 && finished[0] == m {
+					l.Finished(at(m))
+					finished = finished[1:]
+				}
+			}
+			if tt.override {
+				l.Override()
+			}
+			until, locked := l.Locked(at(tt.checkAt))
+			if locked != tt.wantLocked {
+				t.Fatalf("Locked() = %v, want %v", locked, tt.wantLocked)
+			}
+			if locked && !until.Equal(at(tt.finished[len(tt.finished)-1]+15)) {
+				t.Errorf("break ends at %s, want 15 minutes after the last match", until)
+			}
+		})
+	}
+}
+
+func TestLimiterFinishedStartsBreak(t *testing.T) {
+	var l Limiter
+	l.Configure(2, time.Minute)
+	now := time.Now()
+	if _, started := l.Finished(now); started {
+		t.Fatal("first match started a break")
+	}
+	until, started := l.Finished(now.Add(time.Second))
+	if !started || !until.Equal(now.Add(time.Second+time.Minute)) {
+		t.Fatalf("Finished() = %s, %v, want a break until a minute later", until, started)
+	}
+	// The run starts again after the break.
+	if _, started := l.Finished(now.Add(2 * time.Minute)); started {
+		t.Error("first match after the break started another")
+	}
+}
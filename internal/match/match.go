@@ -0,0 +1,271 @@
+// Package match keeps score for one match: rounds won by each side, which
+// side the player is on, and which half or overtime the match is in. It has
+// no UI, sound or database dependencies; a Tracker drives it from hotkeys
+// and buttons and reacts to its events.
+package match
+
+import (
+	"fmt"
+	"sync"
+
+	"csstatstracker/internal/database"
+)
+
+// Rules describe the match format used to work out the phase.
+type Rules struct {
+	RegulationRounds int // rounds in regulation, split into two halves
+	OvertimeRounds   int // rounds in each overtime, split into two halves
+}
+
+// DefaultRules is CS2's MR12 format with MR3 overtimes.
+var DefaultRules = Rules{RegulationRounds: 24, OvertimeRounds: 6}
+
+// ShortRules is the first-to-8 format of Wingman and Casual, with no
+// overtime: a 7–7 tie is a draw.
+var ShortRules = Rules{RegulationRounds: 14}
+
+// RulesFor returns the rules mode is played under. Rounds recorded without a
+// mode count as DefaultRules.
+func RulesFor(mode database.Mode) Rules {
+	switch mode {
+	case database.ModeWingman, database.ModeCasual:
+		return ShortRules
+	default:
+		return DefaultRules
+	}
+}
+
+// Phase is the part of the match the next round belongs to.
+type Phase struct {
+	Overtime int // 0 in regulation, then 1, 2, ... for each overtime
+	Half     int // 1 or 2 within regulation or the overtime
+}
+
+func (p Phase) String() string {
+	if p.Overtime == 0 {
+		return fmt.Sprintf("Half %d", p.Half)
+	}
+	return fmt.Sprintf("OT%d Half %d", p.Overtime, p.Half)
+}
+
+// PhaseOf returns the phase of the round played after the given number of
+// completed rounds.
+func PhaseOf(played int, rules Rules) Phase {
+	if played < rules.RegulationRounds || rules.OvertimeRounds <= 0 {
+		half := 1
+		if played >= rules.RegulationRounds/2 {
+			half = 2
+		}
+		return Phase{Half: half}
+	}
+	intoOT := played - rules.RegulationRounds
+	half := 1
+	if intoOT%rules.OvertimeRounds >= rules.OvertimeRounds/2 {
+		half = 2
+	}
+	return Phase{Overtime: intoOT/rules.OvertimeRounds + 1, Half: half}
+}
+
+// Decided reports whether a match at a–b is over: one side has more than
+// half the regulation rounds, or more than half of the current overtime's on
+// top of the tie it started from. Without overtime a tie at the end of
+// regulation is a draw.
+func (r Rules) Decided(a, b int) bool {
+	played := a + b
+	limit := r.RegulationRounds / 2
+	if played > r.RegulationRounds {
+		if r.OvertimeRounds <= 0 {
+			return true
+		}
+		limit += ((played-r.RegulationRounds-1)/r.OvertimeRounds + 1) * r.OvertimeRounds / 2
+	} else if played == r.RegulationRounds && r.OvertimeRounds <= 0 {
+		return true
+	}
+	return max(a, b) > limit
+}
+
+// State is a snapshot of a match.
+type State struct {
+	CTWins int
+	TWins  int
+	Team   database.Team // the player's side, TeamNone if not set
+	Phase  Phase
+}
+
+// EventKind says what changed.
+type EventKind int
+
+const (
+	RoundWon     EventKind = iota // Side won a round
+	RoundUndone                   // Side's most recent round was taken back
+	TeamSelected                  // the player picked a side (even the current one)
+	PhaseChanged                  // the match moved to another half or overtime
+	Reset                         // the score went back to 0–0 for a new match
+)
+
+// Event describes one change to a match.
+type Event struct {
+	Kind  EventKind
+	Side  database.Team // the side for RoundWon and RoundUndone
+	State State         // the match after the change
+}
+
+// Observer is notified of every change to a match, synchronously and in
+// order, on the goroutine that made the change.
+type Observer interface {
+	Observe(Event)
+}
+
+// ObserverFunc adapts a function to an Observer.
+type ObserverFunc func(Event)
+
+// Observe calls f.
+func (f ObserverFunc) Observe(e Event) { f(e) }
+
+// Machine is a match's score-keeping state machine. It's safe for use from
+// several goroutines, e.g. the hotkey listener and the UI.
+type Machine struct {
+	mu        sync.Mutex
+	rules     Rules
+	ctWins    int
+	tWins     int
+	team      database.Team
+	observers []Observer
+}
+
+// New creates a match at 0–0 with no side selected.
+func New(rules Rules) *Machine {
+	return &Machine{rules: rules}
+}
+
+// Subscribe adds an observer.
+func (m *Machine) Subscribe(o Observer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.observers = append(m.observers, o)
+}
+
+// Rules returns the rules the match is played under.
+func (m *Machine) Rules() Rules {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rules
+}
+
+// SetRules changes the rules the match is played under, e.g. when the player
+// picks another game mode. The score is kept; observers aren't notified.
+func (m *Machine) SetRules(rules Rules) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rules = rules
+}
+
+// State returns the current state.
+func (m *Machine) State() State {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stateLocked()
+}
+
+func (m *Machine) stateLocked() State {
+	return State{
+		CTWins: m.ctWins,
+		TWins:  m.tWins,
+		Team:   m.team,
+		Phase:  PhaseOf(m.ctWins+m.tWins, m.rules),
+	}
+}
+
+// Win records a round won by side, which must be CT or T.
+func (m *Machine) Win(side database.Team) {
+	m.update(func() []Event {
+		switch side {
+		case database.TeamCT:
+			m.ctWins++
+		case database.TeamT:
+			m.tWins++
+		default:
+			return nil
+		}
+		return []Event{{Kind: RoundWon, Side: side}}
+	})
+}
+
+// Undo takes back side's most recent round. It does nothing if side hasn't
+// won any.
+func (m *Machine) Undo(side database.Team) {
+	m.update(func() []Event {
+		switch {
+		case side == database.TeamCT && m.ctWins > 0:
+			m.ctWins--
+		case side == database.TeamT && m.tWins > 0:
+			m.tWins--
+		default:
+			return nil
+		}
+		return []Event{{Kind: RoundUndone, Side: side}}
+	})
+}
+
+// Reset starts a new match at 0–0. The player's side is kept.
+func (m *Machine) Reset() {
+	m.update(func() []Event {
+		m.ctWins, m.tWins = 0, 0
+		return []Event{{Kind: Reset}}
+	})
+}
+
+// SelectTeam sets the player's side and notifies observers, even if it's
+// already the selected side.
+func (m *Machine) SelectTeam(team database.Team) {
+	m.update(func() []Event {
+		m.team = team
+		return []Event{{Kind: TeamSelected}}
+	})
+}
+
+// SetTeam sets the player's side without notifying observers, for callers
+// that already show the change, such as the team selector itself.
+func (m *Machine) SetTeam(team database.Team) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.team = team
+}
+
+// SwapTeams flips the player's side. It does nothing if no side is selected.
+// The scores stay as they are: they count rounds by side, not by player.
+func (m *Machine) SwapTeams() {
+	m.update(func() []Event {
+		switch m.team {
+		case database.TeamCT:
+			m.team = database.TeamT
+		case database.TeamT:
+			m.team = database.TeamCT
+		default:
+			return nil
+		}
+		return []Event{{Kind: TeamSelected}}
+	})
+}
+
+// update applies change under the lock, then notifies observers of the events
+// it returns, adding a PhaseChanged event if the phase moved. The state in
+// each event is the state after the whole change.
+func (m *Machine) update(change func() []Event) {
+	m.mu.Lock()
+	before := PhaseOf(m.ctWins+m.tWins, m.rules)
+	events := change()
+	state := m.stateLocked()
+	if len(events) > 0 && state.Phase != before {
+		events = append(events, Event{Kind: PhaseChanged})
+	}
+	observers := m.observers
+	m.mu.Unlock()
+
+	for _, e := range events {
+		e.State = state
+		for _, o := range observers {
+			o.Observe(e)
+		}
+	}
+}
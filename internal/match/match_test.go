@@ -0,0 +1,235 @@
+package match
+
+import (
+	"testing"
+
+	"csstatstracker/internal/database"
+)
+
+func TestPhaseOf(t *testing.T) {
+	tests := []struct {
+		played int
+		want   Phase
+	}{
+		{0, Phase{Half: 1}},
+		{11, Phase{Half: 1}},
+		{12, Phase{Half: 2}},
+		{23, Phase{Half: 2}},
+		{24, Phase{Overtime: 1, Half: 1}},
+		{26, Phase{Overtime: 1, Half: 1}},
+		{27, Phase{Overtime: 1, Half: 2}},
+		{30, Phase{Overtime: 2, Half: 1}},
+		{35, Phase{Overtime: 2, Half: 2}},
+	}
+	for _, tt := range tests {
+		if got := PhaseOf(tt.played, DefaultRules); got != tt.want {
+			t.Errorf("PhaseOf(%d) = %v, want %v", tt.played, got, tt.want)
+		}
+	}
+}
+
+func TestPhaseOfWithoutOvertime(t *testing.T) {
+	rules := Rules{RegulationRounds: 16}
+	if got := PhaseOf(40, rules); got != (Phase{Half: 2}) {
+		t.Errorf("PhaseOf(40) without overtime = %v, want Half 2", got)
+	}
+}
+
+// recorder collects the events a machine emits.
+type recorder []Event
+
+func (r *recorder) Observe(e Event) { *r = append(*r, e) }
+
+func (r recorder) kinds() []EventKind {
+	kinds := make([]EventKind, len(r))
+	for i, e := range r {
+		kinds[i] = e.Kind
+	}
+	return kinds
+}
+
+func TestDecided(t *testing.T) {
+	noOT := Rules{RegulationRounds: 24}
+	tests := []struct {
+		wins, losses int
+		rules        Rules
+		want         bool
+	}{
+		{12, 11, DefaultRules, false},
+		{13, 11, DefaultRules, true},
+		{5, 13, DefaultRules, true},
+		{12, 12, DefaultRules, false},
+		{15, 13, DefaultRules, false},
+		{16, 13, DefaultRules, true},
+		{15, 15, DefaultRules, false},
+		{19, 17, DefaultRules, true},
+		{12, 12, noOT, true},
+		{8, 6, ShortRules, true},
+		{7, 6, ShortRules, false},
+		{7, 7, ShortRules, true},
+	}
+	for _, tt := range tests {
+		if got := tt.rules.Decided(tt.wins, tt.losses); got != tt.want {
+			t.Errorf("%+v.Decided(%d, %d) = %v, want %v", tt.rules, tt.wins, tt.losses, got, tt.want)
+		}
+	}
+}
+
+func TestRulesFor(t *testing.T) {
+	tests := []struct {
+		mode database.Mode
+		want Rules
+	}{
+		{database.ModeUnknown, DefaultRules},
+		{database.ModePremier, DefaultRules},
+		{database.ModeCompetitive, DefaultRules},
+		{database.ModeWingman, ShortRules},
+		{database.ModeCasual, ShortRules},
+	}
+	for _, tt := range tests {
+		if got := RulesFor(tt.mode); got != tt.want {
+			t.Errorf("RulesFor(%q) = %+v, want %+v", tt.mode, got, tt.want)
+		}
+	}
+
+	m := New(DefaultRules)
+	m.SetRules(RulesFor(database.ModeWingman))
+	if got := m.Rules(); got != ShortRules {
+		t.Errorf("Rules() after SetRules = %+v, want %+v", got, ShortRules)
+	}
+	for range 7 {
+		m.Win(database.TeamCT)
+	}
+	if got := m.State().Phase; got != (Phase{Half: 2}) {
+		t.Errorf("phase after 7 rounds of Wingman = %v, want Half 2", got)
+	}
+}
+
+func TestMachine(t *testing.T) {
+	tests := []struct {
+		name       string
+		run        func(m *Machine)
+		want       State
+		wantEvents []EventKind
+	}{
+		{
+			name:       "wins",
+			run:        func(m *Machine) { m.Win(database.TeamCT); m.Win(database.TeamCT); m.Win(database.TeamT) },
+			want:       State{CTWins: 2, TWins: 1, Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{RoundWon, RoundWon, RoundWon},
+		},
+		{
+			name:       "undo",
+			run:        func(m *Machine) { m.Win(database.TeamT); m.Undo(database.TeamT) },
+			want:       State{Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{RoundWon, RoundUndone},
+		},
+		{
+			name:       "undo at zero does nothing",
+			run:        func(m *Machine) { m.Win(database.TeamCT); m.Undo(database.TeamT) },
+			want:       State{CTWins: 1, Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{RoundWon},
+		},
+		{
+			name:       "win for no side does nothing",
+			run:        func(m *Machine) { m.Win(database.TeamNone) },
+			want:       State{Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{},
+		},
+		{
+			name:       "select and swap",
+			run:        func(m *Machine) { m.SelectTeam(database.TeamCT); m.SwapTeams() },
+			want:       State{Team: database.TeamT, Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{TeamSelected, TeamSelected},
+		},
+		{
+			name:       "swap without a side does nothing",
+			run:        func(m *Machine) { m.SwapTeams() },
+			want:       State{Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{},
+		},
+		{
+			name:       "set team is silent",
+			run:        func(m *Machine) { m.SetTeam(database.TeamT) },
+			want:       State{Team: database.TeamT, Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{},
+		},
+		{
+			name: "reset keeps the side",
+			run: func(m *Machine) {
+				m.SelectTeam(database.TeamT)
+				m.Win(database.TeamCT)
+				m.Win(database.TeamT)
+				m.Reset()
+			},
+			want:       State{Team: database.TeamT, Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{TeamSelected, RoundWon, RoundWon, Reset},
+		},
+		{
+			name: "halftime",
+			run: func(m *Machine) {
+				for range 12 {
+					m.Win(database.TeamT)
+				}
+			},
+			want: State{TWins: 12, Phase: Phase{Half: 2}},
+			wantEvents: []EventKind{
+				RoundWon, RoundWon, RoundWon, RoundWon, RoundWon, RoundWon,
+				RoundWon, RoundWon, RoundWon, RoundWon, RoundWon, RoundWon, PhaseChanged,
+			},
+		},
+		{
+			name: "undo back into the first half",
+			run: func(m *Machine) {
+				for range 12 {
+					m.Win(database.TeamCT)
+				}
+				m.Undo(database.TeamCT)
+			},
+			want: State{CTWins: 11, Phase: Phase{Half: 1}},
+			wantEvents: []EventKind{
+				RoundWon, RoundWon, RoundWon, RoundWon, RoundWon, RoundWon,
+				RoundWon, RoundWon, RoundWon, RoundWon, RoundWon, RoundWon, PhaseChanged,
+				RoundUndone, PhaseChanged,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(DefaultRules)
+			var events recorder
+			m.Subscribe(&events)
+			tt.run(m)
+
+			if got := m.State(); got != tt.want {
+				t.Errorf("State() = %+v, want %+v", got, tt.want)
+			}
+			got := events.kinds()
+			if len(got) != len(tt.wantEvents) {
+				t.Fatalf("events = %v, want %v", got, tt.wantEvents)
+			}
+			for i := range got {
+				if got[i] != tt.wantEvents[i] {
+					t.Fatalf("events = %v, want %v", got, tt.wantEvents)
+				}
+			}
+		})
+	}
+}
+
+func TestEventCarriesStateAfterChange(t *testing.T) {
+	m := New(DefaultRules)
+	var events recorder
+	m.Subscribe(&events)
+	m.SelectTeam(database.TeamCT)
+	m.Win(database.TeamCT)
+
+	last := events[len(events)-1]
+	if last.Side != database.TeamCT {
+		t.Errorf("Side = %q, want CT", last.Side)
+	}
+	want := State{CTWins: 1, Team: database.TeamCT, Phase: Phase{Half: 1}}
+	if last.State != want {
+		t.Errorf("State = %+v, want %+v", last.State, want)
+	}
+}
@@ -0,0 +1,87 @@
+// Package options resolves per-run startup overrides from command-line flags
+// and CSST_* environment variables, for portable installs and debugging.
+package options
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/toast"
+)
+
+// Options are the startup settings that can be overridden for a single run.
+// None of them are persisted to the config file.
+type Options struct {
+	ConfigPath string // config file to load and save
+	DBPath     string // SQLite database file
+	NoSound    bool   // mute sound effects regardless of config
+	NoHotkeys  bool   // don't install the global keyboard hook
+	Headless   bool   // start hidden in the system tray, without the main window
+	ReadOnly   bool   // view DBPath without recording or editing, e.g. on a network share
+	TempDB     bool   // use a fresh database that's deleted on exit instead of DBPath
+	URL        string // csstatstracker: URL from a notification button, if launched by one
+}
+
+// Resolve builds Options from defaults, then CSST_* environment variables,
+// then command-line flags, with later sources taking precedence. getenv is
+// normally os.Getenv. Usage and errors are written to output.
+func Resolve(args []string, getenv func(string) string, output io.Writer) (*Options, error) {
+	opts := &Options{
+		ConfigPath: config.DefaultConfigFile,
+		DBPath:     database.DefaultDBFile,
+	}
+
+	if v := getenv("CSST_CONFIG"); v != "" {
+		opts.ConfigPath = v
+	}
+	if v := getenv("CSST_DB"); v != "" {
+		opts.DBPath = v
+	}
+	for name, target := range map[string]*bool{
+		"CSST_NO_SOUND":   &opts.NoSound,
+		"CSST_NO_HOTKEYS": &opts.NoHotkeys,
+		"CSST_HEADLESS":   &opts.Headless,
+		"CSST_READ_ONLY":  &opts.ReadOnly,
+		"CSST_TEMP_DB":    &opts.TempDB,
+	} {
+		v := getenv(name)
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s=%q: expected true or false", name, v)
+		}
+		*target = b
+	}
+
+	fs := flag.NewFlagSet("csstatstracker", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&opts.ConfigPath, "config", opts.ConfigPath, "config file `path` (env CSST_CONFIG)")
+	fs.StringVar(&opts.DBPath, "db", opts.DBPath, "database file `path` (env CSST_DB)")
+	fs.BoolVar(&opts.NoSound, "no-sound", opts.NoSound, "mute sound effects (env CSST_NO_SOUND)")
+	fs.BoolVar(&opts.NoHotkeys, "no-hotkeys", opts.NoHotkeys, "disable global hotkeys (env CSST_NO_HOTKEYS)")
+	fs.BoolVar(&opts.Headless, "headless", opts.Headless, "start hidden in the system tray (env CSST_HEADLESS)")
+	fs.BoolVar(&opts.ReadOnly, "read-only", opts.ReadOnly, "view the database without recording or editing (env CSST_READ_ONLY)")
+	fs.BoolVar(&opts.TempDB, "temp-db", opts.TempDB, "use an empty database that's deleted on exit, for demos and tests (env CSST_TEMP_DB)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if opts.TempDB && opts.ReadOnly {
+		return nil, fmt.Errorf("-temp-db and -read-only can't be used together")
+	}
+	// Notification buttons launch the app with their URL as the only
+	// argument.
+	if fs.NArg() == 1 && strings.HasPrefix(fs.Arg(0), toast.Scheme+":") {
+		opts.URL = fs.Arg(0)
+	} else if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,297 @@
+// Package plugins runs third-party integrations as separate processes, so
+// they can be shipped without forking the app.
+//
+// A plugin is an executable in the plugins directory next to the config
+// file. It only runs once the user enables it in Settings. The app speaks a
+// line-based protocol over the plugin's standard streams:
+//
+//   - The app starts the plugin with CSST_PLUGIN_PROTOCOL set to the
+//     protocol version, currently 1.
+//   - Each tracker event is written to the plugin's stdin as one line of
+//     JSON, a hooks.Data object, e.g.
+//     {"event":"round_recorded","winner":"CT","ct_score":8,...}.
+//   - Anything the plugin writes to stderr is logged. Stdout is ignored.
+//   - Closing stdin asks the plugin to exit; it's killed if it hasn't
+//     within a few seconds.
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"csstatstracker/internal/hooks"
+)
+
+// ProtocolVersion is the protocol version passed in CSST_PLUGIN_PROTOCOL.
+const ProtocolVersion = 1
+
+// DirName is the name of the plugins directory, next to the config file.
+const DirName = "plugins"
+
+// DirPath returns the plugins directory for the given config file.
+func DirPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), DirName)
+}
+
+// stopTimeout is how long a plugin has to exit after its stdin is closed.
+const stopTimeout = 3 * time.Second
+
+// queueSize is how many events are buffered for a slow plugin before newer
+// ones are dropped.
+const queueSize = 64
+
+// Info describes a plugin found in the plugins directory.
+type Info struct {
+	Name    string // file name, which is how the config refers to it
+	Path    string
+	Running bool
+	Err     error // why the plugin last stopped or failed to start, if it did
+}
+
+// Manager discovers, starts and stops plugins and forwards events to them.
+type Manager struct {
+	dir string
+
+	mu      sync.Mutex
+	running map[string]*process
+	errs    map[string]error
+}
+
+// NewManager creates a Manager for the plugins in dir. Nothing runs until
+// Sync.
+func NewManager(dir string) *Manager {
+	return &Manager{
+		dir:     dir,
+		running: make(map[string]*process),
+		errs:    make(map[string]error),
+	}
+}
+
+// Dir returns the plugins directory.
+func (m *Manager) Dir() string { return m.dir }
+
+// List returns the plugins in the directory, sorted by name. A missing
+// directory has none.
+func (m *Manager) List() ([]Info, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list plugins: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var infos []Info
+	for _, e := range entries {
+		if !isExecutable(e) {
+			continue
+		}
+		_, running := m.running[e.Name()]
+		infos = append(infos, Info{
+			Name:    e.Name(),
+			Path:    filepath.Join(m.dir, e.Name()),
+			Running: running,
+			Err:     m.errs[e.Name()],
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos, nil
+}
+
+// isExecutable reports whether a directory entry looks like a plugin.
+func isExecutable(e os.DirEntry) bool {
+	if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(e.Name()), ".exe")
+	}
+	info, err := e.Info()
+	return err == nil && info.Mode()&0111 != 0
+}
+
+// Sync starts the enabled plugins that aren't running and stops the running
+// ones that aren't enabled any more.
+func (m *Manager) Sync(enabled []string) {
+	infos, err := m.List()
+	if err != nil {
+		log.Printf("plugins: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name, p := range m.running {
+		if !slices.Contains(enabled, name) {
+			delete(m.running, name)
+			go p.stop()
+		}
+	}
+	for _, info := range infos {
+		if !slices.Contains(enabled, info.Name) {
+			continue
+		}
+		if _, ok := m.running[info.Name]; ok {
+			continue
+		}
+		name := info.Name
+		p, err := start(info.Path, func(p *process, err error) { m.exited(name, p, err) })
+		if err != nil {
+			m.errs[info.Name] = err
+			log.Printf("plugin %s: %v", info.Name, err)
+			continue
+		}
+		delete(m.errs, info.Name)
+		m.running[info.Name] = p
+	}
+}
+
+// exited records that plugin p stopped by itself.
+func (m *Manager) exited(name string, p *process, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.running[name] == p {
+		delete(m.running, name)
+	}
+	if err == nil {
+		err = errors.New("exited")
+	}
+	m.errs[name] = err
+	log.Printf("plugin %s exited: %v", name, err)
+}
+
+// Send forwards an event to every running plugin. It never blocks; a plugin
+// that has fallen queueSize events behind misses newer ones.
+func (m *Manager) Send(data hooks.Data) {
+	line, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("plugins: failed to encode event: %v", err)
+		return
+	}
+	line = append(line, '\n')
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, p := range m.running {
+		select {
+		case p.events <- line:
+		default:
+		}
+	}
+}
+
+// Stop stops every plugin and waits for them to exit.
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	running := m.running
+	m.running = make(map[string]*process)
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, p := range running {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.stop()
+		}()
+	}
+	wg.Wait()
+}
+
+// process is a running plugin.
+type process struct {
+	cmd      *exec.Cmd
+	events   chan []byte
+	done     chan struct{} // closed once the process has exited
+	stopping atomic.Bool
+	once     sync.Once
+}
+
+// start runs the plugin at path. onExit is called if it exits before stop.
+func start(path string, onExit func(*process, error)) (*process, error) {
+	cmd := exec.Command(path)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("CSST_PLUGIN_PROTOCOL=%d", ProtocolVersion))
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start plugin: %w", err)
+	}
+	cmd.Stderr = &lineLogger{prefix: "plugin " + filepath.Base(path) + ": "}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	p := &process{cmd: cmd, events: make(chan []byte, queueSize), done: make(chan struct{})}
+	go p.write(stdin)
+	go func() {
+		err := cmd.Wait()
+		close(p.done)
+		if !p.stopping.Load() {
+			onExit(p, err)
+		}
+	}()
+	return p, nil
+}
+
+// write copies events to the plugin's stdin until the queue is closed or the
+// plugin exits.
+func (p *process) write(stdin io.WriteCloser) {
+	defer stdin.Close()
+	for {
+		select {
+		case line, ok := <-p.events:
+			if !ok {
+				return
+			}
+			if _, err := stdin.Write(line); err != nil {
+				return
+			}
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// stop closes the plugin's stdin and kills it if it doesn't exit in time.
+func (p *process) stop() {
+	p.stopping.Store(true)
+	p.once.Do(func() { close(p.events) })
+	select {
+	case <-p.done:
+	case <-time.After(stopTimeout):
+		_ = p.cmd.Process.Kill()
+		<-p.done
+	}
+}
+
+// lineLogger logs each complete line written to it.
+type lineLogger struct {
+	prefix string
+	buf    []byte
+}
+
+func (l *lineLogger) Write(b []byte) (int, error) {
+	l.buf = append(l.buf, b...)
+	for {
+		i := bytes.IndexByte(l.buf, '\n')
+		if i < 0 {
+			break
+		}
+		log.Print(l.prefix + string(bytes.TrimRight(l.buf[:i], "\r")))
+		l.buf = l.buf[i+1:]
+	}
+	return len(b), nil
+}
@@ -0,0 +1,105 @@
+//go:build !windows
+
+package plugins
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/hooks"
+)
+
+// writePlugin writes a shell script plugin to dir.
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitFor polls cond until it holds or a second has passed.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestList(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "b-plugin", "")
+	writePlugin(t, dir, "a-plugin", "")
+	if err := os.WriteFile(filepath.Join(dir, "README.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "data"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err := NewManager(dir).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name)
+	}
+	if got := strings.Join(names, ","); got != "a-plugin,b-plugin" {
+		t.Errorf("List() = %s, want a-plugin,b-plugin", got)
+	}
+
+	infos, err = NewManager(filepath.Join(dir, "missing")).List()
+	if err != nil || len(infos) != 0 {
+		t.Errorf("List() of a missing directory = %v, %v, want none", infos, err)
+	}
+}
+
+func TestManager(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("needs sh")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "events.jsonl")
+	// Copies events to a file until stdin is closed.
+	writePlugin(t, dir, "recorder", `test "$CSST_PLUGIN_PROTOCOL" = 1 || exit 1
+cat >> '`+out+`'
+`)
+	writePlugin(t, dir, "crasher", "exit 3\n")
+
+	m := NewManager(dir)
+	m.Sync([]string{"recorder", "crasher"})
+	m.Send(hooks.Data{Event: hooks.RoundRecorded, Winner: "CT", CTScore: 1})
+
+	waitFor(t, "the event", func() bool {
+		b, _ := os.ReadFile(out)
+		return strings.Contains(string(b), `"event":"round_recorded","winner":"CT"`)
+	})
+	waitFor(t, "the crash", func() bool {
+		infos, _ := m.List()
+		return len(infos) == 2 && infos[0].Name == "crasher" && !infos[0].Running && infos[0].Err != nil
+	})
+
+	m.Sync(nil)
+	waitFor(t, "the plugin to stop", func() bool {
+		infos, _ := m.List()
+		return !infos[1].Running
+	})
+	m.Send(hooks.Data{Event: hooks.RoundRecorded})
+	m.Stop()
+
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(b), "\n"); n != 1 {
+		t.Errorf("recorder got %d events, want 1", n)
+	}
+}
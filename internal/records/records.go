@@ -0,0 +1,122 @@
+// Package records finds personal bests in the round history, such as the
+// best week ever or the longest session, so charts can call them out.
+package records
+
+import (
+	"slices"
+	"time"
+
+	"csstatstracker/internal/database"
+)
+
+// SessionGap is the longest pause between two rounds that still counts as
+// one session.
+const SessionGap = 30 * time.Minute
+
+// Week is an ISO week's results.
+type Week struct {
+	Start   time.Time // Monday 00:00 UTC
+	Wins    int
+	Losses  int
+	WinRate float64 // percent of decided rounds
+}
+
+// Session is a run of rounds with no gap longer than SessionGap.
+type Session struct {
+	Start  time.Time
+	End    time.Time
+	Rounds int
+	Wins   int
+	Losses int
+}
+
+// Duration is the time from the session's first round to its last.
+func (s Session) Duration() time.Duration { return s.End.Sub(s.Start) }
+
+// Records holds the personal bests. A nil field means there's no qualifying
+// data yet.
+type Records struct {
+	BestWeek        *Week    // most net wins (wins minus losses)
+	BestWinRateWeek *Week    // highest win rate among weeks with enough rounds
+	LongestSession  *Session // longest by duration
+}
+
+// Compute finds the personal bests in rounds, which may be in any order.
+// Weeks with fewer than minSample decided rounds don't compete for the best
+// win rate so a 1–0 week can't hold the record.
+func Compute(rounds []database.Round, minSample int) Records {
+	var recs Records
+	if len(rounds) == 0 {
+		return recs
+	}
+
+	weeks := make(map[time.Time]*Week)
+	for _, r := range rounds {
+		start := weekStart(r.CreatedAt)
+		w, ok := weeks[start]
+		if !ok {
+			w = &Week{Start: start}
+			weeks[start] = w
+		}
+		switch r.Result() {
+		case database.ResultWin:
+			w.Wins++
+		case database.ResultLoss:
+			w.Losses++
+		}
+	}
+	for _, w := range weeks {
+		decided := w.Wins + w.Losses
+		if decided == 0 {
+			continue
+		}
+		w.WinRate = float64(w.Wins) / float64(decided) * 100
+		if recs.BestWeek == nil || w.Wins-w.Losses > recs.BestWeek.Wins-recs.BestWeek.Losses ||
+			(w.Wins-w.Losses == recs.BestWeek.Wins-recs.BestWeek.Losses && w.Start.Before(recs.BestWeek.Start)) {
+			recs.BestWeek = w
+		}
+		if decided >= minSample && (recs.BestWinRateWeek == nil || w.WinRate > recs.BestWinRateWeek.WinRate ||
+			(w.WinRate == recs.BestWinRateWeek.WinRate && w.Start.Before(recs.BestWinRateWeek.Start))) {
+			recs.BestWinRateWeek = w
+		}
+	}
+
+	for _, s := range Sessions(rounds) {
+		if recs.LongestSession == nil || s.Duration() > recs.LongestSession.Duration() {
+			recs.LongestSession = &s
+		}
+	}
+	return recs
+}
+
+// Sessions groups rounds into sessions, oldest first.
+func Sessions(rounds []database.Round) []Session {
+	sorted := slices.Clone(rounds)
+	slices.SortFunc(sorted, func(a, b database.Round) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	var sessions []Session
+	for _, r := range sorted {
+		t := r.CreatedAt
+		if n := len(sessions); n == 0 || t.Sub(sessions[n-1].End) > SessionGap {
+			sessions = append(sessions, Session{Start: t})
+		}
+		s := &sessions[len(sessions)-1]
+		s.End = t
+		s.Rounds++
+		switch r.Result() {
+		case database.ResultWin:
+			s.Wins++
+		case database.ResultLoss:
+			s.Losses++
+		}
+	}
+	return sessions
+}
+
+// weekStart returns midnight UTC on the Monday of t's ISO week. Weeks are cut
+// in UTC to match the date buckets the stats queries produce.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
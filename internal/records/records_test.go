@@ -0,0 +1,177 @@
+package records_test
+
+import (
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/records"
+)
+
+// sunday is the last second of the ISO week starting Monday 23 February 2026.
+var sunday = time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+
+// round returns a round played on CT at t: a win, loss or draw for 'W', 'L'
+// or 'D'.
+func round(t time.Time, result byte) database.Round {
+	r := database.Round{Team: database.TeamCT, Winner: database.TeamCT, CreatedAt: t}
+	switch result {
+	case 'L':
+		r.Winner = database.TeamT
+	case 'D':
+		r.Team = ""
+	}
+	return r
+}
+
+// rounds returns the given results, a minute apart from start.
+func rounds(start time.Time, results string) []database.Round {
+	var out []database.Round
+	for i := range len(results) {
+		out = append(out, round(start.Add(time.Duration(i)*time.Minute), results[i]))
+	}
+	return out
+}
+
+func TestComputeWeeks(t *testing.T) {
+	monday := sunday.Add(time.Second)
+	nextMonday := monday.AddDate(0, 0, 7)
+	istanbul := time.FixedZone("UTC+3", 3*60*60)
+
+	tests := []struct {
+		name      string
+		rounds    []database.Round
+		minSample int
+		bestWeek  time.Time // zero for none
+		bestRate  time.Time // zero for none
+	}{
+		{
+			name:      "no rounds",
+			minSample: 1,
+		},
+		{
+			name: "sunday and monday are different weeks",
+			rounds: []database.Round{
+				round(sunday, 'W'), round(sunday.Add(-time.Minute), 'W'),
+				round(monday, 'W'), round(monday.Add(time.Minute), 'L'),
+			},
+			minSample: 1,
+			bestWeek:  monday.AddDate(0, 0, -7),
+			bestRate:  monday.AddDate(0, 0, -7),
+		},
+		{
+			name: "weeks are cut in UTC",
+			// Monday 01:00 in Istanbul is still Sunday in UTC.
+			rounds: append(rounds(time.Date(2026, 3, 2, 1, 0, 0, 0, istanbul), "WW"),
+				rounds(monday.Add(2*time.Hour), "W")...),
+			minSample: 1,
+			bestWeek:  monday.AddDate(0, 0, -7),
+			bestRate:  monday.AddDate(0, 0, -7),
+		},
+		{
+			name:      "ties go to the earlier week",
+			rounds:    append(rounds(monday, "WWL"), rounds(nextMonday, "WL")...),
+			minSample: 1,
+			bestWeek:  monday,
+			bestRate:  monday,
+		},
+		{
+			name: "draws don't count",
+			// 4 rounds, but 1 decided, is too few for the win rate.
+			rounds:    append(rounds(monday, "DDDW"), rounds(nextMonday, "WWWL")...),
+			minSample: 2,
+			bestWeek:  nextMonday,
+			bestRate:  nextMonday,
+		},
+		{
+			name: "a small week can't hold the win rate",
+			// 1–0 is the better rate but too few rounds; 6–4 has more net wins.
+			rounds:    append(rounds(monday, "W"), rounds(nextMonday, "WWWWWWLLLL")...),
+			minSample: 5,
+			bestWeek:  nextMonday,
+			bestRate:  nextMonday,
+		},
+		{
+			name:      "small weeks compete under a small sample",
+			rounds:    append(rounds(monday, "W"), rounds(nextMonday, "WWWWWWLLLL")...),
+			minSample: 1,
+			bestWeek:  nextMonday,
+			bestRate:  monday,
+		},
+		{
+			name:      "no week has enough rounds",
+			rounds:    append(rounds(monday, "WL"), rounds(nextMonday, "WWL")...),
+			minSample: 10,
+			bestWeek:  nextMonday,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs := records.Compute(tt.rounds, tt.minSample)
+			check := func(what string, w *records.Week, want time.Time) {
+				t.Helper()
+				switch {
+				case want.IsZero() && w != nil:
+					t.Errorf("%s starts %v, want none", what, w.Start)
+				case !want.IsZero() && w == nil:
+					t.Errorf("no %s, want the week starting %v", what, want)
+				case w != nil && !w.Start.Equal(want):
+					t.Errorf("%s starts %v, want %v", what, w.Start, want)
+				}
+			}
+			check("best week", recs.BestWeek, tt.bestWeek)
+			check("best win rate week", recs.BestWinRateWeek, tt.bestRate)
+		})
+	}
+}
+
+func TestSessions(t *testing.T) {
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		gaps   []time.Duration // between consecutive rounds
+		rounds []int           // per session
+	}{
+		{"one round", nil, []int{1}},
+		{"a gap of exactly the limit", []time.Duration{records.SessionGap}, []int{2}},
+		{"a longer gap", []time.Duration{records.SessionGap + time.Second}, []int{1, 1}},
+		{"several", []time.Duration{time.Minute, time.Hour, time.Minute, time.Minute, 2 * time.Hour}, []int{2, 3, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at := start
+			played := []database.Round{round(at, 'W')}
+			for _, gap := range tt.gaps {
+				at = at.Add(gap)
+				played = append(played, round(at, 'L'))
+			}
+			// Newest first, as the database returns them.
+			for i, j := 0, len(played)-1; i < j; i, j = i+1, j-1 {
+				played[i], played[j] = played[j], played[i]
+			}
+
+			sessions := records.Sessions(played)
+			if len(sessions) != len(tt.rounds) {
+				t.Fatalf("got %d sessions, want %d", len(sessions), len(tt.rounds))
+			}
+			for i, s := range sessions {
+				if s.Rounds != tt.rounds[i] || s.Wins+s.Losses != s.Rounds {
+					t.Errorf("session %d has %d rounds (%d–%d), want %d", i, s.Rounds, s.Wins, s.Losses, tt.rounds[i])
+				}
+			}
+			if !sessions[0].Start.Equal(start) || !sessions[len(sessions)-1].End.Equal(at) {
+				t.Errorf("sessions run from %v to %v, want %v to %v",
+					sessions[0].Start, sessions[len(sessions)-1].End, start, at)
+			}
+		})
+	}
+}
+
+func TestLongestSession(t *testing.T) {
+	start := time.Date(2026, 3, 1, 18, 0, 0, 0, time.UTC)
+	played := append(rounds(start, "WWW"), rounds(start.Add(2*time.Hour), "WLWLW")...)
+	recs := records.Compute(played, 1)
+	if l := recs.LongestSession; l == nil || l.Rounds != 5 || l.Duration() != 4*time.Minute {
+		t.Errorf("longest session = %+v, want the 5 rounds over 4 minutes", l)
+	}
+}
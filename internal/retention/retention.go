@@ -0,0 +1,135 @@
+// Package retention prunes round-level detail older than the configured
+// retention period. Pruned rounds are first written to a CSV archive, and
+// their per-day counts are kept in the database so totals don't change.
+package retention
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"csstatstracker/internal/database"
+)
+
+// DirName is the name of the directory archives are written to, next to the
+// config file.
+const DirName = "archives"
+
+// DirPath returns the archives directory for the given config file.
+func DirPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), DirName)
+}
+
+// Cutoff returns the start of the UTC day keepMonths months before now;
+// rounds recorded before it are pruned.
+func Cutoff(now time.Time, keepMonths int) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month()-time.Month(keepMonths), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Preview describes the rounds a prune would remove.
+type Preview struct {
+	Cutoff time.Time
+	Rounds []database.Round // oldest first
+}
+
+// NewPreview finds the rounds recorded before cutoff.
+func NewPreview(ctx context.Context, db *sql.DB, cutoff time.Time) (*Preview, error) {
+	rounds, err := database.GetRoundsBefore(ctx, db, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	return &Preview{Cutoff: cutoff, Rounds: rounds}, nil
+}
+
+// ArchiveName is the file name the preview's rounds are archived under.
+func (p *Preview) ArchiveName() string {
+	return "rounds-before-" + p.Cutoff.Format("2006-01-02") + ".csv"
+}
+
+// WriteCSV writes the preview's rounds as CSV, one round per line under a
+// header row.
+func (p *Preview) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"id", "uuid", "created_at", "winner", "team", "party_size", "account", "map", "mode", "ct_name", "t_name"})
+	for _, r := range p.Rounds {
+		_ = cw.Write([]string{
+			strconv.Itoa(r.ID),
+			r.UUID,
+			r.CreatedAt.UTC().Format(time.RFC3339),
+			string(r.Winner),
+			string(r.Team),
+			r.PartySize.String(),
+			r.Account,
+			r.Map,
+			string(r.Mode),
+			r.CTName,
+			r.TName,
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// Prune deletes the preview's rounds, keeping their per-day counts. Call it
+// once they've been archived. Returns how many rounds were deleted.
+func (p *Preview) Prune(ctx context.Context, db *sql.DB) (int, error) {
+	return database.PruneRoundsBefore(ctx, db, p.Cutoff)
+}
+
+// Run archives the rounds recorded before cutoff into dir and then prunes
+// them. It returns the archive's path, or "" if there was nothing to prune.
+func Run(ctx context.Context, db *sql.DB, dir string, cutoff time.Time) (string, error) {
+	p, err := NewPreview(ctx, db, cutoff)
+	if err != nil || len(p.Rounds) == 0 {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	path, err := writeArchive(dir, p)
+	if err != nil {
+		return "", err
+	}
+	if _, err := p.Prune(ctx, db); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// writeArchive writes p to a new file in dir, numbering the name if an
+// archive for the same cutoff already exists.
+func writeArchive(dir string, p *Preview) (string, error) {
+	base := strings.TrimSuffix(p.ArchiveName(), ".csv")
+	for i := 1; ; i++ {
+		path := filepath.Join(dir, base+".csv")
+		if i > 1 {
+			path = filepath.Join(dir, fmt.Sprintf("%s-%d.csv", base, i))
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create archive: %w", err)
+		}
+		if err := p.WriteCSV(f); err != nil {
+			_ = f.Close()
+			return "", err
+		}
+		if err := f.Close(); err != nil {
+			return "", fmt.Errorf("failed to write archive: %w", err)
+		}
+		return path, nil
+	}
+}
@@ -0,0 +1,67 @@
+package retention
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestCutoff(t *testing.T) {
+	now := time.Date(2025, 3, 14, 18, 30, 0, 0, time.UTC)
+	if got, want := Cutoff(now, 12), time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Cutoff = %s, want %s", got, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	dir := filepath.Join(t.TempDir(), DirName)
+	cutoff := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	dbtest.Insert(t, db, slices.Concat(
+		dbtest.Series(cutoff.AddDate(0, -2, 0), time.Minute, "WLw"),
+		dbtest.Series(cutoff.Add(time.Hour), time.Minute, "WW"),
+	)...)
+
+	path, err := Run(ctx, db, dir, cutoff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "rounds-before-2024-03-14.csv" {
+		t.Errorf("archive = %s", path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 4 || records[1][2] != "2024-01-14T00:00:00Z" || records[3][4] != "T" || len(records[1][1]) != 36 {
+		t.Errorf("archive rows = %v", records)
+	}
+	if n, _ := database.CountRounds(ctx, db); n != 2 {
+		t.Errorf("%d rounds left, want 2", n)
+	}
+
+	// Nothing left to prune: no archive.
+	if path, err := Run(ctx, db, dir, cutoff); err != nil || path != "" {
+		t.Errorf("second Run = %q, %v, want nothing", path, err)
+	}
+
+	// A later prune with the same cutoff gets its own archive.
+	dbtest.Insert(t, db, dbtest.Series(cutoff.AddDate(0, -1, 0), time.Minute, "L")...)
+	path, err = Run(ctx, db, dir, cutoff)
+	if err != nil || filepath.Base(path) != "rounds-before-2024-03-14-2.csv" {
+		t.Errorf("third Run = %q, %v", path, err)
+	}
+}
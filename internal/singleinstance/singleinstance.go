@@ -2,10 +2,19 @@
 package singleinstance
 
 import (
+	"bufio"
 	"fmt"
 	"net"
+	"time"
 )
 
+// messageTimeout bounds how long a message from another instance can take
+// to arrive.
+const messageTimeout = 2 * time.Second
+
+// maxMessageSize caps a single message.
+const maxMessageSize = 4096
+
 // Lock holds the resource that enforces single-instance execution.
 type Lock struct {
 	listener net.Listener
@@ -23,6 +32,43 @@ func Acquire(port int) (*Lock, error) {
 	return &Lock{listener: l}, nil
 }
 
+// Serve hands each message sent by another instance with Send to handle,
+// from a background goroutine, until the lock is released. Any local process
+// can connect, so handle should only act on messages that are harmless to
+// receive from anywhere, or that prove where they came from.
+func (l *Lock) Serve(handle func(msg string)) {
+	ln := l.listener
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return // released
+			}
+			_ = conn.SetDeadline(time.Now().Add(messageTimeout))
+			sc := bufio.NewScanner(conn)
+			sc.Buffer(make([]byte, 0, 256), maxMessageSize)
+			if sc.Scan() {
+				handle(sc.Text())
+			}
+			_ = conn.Close()
+		}
+	}()
+}
+
+// Send passes msg, a single line, to the instance holding the lock on port.
+func Send(port int, msg string) error {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), messageTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to reach the running instance: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(messageTimeout))
+	if _, err := fmt.Fprintln(conn, msg); err != nil {
+		return fmt.Errorf("failed to reach the running instance: %w", err)
+	}
+	return nil
+}
+
 // Release frees the lock. Safe to call multiple times.
 func (l *Lock) Release() {
 	if l == nil || l.listener == nil {
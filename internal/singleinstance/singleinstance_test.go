@@ -0,0 +1,48 @@
+package singleinstance
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSend(t *testing.T) {
+	// Grab a free port, then take the lock on it.
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := probe.Addr().(*net.TCPAddr).Port
+	_ = probe.Close()
+
+	lock, err := Acquire(port)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+	if _, err := Acquire(port); !errors.Is(err, ErrAlreadyRunning) {
+		t.Fatalf("second Acquire = %v, want ErrAlreadyRunning", err)
+	}
+
+	got := make(chan string, 2)
+	lock.Serve(func(msg string) { got <- msg })
+	for _, msg := range []string{"csstatstracker:show", "csstatstracker:round?winner=CT"} {
+		if err := Send(port, msg); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case m := <-got:
+			if m != msg {
+				t.Errorf("received %q, want %q", m, msg)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("%q never arrived", msg)
+		}
+	}
+
+	lock.Release()
+	if err := Send(port, "late"); err == nil {
+		t.Error("Send after Release succeeded")
+	}
+}
@@ -0,0 +1,155 @@
+// Package snapshot exports a player's stats as a signed JSON file that can be
+// shared with friends and compared side by side, with no central server.
+//
+// A snapshot is signed with an ed25519 key generated on first export and kept
+// next to the config file. The signature shows a snapshot hasn't been edited
+// since it was exported, and the key fingerprint lets a friend recognise
+// later snapshots as coming from the same person.
+package snapshot
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"csstatstracker/internal/database"
+)
+
+// CurrentVersion is the snapshot format version written by this build.
+const CurrentVersion = 1
+
+// KeyFile is the name of the signing key file, stored in the config directory.
+const KeyFile = "snapshot.key"
+
+// Snapshot is the shareable summary of one player's stats over a period.
+type Snapshot struct {
+	Version     int                   `json:"version"`
+	Name        string                `json:"name"`
+	CreatedAt   time.Time             `json:"created_at"`
+	Period      string                `json:"period"`
+	Stats       database.Stats        `json:"stats"`
+	Parties     []database.PartyStats `json:"parties,omitempty"`
+	PlayMinutes int                   `json:"play_minutes"`
+}
+
+// envelope is the on-disk form: the snapshot as signed, plus the signature
+// and the signer's public key.
+type envelope struct {
+	Snapshot  json.RawMessage `json:"snapshot"`
+	PublicKey string          `json:"public_key"`
+	Signature string          `json:"signature"`
+}
+
+// Verified is a snapshot whose signature has been checked.
+type Verified struct {
+	Snapshot
+	Fingerprint string // short hex digest of the signer's public key
+}
+
+// KeyPath returns where the signing key lives for the given config file.
+func KeyPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), KeyFile)
+}
+
+// LoadOrCreateKey reads the signing key at path, generating and saving a new
+// one if it doesn't exist yet.
+func LoadOrCreateKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		seed, err := base64.StdEncoding.DecodeString(string(data))
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("invalid snapshot key in %s", path)
+		}
+		return ed25519.NewKeyFromSeed(seed), nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read snapshot key: %w", err)
+	}
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate snapshot key: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString(key.Seed())
+	if err := os.WriteFile(path, []byte(encoded), 0600); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot key: %w", err)
+	}
+	return key, nil
+}
+
+// Write signs s with key and writes it to w.
+func Write(s Snapshot, key ed25519.PrivateKey, w io.Writer) error {
+	s.Version = CurrentVersion
+	body, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	env := envelope{
+		Snapshot:  body,
+		PublicKey: base64.StdEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(key, body)),
+	}
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Read parses a snapshot from r and checks its signature.
+func Read(r io.Reader) (*Verified, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+
+	pub, err := base64.StdEncoding.DecodeString(env.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return nil, errors.New("snapshot has an invalid public key")
+	}
+	sig, err := base64.StdEncoding.DecodeString(env.Signature)
+	if err != nil {
+		return nil, errors.New("snapshot has an invalid signature")
+	}
+	// The snapshot was signed in compact form; the file is indented for
+	// readability, so compact it back before verifying.
+	var body bytes.Buffer
+	if err := json.Compact(&body, env.Snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if !ed25519.Verify(pub, body.Bytes(), sig) {
+		return nil, errors.New("snapshot signature does not match; the file was modified after export")
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(body.Bytes(), &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	if s.Version > CurrentVersion {
+		return nil, fmt.Errorf("snapshot version %d is newer than this app supports (%d)", s.Version, CurrentVersion)
+	}
+	return &Verified{Snapshot: s, Fingerprint: Fingerprint(pub)}, nil
+}
+
+// Fingerprint returns a short, human-comparable digest of a public key.
+func Fingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,101 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/snapshot"
+)
+
+func testSnapshot() snapshot.Snapshot {
+	return snapshot.Snapshot{
+		Name:        "me",
+		CreatedAt:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Period:      "Last 30 Days",
+		Stats:       database.Stats{TotalRounds: 10, Wins: 6, Losses: 4, WinRate: 60},
+		PlayMinutes: 95,
+	}
+}
+
+// write signs s with key and returns the written file's contents.
+func write(t *testing.T, s snapshot.Snapshot, key ed25519.PrivateKey) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := snapshot.Write(s, key, &buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), snapshot.KeyFile)
+	key, err := snapshot.LoadOrCreateKey(path)
+	if err != nil {
+		t.Fatalf("LoadOrCreateKey: %v", err)
+	}
+	// The key is kept, so later snapshots carry the same fingerprint.
+	again, err := snapshot.LoadOrCreateKey(path)
+	if err != nil || !key.Equal(again) {
+		t.Fatalf("LoadOrCreateKey a second time = %v; want the saved key", err)
+	}
+
+	want := testSnapshot()
+	got, err := snapshot.Read(bytes.NewReader(write(t, want, key)))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want.Version = snapshot.CurrentVersion
+	if !got.CreatedAt.Equal(want.CreatedAt) || got.Name != want.Name || got.Period != want.Period ||
+		got.Stats != want.Stats || got.PlayMinutes != want.PlayMinutes || got.Version != want.Version {
+		t.Errorf("Read = %+v, want %+v", got.Snapshot, want)
+	}
+	if fp := snapshot.Fingerprint(key.Public().(ed25519.PublicKey)); got.Fingerprint != fp {
+		t.Errorf("Fingerprint = %s, want %s", got.Fingerprint, fp)
+	}
+}
+
+func TestReadRejects(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(nil)
+	_, other, _ := ed25519.GenerateKey(nil)
+	signed := write(t, testSnapshot(), key)
+
+	tests := []struct {
+		name   string
+		modify func(env map[string]json.RawMessage)
+	}{
+		{"tampered payload", func(env map[string]json.RawMessage) {
+			env["snapshot"] = json.RawMessage(strings.Replace(string(env["snapshot"]), `"Wins": 6`, `"Wins": 9`, 1))
+		}},
+		{"wrong key", func(env map[string]json.RawMessage) {
+			pub := base64.StdEncoding.EncodeToString(other.Public().(ed25519.PublicKey))
+			env["public_key"], _ = json.Marshal(pub)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var env map[string]json.RawMessage
+			if err := json.Unmarshal(signed, &env); err != nil {
+				t.Fatal(err)
+			}
+			before := string(env["snapshot"]) + string(env["public_key"])
+			tt.modify(env)
+			if string(env["snapshot"])+string(env["public_key"]) == before {
+				t.Fatal("test didn't change the snapshot")
+			}
+			data, err := json.Marshal(env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := snapshot.Read(bytes.NewReader(data)); err == nil || !strings.Contains(err.Error(), "signature") {
+				t.Errorf("Read = %v, want a signature error", err)
+			}
+		})
+	}
+}
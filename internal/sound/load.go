@@ -0,0 +1,101 @@
+package sound
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/effects"
+	"github.com/gopxl/beep/v2/mp3"
+	"github.com/gopxl/beep/v2/vorbis"
+	"github.com/gopxl/beep/v2/wav"
+)
+
+// Extensions are the sound files the player can decode.
+var Extensions = []string{".wav", ".mp3", ".ogg"}
+
+// decode decodes data as WAV, Ogg Vorbis or MP3, by path's extension.
+// FLAC is rejected with a hint to convert it.
+func decode(path string, data []byte) (beep.StreamSeekCloser, beep.Format, error) {
+	r := io.NopCloser(bytes.NewReader(data))
+	var streamer beep.StreamSeekCloser
+	var format beep.Format
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".flac":
+		// beep/v2/flac is built on github.com/mewkiz/flac, which go.mod
+		// doesn't require; say so instead of failing as a bad MP3.
+		return nil, beep.Format{}, errors.New("failed to decode sound: FLAC files aren't supported, convert to OGG or WAV")
+	case ".wav":
+		streamer, format, err = wav.Decode(r)
+	case ".ogg", ".oga":
+		streamer, format, err = vorbis.Decode(r)
+	default:
+		streamer, format, err = mp3.Decode(r)
+	}
+	if err != nil {
+		return nil, beep.Format{}, fmt.Errorf("failed to decode sound: %w", err)
+	}
+	return streamer, format, nil
+}
+
+// resampleQuality is beep's resampling quality, 1 to 64; 3 is enough for
+// short effects and cheap to compute.
+const resampleQuality = 3
+
+// resample converts streamer from rate to the speaker's sampleRate, so
+// sounds recorded at other rates don't play too fast or too slow.
+func resample(streamer beep.Streamer, rate beep.SampleRate) beep.Streamer {
+	if rate == sampleRate {
+		return streamer
+	}
+	return beep.Resample(resampleQuality, rate, sampleRate, streamer)
+}
+
+// targetRMS is the loudness custom sounds are normalized to: -18 dBFS, as
+// loud as the loudest built-in sounds.
+const targetRMS = 0.126
+
+// normalize reads all of streamer, which sounds are short enough for, and
+// returns it with the gain that brings it to targetRMS, lowered if that
+// would push its peak past full scale.
+func normalize(streamer beep.Streamer, format beep.Format) (beep.Streamer, error) {
+	buf := beep.NewBuffer(format)
+	buf.Append(streamer)
+	if err := streamer.Err(); err != nil {
+		return nil, fmt.Errorf("failed to decode sound: %w", err)
+	}
+	gain := loudnessGain(buf.Streamer(0, buf.Len()))
+	return &effects.Gain{Streamer: buf.Streamer(0, buf.Len()), Gain: gain - 1}, nil
+}
+
+// loudnessGain returns the factor that brings the samples of streamer to
+// targetRMS without clipping, or 1 for silence.
+func loudnessGain(streamer beep.Streamer) float64 {
+	var sum, peak float64
+	n := 0
+	samples := make([][2]float64, 512)
+	for {
+		read, ok := streamer.Stream(samples)
+		for _, s := range samples[:read] {
+			for _, v := range s {
+				sum += v * v
+				peak = max(peak, math.Abs(v))
+			}
+		}
+		n += 2 * read
+		if !ok {
+			break
+		}
+	}
+	if n == 0 || sum == 0 {
+		return 1
+	}
+	rms := math.Sqrt(sum / float64(n))
+	return min(targetRMS/rms, 1/peak)
+}
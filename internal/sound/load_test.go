@@ -0,0 +1,76 @@
+package sound
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/gopxl/beep/v2"
+)
+
+// constant streams n samples of v on both channels.
+func constant(v float64, n int) beep.Streamer {
+	return beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		if n == 0 {
+			return 0, false
+		}
+		read := min(n, len(samples))
+		for i := range samples[:read] {
+			samples[i] = [2]float64{v, -v}
+		}
+		n -= read
+		return read, true
+	})
+}
+
+func TestLoudnessGain(t *testing.T) {
+	tests := []struct {
+		name     string
+		streamer beep.Streamer
+		want     float64
+	}{
+		{"quiet", constant(0.0126, 1000), 10},
+		{"loud", constant(0.63, 1000), 0.2},
+		{"at target", constant(targetRMS, 1000), 1},
+		{"silent", constant(0, 1000), 1},
+		{"empty", constant(0.5, 0), 1},
+	}
+	for _, tt := range tests {
+		if got := loudnessGain(tt.streamer); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: loudnessGain() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoudnessGainDoesNotClip(t *testing.T) {
+	// A click: quiet on average, but a peak at nearly full scale.
+	click := beep.Seq(constant(0.9, 1), constant(0, 9999))
+	if got := loudnessGain(click); got*0.9 > 1+1e-9 {
+		t.Errorf("loudnessGain() = %v, which clips the peak", got)
+	}
+}
+
+func TestDecodeUnsupported(t *testing.T) {
+	if _, _, err := decode("pack/win.flac", nil); err == nil {
+		t.Error("decode() of a FLAC file succeeded, want an error")
+	}
+}
+
+func TestResample(t *testing.T) {
+	// A second of sound lasts a second at the speaker's rate, whatever rate
+	// it was recorded at.
+	for _, rate := range []beep.SampleRate{22050, 44100, 48000} {
+		streamer := resample(constant(0.5, rate.N(time.Second)), rate)
+		n, samples := 0, make([][2]float64, 512)
+		for {
+			read, ok := streamer.Stream(samples)
+			n += read
+			if !ok {
+				break
+			}
+		}
+		if want := sampleRate.N(time.Second); n < want-8 || n > want+8 {
+			t.Errorf("%d Hz: got %d samples, want about %d", rate, n, want)
+		}
+	}
+}
@@ -1,38 +1,67 @@
 package sound
 
 import (
-	"bytes"
 	"embed"
-	"io"
+	"fmt"
+	"log"
 	"math"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/gopxl/beep/v2"
 	"github.com/gopxl/beep/v2/effects"
-	"github.com/gopxl/beep/v2/mp3"
 	"github.com/gopxl/beep/v2/speaker"
-	"github.com/gopxl/beep/v2/wav"
 )
 
 // Player handles sound playback
 type Player struct {
-	enabled     bool
-	volume      float64 // 0.0 to 1.0
-	initialized bool
-	mu          sync.Mutex
-	soundsFS    embed.FS
+	enabled       bool
+	volume        float64 // 0.0 to 1.0
+	ducked        bool    // while set, sounds play at duckVolume of volume
+	duckVolume    float64
+	stingerVolume float64 // 0.0 to 1.0, for stingers rather than effects
+	initialized   bool
+	timing        Timing
+	lastPlayed    map[string]time.Time  // when each sound was last played, by key
+	playing       map[string]*beep.Ctrl // each sound still playing, by key
+	mu            sync.Mutex
+	soundsFS      embed.FS
+}
+
+// Timing keeps a sound retriggered quickly, e.g. while mashing +/- to fix a
+// miscount, from piling up.
+type Timing struct {
+	Cooldown  time.Duration            // minimum time between two plays of the same sound
+	Cooldowns map[string]time.Duration // per sound, keyed like Effect.Key, overriding Cooldown
+	Restart   bool                     // a retriggered sound cuts off the one still playing
+}
+
+func (t *Timing) cooldown(key string) time.Duration {
+	if d, ok := t.Cooldowns[key]; ok {
+		return d
+	}
+	return t.Cooldown
 }
 
 // New creates a new sound player
 func New(soundsFS embed.FS, enabled bool, volume float64) *Player {
 	return &Player{
-		enabled:  enabled,
-		volume:   volume,
-		soundsFS: soundsFS,
+		enabled:    enabled,
+		volume:     volume,
+		lastPlayed: make(map[string]time.Time),
+		playing:    make(map[string]*beep.Ctrl),
+		soundsFS:   soundsFS,
 	}
 }
 
+// SetTiming sets how sounds retriggered quickly are handled.
+func (p *Player) SetTiming(timing Timing) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.timing = timing
+}
+
 // SetEnabled enables or disables sound playback
 func (p *Player) SetEnabled(enabled bool) {
 	p.mu.Lock()
@@ -61,123 +90,264 @@ func (p *Player) GetVolume() float64 {
 	return p.volume
 }
 
-// initSpeaker initializes the speaker if not already done
-func (p *Player) initSpeaker(sampleRate beep.SampleRate) error {
+// SetDucked turns sounds down to the ducking volume while ducked is set,
+// e.g. while the microphone is in use.
+func (p *Player) SetDucked(ducked bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ducked = ducked
+}
+
+// SetDuckVolume sets the share of the volume sounds play at while ducked
+// (0.0 to 1.0).
+func (p *Player) SetDuckVolume(volume float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.duckVolume = volume
+}
+
+// sampleRate is the rate the speaker is opened at, the built-in sounds'
+// own; other sounds are resampled to it.
+const sampleRate beep.SampleRate = 44100
+
+// Init opens the audio device, if it isn't open already, and returns why it
+// couldn't be. Sounds open it when they're first played otherwise.
+func (p *Player) Init() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if p.initialized {
 		return nil
 	}
-	err := speaker.Init(sampleRate, sampleRate.N(time.Second/10))
-	if err != nil {
-		return err
+	if err := speaker.Init(sampleRate, sampleRate.N(time.Second/10)); err != nil {
+		return fmt.Errorf("failed to open audio device: %w", err)
 	}
 	p.initialized = true
 	return nil
 }
 
-// playFile plays an audio file from the embedded filesystem
-func (p *Player) playFile(path string) {
+// admit reports whether the sound key may play now, i.e. its cooldown has
+// passed, and if so records it as played, cutting off the one still playing
+// if retriggered sounds restart.
+func (p *Player) admit(key string) bool {
 	p.mu.Lock()
+	defer p.mu.Unlock()
 	if !p.enabled {
-		p.mu.Unlock()
+		return false
+	}
+	now := time.Now()
+	if last, ok := p.lastPlayed[key]; ok && now.Sub(last) < p.timing.cooldown(key) {
+		return false
+	}
+	p.lastPlayed[key] = now
+	if ctrl := p.playing[key]; ctrl != nil && p.timing.Restart {
+		speaker.Lock()
+		ctrl.Streamer = nil // ends it, as if it had finished
+		speaker.Unlock()
+	}
+	return true
+}
+
+// playFile plays the sound key, an audio file from the embedded filesystem,
+// logging why it couldn't.
+func (p *Player) playFile(key, path string) {
+	if !p.admit(key) {
+		return
+	}
+	if err := p.playEmbedded(key, path); err != nil {
+		log.Printf("failed to play %s: %v", path, err)
+	}
+}
+
+// playCustom plays the sound key, the audio file at path on disk, or the
+// embedded builtin if path is empty or can't be read.
+func (p *Player) playCustom(key, path, builtin string) {
+	if !p.admit(key) {
 		return
 	}
-	volume := p.volume
-	p.mu.Unlock()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = p.play(key, path, data, p.volumeNow(), true)
+		} else {
+			err = fmt.Errorf("failed to read sound: %w", err)
+		}
+		if err == nil {
+			return
+		}
+		log.Printf("failed to play %s, playing the built-in one: %v", path, err)
+	}
+	if err := p.playEmbedded(key, builtin); err != nil {
+		log.Printf("failed to play %s: %v", builtin, err)
+	}
+}
 
+// playEmbedded plays the sound key, an audio file from the embedded
+// filesystem, at the current volume, even while sound is disabled.
+func (p *Player) playEmbedded(key, path string) error {
 	data, err := p.soundsFS.ReadFile(path)
 	if err != nil {
-		return
+		return fmt.Errorf("failed to read sound: %w", err)
 	}
+	return p.play(key, path, data, p.volumeNow(), false)
+}
 
-	reader := bytes.NewReader(data)
-	var streamer beep.StreamSeekCloser
-	var format beep.Format
+// volumeNow returns the volume sounds play at now, ducked or not.
+func (p *Player) volumeNow() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ducked {
+		return p.volume * p.duckVolume
+	}
+	return p.volume
+}
 
-	// Try to decode based on file extension
-	if len(path) > 4 && path[len(path)-4:] == ".wav" {
-		streamer, format, err = wav.Decode(io.NopCloser(reader))
-	} else {
-		streamer, format, err = mp3.Decode(io.NopCloser(reader))
+// play decodes data, WAV, Ogg Vorbis or MP3 by path's extension, and plays
+// it as the sound key at volume, loudness normalized if normalized is set,
+// returning once it's finished or cut off. Sounds with no key can't be cut
+// off.
+func (p *Player) play(key, path string, data []byte, volume float64, normalized bool) error {
+	if volume <= 0 {
+		return nil // Silent, don't play
 	}
 
+	streamer, format, err := decode(path, data)
 	if err != nil {
-		return
+		return err
 	}
 	defer func() { _ = streamer.Close() }()
 
-	if err := p.initSpeaker(format.SampleRate); err != nil {
-		return
+	if err := p.Init(); err != nil {
+		return err
 	}
 
+	var finalStreamer beep.Streamer = streamer
+	if normalized {
+		// The user's own sounds, which can be much louder or quieter than
+		// the built-in ones.
+		if finalStreamer, err = normalize(streamer, format); err != nil {
+			return err
+		}
+	}
+	finalStreamer = resample(finalStreamer, format.SampleRate)
+
 	// Apply volume adjustment (convert 0-1 range to decibels)
 	// Volume 1.0 = 0dB, Volume 0.5 = -6dB, Volume 0.0 = silence
-	var finalStreamer beep.Streamer = streamer
 	if volume < 1.0 {
-		if volume <= 0 {
-			return // Silent, don't play
-		}
 		// Convert linear volume to decibels: dB = 20 * log10(volume)
 		db := 20 * math.Log10(volume)
 		finalStreamer = &effects.Volume{
-			Streamer: streamer,
+			Streamer: finalStreamer,
 			Base:     2,
 			Volume:   db / 10, // effects.Volume uses Base^Volume, so we adjust
 		}
 	}
 
+	ctrl := &beep.Ctrl{Streamer: finalStreamer}
+	if key != "" {
+		p.mu.Lock()
+		p.playing[key] = ctrl
+		p.mu.Unlock()
+		defer func() {
+			p.mu.Lock()
+			if p.playing[key] == ctrl {
+				delete(p.playing, key)
+			}
+			p.mu.Unlock()
+		}()
+	}
+
 	done := make(chan bool)
-	speaker.Play(beep.Seq(finalStreamer, beep.Callback(func() {
+	speaker.Play(beep.Seq(ctrl, beep.Callback(func() {
 		done <- true
 	})))
 	<-done
+	return nil
+}
+
+// Effect is one of the built-in sound effects.
+type Effect struct {
+	Key   string // e.g. "ct_increment", as in config.SoundEvents
+	Label string // what it's played for, e.g. "CT round won"
+	file  string
+}
+
+// Effects lists the built-in sound effects the tracker plays, e.g. for
+// testing them.
+var Effects = []Effect{
+	{"ct_increment", "CT round won", "sound/ct_increment.wav"},
+	{"ct_decrement", "CT round removed", "sound/ct_decrement.wav"},
+	{"t_increment", "T round won", "sound/t_increment.wav"},
+	{"t_decrement", "T round removed", "sound/t_decrement.wav"},
+	{"ct_select", "CT selected", "sound/ct_select.wav"},
+	{"t_select", "T selected", "sound/t_select.wav"},
+	{"streak", "Win streak", "sound/win.wav"},
+	{"comeback", "Reverse sweep", "sound/match_end.wav"},
+}
+
+// Test plays e at the current volume, even while sound is disabled, and
+// returns why it couldn't once it's finished.
+func (p *Player) Test(e Effect) error {
+	return p.playEmbedded("", e.file)
 }
 
 // PlayCTIncrement plays the CT increment sound
 func (p *Player) PlayCTIncrement() {
-	go p.playFile("sound/ct_increment.wav")
+	go p.playFile("ct_increment", "sound/ct_increment.wav")
 }
 
 // PlayCTDecrement plays the CT decrement sound
 func (p *Player) PlayCTDecrement() {
-	go p.playFile("sound/ct_decrement.wav")
+	go p.playFile("ct_decrement", "sound/ct_decrement.wav")
 }
 
 // PlayTIncrement plays the T increment sound
 func (p *Player) PlayTIncrement() {
-	go p.playFile("sound/t_increment.wav")
+	go p.playFile("t_increment", "sound/t_increment.wav")
 }
 
 // PlayTDecrement plays the T decrement sound
 func (p *Player) PlayTDecrement() {
-	go p.playFile("sound/t_decrement.wav")
+	go p.playFile("t_decrement", "sound/t_decrement.wav")
 }
 
 // PlayMatchEnd plays the match end sound
 func (p *Player) PlayMatchEnd() {
-	go p.playFile("sound/match_end.wav")
+	go p.playFile("match_end", "sound/match_end.wav")
 }
 
 // PlayReset plays the reset sound
 func (p *Player) PlayReset() {
-	go p.playFile("sound/reset.wav")
+	go p.playFile("reset", "sound/reset.wav")
 }
 
 // PlayCTSelect plays the CT team selection sound
 func (p *Player) PlayCTSelect() {
-	go p.playFile("sound/ct_select.wav")
+	go p.playFile("ct_select", "sound/ct_select.wav")
 }
 
 // PlayTSelect plays the T team selection sound
 func (p *Player) PlayTSelect() {
-	go p.playFile("sound/t_select.wav")
+	go p.playFile("t_select", "sound/t_select.wav")
 }
 
 // PlayWin plays the win melody
 func (p *Player) PlayWin() {
-	go p.playFile("sound/win.wav")
+	go p.playFile("win", "sound/win.wav")
+}
+
+// PlayStreak plays the win streak announcement: the sound file at custom,
+// or the built-in win melody if custom is "".
+func (p *Player) PlayStreak(custom string) {
+	go p.playCustom("streak", custom, "sound/win.wav")
+}
+
+// PlayComeback plays the reverse sweep announcement: the sound file at
+// custom, or the built-in match end sound if custom is "".
+func (p *Player) PlayComeback(custom string) {
+	go p.playCustom("comeback", custom, "sound/match_end.wav")
 }
 
 // PlayLose plays the lose melody
 func (p *Player) PlayLose() {
-	go p.playFile("sound/lose.wav")
+	go p.playFile("lose", "sound/lose.wav")
 }
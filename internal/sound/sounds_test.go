@@ -0,0 +1,48 @@
+package sound_test
+
+import (
+	"embed"
+	"slices"
+	"strings"
+	"testing"
+
+	csstatstracker "csstatstracker"
+	"csstatstracker/internal/sound"
+)
+
+func TestEffects(t *testing.T) {
+	// At volume 0 a sound is read but not played, so no audio device is
+	// needed.
+	p := sound.New(csstatstracker.SoundFS, false, 0)
+	for _, e := range sound.Effects {
+		if err := p.Test(e); err != nil {
+			t.Errorf("Test(%s) = %v", e.Label, err)
+		}
+	}
+
+	missing := sound.New(embed.FS{}, true, 0)
+	if err := missing.Test(sound.Effects[0]); err == nil || !strings.Contains(err.Error(), "failed to read sound") {
+		t.Errorf("Test of a missing sound = %v, want it to fail to read", err)
+	}
+}
+
+func TestStingers(t *testing.T) {
+	// The stinger volume starts at 0, so nothing is played.
+	p := sound.New(csstatstracker.SoundFS, true, 1)
+	for _, s := range append(slices.Clone(sound.WinStingers), sound.LoseStingers...) {
+		if err := p.TestStinger(s, ""); err != nil {
+			t.Errorf("TestStinger(%s) = %v", s.Label, err)
+		}
+	}
+	// A custom file that can't be read falls back to the bundled stinger.
+	if err := p.TestStinger(sound.WinStingers[0], "/nonexistent/win.ogg"); err != nil {
+		t.Errorf("TestStinger with a missing custom file = %v, want the bundled one played", err)
+	}
+
+	if s := sound.FindStinger(sound.LoseStingers, "minor"); s.Key != "minor" {
+		t.Errorf("FindStinger(minor) = %s", s.Key)
+	}
+	if s := sound.FindStinger(sound.LoseStingers, "removed"); s != sound.LoseStingers[0] {
+		t.Errorf("FindStinger of an unknown key = %s, want the default %s", s.Key, sound.LoseStingers[0].Key)
+	}
+}
@@ -0,0 +1,111 @@
+package sound
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/gopxl/beep/v2/speaker"
+)
+
+// Stinger is a bundled piece of music played when a match is won or lost,
+// a few seconds long where the effects are blips.
+type Stinger struct {
+	Key   string // e.g. "fanfare", as in config.Stingers
+	Label string
+	file  string
+}
+
+// WinStingers and LoseStingers are the bundled stingers, the default
+// first.
+var (
+	WinStingers = []Stinger{
+		{"fanfare", "Fanfare", "sound/stinger_win_fanfare.wav"},
+		{"arcade", "Arcade", "sound/stinger_win_arcade.wav"},
+	}
+	LoseStingers = []Stinger{
+		{"trombone", "Sad trombone", "sound/stinger_lose_trombone.wav"},
+		{"minor", "Minor key", "sound/stinger_lose_minor.wav"},
+	}
+)
+
+// FindStinger returns the stinger in stingers with the given key, or the
+// first one if none has it.
+func FindStinger(stingers []Stinger, key string) Stinger {
+	for _, s := range stingers {
+		if s.Key == key {
+			return s
+		}
+	}
+	return stingers[0]
+}
+
+// stingerKey is the key stingers play under, so a new one cuts off the one
+// still playing and SkipStinger can find it.
+const stingerKey = "stinger"
+
+// SetStingerVolume sets the volume stingers play at (0.0 to 1.0), apart
+// from the effects' volume.
+func (p *Player) SetStingerVolume(volume float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stingerVolume = volume
+}
+
+// stingerVolumeNow returns the volume stingers play at now, ducked or not.
+func (p *Player) stingerVolumeNow() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ducked {
+		return p.stingerVolume * p.duckVolume
+	}
+	return p.stingerVolume
+}
+
+// PlayStinger plays s, or the sound file at custom instead if it isn't
+// empty, unless sound is disabled. A stinger still playing is cut off.
+func (p *Player) PlayStinger(s Stinger, custom string) {
+	if !p.IsEnabled() {
+		return
+	}
+	go func() {
+		if err := p.TestStinger(s, custom); err != nil {
+			log.Printf("failed to play stinger: %v", err)
+		}
+	}()
+}
+
+// TestStinger plays s, or the sound file at custom instead, even while
+// sound is disabled, and returns why it couldn't once it's finished or
+// skipped. A custom file that can't be played falls back to s.
+func (p *Player) TestStinger(s Stinger, custom string) error {
+	p.SkipStinger()
+	if custom != "" {
+		data, err := os.ReadFile(custom)
+		if err == nil {
+			err = p.play(stingerKey, custom, data, p.stingerVolumeNow(), true)
+		} else {
+			err = fmt.Errorf("failed to read sound: %w", err)
+		}
+		if err == nil {
+			return nil
+		}
+		log.Printf("failed to play %s, playing the bundled stinger: %v", custom, err)
+	}
+	data, err := p.soundsFS.ReadFile(s.file)
+	if err != nil {
+		return fmt.Errorf("failed to read sound: %w", err)
+	}
+	return p.play(stingerKey, s.file, data, p.stingerVolumeNow(), false)
+}
+
+// SkipStinger cuts off the stinger playing, if there is one.
+func (p *Player) SkipStinger() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ctrl := p.playing[stingerKey]; ctrl != nil {
+		speaker.Lock()
+		ctrl.Streamer = nil // ends it, as if it had finished
+		speaker.Unlock()
+	}
+}
@@ -0,0 +1,32 @@
+package sound
+
+import (
+	"embed"
+	"testing"
+	"time"
+)
+
+func TestAdmit(t *testing.T) {
+	p := New(embed.FS{}, true, 1)
+	p.SetTiming(Timing{
+		Cooldown:  time.Hour,
+		Cooldowns: map[string]time.Duration{"t_increment": 0},
+	})
+	if !p.admit("ct_increment") {
+		t.Fatal("first ct_increment wasn't admitted")
+	}
+	if p.admit("ct_increment") {
+		t.Error("ct_increment was admitted again within its cooldown")
+	}
+	if !p.admit("ct_decrement") {
+		t.Error("ct_decrement was held back by ct_increment's cooldown")
+	}
+	if !p.admit("t_increment") || !p.admit("t_increment") {
+		t.Error("t_increment, with no cooldown, wasn't admitted twice")
+	}
+
+	p.SetEnabled(false)
+	if p.admit("reset") {
+		t.Error("a sound was admitted while sound is disabled")
+	}
+}
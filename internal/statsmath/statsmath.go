@@ -0,0 +1,33 @@
+// Package statsmath holds small statistical helpers used by the stats views.
+package statsmath
+
+import "math"
+
+// Z95 is the standard normal quantile for a two-sided 95% interval.
+const Z95 = 1.959963984540054
+
+// Wilson returns the Wilson score interval for successes out of trials at the
+// given z (use Z95 for 95%). Both bounds are proportions in [0, 1]. Unlike the
+// normal approximation it behaves sensibly for small samples and rates near
+// 0 or 1. With no trials the interval is the whole range.
+func Wilson(successes, trials int, z float64) (low, high float64) {
+	if trials <= 0 {
+		return 0, 1
+	}
+	n := float64(trials)
+	p := float64(successes) / n
+	z2 := z * z
+
+	denom := 1 + z2/n
+	center := (p + z2/(2*n)) / denom
+	margin := z * math.Sqrt(p*(1-p)/n+z2/(4*n*n)) / denom
+
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// WilsonMargin returns half the width of the Wilson interval, in percentage
+// points, for showing a rate as "54% ±6".
+func WilsonMargin(successes, trials int, z float64) float64 {
+	low, high := Wilson(successes, trials, z)
+	return (high - low) / 2 * 100
+}
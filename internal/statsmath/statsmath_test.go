@@ -0,0 +1,38 @@
+package statsmath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWilson(t *testing.T) {
+	tests := []struct {
+		name      string
+		successes int
+		trials    int
+		low, high float64
+	}{
+		{name: "no trials", successes: 0, trials: 0, low: 0, high: 1},
+		{name: "half of ten", successes: 5, trials: 10, low: 0.2366, high: 0.7634},
+		{name: "all of one", successes: 1, trials: 1, low: 0.2065, high: 1},
+		{name: "none of twenty", successes: 0, trials: 20, low: 0, high: 0.1611},
+		{name: "54 of 100", successes: 54, trials: 100, low: 0.4426, high: 0.6344},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, high := Wilson(tt.successes, tt.trials, Z95)
+			if math.Abs(low-tt.low) > 1e-4 || math.Abs(high-tt.high) > 1e-4 {
+				t.Errorf("Wilson(%d, %d) = [%.4f, %.4f], want [%.4f, %.4f]",
+					tt.successes, tt.trials, low, high, tt.low, tt.high)
+			}
+		})
+	}
+}
+
+func TestWilsonMarginShrinksWithSample(t *testing.T) {
+	small := WilsonMargin(6, 10, Z95)
+	large := WilsonMargin(600, 1000, Z95)
+	if large >= small {
+		t.Errorf("margin for 1000 trials (%.2f) should be below margin for 10 (%.2f)", large, small)
+	}
+}
@@ -0,0 +1,61 @@
+package summary
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+// Chart dimensions in pixels. Wins are drawn up from the middle line and
+// losses down from it.
+const (
+	chartWidth  = 600
+	chartHeight = 200
+	chartMargin = 10
+)
+
+var (
+	chartBackground = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	chartAxis       = color.RGBA{R: 189, G: 189, B: 189, A: 255}
+	chartWin        = color.RGBA{R: 67, G: 160, B: 71, A: 255}
+	chartLoss       = color.RGBA{R: 229, G: 57, B: 53, A: 255}
+)
+
+// Chart draws the summary's days as a PNG bar chart, wins above the line
+// and losses below it.
+func Chart(s Summary) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight))
+	draw.Draw(img, img.Bounds(), image.NewUniform(chartBackground), image.Point{}, draw.Src)
+
+	mid := chartHeight / 2
+	fill(img, chartMargin, mid, chartWidth-chartMargin, mid+1, chartAxis)
+
+	most := 1
+	for _, d := range s.Days {
+		most = max(most, d.Wins, d.Losses)
+	}
+	if len(s.Days) > 0 {
+		slot := (chartWidth - 2*chartMargin) / len(s.Days)
+		bar := max(slot*2/3, 1)
+		scale := float64(mid-chartMargin) / float64(most)
+		for i, d := range s.Days {
+			x := chartMargin + i*slot + (slot-bar)/2
+			fill(img, x, mid-int(float64(d.Wins)*scale), x+bar, mid, chartWin)
+			fill(img, x, mid+1, x+bar, mid+1+int(float64(d.Losses)*scale), chartLoss)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("failed to encode chart: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// fill paints the rectangle from (x0, y0) to (x1, y1) exclusive.
+func fill(img *image.RGBA, x0, y0, x1, y1 int, c color.Color) {
+	draw.Draw(img, image.Rect(x0, y0, x1, y1), image.NewUniform(c), image.Point{}, draw.Src)
+}
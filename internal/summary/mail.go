@@ -0,0 +1,248 @@
+package summary
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+)
+
+// checkInterval is how often the scheduler checks whether a summary is due.
+const checkInterval = time.Hour
+
+// smtpTimeout bounds a whole SMTP conversation.
+const smtpTimeout = 30 * time.Second
+
+// chartCID is the Content-ID the HTML body refers to the chart by.
+const chartCID = "daily-chart@csstatstracker"
+
+var bodyTemplate = template.Must(template.New("summary").Funcs(template.FuncMap{
+	"percent": func(f float64) string { return strconv.FormatFloat(f, 'f', 1, 64) + "%" },
+	"date":    func(t time.Time) string { return t.Format("Mon 2 Jan") },
+	"hours":   formatHours,
+}).Parse(`<!DOCTYPE html>
+<html><body style="font-family: sans-serif; color: #212121;">
+<h2>Your CS2 month: {{.Month.Format "January 2006"}}</h2>
+{{if .Rounds}}
+<table cellpadding="4">
+<tr><td>Rounds</td><td><b>{{.Rounds}}</b></td></tr>
+<tr><td>Wins – Losses</td><td><b>{{.Wins}} – {{.Losses}}</b></td></tr>
+<tr><td>Win rate</td><td><b>{{percent .WinRate}}</b></td></tr>
+<tr><td>Sessions</td><td><b>{{.Sessions}}</b>, {{hours .PlayTime}} in total</td></tr>
+</table>
+<p><img src="cid:` + chartCID + `" alt="Wins and losses per day" width="600" height="200"></p>
+<h3>Notable</h3>
+<ul>
+{{with .BestDay}}<li>Best day: {{date .Date}}, {{.Wins}} – {{.Losses}}</li>{{end}}
+{{with .LongestSession}}<li>Longest session: {{date .Start}}, {{hours .Duration}} over {{.Rounds}} rounds ({{.Wins}} – {{.Losses}})</li>{{end}}
+{{if .LongestStreak}}<li>Longest win streak: {{.LongestStreak}} rounds</li>{{end}}
+</ul>
+{{else}}
+<p>No rounds recorded this month.</p>
+{{end}}
+<p style="color: #757575; font-size: small;">Sent by CS Stats Tracker. Turn it off under Settings → Monthly Email Summary.</p>
+</body></html>
+`))
+
+// formatHours formats d as e.g. "3h 20m".
+func formatHours(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// Message builds the summary email: an HTML body with the chart attached
+// inline.
+func Message(s Summary, from, to string, now time.Time) ([]byte, error) {
+	var html bytes.Buffer
+	if err := bodyTemplate.Execute(&html, s); err != nil {
+		return nil, fmt.Errorf("failed to render summary: %w", err)
+	}
+	chart, err := Chart(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	mw := multipart.NewWriter(&msg)
+	subject := "CS2 summary for " + s.Month.Format("January 2006")
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/related; boundary=%q; type=\"text/html\"\r\n\r\n", mw.Boundary())
+
+	parts := []struct {
+		header textproto.MIMEHeader
+		body   []byte
+	}{
+		{textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}}, html.Bytes()},
+		{textproto.MIMEHeader{
+			"Content-Type":        {"image/png"},
+			"Content-ID":          {"<" + chartCID + ">"},
+			"Content-Disposition": {`inline; filename="daily.png"`},
+		}, chart},
+	}
+	for _, p := range parts {
+		p.header.Set("Content-Transfer-Encoding", "base64")
+		w, err := mw.CreatePart(p.header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+		if _, err := w.Write(wrapBase64(p.body)); err != nil {
+			return nil, fmt.Errorf("failed to build email: %w", err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build email: %w", err)
+	}
+	return msg.Bytes(), nil
+}
+
+// wrapBase64 encodes b as base64 in lines of 76 characters.
+func wrapBase64(b []byte) []byte {
+	enc := base64.StdEncoding.EncodeToString(b)
+	var out strings.Builder
+	for len(enc) > 76 {
+		out.WriteString(enc[:76] + "\r\n")
+		enc = enc[76:]
+	}
+	out.WriteString(enc + "\r\n")
+	return []byte(out.String())
+}
+
+// Send delivers msg with the SMTP settings in e. Port 465 uses implicit TLS;
+// other ports upgrade with STARTTLS when the server offers it. Credentials
+// are only sent over TLS.
+func Send(e config.Email, msg []byte) error {
+	addr := net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	tlsConfig := &tls.Config{ServerName: e.Host}
+
+	var conn net.Conn
+	var err error
+	if e.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	c, err := smtp.NewClient(conn, e.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to connect to %s: %w", addr, err)
+	}
+	defer func() { _ = c.Close() }()
+
+	if ok, _ := c.Extension("STARTTLS"); ok && e.Port != 465 {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if e.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", e.Username, e.Password, e.Host)); err != nil {
+			return fmt.Errorf("failed to log in: %w", err)
+		}
+	}
+	if err := c.Mail(e.From); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := c.Rcpt(e.To); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return c.Quit()
+}
+
+// Scheduler sends each month's summary once the month is over.
+type Scheduler struct {
+	ctx    context.Context // cancelled on app shutdown
+	db     *sql.DB
+	cfg    *config.Config
+	onSent func(month string)
+}
+
+// NewScheduler creates a Scheduler for cfg's email settings. onSent is
+// called, from the scheduler's goroutine, with the MonthKey of each summary
+// sent; it should record it in cfg.Email.LastSent and save the config.
+func NewScheduler(ctx context.Context, db *sql.DB, cfg *config.Config, onSent func(month string)) *Scheduler {
+	return &Scheduler{ctx: ctx, db: db, cfg: cfg, onSent: onSent}
+}
+
+// Run checks whether last month's summary is due now and then hourly, until
+// the context is cancelled.
+func (s *Scheduler) Run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+	for {
+		s.check(time.Now())
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check sends last month's summary unless it's been sent. Failures are
+// logged and retried at the next check.
+func (s *Scheduler) check(now time.Time) {
+	if !s.cfg.Email.Enabled {
+		return
+	}
+	month := PreviousMonth(now)
+	key := MonthKey(month)
+	if s.cfg.Email.LastSent >= key {
+		return
+	}
+	if err := s.Send(month); err != nil {
+		log.Printf("monthly summary: %v", err)
+		return
+	}
+	s.onSent(key)
+}
+
+// Send builds and sends the summary of the month starting at month, e.g. to
+// test the settings.
+func (s *Scheduler) Send(month time.Time) error {
+	ctx, cancel := database.WithTimeout(s.ctx)
+	rounds, err := database.GetRoundsInMonth(ctx, s.db, month)
+	cancel()
+	if err != nil {
+		return err
+	}
+	e := s.cfg.Email
+	msg, err := Message(Build(month, rounds), e.From, e.To, time.Now())
+	if err != nil {
+		return err
+	}
+	return Send(e, msg)
+}
@@ -0,0 +1,101 @@
+// Package summary builds the monthly summary email: the month's results, a
+// chart of each day's wins and losses, and its notable sessions, sent over
+// SMTP by a scheduler once the month is over.
+package summary
+
+import (
+	"slices"
+	"time"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/records"
+)
+
+// Day is one day's results.
+type Day struct {
+	Date   time.Time // midnight UTC
+	Wins   int
+	Losses int
+}
+
+// Summary is a month's results.
+type Summary struct {
+	Month    time.Time // first of the month, midnight UTC
+	Rounds   int
+	Wins     int
+	Losses   int
+	WinRate  float64 // percent of decided rounds
+	Sessions int
+	PlayTime time.Duration
+	Days     []Day // every day of the month, played or not
+
+	LongestSession *records.Session
+	BestDay        *Day // most net wins
+	LongestStreak  int  // most wins in a row
+}
+
+// Build summarises the rounds of the UTC calendar month starting at month.
+// Rounds outside it are ignored; they may be in any order.
+func Build(month time.Time, rounds []database.Round) Summary {
+	month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := month.AddDate(0, 1, 0)
+	s := Summary{Month: month}
+	for d := month; d.Before(end); d = d.AddDate(0, 0, 1) {
+		s.Days = append(s.Days, Day{Date: d})
+	}
+
+	var inMonth []database.Round
+	for _, r := range rounds {
+		if t := r.CreatedAt.UTC(); !t.Before(month) && t.Before(end) {
+			inMonth = append(inMonth, r)
+		}
+	}
+	slices.SortFunc(inMonth, func(a, b database.Round) int { return a.CreatedAt.Compare(b.CreatedAt) })
+
+	streak := 0
+	for _, r := range inMonth {
+		s.Rounds++
+		day := &s.Days[r.CreatedAt.UTC().Day()-1]
+		switch r.Result() {
+		case database.ResultWin:
+			s.Wins++
+			day.Wins++
+			streak++
+			s.LongestStreak = max(s.LongestStreak, streak)
+		case database.ResultLoss:
+			s.Losses++
+			day.Losses++
+			streak = 0
+		}
+	}
+	if decided := s.Wins + s.Losses; decided > 0 {
+		s.WinRate = float64(s.Wins) / float64(decided) * 100
+	}
+
+	for _, session := range records.Sessions(inMonth) {
+		s.Sessions++
+		s.PlayTime += session.Duration()
+		if s.LongestSession == nil || session.Duration() > s.LongestSession.Duration() {
+			s.LongestSession = &session
+		}
+	}
+	for i := range s.Days {
+		d := &s.Days[i]
+		if d.Wins+d.Losses > 0 && (s.BestDay == nil || d.Wins-d.Losses > s.BestDay.Wins-s.BestDay.Losses) {
+			s.BestDay = d
+		}
+	}
+	return s
+}
+
+// PreviousMonth returns the first of the month before now's, in UTC.
+func PreviousMonth(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// MonthKey is how a month is written in config.Email.LastSent, e.g.
+// "2025-03".
+func MonthKey(month time.Time) string {
+	return month.Format("2006-01")
+}
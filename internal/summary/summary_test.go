@@ -0,0 +1,115 @@
+package summary
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image/png"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/database/dbtest"
+)
+
+func TestBuild(t *testing.T) {
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rounds := slices.Concat(
+		dbtest.Series(march.AddDate(0, 0, -1), time.Minute, "WWW"),                     // February
+		dbtest.Series(march.Add(20*time.Hour), 10*time.Minute, "WWLwwwD"),              // 1 March, one hour
+		dbtest.Series(march.AddDate(0, 0, 4).Add(18*time.Hour), 5*time.Minute, "LLlW"), // 5 March
+		dbtest.Series(march.AddDate(0, 1, 0), time.Minute, "L"),                        // April
+	)
+
+	s := Build(march.Add(12*time.Hour), rounds)
+	if !s.Month.Equal(march) || len(s.Days) != 31 {
+		t.Fatalf("Month = %s with %d days, want March with 31", s.Month, len(s.Days))
+	}
+	if s.Rounds != 11 || s.Wins != 6 || s.Losses != 4 || s.WinRate != 60 {
+		t.Errorf("rounds %d, %d – %d (%.1f%%), want 11, 6 – 4 (60%%)", s.Rounds, s.Wins, s.Losses, s.WinRate)
+	}
+	if s.Sessions != 2 || s.PlayTime != 75*time.Minute {
+		t.Errorf("%d sessions over %s, want 2 over 1h15m", s.Sessions, s.PlayTime)
+	}
+	if s.LongestSession == nil || s.LongestSession.Rounds != 7 {
+		t.Errorf("LongestSession = %+v, want the 7-round one", s.LongestSession)
+	}
+	if s.BestDay == nil || s.BestDay.Date.Day() != 1 || s.BestDay.Wins != 5 {
+		t.Errorf("BestDay = %+v, want 1 March with 5 wins", s.BestDay)
+	}
+	if s.LongestStreak != 3 {
+		t.Errorf("LongestStreak = %d, want 3", s.LongestStreak)
+	}
+}
+
+func TestPreviousMonth(t *testing.T) {
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC), "2025-02"},
+		{time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), "2024-12"},
+	}
+	for _, tt := range tests {
+		if got := MonthKey(PreviousMonth(tt.now)); got != tt.want {
+			t.Errorf("PreviousMonth(%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	s := Build(march, dbtest.Series(march.Add(20*time.Hour), 10*time.Minute, "WWLw"))
+	raw, err := Message(s, "tracker@example.com", "me@example.com", march.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ReadMessage: %v", err)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject")); subject != "CS2 summary for March 2025" {
+		t.Errorf("Subject = %q", subject)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/related" {
+		t.Fatalf("Content-Type = %q, %v", mediaType, err)
+	}
+
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	html, err := mr.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(html)
+	decoded := decodeBase64(t, body)
+	if !strings.Contains(decoded, "cid:"+chartCID) || !strings.Contains(decoded, "3 – 1") {
+		t.Errorf("HTML body doesn't reference the chart or show the score:\n%s", decoded)
+	}
+
+	chart, err := mr.NextPart()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chart.Header.Get("Content-ID") != "<"+chartCID+">" {
+		t.Errorf("chart Content-ID = %q", chart.Header.Get("Content-ID"))
+	}
+	b, _ := io.ReadAll(chart)
+	if _, err := png.Decode(strings.NewReader(decodeBase64(t, b))); err != nil {
+		t.Errorf("chart isn't a PNG: %v", err)
+	}
+}
+
+func decodeBase64(t *testing.T, b []byte) string {
+	t.Helper()
+	out, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(b)))
+	if err != nil {
+		t.Fatalf("decoding base64: %v", err)
+	}
+	return string(out)
+}
@@ -0,0 +1,110 @@
+// Package toast asks for round results in actionable desktop notifications,
+// so a round can be logged without switching to the tracker's window.
+//
+// Notification buttons activate csstatstracker: URLs. Windows launches a new
+// instance of the app with the URL as its argument, which hands it to the
+// running instance through the single-instance lock and exits. Any web page
+// or local process can send such URLs too, so the ones that record a round
+// carry a nonce only the running instance's notifications know.
+package toast
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+
+	"csstatstracker/internal/database"
+)
+
+// Scheme is the URL scheme notification actions are registered under.
+const Scheme = "csstatstracker"
+
+// Action is what a notification URL asks the running instance to do.
+type Action struct {
+	Show   bool          // bring the window to the front
+	Winner database.Team // record a round won by this side
+	Nonce  string        // with Winner, the nonce of the notification it came from
+}
+
+// URL returns the URL that triggers a.
+func (a Action) URL() string {
+	if a.Show {
+		return Scheme + ":show"
+	}
+	u := Scheme + ":round?winner=" + string(a.Winner)
+	if a.Nonce != "" {
+		u += "&nonce=" + url.QueryEscape(a.Nonce)
+	}
+	return u
+}
+
+// ParseURL parses a notification URL as passed on the command line.
+func ParseURL(raw string) (Action, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != Scheme {
+		return Action{}, fmt.Errorf("not a %s URL: %q", Scheme, raw)
+	}
+	switch u.Opaque {
+	case "show":
+		return Action{Show: true}, nil
+	case "round":
+		query := u.Query()
+		switch winner := database.Team(query.Get("winner")); winner {
+		case database.TeamCT, database.TeamT:
+			return Action{Winner: winner, Nonce: query.Get("nonce")}, nil
+		}
+	}
+	return Action{}, fmt.Errorf("unknown %s URL: %q", Scheme, raw)
+}
+
+// Prompt is the content of a round result notification.
+type Prompt struct {
+	CT, T         int    // the active match's score
+	CTName, TName string // button labels
+	Nonce         string // passed back by the buttons, to tell them from forged URLs
+}
+
+// toastXML mirrors the Windows toast schema, limited to what Prompt uses.
+type toastXML struct {
+	XMLName        xml.Name     `xml:"toast"`
+	ActivationType string       `xml:"activationType,attr"`
+	Launch         string       `xml:"launch,attr"`
+	Binding        toastBinding `xml:"visual>binding"`
+	Actions        []toastAct   `xml:"actions>action"`
+}
+
+type toastBinding struct {
+	Template string   `xml:"template,attr"`
+	Texts    []string `xml:"text"`
+}
+
+type toastAct struct {
+	Content        string `xml:"content,attr"`
+	ActivationType string `xml:"activationType,attr"`
+	Arguments      string `xml:"arguments,attr"`
+}
+
+// XML renders p as a Windows toast: clicking the body opens the window and
+// the buttons record the round for either side.
+func (p Prompt) XML() (string, error) {
+	doc := toastXML{
+		ActivationType: "protocol",
+		Launch:         Action{Show: true}.URL(),
+		Binding: toastBinding{
+			Template: "ToastGeneric",
+			Texts: []string{
+				"Who won the round?",
+				fmt.Sprintf("%s %d – %d %s", p.CTName, p.CT, p.T, p.TName),
+			},
+		},
+		Actions: []toastAct{
+			{Content: p.CTName, ActivationType: "protocol", Arguments: Action{Winner: database.TeamCT, Nonce: p.Nonce}.URL()},
+			{Content: p.TName, ActivationType: "protocol", Arguments: Action{Winner: database.TeamT, Nonce: p.Nonce}.URL()},
+		},
+	}
+	b, err := xml.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("failed to build notification: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,14 @@
+//go:build linux
+
+package toast
+
+// Supported reports whether round result notifications work on this
+// platform. Linux notification daemons can't launch URLs from buttons
+// consistently, so they're Windows-only.
+const Supported = false
+
+// Register does nothing on Linux.
+func Register() error { return nil }
+
+// Show does nothing on Linux.
+func Show(Prompt) error { return nil }
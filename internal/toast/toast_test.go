@@ -0,0 +1,51 @@
+package toast
+
+import (
+	"strings"
+	"testing"
+
+	"csstatstracker/internal/database"
+)
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    Action
+		wantErr bool
+	}{
+		{raw: "csstatstracker:show", want: Action{Show: true}},
+		{raw: "csstatstracker:round?winner=CT", want: Action{Winner: database.TeamCT}},
+		{raw: "csstatstracker:round?winner=T", want: Action{Winner: database.TeamT}},
+		{raw: "csstatstracker:round?winner=T&nonce=N0NCE", want: Action{Winner: database.TeamT, Nonce: "N0NCE"}},
+		{raw: "csstatstracker:round?winner=X", wantErr: true},
+		{raw: "csstatstracker:undo", wantErr: true},
+		{raw: "https://example.com/round?winner=CT", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseURL(tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseURL(%q) = %+v, %v", tt.raw, got, err)
+		}
+		if err == nil && got.URL() != tt.raw {
+			t.Errorf("URL() = %q, want %q", got.URL(), tt.raw)
+		}
+	}
+}
+
+func TestPromptXML(t *testing.T) {
+	doc, err := Prompt{CT: 8, T: 7, CTName: "Blue & Co", TName: "T", Nonce: "N0NCE"}.XML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<toast activationType="protocol" launch="csstatstracker:show">`,
+		`<binding template="ToastGeneric">`,
+		`<text>Blue &amp; Co 8 – 7 T</text>`,
+		`<action content="Blue &amp; Co" activationType="protocol" arguments="csstatstracker:round?winner=CT&amp;nonce=N0NCE">`,
+		`arguments="csstatstracker:round?winner=T&amp;nonce=N0NCE"`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("XML missing %s:\n%s", want, doc)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+//go:build windows
+
+package toast
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// Supported reports whether round result notifications work on this
+// platform.
+const Supported = true
+
+// appID is the AppUserModelID toasts are shown under. Windows only shows
+// toasts for apps with a Start menu shortcut, so borrow PowerShell's, which
+// every install has, rather than requiring an installer.
+const appID = `{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe`
+
+// showScript shows the toast in $env:CSST_TOAST_XML. Passing the XML through
+// the environment avoids quoting it for the command line. The tag makes each
+// prompt replace the previous one in the Action Center.
+const showScript = `$ErrorActionPreference = 'Stop'
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+[Windows.Data.Xml.Dom.XmlDocument, Windows.Data.Xml.Dom.XmlDocument, ContentType = WindowsRuntime] > $null
+$xml = New-Object Windows.Data.Xml.Dom.XmlDocument
+$xml.LoadXml($env:CSST_TOAST_XML)
+$toast = [Windows.UI.Notifications.ToastNotification]::new($xml)
+$toast.Tag = 'round'
+$toast.Group = 'csstatstracker'
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier($env:CSST_TOAST_APPID).Show($toast)`
+
+// createNoWindow keeps PowerShell's console from flashing up.
+const createNoWindow = 0x08000000
+
+// Register points the csstatstracker: URL scheme at this executable for the
+// current user, so notification buttons reach the app. It's rewritten on
+// every start in case the executable has moved.
+func Register() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to register %s URLs: %w", Scheme, err)
+	}
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\`+Scheme, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to register %s URLs: %w", Scheme, err)
+	}
+	defer func() { _ = key.Close() }()
+	if err := key.SetStringValue("", "URL:CS Stats Tracker"); err != nil {
+		return fmt.Errorf("failed to register %s URLs: %w", Scheme, err)
+	}
+	if err := key.SetStringValue("URL Protocol", ""); err != nil {
+		return fmt.Errorf("failed to register %s URLs: %w", Scheme, err)
+	}
+
+	cmd, _, err := registry.CreateKey(key, `shell\open\command`, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to register %s URLs: %w", Scheme, err)
+	}
+	defer func() { _ = cmd.Close() }()
+	if err := cmd.SetStringValue("", fmt.Sprintf(`"%s" "%%1"`, exe)); err != nil {
+		return fmt.Errorf("failed to register %s URLs: %w", Scheme, err)
+	}
+	return nil
+}
+
+// Show displays p as a toast notification. It blocks until PowerShell has
+// handed the toast to Windows, which takes a moment, so call it off the UI
+// goroutine.
+func Show(p Prompt) error {
+	doc, err := p.XML()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("powershell.exe", "-NoProfile", "-NonInteractive", "-Command", showScript)
+	cmd.Env = append(os.Environ(), "CSST_TOAST_XML="+doc, "CSST_TOAST_APPID="+appID)
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: createNoWindow}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to show notification: %w: %s", err, out)
+	}
+	return nil
+}
@@ -5,23 +5,46 @@ import (
 	"database/sql"
 	"embed"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 
+	"csstatstracker/internal/announcer"
 	"csstatstracker/internal/config"
 	"csstatstracker/internal/database"
+	"csstatstracker/internal/gameprocess"
+	"csstatstracker/internal/gsi"
+	"csstatstracker/internal/hooks"
 	"csstatstracker/internal/hotkey"
+	"csstatstracker/internal/limiter"
+	"csstatstracker/internal/match"
 	"csstatstracker/internal/sound"
 )
 
-// Tracker owns the on-screen counters and records each increment as a round
-// in the database. There is no concept of a "game" — counters are purely a
-// visual running total since app start.
+// Tracker connects a match.Machine, which keeps the score, to the outside
+// world: hotkeys and buttons drive the machine, and the tracker observes it to
+// record each round in the database, play sounds and update the on-screen
+// counters. There is no concept of a "game" — counters are purely a visual
+// running total since app start.
 type Tracker struct {
-	ctWins       int
-	tWins        int
-	team         database.Team
+	match *match.Machine
+
+	// mu guards the fields in this block, which the UI, the hotkey listener
+	// and the game state server all read and write.
+	mu        sync.Mutex
+	party     database.PartySize
+	mode      database.Mode
+	mapName   string
+	ctName    string // the sides' names, recorded with each round
+	tName     string
+	streak    int             // current run of wins (positive) or losses (negative)
+	recorded  []recordedRound // this match's rounds as recorded here, oldest first, for undo
+	announcer announcer.Detector
+
 	ctLabel      *canvas.Text
 	tLabel       *canvas.Text
 	db           *sql.DB
@@ -29,81 +52,308 @@ type Tracker struct {
 	Config       *config.Config
 	hotkey       *hotkey.Handler
 	sound        *sound.Player
+	group        *group
 	onTeamChange func(database.Team)
+	onMapChange  func(string)
+	onModeChange func(database.Mode)
+	onRounds     func()
+	onScore      func(match.State)
 }
 
-// New creates a new Tracker instance.
-func New(db *sql.DB, w fyne.Window, cfg *config.Config, ctLabel, tLabel *canvas.Text, soundFS embed.FS) *Tracker {
+// group is the state shared by a tracker and its siblings: the one global
+// hotkey hook and which tracker its actions are routed to, plus the game
+// state event detector.
+type group struct {
+	ctx             context.Context // cancelled on app shutdown
+	active          atomic.Pointer[Tracker]
+	events          gsi.Detector
+	rounds          gsi.RoundDetector
+	account         atomic.Value // string: account new rounds are recorded against
+	onAccountChange func(string)
+	onHotkey        func(*Tracker)
+	onEvent         func(hooks.Data)
+	hooks           *hooks.Runner
+	limiter         limiter.Limiter
+	onBreak         func(until time.Time, started bool)
+	roundOver       atomic.Bool // game state last reported the round as over
+	onRoundOver     func()
+	onMatchOver     func(target *Tracker, side database.Team)
+}
+
+// New creates a new Tracker instance. Database writes are abandoned once ctx
+// is cancelled.
+func New(ctx context.Context, db *sql.DB, w fyne.Window, cfg *config.Config, ctLabel, tLabel *canvas.Text, soundFS embed.FS) *Tracker {
 	t := &Tracker{
+		match:   match.New(match.DefaultRules),
 		ctLabel: ctLabel,
 		tLabel:  tLabel,
 		db:      db,
 		window:  w,
 		Config:  cfg,
 		sound:   sound.New(soundFS, cfg.SoundEnabled, cfg.SoundVolume),
+		group:   &group{ctx: ctx, hooks: hooks.NewRunner(ctx, cfg)},
 	}
+	t.match.Subscribe(t)
+	t.group.active.Store(t)
+	t.group.account.Store(cfg.ActiveAccount)
 
-	bindings := &hotkey.Bindings{
-		IncrementCT: cfg.Hotkeys.IncrementCT,
-		DecrementCT: cfg.Hotkeys.DecrementCT,
-		IncrementT:  cfg.Hotkeys.IncrementT,
-		DecrementT:  cfg.Hotkeys.DecrementT,
-		SelectCT:    cfg.Hotkeys.SelectCT,
-		SelectT:     cfg.Hotkeys.SelectT,
-		SwapTeams:   cfg.Hotkeys.SwapTeams,
-	}
-	t.hotkey = hotkey.NewHandler(bindings)
+	t.hotkey = hotkey.NewHandler(hotkeyBindings(cfg))
+	t.hotkey.SetTiming(hotkeyTiming(cfg))
+	t.hotkey.SetFocusCheck(hotkeyFocus(cfg))
+	t.UpdateBreakLimit()
+	t.UpdateSoundTiming()
 
 	return t
 }
 
-// StartHotkeys begins listening for global hotkey events.
+// NewSibling creates another tracker with its own counters and team that
+// shares t's database, config, sound player and hotkey hook, e.g. for a
+// caster following two matches at once. Hotkeys drive whichever tracker in
+// the group was most recently activated.
+func (t *Tracker) NewSibling(ctLabel, tLabel *canvas.Text) *Tracker {
+	s := &Tracker{
+		match:   match.New(match.DefaultRules),
+		ctLabel: ctLabel,
+		tLabel:  tLabel,
+		db:      t.db,
+		window:  t.window,
+		Config:  t.Config,
+		hotkey:  t.hotkey,
+		sound:   t.sound,
+		group:   t.group,
+	}
+	s.match.Subscribe(s)
+	return s
+}
+
+// Active returns the tracker in t's group that hotkey actions go to.
+func (t *Tracker) Active() *Tracker { return t.group.active.Load() }
+
+// Activate routes hotkey actions to this tracker.
+func (t *Tracker) Activate() {
+	t.group.active.Store(t)
+}
+
+// StartHotkeys begins listening for global hotkey events. Call it once per
+// group; actions go to the active tracker.
 func (t *Tracker) StartHotkeys() {
 	t.hotkey.Start()
 
 	go func() {
 		for action := range t.hotkey.Actions() {
-			switch action {
-			case hotkey.ActionIncrementCT:
-				t.IncrementCT()
-			case hotkey.ActionDecrementCT:
-				t.DecrementCT()
-			case hotkey.ActionIncrementT:
-				t.IncrementT()
-			case hotkey.ActionDecrementT:
-				t.DecrementT()
-			case hotkey.ActionSelectCT:
-				t.SelectCT()
-			case hotkey.ActionSelectT:
-				t.SelectT()
-			case hotkey.ActionSwapTeams:
-				t.SwapTeams()
+			target := t.group.active.Load()
+			if run, ok := actionHandlers[action]; ok {
+				run(target)
+			}
+			if t.group.onHotkey != nil {
+				t.group.onHotkey(target)
 			}
 		}
 	}()
 }
 
+// actionHandlers runs each action in config.HotkeyActions on the tracker
+// hotkeys are routed to.
+var actionHandlers = map[hotkey.Action]func(*Tracker){
+	"increment_ct": (*Tracker).IncrementCT,
+	"decrement_ct": (*Tracker).DecrementCT,
+	"increment_t":  (*Tracker).IncrementT,
+	"decrement_t":  (*Tracker).DecrementT,
+	"amend_last":   (*Tracker).AmendLast,
+	"select_ct":    (*Tracker).SelectCT,
+	"select_t":     (*Tracker).SelectT,
+	"swap_teams":   (*Tracker).SwapTeams,
+	"toggle_sound": (*Tracker).ToggleSound,
+}
+
+// SetOnHotkey sets a callback run after each hotkey action with the tracker it
+// went to. It's shared by the group; set it before StartHotkeys.
+func (t *Tracker) SetOnHotkey(callback func(*Tracker)) {
+	t.group.onHotkey = callback
+}
+
+// SetOnEvent sets a callback run with every event hooks fire on, e.g. to
+// forward it to plugins. It's shared by the group.
+func (t *Tracker) SetOnEvent(callback func(hooks.Data)) {
+	t.group.onEvent = callback
+}
+
 // Sound returns the sound player.
 func (t *Tracker) Sound() *sound.Player { return t.sound }
 
-// SetTeam sets the player's team.
-func (t *Tracker) SetTeam(team database.Team) { t.team = team }
+// SetTeam sets the player's team without playing the select sound or
+// running the team change callback, for the team selector itself.
+func (t *Tracker) SetTeam(team database.Team) { t.match.SetTeam(team) }
 
 // Team returns the current team.
-func (t *Tracker) Team() database.Team { return t.team }
+func (t *Tracker) Team() database.Team { return t.match.State().Team }
 
-// UpdateHotkeys updates the hotkey bindings.
+// SetPartySize sets the party size recorded with subsequent rounds.
+func (t *Tracker) SetPartySize(party database.PartySize) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.party = party
+}
+
+// PartySize returns the current party size.
+func (t *Tracker) PartySize() database.PartySize {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.party
+}
+
+// SetMode sets the game mode recorded with subsequent rounds, and plays the
+// match under its rules, e.g. first to 8 in Wingman.
+func (t *Tracker) SetMode(mode database.Mode) {
+	t.mu.Lock()
+	t.mode = mode
+	t.mu.Unlock()
+	t.match.SetRules(match.RulesFor(mode))
+}
+
+// Mode returns the current game mode.
+func (t *Tracker) Mode() database.Mode {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.mode
+}
+
+// SetSideNames sets the names of the CT and T sides recorded with subsequent
+// rounds, e.g. "Us" and "Them".
+func (t *Tracker) SetSideNames(ctName, tName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.ctName, t.tName = ctName, tName
+}
+
+// SetOnModeChange sets the callback run when game state reports another
+// game mode.
+func (t *Tracker) SetOnModeChange(callback func(database.Mode)) {
+	t.onModeChange = callback
+}
+
+// UpdateHotkeys updates the hotkey bindings and timing.
 func (t *Tracker) UpdateHotkeys() {
-	bindings := &hotkey.Bindings{
-		IncrementCT: t.Config.Hotkeys.IncrementCT,
-		DecrementCT: t.Config.Hotkeys.DecrementCT,
-		IncrementT:  t.Config.Hotkeys.IncrementT,
-		DecrementT:  t.Config.Hotkeys.DecrementT,
-		SelectCT:    t.Config.Hotkeys.SelectCT,
-		SelectT:     t.Config.Hotkeys.SelectT,
-		SwapTeams:   t.Config.Hotkeys.SwapTeams,
+	t.hotkey.UpdateBindings(hotkeyBindings(t.Config))
+	t.hotkey.SetTiming(hotkeyTiming(t.Config))
+	t.hotkey.SetFocusCheck(hotkeyFocus(t.Config))
+}
+
+// UpdateSoundTiming applies the sound cooldown settings.
+func (t *Tracker) UpdateSoundTiming() {
+	st := t.Config.SoundTiming
+	timing := sound.Timing{
+		Cooldown:  time.Duration(st.Cooldown) * time.Millisecond,
+		Cooldowns: make(map[string]time.Duration),
+		Restart:   st.Restart,
+	}
+	for name, ms := range st.Cooldowns {
+		if ms >= 0 {
+			timing.Cooldowns[name] = time.Duration(ms) * time.Millisecond
+		}
 	}
-	t.hotkey.UpdateBindings(bindings)
+	t.sound.SetTiming(timing)
+}
+
+// UpdateBreakLimit applies the break limit settings.
+func (t *Tracker) UpdateBreakLimit() {
+	bl := t.Config.BreakLimit
+	matches := 0
+	if bl.Enabled {
+		matches = bl.Matches
+	}
+	t.group.limiter.Configure(matches, time.Duration(bl.BreakMinutes)*time.Minute)
+}
+
+// SetOnBreak sets the callback run when a finished match starts a break
+// (started is true) and when a new match can't be started because one is
+// on. It's shared by the group.
+func (t *Tracker) SetOnBreak(callback func(until time.Time, started bool)) {
+	t.group.onBreak = callback
+}
+
+// SetOnRoundOver sets the callback run when game state reports a round has
+// ended. It's shared by the group.
+func (t *Tracker) SetOnRoundOver(callback func()) {
+	t.group.onRoundOver = callback
+}
+
+// SetOnMatchOver sets the callback run instead of recording a round when
+// the match's score is already decided under its rules, so the round can't
+// belong to it. The callback can record it anyway with Record, or in a new
+// match with NewMatch. It's shared by the group.
+func (t *Tracker) SetOnMatchOver(callback func(target *Tracker, side database.Team)) {
+	t.group.onMatchOver = callback
+}
+
+// BreakUntil reports whether a break holds off the next round, and when it
+// ends. A break only holds off a new match, so a match already under way
+// can be finished.
+func (t *Tracker) BreakUntil() (time.Time, bool) {
+	state := t.match.State()
+	if state.CTWins+state.TWins > 0 && !t.match.Rules().Decided(state.CTWins, state.TWins) {
+		return time.Time{}, false
+	}
+	return t.group.limiter.Locked(time.Now())
+}
+
+// OverrideBreak ends the current break early so rounds can be recorded.
+func (t *Tracker) OverrideBreak() { t.group.limiter.Override() }
+
+// breakActive reports whether a break holds off the next round, telling the
+// onBreak callback if it does.
+func (t *Tracker) breakActive() bool {
+	until, locked := t.BreakUntil()
+	if locked && t.group.onBreak != nil {
+		cb := t.group.onBreak
+		fyne.Do(func() { cb(until, false) })
+	}
+	return locked
+}
+
+// hotkeyBindings returns cfg's combos in config.HotkeyActions order. It
+// copies them, since Settings edits cfg while the hook reads the bindings.
+func hotkeyBindings(cfg *config.Config) *hotkey.Bindings {
+	strategy := hotkey.MatchExact
+	switch cfg.HotkeyMatch {
+	case config.MatchSuperset:
+		strategy = hotkey.MatchSuperset
+	case config.MatchLongest:
+		strategy = hotkey.MatchLongest
+	}
+	b := &hotkey.Bindings{Strategy: strategy}
+	for _, a := range config.HotkeyActions {
+		b.Combos = append(b.Combos, hotkey.Combo{
+			Action: hotkey.Action(a.Key),
+			Keys:   slices.Clone(cfg.Hotkeys[a.Key]),
+		})
+	}
+	return b
+}
+
+func hotkeyTiming(cfg *config.Config) hotkey.Timing {
+	timing := hotkey.Timing{
+		Cooldowns:   make(map[hotkey.Action]time.Duration),
+		RepeatDelay: time.Duration(cfg.HotkeyTiming.RepeatDelay) * time.Millisecond,
+	}
+	if cfg.HotkeyTiming.Repeat == config.RepeatDelay {
+		timing.Repeat = hotkey.RepeatAfterDelay
+	}
+	for name, ms := range cfg.HotkeyTiming.Cooldowns {
+		if ms >= 0 {
+			timing.Cooldowns[hotkey.Action(name)] = time.Duration(ms) * time.Millisecond
+		}
+	}
+	return timing
+}
+
+// hotkeyFocus returns the check that keeps hotkeys to the game and the
+// tracker, or nil if they fire whatever has the focus.
+func hotkeyFocus(cfg *config.Config) func() bool {
+	if !cfg.HotkeyFocus {
+		return nil
+	}
+	return gameprocess.Focused
 }
 
 // SetOnTeamChange sets the callback for team changes.
@@ -111,96 +361,454 @@ func (t *Tracker) SetOnTeamChange(callback func(database.Team)) {
 	t.onTeamChange = callback
 }
 
-// SelectCT selects CT as the player's team.
-func (t *Tracker) SelectCT() {
-	t.team = database.TeamCT
-	t.sound.PlayCTSelect()
-	if t.onTeamChange != nil {
-		fyne.Do(func() { t.onTeamChange(database.TeamCT) })
+// SetAccount sets the account new rounds are recorded against, for this
+// tracker and its siblings.
+func (t *Tracker) SetAccount(name string) { t.group.account.Store(name) }
+
+// Account returns the account new rounds are recorded against.
+func (t *Tracker) Account() string { return t.group.account.Load().(string) }
+
+// SetOnAccountChange sets the callback run when game state switches the
+// account because a different configured Steam account is playing.
+func (t *Tracker) SetOnAccountChange(callback func(string)) {
+	t.group.onAccountChange = callback
+}
+
+// SetOnMapChange sets the callback run when game state reports a new map.
+func (t *Tracker) SetOnMapChange(callback func(string)) {
+	t.onMapChange = callback
+}
+
+// SetOnScoreChange sets the callback run with the match state after the
+// counters change, e.g. to mirror them on another screen. Pass nil to stop.
+func (t *Tracker) SetOnScoreChange(callback func(match.State)) {
+	t.onScore = callback
+}
+
+// MapName returns the map reported by game state, or "" if none has been.
+func (t *Tracker) MapName() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.mapName
+}
+
+// setMapName sets the map reported by game state, and reports whether it's
+// a different one.
+func (t *Tracker) setMapName(name string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if name == t.mapName {
+		return false
 	}
+	t.mapName = name
+	return true
 }
 
-// SelectT selects T as the player's team.
-func (t *Tracker) SelectT() {
-	t.team = database.TeamT
-	t.sound.PlayTSelect()
-	if t.onTeamChange != nil {
-		fyne.Do(func() { t.onTeamChange(database.TeamT) })
+// HandleGSI applies a game state update to the active tracker: the map name
+// and game mode are picked up, and the team follows the side the local
+// player is on, which also covers the halftime switch. Updates while
+// spectating someone else don't change the team.
+//
+// Notable moments (bomb plants, aces, MVPs) and how each round ended are
+// recorded to the database, and if the game is running on a configured
+// Steam account, that account becomes the active one.
+func (t *Tracker) HandleGSI(state *gsi.State) {
+	target := t.group.active.Load()
+
+	for _, ev := range t.group.events.Observe(state) {
+		ctx, cancel := database.WithTimeout(t.group.ctx)
+		err := database.InsertMoment(ctx, t.db, database.MomentKind(ev.Kind), ev.Map, ev.Round, t.Account())
+		cancel()
+		if err != nil {
+			fyne.LogError("failed to record moment", err)
+		}
+	}
+	if o, ok := t.group.rounds.Observe(state); ok {
+		ctx, cancel := database.WithTimeout(t.group.ctx)
+		err := database.InsertRoundOutcome(ctx, t.db, database.RoundOutcome{
+			Map:        o.Map,
+			Round:      o.Round,
+			Winner:     database.Team(o.Winner),
+			Reason:     database.WinReason(o.Reason),
+			Bomb:       o.Bomb,
+			Side:       database.Team(o.Side),
+			Money:      o.Money,
+			EquipValue: o.EquipValue,
+			Account:    t.Account(),
+		})
+		cancel()
+		if err != nil {
+			fyne.LogError("failed to record round outcome", err)
+		}
+	}
+
+	if name := state.Map.Name; name != "" && target.setMapName(name) {
+		if target.onMapChange != nil {
+			fyne.Do(func() { target.onMapChange(name) })
+		}
+	}
+
+	if mode := gsiMode(state.Map.Mode); mode != database.ModeUnknown && mode != target.Mode() {
+		target.SetMode(mode)
+		if target.onModeChange != nil {
+			fyne.Do(func() { target.onModeChange(mode) })
+		}
+	}
+
+	if name := t.Config.AccountBySteamID(state.Provider.SteamID); name != "" && name != t.Account() {
+		t.SetAccount(name)
+		if cb := t.group.onAccountChange; cb != nil {
+			fyne.Do(func() { cb(name) })
+		}
+	}
+
+	// Game state keeps reporting "over" until the next freeze time, so only
+	// the change counts.
+	over := state.Round.Phase == "over"
+	if t.group.roundOver.Swap(over) != over && over && t.group.onRoundOver != nil {
+		fyne.Do(t.group.onRoundOver)
+	}
+
+	if !state.IsLocalPlayer() {
+		return
+	}
+	if side := database.Team(state.Player.Team); side == database.TeamCT || side == database.TeamT {
+		if target.Team() != side {
+			target.match.SelectTeam(side)
+		}
+	}
+}
+
+// gsiMode returns the game mode game state calls name, or ModeUnknown for
+// modes the tracker doesn't keep score in, such as Deathmatch.
+func gsiMode(name string) database.Mode {
+	switch name {
+	case "premier":
+		return database.ModePremier
+	case "competitive":
+		return database.ModeCompetitive
+	case "scrimcomp2v2", "wingman":
+		return database.ModeWingman
+	case "casual":
+		return database.ModeCasual
+	default:
+		return database.ModeUnknown
 	}
 }
 
+// SetOnRoundsChange sets the callback run after a round is recorded or undone.
+func (t *Tracker) SetOnRoundsChange(callback func()) {
+	t.onRounds = callback
+}
+
+// SelectCT selects CT as the player's team.
+func (t *Tracker) SelectCT() { t.match.SelectTeam(database.TeamCT) }
+
+// SelectT selects T as the player's team.
+func (t *Tracker) SelectT() { t.match.SelectTeam(database.TeamT) }
+
 // SwapTeams flips the player's team. Counters stay as-is — they just reflect
 // rounds recorded so far, unrelated to which side the player is on now.
-func (t *Tracker) SwapTeams() {
-	switch t.team {
-	case database.TeamCT:
-		t.team = database.TeamT
-		t.sound.PlayTSelect()
-		if t.onTeamChange != nil {
-			fyne.Do(func() { t.onTeamChange(database.TeamT) })
+func (t *Tracker) SwapTeams() { t.match.SwapTeams() }
+
+// ToggleSound mutes or unmutes sounds until the app restarts or the sound
+// setting changes.
+func (t *Tracker) ToggleSound() { t.sound.SetEnabled(!t.sound.IsEnabled()) }
+
+// IncrementCT records a CT round, unless the match is over or a break holds
+// off a new one.
+func (t *Tracker) IncrementCT() { t.increment(database.TeamCT) }
+
+// DecrementCT deletes the most recent CT round.
+func (t *Tracker) DecrementCT() { t.match.Undo(database.TeamCT) }
+
+// IncrementT records a T round, unless the match is over or a break holds
+// off a new one.
+func (t *Tracker) IncrementT() { t.increment(database.TeamT) }
+
+func (t *Tracker) increment(side database.Team) {
+	if t.breakActive() {
+		return
+	}
+	state := t.match.State()
+	if t.match.Rules().Decided(state.CTWins, state.TWins) && t.group.onMatchOver != nil {
+		cb := t.group.onMatchOver
+		fyne.Do(func() { cb(t, side) })
+		return
+	}
+	t.match.Win(side)
+}
+
+// Record records a round won by side without checking the break or the
+// score.
+func (t *Tracker) Record(side database.Team) { t.match.Win(side) }
+
+// NewMatch resets the counters to 0–0 for a new match, keeping the side,
+// and records a round won by side in it.
+func (t *Tracker) NewMatch(side database.Team) {
+	t.match.Reset()
+	t.match.Win(side)
+}
+
+// DecrementT deletes the most recent T round.
+func (t *Tracker) DecrementT() { t.match.Undo(database.TeamT) }
+
+// AmendLast re-scores the most recent round this tracker recorded for the
+// other side, for a round recorded for the wrong one.
+func (t *Tracker) AmendLast() {
+	t.mu.Lock()
+	n := len(t.recorded)
+	var winner database.Team
+	if n > 0 {
+		winner = t.recorded[n-1].winner
+	}
+	t.mu.Unlock()
+	if n == 0 {
+		return
+	}
+	t.match.Undo(winner)
+	if winner == database.TeamCT {
+		t.match.Win(database.TeamT)
+	} else {
+		t.match.Win(database.TeamCT)
+	}
+}
+
+// Match returns the score-keeping state machine behind the counters, e.g.
+// to subscribe to its events.
+func (t *Tracker) Match() *match.Machine { return t.match }
+
+// Observe implements match.Observer: it records and undoes rounds in the
+// database, plays the sounds and keeps the counters and team selector in
+// step with the match.
+func (t *Tracker) Observe(e match.Event) {
+	switch e.Kind {
+	case match.RoundWon:
+		t.sound.SkipStinger()
+		t.recordRound(e.Side, e.State.Team)
+		t.updateLabels(e.State)
+		if e.Side == database.TeamCT {
+			t.sound.PlayCTIncrement()
+		} else {
+			t.sound.PlayTIncrement()
+		}
+		t.playStinger(e)
+		if decides(e, t.match.Rules()) {
+			t.finishMatch()
+		}
+	case match.RoundUndone:
+		t.sound.SkipStinger()
+		t.undoLastRound(e.Side)
+		t.updateLabels(e.State)
+		if e.Side == database.TeamCT {
+			t.sound.PlayCTDecrement()
+		} else {
+			t.sound.PlayTDecrement()
+		}
+	case match.Reset:
+		t.sound.SkipStinger()
+		t.mu.Lock()
+		t.recorded = nil
+		t.announcer.NewMatch()
+		t.mu.Unlock()
+		t.updateLabels(e.State)
+	case match.TeamSelected:
+		switch e.State.Team {
+		case database.TeamCT:
+			t.sound.PlayCTSelect()
+		case database.TeamT:
+			t.sound.PlayTSelect()
 		}
-	case database.TeamT:
-		t.team = database.TeamCT
-		t.sound.PlayCTSelect()
 		if t.onTeamChange != nil {
-			fyne.Do(func() { t.onTeamChange(database.TeamCT) })
+			team := e.State.Team
+			fyne.Do(func() { t.onTeamChange(team) })
 		}
 	}
 }
 
-// IncrementCT records a CT round.
-func (t *Tracker) IncrementCT() {
-	t.ctWins++
-	t.recordRound(database.TeamCT)
-	t.updateLabels()
-	t.sound.PlayCTIncrement()
+func (t *Tracker) recordRound(winner, team database.Team) {
+	t.mu.Lock()
+	r := database.Round{
+		Winner:    winner,
+		Team:      team,
+		PartySize: t.party,
+		Account:   t.Account(),
+		Map:       t.mapName,
+		Mode:      t.mode,
+		CTName:    t.ctName,
+		TName:     t.tName,
+	}
+	t.mu.Unlock()
+	ctx, cancel := database.WithTimeout(t.group.ctx)
+	defer cancel()
+	id, err := database.InsertRound(ctx, t.db, r)
+	if err != nil {
+		fyne.LogError("failed to record round", err)
+		return
+	}
+	t.mu.Lock()
+	t.recorded = append(t.recorded, recordedRound{id: int(id), winner: winner})
+	t.mu.Unlock()
+	t.notifyRounds()
+	t.fireHooks(r)
+	t.announce(r.Result())
+	t.group.limiter.Played(time.Now())
 }
 
-// DecrementCT deletes the most recent CT round.
-func (t *Tracker) DecrementCT() {
-	if t.ctWins > 0 {
-		t.ctWins--
-		t.undoLastRound(database.TeamCT)
-		t.updateLabels()
-		t.sound.PlayCTDecrement()
+// finishMatch counts a match the last round decided toward the break limit,
+// telling the onBreak callback if it starts a break.
+func (t *Tracker) finishMatch() {
+	if until, started := t.group.limiter.Finished(time.Now()); started && t.group.onBreak != nil {
+		cb := t.group.onBreak
+		fyne.Do(func() { cb(until, true) })
 	}
 }
 
-// IncrementT records a T round.
-func (t *Tracker) IncrementT() {
-	t.tWins++
-	t.recordRound(database.TeamT)
-	t.updateLabels()
-	t.sound.PlayTIncrement()
+// fireHooks runs the user's hooks for a recorded round, and the streak hooks
+// if it extended a run of wins or losses.
+func (t *Tracker) fireHooks(r database.Round) {
+	res := r.Result()
+	result := "draw"
+	t.mu.Lock()
+	switch {
+	case res == database.ResultWin && t.streak > 0:
+		t.streak++
+	case res == database.ResultWin:
+		t.streak = 1
+	case res == database.ResultLoss && t.streak < 0:
+		t.streak--
+	case res == database.ResultLoss:
+		t.streak = -1
+	}
+	streak := t.streak
+	t.mu.Unlock()
+	switch res {
+	case database.ResultWin:
+		result = "win"
+	case database.ResultLoss:
+		result = "loss"
+	}
+
+	state := t.match.State()
+	data := hooks.Data{
+		Event:   hooks.RoundRecorded,
+		Winner:  string(r.Winner),
+		Team:    string(r.Team),
+		Result:  result,
+		Map:     r.Map,
+		Account: r.Account,
+		CTScore: state.CTWins,
+		TScore:  state.TWins,
+		Streak:  streak,
+	}
+	t.fire(data)
+	if res != database.ResultDraw {
+		data.Event = hooks.StreakReached
+		t.fire(data)
+	}
 }
 
-// DecrementT deletes the most recent T round.
-func (t *Tracker) DecrementT() {
-	if t.tWins > 0 {
-		t.tWins--
-		t.undoLastRound(database.TeamT)
-		t.updateLabels()
-		t.sound.PlayTDecrement()
+// announce plays the announcer's sound if the round just recorded, with
+// result res, reached a win streak milestone or completed a reverse sweep.
+func (t *Tracker) announce(res database.Result) {
+	cfg := t.Config.Announcer
+	t.mu.Lock()
+	moment := t.announcer.Record(res, time.Now(), announcer.Rules{Streaks: cfg.Streaks, Comeback: cfg.Comeback})
+	t.mu.Unlock()
+	if !cfg.Enabled {
+		return
+	}
+	switch moment {
+	case announcer.WinStreak:
+		t.sound.PlayStreak(cfg.StreakSound)
+	case announcer.Comeback:
+		t.sound.PlayComeback(cfg.ComebackSound)
 	}
 }
 
-func (t *Tracker) recordRound(winner database.Team) {
-	if _, err := database.InsertRound(context.Background(), t.db, winner, t.team); err != nil {
-		fyne.LogError("failed to record round", err)
+// decides reports whether the round won in e decided the match under rules,
+// rather than being one recorded past the end of it.
+func decides(e match.Event, rules match.Rules) bool {
+	ct, tWins := e.State.CTWins, e.State.TWins
+	if !rules.Decided(ct, tWins) {
+		return false
+	}
+	if e.Side == database.TeamCT {
+		ct--
+	} else {
+		tWins--
+	}
+	return !rules.Decided(ct, tWins)
+}
+
+// playStinger plays the match won or lost stinger if the round won in e
+// decided the match. The side that wins the deciding round wins the match.
+func (t *Tracker) playStinger(e match.Event) {
+	cfg := t.Config.Stingers
+	team := e.State.Team
+	if !cfg.Enabled || team == database.TeamNone || !decides(e, t.match.Rules()) {
+		return
+	}
+	if e.Side == team {
+		t.sound.PlayStinger(sound.FindStinger(sound.WinStingers, cfg.Win), cfg.WinFile)
+	} else {
+		t.sound.PlayStinger(sound.FindStinger(sound.LoseStingers, cfg.Lose), cfg.LoseFile)
 	}
 }
 
+// fire runs the hooks for data and passes it to the event callback.
+func (t *Tracker) fire(data hooks.Data) {
+	t.group.hooks.Fire(data)
+	if t.group.onEvent != nil {
+		t.group.onEvent(data)
+	}
+}
+
+// recordedRound is a round the tracker recorded, kept to undo it.
+type recordedRound struct {
+	id     int
+	winner database.Team
+}
+
+// undoLastRound deletes the last round won by winner that this tracker
+// recorded. Rounds recorded elsewhere, such as by another match tab or a
+// FACEIT sync, are never undone.
 func (t *Tracker) undoLastRound(winner database.Team) {
-	if _, err := database.DeleteLastRoundForWinner(context.Background(), t.db, winner); err != nil {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	i := len(t.recorded) - 1
+	for i >= 0 && t.recorded[i].winner != winner {
+		i--
+	}
+	if i < 0 {
+		return
+	}
+	ctx, cancel := database.WithTimeout(t.group.ctx)
+	defer cancel()
+	if err := database.DeleteRound(ctx, t.db, t.recorded[i].id); err != nil {
 		fyne.LogError("failed to undo round", err)
+		return
 	}
+	t.recorded = slices.Delete(t.recorded, i, i+1)
+	t.streak = 0 // the round before the undone one isn't known here
+	t.announcer.Undo(database.Round{Winner: winner, Team: t.Team()}.Result())
+	t.notifyRounds()
 }
 
-func (t *Tracker) updateLabels() {
+func (t *Tracker) notifyRounds() {
+	if t.onRounds != nil {
+		fyne.Do(t.onRounds)
+	}
+}
+
+func (t *Tracker) updateLabels(state match.State) {
 	fyne.Do(func() {
-		t.ctLabel.Text = fmt.Sprintf("%d", t.ctWins)
-		t.tLabel.Text = fmt.Sprintf("%d", t.tWins)
+		t.ctLabel.Text = fmt.Sprintf("%d", state.CTWins)
+		t.tLabel.Text = fmt.Sprintf("%d", state.TWins)
 		t.ctLabel.Refresh()
 		t.tLabel.Refresh()
+		if t.onScore != nil {
+			t.onScore(state)
+		}
 	})
 }
@@ -0,0 +1,148 @@
+package tracker_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+
+	"csstatstracker"
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/gsi"
+	"csstatstracker/internal/tracker"
+)
+
+// TestConcurrentGSIAndHotkeys records and undoes rounds, as hotkeys do, while
+// game state keeps changing the map and mode. Run it with -race.
+func TestConcurrentGSIAndHotkeys(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+	tr := tracker.New(ctx, db, test.NewTempWindow(t, nil), cfg,
+		canvas.NewText("", nil), canvas.NewText("", nil), csstatstracker.SoundFS)
+	tr.SelectCT()
+	tr.HandleGSI(&gsi.State{Map: gsi.Map{Name: "de_mirage"}})
+
+	const n = 50
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		maps := []string{"de_mirage", "de_nuke"}
+		modes := []string{"competitive", "premier"}
+		for i := range n {
+			tr.HandleGSI(&gsi.State{Map: gsi.Map{Name: maps[i%2], Mode: modes[i%2]}})
+		}
+	})
+	wg.Go(func() {
+		for i := range n {
+			tr.IncrementCT()
+			if i%2 == 1 {
+				tr.DecrementCT()
+			}
+		}
+	})
+	wg.Go(func() {
+		for range n {
+			tr.SetPartySize(database.PartyDuo)
+			_ = tr.MapName()
+		}
+	})
+	wg.Wait()
+
+	rounds, err := database.GetAllRounds(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != n/2 {
+		t.Errorf("%d rounds recorded, want %d after undoing every other one", len(rounds), n/2)
+	}
+	for _, r := range rounds {
+		if r.Map != "de_mirage" && r.Map != "de_nuke" {
+			t.Errorf("round recorded on map %q, want one game state reported", r.Map)
+		}
+	}
+}
+
+func TestBreakLimitCountsMatches(t *testing.T) {
+	test.NewTempApp(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+	cfg.BreakLimit = config.BreakLimit{Enabled: true, Matches: 1, BreakMinutes: 15}
+	tr := tracker.New(context.Background(), dbtest.New(t), test.NewTempWindow(t, nil), cfg,
+		canvas.NewText("", nil), canvas.NewText("", nil), csstatstracker.SoundFS)
+	other := tr.NewSibling(canvas.NewText("", nil), canvas.NewText("", nil))
+	breaks := 0
+	tr.SetOnBreak(func(_ time.Time, started bool) {
+		if started {
+			breaks++
+		}
+	})
+	score := func(tr *tracker.Tracker) int {
+		s := tr.Match().State()
+		return s.CTWins + s.TWins
+	}
+
+	for range 5 {
+		tr.IncrementCT()
+	}
+	for range 12 {
+		other.IncrementT()
+	}
+	if breaks != 0 {
+		t.Fatal("a break started before any match was over")
+	}
+	other.IncrementT() // 13–0 ends the sibling's match
+	if _, on := other.BreakUntil(); breaks != 1 || !on {
+		t.Fatalf("%d breaks started, sibling on a break: %v; want one break after its match", breaks, on)
+	}
+
+	// The break holds off the sibling's next match, not the rest of this one.
+	other.IncrementT()
+	tr.IncrementCT()
+	if score(other) != 13 || score(tr) != 6 {
+		t.Errorf("scores during the break = %d and %d rounds; want 13 kept and 6 recorded", score(other), score(tr))
+	}
+	for range 7 {
+		tr.IncrementCT() // ends this match too
+	}
+	tr.IncrementCT()
+	if score(tr) != 13 {
+		t.Errorf("%d rounds recorded past the match during the break; want it held at 13", score(tr)-13)
+	}
+}
+
+func TestAmendLast(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+	tr := tracker.New(ctx, db, test.NewTempWindow(t, nil), cfg,
+		canvas.NewText("", nil), canvas.NewText("", nil), csstatstracker.SoundFS)
+	tr.SelectCT()
+
+	tr.AmendLast() // nothing recorded yet
+	tr.IncrementCT()
+	tr.IncrementCT()
+	tr.AmendLast()
+	if s := tr.Match().State(); s.CTWins != 1 || s.TWins != 1 {
+		t.Errorf("score after amending = %d–%d, want 1–1", s.CTWins, s.TWins)
+	}
+	rounds, err := database.GetAllRounds(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	winners := map[database.Team]int{}
+	for _, r := range rounds {
+		winners[r.Winner]++
+	}
+	if len(rounds) != 2 || winners[database.TeamCT] != 1 || winners[database.TeamT] != 1 {
+		t.Errorf("rounds after amending = %+v, want one won by each side", rounds)
+	}
+}
@@ -0,0 +1,89 @@
+// Package trayicon renders the tray icon with the current score on it, so
+// the score can be read from the tray without opening the window.
+package trayicon
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Size is the width and height of the rendered icon in pixels, enough for
+// tray icons on high-DPI screens.
+const Size = 64
+
+// shade darkens the base icon behind the score so the digits stand out.
+var shade = color.RGBA{A: 150}
+
+var face font.Face
+
+func init() {
+	f, err := opentype.Parse(gobold.TTF)
+	if err != nil {
+		panic(fmt.Sprintf("trayicon: %v", err))
+	}
+	face, err = opentype.NewFace(f, &opentype.FaceOptions{Size: Size * 0.45, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		panic(fmt.Sprintf("trayicon: %v", err))
+	}
+}
+
+// Renderer draws scores onto a base icon.
+type Renderer struct {
+	base *image.RGBA // the icon scaled to Size and shaded
+}
+
+// New creates a Renderer for base, a PNG of any size.
+func New(base []byte) (*Renderer, error) {
+	src, err := png.Decode(bytes.NewReader(base))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tray icon: %w", err)
+	}
+	img := image.NewRGBA(image.Rect(0, 0, Size, Size))
+	draw.CatmullRom.Scale(img, img.Bounds(), src, src.Bounds(), draw.Src, nil)
+	draw.Draw(img, img.Bounds(), image.NewUniform(shade), image.Point{}, draw.Over)
+	return &Renderer{base: img}, nil
+}
+
+// Render returns a PNG of the icon with the CT score drawn on its top half
+// and the T score on its bottom half, in the sides' colours.
+func (r *Renderer) Render(ct, t int, ctColor, tColor color.Color) ([]byte, error) {
+	img := image.NewRGBA(r.base.Bounds())
+	copy(img.Pix, r.base.Pix)
+	drawCentered(img, strconv.Itoa(ct), Size/2-2, ctColor)
+	drawCentered(img, strconv.Itoa(t), Size-2, tColor)
+
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, fmt.Errorf("failed to encode tray icon: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// drawCentered draws text centred horizontally with its baseline at y,
+// shrinking it to fit if it's too wide.
+func drawCentered(img *image.RGBA, text string, y int, c color.Color) {
+	d := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: face}
+	width := d.MeasureString(text).Ceil()
+	if width > Size {
+		// Three or more digits: draw at full size on a wider canvas and
+		// scale it down into place.
+		wide := image.NewRGBA(image.Rect(0, 0, width, Size/2))
+		d.Dst = wide
+		d.Dot = fixed.P(0, Size/2-2)
+		d.DrawString(text)
+		draw.CatmullRom.Scale(img, image.Rect(0, y-Size/2+2, Size, y+2), wide, wide.Bounds(), draw.Over, nil)
+		return
+	}
+	d.Dot = fixed.P((Size-width)/2, y)
+	d.DrawString(text)
+}
@@ -0,0 +1,45 @@
+package trayicon
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	var base bytes.Buffer
+	src := image.NewRGBA(image.Rect(0, 0, 256, 256))
+	if err := png.Encode(&base, src); err != nil {
+		t.Fatal(err)
+	}
+	r, err := New(base.Bytes())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	ct, tc := color.RGBA{R: 100, G: 149, B: 237, A: 255}, color.RGBA{R: 255, G: 140, B: 0, A: 255}
+	seen := map[string]bool{}
+	for _, score := range [][2]int{{0, 0}, {8, 7}, {7, 8}, {13, 11}, {120, 4}} {
+		b, err := r.Render(score[0], score[1], ct, tc)
+		if err != nil {
+			t.Fatalf("Render(%v): %v", score, err)
+		}
+		img, err := png.Decode(bytes.NewReader(b))
+		if err != nil {
+			t.Fatalf("Render(%v) isn't a PNG: %v", score, err)
+		}
+		if img.Bounds().Dx() != Size || img.Bounds().Dy() != Size {
+			t.Errorf("Render(%v) is %v, want %dx%d", score, img.Bounds(), Size, Size)
+		}
+		if seen[string(b)] {
+			t.Errorf("Render(%v) looks the same as another score", score)
+		}
+		seen[string(b)] = true
+	}
+
+	if _, err := New([]byte("not a png")); err == nil {
+		t.Error("New accepted an invalid PNG")
+	}
+}
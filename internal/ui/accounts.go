@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+)
+
+// accountNames returns select options for choosing an account: none first
+// (standing for "no account"), then the configured accounts, then any extra
+// names not configured anymore, such as the account on an old round.
+func accountNames(cfg *config.Config, none string, extra ...string) []string {
+	names := []string{none}
+	for _, a := range cfg.Accounts {
+		if a.Name != "" && !slices.Contains(names, a.Name) {
+			names = append(names, a.Name)
+		}
+	}
+	for _, name := range extra {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// accountOption maps an account name to its select option.
+func accountOption(name, none string) string {
+	if name == "" {
+		return none
+	}
+	return name
+}
+
+// accountFromOption maps a select option back to an account name.
+func accountFromOption(option, none string) string {
+	if option == none {
+		return ""
+	}
+	return option
+}
+
+// buildAccountsSection creates the Settings editor for the Steam account list
+// and the account new rounds are recorded against. Switching account
+// switches to its own hotkeys, sounds and appearance if it has them, so the
+// whole tab is rebuilt to show them.
+func (s *SettingsTab) buildAccountsSection() fyne.CanvasObject {
+	const none = "None"
+
+	activeSelect := widget.NewSelect(accountNames(s.cfg, none), func(selected string) {
+		name := accountFromOption(selected, none)
+		if name == s.cfg.ActiveAccount {
+			return
+		}
+		s.cfg.SwitchAccount(name)
+		s.save()
+		s.Reload()
+	})
+	activeSelect.SetSelected(accountOption(s.cfg.ActiveAccount, none))
+
+	rows := container.NewVBox()
+	for i := range s.cfg.Accounts {
+		// Renaming moves the account's rounds with it, so it waits until the
+		// name is done rather than renaming on every keystroke.
+		nameEntry := NewCommitEntry(s.cfg.Accounts[i].Name, func(text string) {
+			s.renameAccount(i, strings.TrimSpace(text))
+		})
+		nameEntry.SetPlaceHolder("Name")
+
+		steamIDEntry := widget.NewEntry()
+		steamIDEntry.SetPlaceHolder("SteamID64 (optional)")
+		steamIDEntry.SetText(s.cfg.Accounts[i].SteamID)
+		steamIDEntry.OnChanged = func(text string) {
+			s.cfg.Accounts[i].SteamID = strings.TrimSpace(text)
+			s.save()
+		}
+
+		ownCheck := widget.NewCheck("Own settings", func(own bool) {
+			s.cfg.SetOwnSettings(i, own)
+			s.save()
+			s.Reload()
+		})
+		ownCheck.Checked = s.cfg.Accounts[i].Settings != nil
+
+		removeBtn := widget.NewButton("Remove", func() {
+			name := s.cfg.Accounts[i].Name
+			if s.cfg.ActiveAccount == name {
+				s.cfg.SwitchAccount("")
+			}
+			s.cfg.Accounts = slices.Delete(s.cfg.Accounts, i, i+1)
+			if s.cfg.StatsAccount == name {
+				s.cfg.StatsAccount = ""
+			}
+			s.save()
+			s.Reload()
+		})
+
+		rows.Add(container.NewBorder(nil, nil, nil, container.NewHBox(withHint(ownCheck, "Keeps hotkeys, sounds and appearance for this account alone. "+
+			"While rounds are recorded to it, changes to them apply to it only, and those it "+
+			"hasn't changed follow the global settings."), removeBtn),
+			container.NewGridWithColumns(2, nameEntry, steamIDEntry)))
+	}
+
+	addBtn := widget.NewButton("Add Account", func() {
+		s.cfg.Accounts = append(s.cfg.Accounts, config.Account{})
+		s.save()
+		s.Reload()
+	})
+
+	return container.NewVBox(
+		widget.NewLabel("Steam Accounts (game state switches to the one playing)"),
+		rows,
+		container.NewHBox(addBtn),
+		widget.NewForm(widget.NewFormItem("Record rounds to", activeSelect)),
+	)
+}
+
+// renameAccount renames account i to name, moving the rounds, ratings and
+// other records kept under its old name with it, once the PIN has been
+// entered; declining puts the old name back. A name another account already
+// has is refused, as it would merge the two.
+func (s *SettingsTab) renameAccount(i int, name string) {
+	old := s.cfg.Accounts[i].Name
+	if name == old {
+		return
+	}
+	for j, a := range s.cfg.Accounts {
+		if j != i && name != "" && a.Name == name {
+			dialog.ShowError(fmt.Errorf("there's already an account called %s", name), s.window)
+			s.Reload()
+			return
+		}
+	}
+	unlockOr(s.cfg, s.window, func() { s.moveAccount(i, old, name) }, s.Reload)
+}
+
+// moveAccount renames account i from old to name in the database and the
+// config.
+func (s *SettingsTab) moveAccount(i int, old, name string) {
+	// A new account has nothing recorded yet, and rounds with no account
+	// stay unassigned if the name is cleared.
+	if old != "" && name != "" && s.db != nil {
+		ctx, cancel := database.WithTimeout(s.ctx)
+		defer cancel()
+		if err := database.RenameAccount(ctx, s.db, old, name); err != nil {
+			dialog.ShowError(err, s.window)
+			s.Reload()
+			return
+		}
+		if s.onRounds != nil {
+			s.onRounds()
+		}
+	}
+	s.cfg.Accounts[i].Name = name
+	if s.cfg.ActiveAccount == old {
+		s.cfg.ActiveAccount = name
+	}
+	if s.cfg.StatsAccount == old {
+		s.cfg.StatsAccount = name
+	}
+	s.save()
+	s.Reload()
+}
@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/sound"
+)
+
+// buildAnnouncerSection creates the Settings editor for the sounds played
+// on win streak milestones and reverse sweeps.
+func (s *SettingsTab) buildAnnouncerSection() fyne.CanvasObject {
+	a := &s.cfg.Announcer
+
+	enabledCheck := widget.NewCheck("Announce win streaks and reverse sweeps", func(enabled bool) {
+		a.Enabled = enabled
+		s.save()
+	})
+	enabledCheck.Checked = a.Enabled
+
+	streaksEntry := widget.NewEntry()
+	streaksEntry.SetPlaceHolder("e.g. 3, 5, 10")
+	streaksEntry.SetText(formatStreaks(a.Streaks))
+	streaksEntry.Validator = func(text string) error {
+		_, err := parseStreaks(text)
+		return err
+	}
+	streaksEntry.OnChanged = func(text string) {
+		streaks, err := parseStreaks(text)
+		if err != nil {
+			return
+		}
+		a.Streaks = streaks
+		s.save()
+	}
+
+	comebackStepper := NewStepper(a.Comeback, 0, 15, func(n int) {
+		a.Comeback = n
+		s.save()
+	})
+
+	streakSound := s.newFilePicker(a.StreakSound, "Built-in", sound.Extensions, nil, func(path string) {
+		a.StreakSound = path
+		s.save()
+	})
+	comebackSound := s.newFilePicker(a.ComebackSound, "Built-in", sound.Extensions, nil, func(path string) {
+		a.ComebackSound = path
+		s.save()
+	})
+
+	return container.NewVBox(
+		enabledCheck,
+		widget.NewForm(
+			widget.NewFormItem("Win streaks", streaksEntry),
+			widget.NewFormItem("Streak sound", streakSound),
+			widget.NewFormItem("Reverse sweep", container.NewHBox(
+				widget.NewLabel("take the lead after trailing by"),
+				comebackStepper,
+				widget.NewLabel("rounds (0 for never)"),
+			)),
+			widget.NewFormItem("Sweep sound", comebackSound),
+		),
+	)
+}
+
+// formatStreaks renders streak milestones as "3, 5".
+func formatStreaks(streaks []int) string {
+	parts := make([]string, len(streaks))
+	for i, n := range streaks {
+		parts[i] = strconv.Itoa(n)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseStreaks parses a comma-separated list of streak milestones, sorted
+// and without repeats. An empty list announces no streaks.
+func parseStreaks(text string) ([]int, error) {
+	streaks := []int{}
+	for part := range strings.SplitSeq(text, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 2 {
+			return nil, fmt.Errorf("%q is not a streak of 2 or more wins", part)
+		}
+		streaks = append(streaks, n)
+	}
+	slices.Sort(streaks)
+	return slices.Compact(streaks), nil
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+)
+
+// buildArchive creates History's Archive view: one collapsible section per
+// month with its W/L summary. A month's rounds are only loaded when it's
+// expanded, so multi-year histories open quickly.
+func (h *HistoryTab) buildArchive() fyne.CanvasObject {
+	h.archiveContainer = container.NewVBox()
+	return container.NewVScroll(h.archiveContainer)
+}
+
+// refreshArchive reloads the month summaries. Expanded months collapse.
+func (h *HistoryTab) refreshArchive() {
+	if h.archiveContainer == nil {
+		return
+	}
+	ctx, cancel := database.WithTimeout(h.ctx)
+	defer cancel()
+	months, err := database.GetMonthlyStats(ctx, h.db)
+	if err != nil {
+		dialog.ShowError(err, h.window)
+		return
+	}
+
+	sections := make([]fyne.CanvasObject, 0, len(months))
+	for _, m := range months {
+		sections = append(sections, h.archiveMonth(m))
+	}
+	if len(sections) == 0 {
+		sections = append(sections, widget.NewLabel("No rounds recorded yet"))
+	}
+	h.archiveContainer.Objects = sections
+	h.archiveContainer.Refresh()
+}
+
+// archiveMonth creates a month's header button and its initially hidden list
+// of rounds.
+func (h *HistoryTab) archiveMonth(m database.MonthStats) fyne.CanvasObject {
+	summary := fmt.Sprintf("%s — %d W / %d L", m.Month.Format("January 2006"), m.Wins, m.Losses)
+	if decided := m.Wins + m.Losses; decided > 0 {
+		summary += fmt.Sprintf(" (%.0f%%)", float64(m.Wins)/float64(decided)*100)
+	}
+	if m.Draws > 0 {
+		summary += fmt.Sprintf(", %d without team", m.Draws)
+	}
+
+	rounds := container.NewVBox()
+	rounds.Hide()
+	loaded := false
+
+	header := widget.NewButtonWithIcon(summary, theme.MenuExpandIcon(), nil)
+	header.Alignment = widget.ButtonAlignLeading
+	header.Importance = widget.LowImportance
+	header.OnTapped = func() {
+		if rounds.Visible() {
+			rounds.Hide()
+			header.SetIcon(theme.MenuExpandIcon())
+			return
+		}
+		if !loaded {
+			ctx, cancel := database.WithTimeout(h.ctx)
+			defer cancel()
+			list, err := database.GetRoundsInMonth(ctx, h.db, m.Month)
+			if err != nil {
+				dialog.ShowError(err, h.window)
+				return
+			}
+			for _, r := range list {
+				rounds.Add(container.NewHBox(newResultChip(r.Result()), widget.NewLabel(roundSummary(r))))
+			}
+			loaded = true
+		}
+		rounds.Show()
+		header.SetIcon(theme.MenuDropDownIcon())
+	}
+
+	return container.NewVBox(header, container.NewPadded(rounds))
+}
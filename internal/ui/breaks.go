@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// BreakPrompt suggests a break when the tracker's break limit is reached,
+// offering to override it. Only one prompt shows at a time.
+type BreakPrompt struct {
+	window     fyne.Window
+	onOverride func()
+	open       dialog.Dialog
+}
+
+// NewBreakPrompt creates a BreakPrompt over window. onOverride is called if
+// the player chooses to keep recording.
+func NewBreakPrompt(window fyne.Window, onOverride func()) *BreakPrompt {
+	return &BreakPrompt{window: window, onOverride: onOverride}
+}
+
+// Show shows the prompt for a break ending at until, unless it's already
+// showing. started is false when the player tried to record a round during
+// the break.
+func (b *BreakPrompt) Show(until time.Time, started bool) {
+	if b.open != nil {
+		return
+	}
+	msg := "New matches are locked until %s."
+	if started {
+		msg = "That's a long run. Time for a break: new matches are locked until %s."
+	}
+	label := widget.NewLabel(fmt.Sprintf(msg, until.Format("15:04")))
+	label.Wrapping = fyne.TextWrapWord
+
+	b.open = dialog.NewCustomConfirm("Take a Break", "Keep Playing", "OK", label, func(override bool) {
+		b.open = nil
+		if override && b.onOverride != nil {
+			b.onOverride()
+		}
+	}, b.window)
+	b.open.Resize(fyne.NewSize(360, 0))
+	b.open.Show()
+}
+
+// BreakText formats a break for the OSD, e.g. "Break until 21:30".
+func BreakText(until time.Time) string {
+	return "Break until " + until.Format("15:04")
+}
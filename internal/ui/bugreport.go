@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"io"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"csstatstracker/internal/bugreport"
+)
+
+// SetBugReport sets what Save Bug Report... writes to the chosen file.
+func (s *SettingsTab) SetBugReport(write func(w io.Writer) error) {
+	s.writeBugReport = write
+	s.Reload()
+}
+
+// saveBugReport asks where to save a bug report and writes it there.
+func (s *SettingsTab) saveBugReport() {
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		if w == nil {
+			return // cancelled
+		}
+		err = s.writeBugReport(w)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		dialog.ShowInformation("Bug Report Saved",
+			"The report has an anonymized copy of your stats, your settings without\n"+
+				"accounts or secrets, and the recent log. See README.txt inside for details.", s.window)
+	}, s.window)
+	save.SetFileName(bugreport.FileName(time.Now()))
+	save.Show()
+}
@@ -0,0 +1,374 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	chartMaxSlot  = float32(50)  // widest bar plus gap at default zoom
+	chartMinSlot  = float32(10)  // narrowest still readable at default zoom
+	chartBarFill  = float32(0.8) // share of a slot the bar fills
+	chartLabelGap = float32(4)
+	chartMinZoom  = float32(0.1)
+	chartMaxZoom  = float32(8)
+	chartZoomStep = float32(1.2)
+)
+
+// barChart is the bar chart shared by the stats sub-tabs. It draws one bar
+// per bucket, either diverging around a zero line (positive values up in
+// posColor, negative down in negColor) or rising from the bottom.
+//
+// The scroll wheel zooms around the pointer, dragging or horizontal
+// scrolling pans, and ResetView returns to the default scale.
+type barChart struct {
+	widget.BaseWidget
+	labels    []string   // bucket labels drawn under each bar
+	values    []int      // one per label
+	marks     [][]string // personal-best annotations per bucket, may be nil
+	diverging bool
+	posColor  func() color.Color // resolved on every refresh so theme
+	negColor  func() color.Color // changes apply immediately
+	format    func(int) string   // value label drawn on each bar
+
+	zoom   float32 // bar width multiplier, 1 = fit to width
+	offset float32 // horizontal pan in pixels from the first bar
+}
+
+func newBarChart(labels []string, values []int, format func(int) string) *barChart {
+	c := &barChart{labels: labels, values: values, format: format, zoom: 1}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// withControls wraps the chart so it's clipped to its area, puts the fixed
+// value axis to its left and adds the legend and a Reset Zoom button below.
+func (c *barChart) withControls(legend fyne.CanvasObject) fyne.CanvasObject {
+	clip := container.NewScroll(c)
+	clip.Direction = container.ScrollNone
+	axis := &chartAxis{chart: c}
+	axis.ExtendBaseWidget(axis)
+	reset := widget.NewButton("Reset Zoom", c.ResetView)
+	return container.NewBorder(nil, container.NewBorder(nil, nil, nil, reset, legend), axis, nil, clip)
+}
+
+// maxValue returns the largest magnitude among the values, at least 1.
+func (c *barChart) maxValue() int {
+	m := 1
+	for _, v := range c.values {
+		m = max(m, v, -v)
+	}
+	return m
+}
+
+// geometry returns, for a chart of the given height, the y of the line bars
+// grow from and the height of a bar of maxValue. The axis uses it too so its
+// ticks line up with the bars.
+func (c *barChart) geometry(height float32) (baseY, fullHeight float32) {
+	// Chart dimensions - scale with available height
+	labelHeight := float32(15)
+	top := markerSpace(c.marks)
+	chartHeight := height - labelHeight - top
+	if chartHeight < 60 {
+		chartHeight = 60
+	}
+	if c.diverging {
+		return top + chartHeight/2, chartHeight / 2
+	}
+	return top + chartHeight, chartHeight
+}
+
+// ResetView restores the default zoom and scrolls back to the first bar.
+func (c *barChart) ResetView() {
+	c.zoom = 1
+	c.offset = 0
+	c.Refresh()
+}
+
+// Scrolled implements fyne.Scrollable: vertical scrolling zooms around the
+// pointer, horizontal scrolling pans.
+func (c *barChart) Scrolled(e *fyne.ScrollEvent) {
+	if e.Scrolled.DX != 0 {
+		c.offset -= e.Scrolled.DX
+	}
+	if e.Scrolled.DY != 0 {
+		zoom := c.zoom * chartZoomStep
+		if e.Scrolled.DY < 0 {
+			zoom = c.zoom / chartZoomStep
+		}
+		zoom = min(max(zoom, chartMinZoom), chartMaxZoom)
+		// Keep the bar under the pointer where it is.
+		anchor := c.offset + e.Position.X
+		c.offset = anchor*zoom/c.zoom - e.Position.X
+		c.zoom = zoom
+	}
+	c.Refresh()
+}
+
+// Dragged implements fyne.Draggable to pan the chart.
+func (c *barChart) Dragged(e *fyne.DragEvent) {
+	c.offset -= e.Dragged.DX
+	c.Refresh()
+}
+
+// DragEnd implements fyne.Draggable.
+func (c *barChart) DragEnd() {}
+
+func (c *barChart) CreateRenderer() fyne.WidgetRenderer {
+	return &barChartRenderer{chart: c}
+}
+
+func (c *barChart) MinSize() fyne.Size {
+	return fyne.NewSize(300, 150)
+}
+
+type barChartRenderer struct {
+	chart   *barChart
+	objects []fyne.CanvasObject
+}
+
+func (r *barChartRenderer) Destroy() {}
+
+func (r *barChartRenderer) Layout(size fyne.Size) {
+	r.Refresh()
+}
+
+func (r *barChartRenderer) MinSize() fyne.Size {
+	return r.chart.MinSize()
+}
+
+func (r *barChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *barChartRenderer) Refresh() {
+	c := r.chart
+	size := c.Size()
+
+	// At default zoom the bars share the available width, within limits
+	// that keep them readable without stretching a handful across the
+	// whole chart.
+	fit := chartMaxSlot
+	if n := len(c.values); n > 0 {
+		fit = min(max(size.Width/float32(n), chartMinSlot), chartMaxSlot)
+	}
+	slot := fit * c.zoom
+	barWidth := slot * chartBarFill
+	contentWidth := float32(len(c.values)) * slot
+	c.offset = min(max(c.offset, 0), max(contentWidth-size.Width, 0))
+
+	maxValue := c.maxValue()
+	baseY, fullHeight := c.geometry(size.Height)
+
+	// When labels are wider than a slot, only every labelStep-th one is
+	// drawn so they don't overlap.
+	labelStep := 1
+	if widest := widestLabel(c.labels); widest+chartLabelGap > slot {
+		labelStep = int(math.Ceil(float64((widest + chartLabelGap) / slot)))
+	}
+
+	var objects []fyne.CanvasObject
+
+	if c.diverging {
+		zeroLine := canvas.NewLine(chartLineColor())
+		zeroLine.Position1 = fyne.NewPos(0, baseY)
+		zeroLine.Position2 = fyne.NewPos(max(contentWidth-c.offset, size.Width), baseY)
+		zeroLine.StrokeWidth = 1
+		objects = append(objects, zeroLine)
+	}
+
+	for i, value := range c.values {
+		x := float32(i)*slot - c.offset
+		if x+slot < 0 || x > size.Width {
+			continue // outside the visible window
+		}
+
+		barBottom := baseY
+		if value != 0 {
+			barHeight := float32(value) / float32(maxValue) * fullHeight
+			if barHeight < 0 {
+				barHeight = -barHeight
+			}
+			// Minimum visible height
+			if barHeight < 3 {
+				barHeight = 3
+			}
+
+			fill := c.posColor()
+			yPos := baseY - barHeight
+			if value < 0 {
+				fill = c.negColor()
+				yPos = baseY
+				barBottom = baseY + barHeight
+			}
+			bar := canvas.NewRectangle(fill)
+			bar.Resize(fyne.NewSize(barWidth, barHeight))
+			bar.Move(fyne.NewPos(x, yPos))
+			objects = append(objects, bar)
+			if value < 0 && patternLosses() {
+				objects = append(objects, stripes(bar.Position(), bar.Size(), fill)...)
+			}
+
+			// Value label centred on the bar, when the bar is wide enough
+			// to hold it.
+			valueLabel := canvas.NewText(c.format(value), contrastText(fill))
+			valueLabel.TextSize = 10
+			valueLabel.Alignment = fyne.TextAlignCenter
+			textSize := valueLabel.MinSize()
+			if textSize.Width <= barWidth {
+				valueLabel.Resize(fyne.NewSize(barWidth, textSize.Height))
+				valueLabel.Move(fyne.NewPos(x, yPos+(barHeight-textSize.Height)/2))
+				objects = append(objects, valueLabel)
+			}
+		}
+
+		// Period label directly below the bar
+		if i%labelStep == 0 {
+			dateLabel := canvas.NewText(c.labels[i], chartTextColor())
+			dateLabel.TextSize = 10
+			dateLabel.Move(fyne.NewPos(x, barBottom+2))
+			objects = append(objects, dateLabel)
+		}
+
+		if i < len(c.marks) {
+			objects = append(objects, markerObjects(c.marks[i], x)...)
+		}
+	}
+
+	r.objects = objects
+}
+
+// chartAxis is the value axis drawn beside a barChart. It sits outside the
+// chart's clip area so it stays put while the bars are zoomed and panned.
+type chartAxis struct {
+	widget.BaseWidget
+	chart *barChart
+}
+
+// chartAxisWidth is the axis column's width.
+const chartAxisWidth = float32(48)
+
+func (a *chartAxis) CreateRenderer() fyne.WidgetRenderer {
+	return &chartAxisRenderer{axis: a}
+}
+
+func (a *chartAxis) MinSize() fyne.Size {
+	return fyne.NewSize(chartAxisWidth, 150)
+}
+
+type chartAxisRenderer struct {
+	axis    *chartAxis
+	objects []fyne.CanvasObject
+}
+
+func (r *chartAxisRenderer) Destroy() {}
+
+func (r *chartAxisRenderer) Layout(size fyne.Size) {
+	r.Refresh()
+}
+
+func (r *chartAxisRenderer) MinSize() fyne.Size {
+	return r.axis.MinSize()
+}
+
+func (r *chartAxisRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *chartAxisRenderer) Refresh() {
+	c := r.axis.chart
+	size := r.axis.Size()
+	maxValue := c.maxValue()
+	baseY, fullHeight := c.geometry(size.Height)
+
+	// Ticks at the top, middle and base of the bars, mirrored below the
+	// zero line for diverging charts.
+	ticks := []int{maxValue, maxValue / 2, 0}
+	if c.diverging {
+		ticks = append(ticks, -maxValue/2, -maxValue)
+	}
+
+	labelColor := chartTextColor()
+	lineColor := chartLineColor()
+	objects := make([]fyne.CanvasObject, 0, len(ticks)*2+1)
+	spine := canvas.NewLine(lineColor)
+	spine.StrokeWidth = 1
+	spine.Position1 = fyne.NewPos(size.Width-1, baseY-fullHeight)
+	if c.diverging {
+		spine.Position2 = fyne.NewPos(size.Width-1, baseY+fullHeight)
+	} else {
+		spine.Position2 = fyne.NewPos(size.Width-1, baseY)
+	}
+	objects = append(objects, spine)
+
+	for _, v := range ticks {
+		y := baseY - float32(v)/float32(maxValue)*fullHeight
+		text := c.format(v)
+		if v == 0 {
+			text = "0"
+		}
+		label := canvas.NewText(text, labelColor)
+		label.TextSize = 10
+		label.Alignment = fyne.TextAlignTrailing
+		labelSize := label.MinSize()
+		label.Resize(fyne.NewSize(size.Width-6, labelSize.Height))
+		label.Move(fyne.NewPos(0, y-labelSize.Height/2))
+
+		tick := canvas.NewLine(lineColor)
+		tick.StrokeWidth = 1
+		tick.Position1 = fyne.NewPos(size.Width-4, y)
+		tick.Position2 = fyne.NewPos(size.Width, y)
+		objects = append(objects, label, tick)
+	}
+	r.objects = objects
+}
+
+// widestLabel returns the rendered width of the longest bucket label.
+func widestLabel(labels []string) float32 {
+	var widest float32
+	for _, l := range labels {
+		widest = max(widest, fyne.MeasureText(l, 10, fyne.TextStyle{}).Width)
+	}
+	return widest
+}
+
+// markerHeight is the band reserved above a chart for personal-best markers.
+const markerHeight = float32(14)
+
+var markerColor = color.RGBA{R: 255, G: 193, B: 7, A: 255} // amber
+
+// markerSpace returns how much room to reserve above the bars: markerHeight
+// if any bucket carries a marker, otherwise none.
+func markerSpace(marks [][]string) float32 {
+	for _, m := range marks {
+		if len(m) > 0 {
+			return markerHeight
+		}
+	}
+	return 0
+}
+
+// markerObjects draws a bucket's personal-best markers in the band above
+// its bar.
+func markerObjects(marks []string, x float32) []fyne.CanvasObject {
+	if len(marks) == 0 {
+		return nil
+	}
+	text := canvas.NewText("★ "+strings.Join(marks, " · "), markerColor)
+	text.TextSize = 10
+	text.TextStyle = fyne.TextStyle{Bold: true}
+	text.Move(fyne.NewPos(x, 0))
+	return []fyne.CanvasObject{text}
+}
+
+// formatNet renders a net win/loss count with its sign.
+func formatNet(v int) string {
+	return fmt.Sprintf("%+d", v)
+}
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/cleanup"
+	"csstatstracker/internal/database"
+)
+
+// cleanupExamples caps the timestamps listed as examples for each issue.
+const cleanupExamples = 3
+
+// showCleanup scans the history for anomalies and shows them in a dialog
+// with a fix for each kind.
+func (h *HistoryTab) showCleanup() {
+	issues := container.NewVBox()
+	var scan func()
+	scan = func() {
+		ctx, cancel := database.WithTimeout(h.ctx)
+		defer cancel()
+		rounds, err := database.GetAllRounds(ctx, h.db)
+		if err != nil {
+			dialog.ShowError(err, h.window)
+			return
+		}
+		found := cleanup.Scan(rounds)
+		rows := make([]fyne.CanvasObject, 0, len(found))
+		for _, issue := range found {
+			rows = append(rows, h.cleanupRow(issue, scan))
+		}
+		if len(rows) == 0 {
+			rows = append(rows, widget.NewLabel("No problems found."))
+		}
+		issues.Objects = rows
+		issues.Refresh()
+	}
+	scan()
+
+	d := dialog.NewCustom("Data Cleanup", "Close", container.NewVScroll(issues), h.window)
+	d.Resize(fyne.NewSize(520, 420))
+	d.Show()
+}
+
+// cleanupRow shows one issue with its fix. rescan is called once it's fixed.
+func (h *HistoryTab) cleanupRow(issue cleanup.Issue, rescan func()) fyne.CanvasObject {
+	title := widget.NewLabelWithStyle(fmt.Sprintf("%s (%d)", issue.Kind.Title(), len(issue.Rounds)),
+		fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	description := widget.NewLabel(issue.Kind.Description())
+	description.Wrapping = fyne.TextWrapWord
+
+	examples := make([]string, 0, cleanupExamples)
+	for _, r := range issue.Rounds[:min(len(issue.Rounds), cleanupExamples)] {
+		examples = append(examples, r.CreatedAt.Local().Format("2006-01-02 15:04:05"))
+	}
+	text := "e.g. " + strings.Join(examples, ", ")
+	if len(issue.Rounds) > cleanupExamples {
+		text += ", …"
+	}
+	row := container.NewVBox(title, description, widget.NewLabel(text))
+
+	if issue.Kind.Deletable() {
+		fix := widget.NewButton(fmt.Sprintf("Delete %d Round(s)", len(issue.Rounds)), func() {
+			h.guard(func() {
+				dialog.ShowConfirm("Delete Rounds",
+					fmt.Sprintf("Delete %d round(s) flagged as %s?", len(issue.Rounds), strings.ToLower(issue.Kind.Title())),
+					func(confirmed bool) {
+						if !confirmed {
+							return
+						}
+						ctx, cancel := database.WithTimeout(h.ctx)
+						defer cancel()
+						if err := database.DeleteRounds(ctx, h.db, issue.IDs()); err != nil {
+							dialog.ShowError(err, h.window)
+							return
+						}
+						h.refresh()
+						if h.onUpdate != nil {
+							h.onUpdate()
+						}
+						rescan()
+					}, h.window)
+			})
+		})
+		fix.Importance = widget.DangerImportance
+		row.Add(container.NewHBox(fix))
+	}
+	row.Add(widget.NewSeparator())
+	return row
+}
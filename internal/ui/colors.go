@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+
+	"csstatstracker/internal/config"
+)
+
+// Default side accents, used when the configured colour doesn't parse.
+var (
+	ctColor = color.RGBA{R: 100, G: 149, B: 237, A: 255}
+	tColor  = color.RGBA{R: 255, G: 140, B: 0, A: 255}
+)
+
+// SideColors returns the configured CT and T accent colours.
+func SideColors(cfg *config.Config) (ct, t color.Color) {
+	ct, t = ctColor, tColor
+	if c, err := parseHexColor(cfg.CTColor); err == nil {
+		ct = c
+	}
+	if c, err := parseHexColor(cfg.TColor); err == nil {
+		t = c
+	}
+	return ct, t
+}
+
+// parseHexColor parses a colour written as #RRGGBB.
+func parseHexColor(s string) (color.Color, error) {
+	var c color.RGBA
+	s = strings.TrimSpace(s)
+	if len(s) != 7 {
+		return nil, fmt.Errorf("%q is not a #RRGGBB colour", s)
+	}
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		return nil, fmt.Errorf("%q is not a #RRGGBB colour", s)
+	}
+	c.A = 255
+	return c, nil
+}
+
+// Chart colours follow the current theme so text and lines stay readable on
+// both the light and dark variants.
+
+// chartTextColor is the colour of axis and bucket labels.
+func chartTextColor() color.Color {
+	return theme.Color(theme.ColorNamePlaceHolder)
+}
+
+// chartLineColor is the colour of zero lines, axes and ticks.
+func chartLineColor() color.Color {
+	return theme.Color(theme.ColorNameDisabled)
+}
+
+// Color-vision modes for the win/loss encoding, see config.Config.ColorVision.
+const (
+	ColorVisionStandard   = ""
+	ColorVisionRedGreen   = "red-green"   // deuteranopia and protanopia
+	ColorVisionBlueYellow = "blue-yellow" // tritanopia
+)
+
+// ColorVisionModes lists the modes with their Settings labels, in display
+// order.
+var ColorVisionModes = []struct{ Mode, Label string }{
+	{ColorVisionStandard, "Standard (green / red)"},
+	{ColorVisionRedGreen, "Red-green safe (blue / orange)"},
+	{ColorVisionBlueYellow, "Blue-yellow safe (teal / vermilion)"},
+}
+
+// colorVision is the active mode, set from the config by SetColorVision.
+var colorVision = ColorVisionStandard
+
+// SetColorVision switches the win/loss palette. It reports whether the mode
+// changed, so callers know to redraw. Unknown modes fall back to standard.
+func SetColorVision(mode string) bool {
+	valid := false
+	for _, m := range ColorVisionModes {
+		valid = valid || m.Mode == mode
+	}
+	if !valid {
+		mode = ColorVisionStandard
+	}
+	changed := mode != colorVision
+	colorVision = mode
+	return changed
+}
+
+// Colour-blind safe colours from the Okabe-Ito palette.
+var (
+	okabeBlue       = color.RGBA{R: 0, G: 114, B: 178, A: 255}
+	okabeOrange     = color.RGBA{R: 230, G: 159, B: 0, A: 255}
+	okabeTeal       = color.RGBA{R: 0, G: 158, B: 115, A: 255}
+	okabeVermillion = color.RGBA{R: 213, G: 94, B: 0, A: 255}
+)
+
+// winColor and lossColor encode round results.
+func winColor() color.Color {
+	switch colorVision {
+	case ColorVisionRedGreen:
+		return okabeBlue
+	case ColorVisionBlueYellow:
+		return okabeTeal
+	}
+	return theme.Color(theme.ColorNameSuccess)
+}
+
+func lossColor() color.Color {
+	switch colorVision {
+	case ColorVisionRedGreen:
+		return okabeOrange
+	case ColorVisionBlueYellow:
+		return okabeVermillion
+	}
+	return theme.Color(theme.ColorNameError)
+}
+
+// patternLosses reports whether loss bars get a striped fill, so results
+// don't rely on hue alone.
+func patternLosses() bool {
+	return colorVision != ColorVisionStandard
+}
+
+// stripes draws horizontal stripes over the rectangle at pos with the given
+// size, in a colour that contrasts with fill.
+func stripes(pos fyne.Position, size fyne.Size, fill color.Color) []fyne.CanvasObject {
+	const gap = 4
+	var objects []fyne.CanvasObject
+	for y := float32(gap / 2); y < size.Height; y += gap {
+		line := canvas.NewLine(contrastText(fill))
+		line.StrokeWidth = 1
+		line.Position1 = fyne.NewPos(pos.X, pos.Y+y)
+		line.Position2 = fyne.NewPos(pos.X+size.Width, pos.Y+y)
+		objects = append(objects, line)
+	}
+	return objects
+}
+
+// timeColor fills the play-time bars.
+func timeColor() color.Color { return theme.Color(theme.ColorNamePrimary) }
+
+// contrastText returns black or white, whichever reads better on bg.
+func contrastText(bg color.Color) color.Color {
+	r, g, b, _ := bg.RGBA()
+	// Relative luminance with the usual Rec. 601 weights, on 16-bit channels.
+	if 299*r+587*g+114*b > 1000*0x8000 {
+		return color.Black
+	}
+	return color.White
+}
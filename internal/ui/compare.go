@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/snapshot"
+)
+
+// buildCompareContent creates the Compare sub-tab: buttons to share your own
+// snapshot or load a friend's, and a side-by-side table below.
+func (s *StatsTab) buildCompareContent() fyne.CanvasObject {
+	s.compareContainer = container.NewVBox()
+
+	shareBtn := widget.NewButton("Share My Snapshot...", s.shareSnapshot)
+	importBtn := widget.NewButton("Load Friend's Snapshot...", s.importSnapshot)
+
+	return container.NewBorder(
+		container.NewVBox(
+			container.NewHBox(shareBtn, importBtn),
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		container.NewVScroll(s.compareContainer),
+	)
+}
+
+// shareSnapshot asks for a display name, then saves a signed snapshot of the
+// current period's stats.
+func (s *StatsTab) shareSnapshot() {
+	if s.lastStats == nil {
+		return
+	}
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Shown to whoever loads the snapshot")
+	nameEntry.SetText(s.cfg.ShareName)
+
+	form := widget.NewForm(widget.NewFormItem("Your Name", nameEntry))
+	dialog.ShowCustomConfirm("Share Snapshot", "Save...", "Cancel", form, func(ok bool) {
+		if !ok {
+			return
+		}
+		name := strings.TrimSpace(nameEntry.Text)
+		if name != s.cfg.ShareName {
+			s.cfg.ShareName = name
+			if s.onSave != nil {
+				s.onSave()
+			}
+		}
+
+		key, err := snapshot.LoadOrCreateKey(s.keyPath)
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		snap := snapshot.Snapshot{
+			Name:        name,
+			CreatedAt:   time.Now(),
+			Period:      s.cfg.StatsPeriod,
+			Stats:       *s.lastStats,
+			Parties:     s.lastParties,
+			PlayMinutes: s.lastStats.TotalRounds * secondsPerRound / 60,
+		}
+
+		save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, s.window)
+				return
+			}
+			if w == nil {
+				return // cancelled
+			}
+			defer func() { _ = w.Close() }()
+			if err := snapshot.Write(snap, key, w); err != nil {
+				dialog.ShowError(err, s.window)
+			}
+		}, s.window)
+		save.SetFileName("csstats-snapshot.json")
+		save.Show()
+	}, s.window)
+}
+
+// importSnapshot loads and verifies a friend's snapshot for comparison.
+func (s *StatsTab) importSnapshot() {
+	dialog.ShowFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		if r == nil {
+			return // cancelled
+		}
+		defer func() { _ = r.Close() }()
+		friend, err := snapshot.Read(r)
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		s.friend = friend
+		s.refreshCompare()
+	}, s.window)
+}
+
+// refreshCompare rebuilds the comparison table from the latest stats and the
+// loaded friend snapshot, if any.
+func (s *StatsTab) refreshCompare() {
+	if s.compareContainer == nil || s.lastStats == nil {
+		return
+	}
+	if s.friend == nil {
+		s.compareContainer.Objects = []fyne.CanvasObject{
+			widget.NewLabel("Load a friend's snapshot to compare stats side by side."),
+		}
+		s.compareContainer.Refresh()
+		return
+	}
+
+	mine, theirs := s.lastStats, &s.friend.Stats
+	friendName := s.friend.Name
+	if friendName == "" {
+		friendName = "Friend"
+	}
+
+	grid := container.NewGridWithColumns(3,
+		widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle("You", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(friendName, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	addRow := func(metric, you, friend string) {
+		grid.Add(widget.NewLabel(metric))
+		grid.Add(widget.NewLabel(you))
+		grid.Add(widget.NewLabel(friend))
+	}
+
+	addRow("Period", s.cfg.StatsPeriod, s.friend.Period)
+	addRow("Rounds", fmt.Sprintf("%d", mine.TotalRounds), fmt.Sprintf("%d", theirs.TotalRounds))
+	addRow("Win Rate",
+		compareRate(mine.WinRate, mine.Wins, mine.TotalRounds),
+		compareRate(theirs.WinRate, theirs.Wins, theirs.TotalRounds))
+	addRow("CT Win Rate",
+		compareRate(mine.CTWinRate, mine.CTWins, mine.CTRounds),
+		compareRate(theirs.CTWinRate, theirs.CTWins, theirs.CTRounds))
+	addRow("T Win Rate",
+		compareRate(mine.TWinRate, mine.TWins, mine.TRounds),
+		compareRate(theirs.TWinRate, theirs.TWins, theirs.TRounds))
+	addRow("Play Time",
+		formatPlayTime(mine.TotalRounds*secondsPerRound/60),
+		formatPlayTime(s.friend.PlayMinutes))
+
+	for _, p := range database.PartySizes {
+		you, friend := findParty(s.lastParties, p), findParty(s.friend.Parties, p)
+		if you == nil && friend == nil {
+			continue
+		}
+		addRow(p.String()+" Win Rate", comparePartyRate(you), comparePartyRate(friend))
+	}
+
+	s.compareContainer.Objects = []fyne.CanvasObject{
+		grid,
+		widget.NewSeparator(),
+		widget.NewLabel(fmt.Sprintf("%s's snapshot from %s, signed by key %s",
+			friendName, s.friend.CreatedAt.Local().Format("2006-01-02 15:04"), s.friend.Fingerprint)),
+	}
+	s.compareContainer.Refresh()
+}
+
+func compareRate(rate float64, wins, rounds int) string {
+	if rounds == 0 {
+		return "--"
+	}
+	return fmt.Sprintf("%.1f%%%s", rate, formatMargin(wins, rounds))
+}
+
+func comparePartyRate(p *database.PartyStats) string {
+	if p == nil {
+		return "--"
+	}
+	return compareRate(p.WinRate, p.Wins, p.TotalRounds)
+}
+
+func findParty(parties []database.PartyStats, party database.PartySize) *database.PartyStats {
+	for i := range parties {
+		if parties[i].Party == party {
+			return &parties[i]
+		}
+	}
+	return nil
+}
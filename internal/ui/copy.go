@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"csstatstracker/internal/database"
+)
+
+// Copy formats for history rows, see config.Config.CopyFormat.
+const (
+	CopyFormatText     = "text"
+	CopyFormatMarkdown = "markdown"
+)
+
+// formatRoundsForCopy renders rounds for pasting into chat, e.g. Discord: one
+// line per round as plain text, or a markdown table. Rounds are listed in
+// the order given.
+func formatRoundsForCopy(rounds []database.Round, format string) string {
+	var b strings.Builder
+	if format == CopyFormatMarkdown {
+		b.WriteString("| Time | Winner | Team | Result | Party | Account |\n")
+		b.WriteString("|---|---|---|---|---|---|\n")
+	}
+
+	wins, losses := 0, 0
+	for _, r := range rounds {
+		res := resultName(r.Result())
+		switch r.Result() {
+		case database.ResultWin:
+			wins++
+		case database.ResultLoss:
+			losses++
+		}
+		team := "None"
+		if r.Team != "" {
+			team = string(r.Team)
+		}
+		party := ""
+		if r.PartySize != database.PartyUnknown {
+			party = r.PartySize.String()
+		}
+		when := r.CreatedAt.Local().Format("2006-01-02 15:04")
+
+		if format == CopyFormatMarkdown {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+				when, r.Winner, team, res, party, r.Account)
+			continue
+		}
+		line := fmt.Sprintf("%s  %s won [%s] %s", when, r.Winner, team, res)
+		if party != "" {
+			line += " " + party
+		}
+		if r.Account != "" {
+			line += " — " + r.Account
+		}
+		b.WriteString(line + "\n")
+	}
+
+	summary := fmt.Sprintf("%d rounds: %d W / %d L", len(rounds), wins, losses)
+	if format == CopyFormatMarkdown {
+		b.WriteString("\n**" + summary + "**\n")
+	} else {
+		b.WriteString(summary + "\n")
+	}
+	return b.String()
+}
+
+// resultName spells out a result for copied text.
+func resultName(res database.Result) string {
+	switch res {
+	case database.ResultWin:
+		return "Win"
+	case database.ResultLoss:
+		return "Loss"
+	}
+	return "Draw"
+}
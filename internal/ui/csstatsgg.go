@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/csstatsgg"
+	"csstatstracker/internal/database"
+)
+
+// importCSStats asks for a csstats.gg match table export and, once the user
+// has checked how its rows will be imported, adds their rounds to History.
+func (h *HistoryTab) importCSStats() {
+	open := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, h.window)
+			return
+		}
+		if r == nil {
+			return // cancelled
+		}
+		rows, err := csstatsgg.Parse(r)
+		_ = r.Close()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to read csstats.gg export: %w", err), h.window)
+			return
+		}
+		h.previewCSStatsImport(rows)
+	}, h.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".tsv", ".txt"}))
+	open.Show()
+}
+
+// csstatsColumns are the preview table's columns.
+var csstatsColumns = []string{"Line", "Date", "Map", "Score", "Imported as"}
+
+// previewCSStatsImport shows each row of an export and what it will be
+// imported as, or why it will be skipped, and adds the rounds if the user
+// agrees.
+func (h *HistoryTab) previewCSStatsImport(rows []csstatsgg.Row) {
+	var rounds []database.Round
+	skipped := 0
+	for _, row := range rows {
+		if row.Err != nil {
+			skipped++
+			continue
+		}
+		rounds = append(rounds, csstatsgg.Rounds(row.Match, h.cfg.ActiveAccount)...)
+	}
+
+	cell := func(row csstatsgg.Row, col int) string {
+		m := row.Match
+		switch col {
+		case 0:
+			return fmt.Sprintf("%d", row.Line)
+		case 1:
+			if row.Err != nil {
+				return ""
+			}
+			return m.FinishedAt.Format("2 Jan 2006")
+		case 2:
+			return m.Map
+		case 3:
+			if row.Err != nil {
+				return ""
+			}
+			return fmt.Sprintf("%d:%d", m.Score, m.EnemyScore)
+		}
+		if row.Err != nil {
+			return "Skipped: " + row.Err.Error()
+		}
+		result := "Draw"
+		switch {
+		case m.Score > m.EnemyScore:
+			result = "Win"
+		case m.Score < m.EnemyScore:
+			result = "Loss"
+		}
+		return fmt.Sprintf("%s, %d rounds", result, m.Score+m.EnemyScore)
+	}
+	table := widget.NewTable(
+		func() (int, int) { return len(rows) + 1, len(csstatsColumns) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row == 0 {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(csstatsColumns[id.Col])
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			label.SetText(cell(rows[id.Row-1], id.Col))
+		},
+	)
+	for col, width := range []float32{50, 110, 110, 60, 280} {
+		table.SetColumnWidth(col, width)
+	}
+
+	account := ""
+	if h.cfg.ActiveAccount != "" {
+		account = fmt.Sprintf(" on %s", h.cfg.ActiveAccount)
+	}
+	summary := widget.NewLabel(fmt.Sprintf(
+		"%d matches make %d rounds to add%s; %d rows will be skipped.\n"+
+			"Rounds are spread evenly through each match, and matches already imported are skipped.",
+		len(rows)-skipped, len(rounds), account, skipped))
+	summary.Wrapping = fyne.TextWrapWord
+
+	confirm := dialog.NewCustomConfirm("Import from csstats.gg", "Import", "Cancel",
+		container.NewBorder(summary, nil, nil, nil, table), func(ok bool) {
+			if !ok || len(rounds) == 0 {
+				return
+			}
+			ctx, cancel := database.WithTimeout(h.ctx)
+			defer cancel()
+			added, err := database.MergeRounds(ctx, h.db, rounds)
+			if err != nil {
+				dialog.ShowError(err, h.window)
+				return
+			}
+			h.refresh()
+			if h.onUpdate != nil {
+				h.onUpdate()
+			}
+			dialog.ShowInformation("Imported", fmt.Sprintf("Added %d of %d rounds.", added, len(rounds)), h.window)
+		}, h.window)
+	confirm.Resize(fyne.NewSize(680, 460))
+	confirm.Show()
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/draws"
+	"csstatstracker/internal/match"
+)
+
+// buildDrawsTab creates the Draws sub-tab: rounds recorded with no team
+// selected, in runs that can be assigned a team in one click. The tab's title
+// carries their count as a nudge to clean them up.
+func (s *StatsTab) buildDrawsTab() *container.TabItem {
+	s.drawsContainer = container.NewVBox()
+	s.drawsTab = container.NewTabItem("Draws", container.NewBorder(
+		container.NewVBox(
+			widget.NewSeparator(),
+			widget.NewLabel("Rounds recorded with no team selected count as draws (all time).\n"+
+				"Pick the side you played to score them as wins and losses."),
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		container.NewVScroll(s.drawsContainer),
+	))
+	return s.drawsTab
+}
+
+// refreshDraws reloads the unassigned rounds and updates the Draws sub-tab
+// and the count on its tab.
+func (s *StatsTab) refreshDraws() {
+	if s.drawsContainer == nil {
+		return
+	}
+	ctx, cancel := database.WithTimeout(s.ctx)
+	defer cancel()
+	rounds, err := database.GetUnassignedRounds(ctx, s.db)
+	if err != nil {
+		fyne.LogError("failed to load unassigned rounds", err)
+		return
+	}
+
+	s.drawsTab.Text = "Draws"
+	if len(rounds) > 0 {
+		s.drawsTab.Text = fmt.Sprintf("Draws (%d)", len(rounds))
+	}
+	s.subTabs.Refresh()
+
+	runs := draws.Runs(rounds, match.DefaultRules.RegulationRounds/2)
+	rows := make([]fyne.CanvasObject, 0, len(runs))
+	for _, run := range runs {
+		label := widget.NewLabel(fmt.Sprintf("%s–%s: %d rounds (CT won %d, T won %d)",
+			run.Start.Local().Format("Mon 02 Jan 15:04"), run.End.Local().Format("15:04"),
+			len(run.IDs), run.CTWon, run.TWon))
+		if s.readOnly {
+			rows = append(rows, label)
+			continue
+		}
+		rows = append(rows, container.NewBorder(nil, nil, nil,
+			container.NewHBox(
+				widget.NewButton("I was CT", func() { s.assignTeam(run, database.TeamCT) }),
+				widget.NewButton("I was T", func() { s.assignTeam(run, database.TeamT) }),
+			),
+			label,
+		))
+	}
+	if len(rows) == 0 {
+		rows = append(rows, widget.NewLabel("No draws to clean up"))
+	}
+	s.drawsContainer.Objects = rows
+	s.drawsContainer.Refresh()
+}
+
+// assignTeam records team as the player's side for every round in run, once
+// the PIN has been entered, as for editing rounds in History.
+func (s *StatsTab) assignTeam(run draws.Run, team database.Team) {
+	unlock(s.cfg, s.window, func() {
+		ctx, cancel := database.WithTimeout(s.ctx)
+		defer cancel()
+		if err := database.UpdateRounds(ctx, s.db, run.IDs, database.RoundChange{Team: &team}); err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		s.refresh()
+		if s.onRoundsEdited != nil {
+			s.onRoundsEdited()
+		}
+	})
+}
+
+// SetOnRoundsEdited sets the callback run after rounds were edited from the
+// Stats tab, e.g. to refresh the History tab.
+func (s *StatsTab) SetOnRoundsEdited(fn func()) {
+	s.onRoundsEdited = fn
+}
@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SetSendTestEmail sets what the Send Test button in the email settings
+// runs, e.g. sending last month's summary now.
+func (s *SettingsTab) SetSendTestEmail(send func() error) {
+	s.sendTestEmail = send
+	s.Reload()
+}
+
+// buildEmailSection creates the Settings editor for the monthly summary
+// email and its SMTP server.
+func (s *SettingsTab) buildEmailSection() fyne.CanvasObject {
+	e := &s.cfg.Email
+
+	enabledCheck := widget.NewCheck("Email me a summary of each month", func(enabled bool) {
+		e.Enabled = enabled
+		s.save()
+	})
+	enabledCheck.Checked = e.Enabled
+
+	entry := func(text, placeholder string, set func(string)) *widget.Entry {
+		en := widget.NewEntry()
+		en.SetPlaceHolder(placeholder)
+		en.SetText(text)
+		en.OnChanged = func(text string) {
+			set(strings.TrimSpace(text))
+			s.save()
+		}
+		return en
+	}
+	hostEntry := entry(e.Host, "smtp.example.com", func(v string) { e.Host = v })
+	portEntry := NewIntEntry(e.Port, 1, 65535, func(n int) {
+		e.Port = n
+		s.save()
+	})
+	userEntry := entry(e.Username, "Optional", func(v string) { e.Username = v })
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetText(e.Password)
+	passwordEntry.OnChanged = func(text string) {
+		e.Password = text
+		s.save()
+	}
+	fromEntry := entry(e.From, "tracker@example.com", func(v string) { e.From = v })
+	toEntry := entry(e.To, "me@example.com", func(v string) { e.To = v })
+
+	testBtn := widget.NewButton("Send Test", func() {
+		if s.sendTestEmail == nil {
+			return
+		}
+		send := s.sendTestEmail
+		go func() {
+			err := send()
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, s.window)
+					return
+				}
+				dialog.ShowInformation("Summary Sent", "Last month's summary was sent to "+e.To+".", s.window)
+			})
+		}()
+	})
+	if s.sendTestEmail == nil {
+		testBtn.Disable()
+	}
+
+	return container.NewVBox(
+		widget.NewLabel("Monthly Email Summary (sent once the month is over)"),
+		enabledCheck,
+		widget.NewForm(
+			widget.NewFormItem("SMTP server", container.NewBorder(nil, nil, nil, portEntry, hostEntry)),
+			widget.NewFormItem("Username", userEntry),
+			widget.NewFormItem("Password", passwordEntry),
+			widget.NewFormItem("From", fromEntry),
+			widget.NewFormItem("To", toEntry),
+		),
+		container.NewHBox(testBtn),
+	)
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/dailygoal"
+	"csstatstracker/internal/database"
+)
+
+// goalRingSize is the diameter of the daily goal ring.
+const goalRingSize = float32(24)
+
+// GoalRing shows progress towards the daily goal as a ring that fills up,
+// with the count beside it. It fills in the win colour once a net wins goal
+// is met and turns the loss colour when a max games goal is exceeded. It's
+// hidden while the goal is switched off.
+type GoalRing struct {
+	widget.BaseWidget
+	ctx      context.Context
+	db       *sql.DB
+	cfg      *config.Config
+	progress dailygoal.Progress
+}
+
+// NewGoalRing creates the daily goal ring over the rounds in db. Loads are
+// abandoned once ctx is cancelled.
+func NewGoalRing(ctx context.Context, db *sql.DB, cfg *config.Config) *GoalRing {
+	g := &GoalRing{ctx: ctx, db: db, cfg: cfg}
+	g.ExtendBaseWidget(g)
+	g.Reload()
+	return g
+}
+
+// Reload re-reads today's rounds and redraws. Call it when rounds change,
+// the goal is edited, and periodically so the ring resets at the rollover
+// hour.
+func (g *GoalRing) Reload() {
+	goal := g.cfg.DailyGoal
+	if !goal.Enabled {
+		g.Hide()
+		return
+	}
+	ctx, cancel := database.WithTimeout(g.ctx)
+	defer cancel()
+	rounds, err := database.GetRoundsSince(ctx, g.db, dailygoal.DayStart(time.Now(), goal.RolloverHour))
+	if err != nil {
+		fyne.LogError("failed to load today's rounds", err)
+		return
+	}
+	g.progress = dailygoal.Compute(rounds, goal)
+	g.Show()
+	g.Refresh()
+}
+
+func (g *GoalRing) CreateRenderer() fyne.WidgetRenderer {
+	track := canvas.NewArc(0, 360, 0.7, chartLineColor())
+	fill := canvas.NewArc(0, 0, 0.7, timeColor())
+	text := canvas.NewText("", chartTextColor())
+	text.TextSize = theme.CaptionTextSize()
+	r := &goalRingRenderer{ring: g, track: track, fill: fill, text: text}
+	r.Refresh()
+	return r
+}
+
+type goalRingRenderer struct {
+	ring  *GoalRing
+	track *canvas.Arc
+	fill  *canvas.Arc
+	text  *canvas.Text
+}
+
+func (r *goalRingRenderer) Destroy() {}
+
+func (r *goalRingRenderer) Layout(size fyne.Size) {
+	y := (size.Height - goalRingSize) / 2
+	for _, arc := range []*canvas.Arc{r.track, r.fill} {
+		arc.Resize(fyne.NewSquareSize(goalRingSize))
+		arc.Move(fyne.NewPos(0, y))
+	}
+	textSize := r.text.MinSize()
+	r.text.Move(fyne.NewPos(goalRingSize+theme.Padding(), (size.Height-textSize.Height)/2))
+	r.text.Resize(textSize)
+}
+
+func (r *goalRingRenderer) MinSize() fyne.Size {
+	textSize := r.text.MinSize()
+	return fyne.NewSize(goalRingSize+theme.Padding()+textSize.Width, max(goalRingSize, textSize.Height))
+}
+
+func (r *goalRingRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.track, r.fill, r.text}
+}
+
+func (r *goalRingRenderer) Refresh() {
+	p := r.ring.progress
+	var fill color.Color = timeColor()
+	switch {
+	case p.Met():
+		fill = winColor()
+	case p.Over():
+		fill = lossColor()
+	}
+	r.track.FillColor = chartLineColor()
+	r.fill.FillColor = fill
+	r.fill.EndAngle = float32(360 * p.Fraction())
+	r.text.Text = "Today: " + p.String()
+	r.text.Color = chartTextColor()
+	r.Layout(r.ring.Size())
+	r.track.Refresh()
+	r.fill.Refresh()
+	r.text.Refresh()
+}
+
+// Daily goal kinds as offered in Settings.
+var (
+	goalKindOptions = []string{"Net wins", "Max games"}
+	goalKindValues  = []string{config.GoalNetWins, config.GoalMaxGames}
+)
+
+// goalTargetRange returns the targets Settings offers for a kind of daily
+// goal: a net win target past 10 isn't realistic for a day, while a cap on
+// games can be set higher.
+func goalTargetRange(kind string) (lo, hi int) {
+	if kind == config.GoalMaxGames {
+		return 1, 30
+	}
+	return 1, 10
+}
+
+// buildDailyGoalSection creates the Settings row for the daily goal shown on
+// the Tracker tab.
+func (s *SettingsTab) buildDailyGoalSection() fyne.CanvasObject {
+	goal := &s.cfg.DailyGoal
+
+	enabledCheck := widget.NewCheck("Daily goal:", func(enabled bool) {
+		goal.Enabled = enabled
+		s.save()
+	})
+	enabledCheck.Checked = goal.Enabled
+
+	lo, hi := goalTargetRange(goal.Kind)
+	targetStepper := NewStepper(goal.Target, lo, hi, func(n int) {
+		goal.Target = n
+		s.save()
+	})
+
+	kindSelect := widget.NewSelect(goalKindOptions, func(selected string) {
+		for i, o := range goalKindOptions {
+			if o == selected && goalKindValues[i] != goal.Kind {
+				goal.Kind = goalKindValues[i]
+				s.save()
+				targetStepper.SetRange(goalTargetRange(goal.Kind))
+			}
+		}
+	})
+	for i, v := range goalKindValues {
+		if v == goal.Kind {
+			kindSelect.Selected = goalKindOptions[i]
+		}
+	}
+
+	rolloverStepper := NewStepper(goal.RolloverHour, 0, 23, func(n int) {
+		goal.RolloverHour = n
+		s.save()
+	})
+
+	return container.NewHBox(
+		enabledCheck,
+		kindSelect,
+		targetStepper,
+		widget.NewLabel("a day, starting at"),
+		rolloverStepper,
+		widget.NewLabel(":00"),
+	)
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+
+	"csstatstracker/internal/gsi"
+)
+
+// writeGSIConfig writes the game state config file into CS2's cfg
+// directory, with the port and token from the settings, so it doesn't have
+// to be copied out of the README by hand. If CS2 can't be found, it offers
+// to pick the directory.
+func (s *SettingsTab) writeGSIConfig() {
+	dir, err := gsi.FindCfgDir()
+	if errors.Is(err, gsi.ErrNoCfgDir) {
+		dialog.ShowConfirm("CS2 Not Found",
+			"CS2 wasn't found in your Steam libraries.\nChoose its "+filepath.Join("game", "csgo", "cfg")+" folder yourself?",
+			func(pick bool) {
+				if pick {
+					s.pickGSIConfigDir()
+				}
+			}, s.window)
+		return
+	}
+	if err != nil {
+		dialog.ShowError(err, s.window)
+		return
+	}
+	s.confirmGSIConfig(dir)
+}
+
+// pickGSIConfigDir asks for CS2's cfg directory and writes the config file
+// there.
+func (s *SettingsTab) pickGSIConfigDir() {
+	dialog.ShowFolderOpen(func(uri fyne.ListableURI, err error) {
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		if uri == nil {
+			return // cancelled
+		}
+		s.confirmGSIConfig(uri.Path())
+	}, s.window)
+}
+
+// confirmGSIConfig writes the config file into dir once the user agrees,
+// and turns game state integration on if it's off.
+func (s *SettingsTab) confirmGSIConfig(dir string) {
+	dialog.ShowConfirm("Write Game State Config",
+		fmt.Sprintf("Write %s to\n%s\nfor port %d?", gsi.ConfigFileName, dir, s.cfg.GSI.Port),
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			path, err := gsi.WriteConfigFile(dir, s.cfg.GSI.Port, s.cfg.GSI.Token)
+			if err != nil {
+				dialog.ShowError(err, s.window)
+				return
+			}
+			if !s.cfg.GSI.Enabled {
+				s.cfg.GSI.Enabled = true
+				s.save()
+				s.Reload()
+			}
+			dialog.ShowInformation("Game State Config Written",
+				"Saved "+path+".\nRestart CS2 if it's running so it picks the file up.", s.window)
+		}, s.window)
+}
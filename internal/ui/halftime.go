@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/games"
+	"csstatstracker/internal/halftime"
+	"csstatstracker/internal/match"
+)
+
+// buildHalftimeContent creates the Halftime sub-tab: how often games were
+// won from each halftime score.
+func (s *StatsTab) buildHalftimeContent() fyne.CanvasObject {
+	s.halftimeLabels = container.NewVBox()
+	s.halftimeChartContainer = container.NewStack()
+	return container.NewBorder(
+		container.NewVBox(
+			widget.NewSeparator(),
+			widget.NewLabel("Games Won by Score at Halftime (all time, rebuilt from rounds):"),
+			s.halftimeLabels,
+			widget.NewSeparator(),
+		),
+		nil, nil, nil,
+		s.halftimeChartContainer,
+	)
+}
+
+// refreshHalftime rebuilds games from rounds and updates the Halftime
+// sub-tab.
+func (s *StatsTab) refreshHalftime(rounds []database.Round) {
+	if s.halftimeLabels == nil {
+		return
+	}
+	rows := halftime.ByHalfScore(games.Rebuild(rounds, match.DefaultRules), match.DefaultRules)
+	sum := halftime.Summarize(rows)
+
+	lines := []struct {
+		text string
+		row  halftime.Row
+	}{
+		{"Leading at half: won", sum.Leading},
+		{"Level at half: won", sum.Level},
+		{"Trailing at half: came back in", sum.Trailing},
+	}
+	labels := make([]fyne.CanvasObject, 0, len(lines))
+	for _, l := range lines {
+		label := widget.NewLabel("")
+		s.setRate(label, fmt.Sprintf("%s %d of %d games (%.1f%%%s)",
+			l.text, l.row.Won, l.row.Games, l.row.WinRate(), formatMargin(l.row.Won, l.row.Games)), l.row.Games)
+		labels = append(labels, label)
+	}
+	s.halftimeLabels.Objects = labels
+	s.halftimeLabels.Refresh()
+
+	if len(rows) == 0 {
+		noData := widget.NewLabel("No finished games yet. Games are rebuilt from rounds recorded\n" +
+			"without a break of more than 30 minutes, so record every round.")
+		noData.Alignment = fyne.TextAlignCenter
+		s.halftimeChartContainer.Objects = []fyne.CanvasObject{container.NewCenter(noData)}
+		s.halftimeChartContainer.Refresh()
+		return
+	}
+	chartLabels := make([]string, len(rows))
+	values := make([]int, len(rows))
+	for i, r := range rows {
+		chartLabels[i] = fmt.Sprintf("%d–%d (%d)", r.HalfWins, r.HalfLosses, r.Games)
+		values[i] = int(r.WinRate() + 0.5)
+	}
+	chart := newBarChart(chartLabels, values, func(v int) string { return strconv.Itoa(v) + "%" })
+	chart.posColor = winColor
+	s.halftimeChartContainer.Objects = []fyne.CanvasObject{
+		chart.withControls(widget.NewLabel("Games won from each halftime score; games played in brackets")),
+	}
+	s.halftimeChartContainer.Refresh()
+}
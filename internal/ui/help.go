@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/match"
+	"csstatstracker/internal/records"
+)
+
+// Definitions shared by the Help tab and the hints next to the labels they
+// explain.
+const (
+	helpDraws = "A draw is a round recorded with no team selected.\n" +
+		"It's neither a win nor a loss, but it counts towards\n" +
+		"total rounds, so draws lower the overall win rate."
+	helpWinRate = "Win rate is wins out of all rounds, draws included.\n" +
+		"The ± figure is the 95% confidence margin: with few\n" +
+		"rounds the true rate could be anywhere in that range."
+	helpSaving = "Rounds are saved to the database the moment they're\n" +
+		"recorded; there's nothing to save by hand. Settings are\n" +
+		"saved automatically half a second after the last change."
+)
+
+// HelpTab describes the hotkeys, how data is saved and what the stats mean,
+// generated from the current config so it shows the user's own bindings.
+type HelpTab struct {
+	cfg  *config.Config
+	text *widget.RichText
+}
+
+// NewHelpTab creates the Help tab.
+func NewHelpTab(cfg *config.Config) *HelpTab {
+	h := &HelpTab{cfg: cfg, text: widget.NewRichText()}
+	h.text.Wrapping = fyne.TextWrapWord
+	h.Refresh()
+	return h
+}
+
+// Container returns the tab's content.
+func (h *HelpTab) Container() fyne.CanvasObject {
+	return container.NewVScroll(h.text)
+}
+
+// Refresh regenerates the help text from the config, e.g. after hotkeys
+// were rebound.
+func (h *HelpTab) Refresh() {
+	h.text.ParseMarkdown(helpMarkdown(h.cfg))
+}
+
+// helpMarkdown renders the Help tab for cfg.
+func helpMarkdown(cfg *config.Config) string {
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format+"\n", args...)
+	}
+
+	line("## Hotkeys")
+	line("")
+	line("Hotkeys work from any window, including the game. Rebind them under Settings.")
+	line("")
+	for _, action := range config.HotkeyActions {
+		line("- **%s** `%s` — %s", action.Label, FormatHotkeys(cfg.Hotkeys[action.Key]), action.Help)
+	}
+	line("")
+	switch cfg.HotkeyMatch {
+	case config.MatchSuperset:
+		line("A combo fires even while other keys are held down.")
+	case config.MatchLongest:
+		line("A combo fires even while other keys are held down; when several match, the one with the most keys wins.")
+	default:
+		line("A combo only fires when exactly its keys are held down.")
+	}
+	if cfg.HotkeyTiming.Repeat == config.RepeatDelay {
+		line("Holding a combo repeats it after %d ms.", cfg.HotkeyTiming.RepeatDelay)
+	} else {
+		line("Holding a combo fires it once.")
+	}
+	line("")
+	line("In the window, **F11** switches to presentation mode: the selected match's side names and score, full screen on black. F11 or Esc leaves it.")
+	line("")
+
+	line("## Saving")
+	line("")
+	line("%s", oneLine(helpSaving))
+	line("")
+	if cfg.BreakLimit.Enabled {
+		line("After %d matches in a row, recording a new match is locked for a %d-minute break; "+
+			"a match already under way can be finished. A pause of more than %s resets the count.",
+			cfg.BreakLimit.Matches, cfg.BreakLimit.BreakMinutes, formatGap())
+		line("")
+	}
+
+	line("## Stats")
+	line("")
+	line("- **Win, loss** — a round won or lost by the team you had selected when it was recorded.")
+	line("- **Draw** — %s", oneLine(helpDraws))
+	line("- **Win rate** — %s", oneLine(helpWinRate))
+	line("- **Low sample** — a rate based on fewer than %d rounds is greyed out; change the threshold under Settings.", cfg.MinSampleSize)
+	line("- **Play time** — estimated at %d seconds per round.", secondsPerRound)
+	line("- **Session** — rounds with no pause longer than %s between them.", formatGap())
+	line("- **Game** — rebuilt from rounds in MR%d: %d regulation rounds, then overtimes of %d. Wingman and Casual rounds are first to %d with no overtime. A session break or a change of mode also ends a game.",
+		match.DefaultRules.RegulationRounds/2, match.DefaultRules.RegulationRounds, match.DefaultRules.OvertimeRounds,
+		match.ShortRules.RegulationRounds/2+1)
+	return b.String()
+}
+
+// oneLine joins a hint's lines into a paragraph.
+func oneLine(s string) string {
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// formatGap renders records.SessionGap as "30 minutes".
+func formatGap() string {
+	return fmt.Sprintf("%d minutes", int(records.SessionGap.Minutes()))
+}
+
+// hint is a question mark icon that shows its text in a popup while the
+// pointer is over it, or when it's tapped on touch screens.
+type hint struct {
+	widget.Icon
+	text  string
+	popup *widget.PopUp
+}
+
+// newHint creates a hint showing text.
+func newHint(text string) *hint {
+	h := &hint{text: text}
+	h.ExtendBaseWidget(h)
+	h.SetResource(theme.QuestionIcon())
+	return h
+}
+
+// MouseIn implements desktop.Hoverable.
+func (h *hint) MouseIn(e *desktop.MouseEvent) { h.show(e.AbsolutePosition) }
+
+// MouseMoved implements desktop.Hoverable.
+func (h *hint) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable.
+func (h *hint) MouseOut() { h.hide() }
+
+// Tapped implements fyne.Tappable.
+func (h *hint) Tapped(e *fyne.PointEvent) {
+	if h.popup != nil && h.popup.Visible() {
+		h.hide()
+		return
+	}
+	h.show(e.AbsolutePosition)
+}
+
+func (h *hint) show(at fyne.Position) {
+	c := fyne.CurrentApp().Driver().CanvasForObject(h)
+	if c == nil {
+		return
+	}
+	h.hide()
+	h.popup = widget.NewPopUp(widget.NewLabel(h.text), c)
+	h.popup.ShowAtPosition(at.Add(fyne.NewPos(theme.Padding(), theme.Padding())))
+}
+
+func (h *hint) hide() {
+	if h.popup != nil {
+		h.popup.Hide()
+		h.popup = nil
+	}
+}
+
+// withHint puts a hint for text after obj, which keeps the rest of the
+// width.
+func withHint(obj fyne.CanvasObject, text string) fyne.CanvasObject {
+	return container.NewBorder(nil, nil, nil, newHint(text), obj)
+}
@@ -5,6 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"image/color"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -14,7 +18,10 @@ import (
 	"fyne.io/fyne/v2/layout"
 	"fyne.io/fyne/v2/widget"
 
+	"csstatstracker/internal/config"
 	"csstatstracker/internal/database"
+	"csstatstracker/internal/ics"
+	"csstatstracker/internal/records"
 )
 
 var (
@@ -26,6 +33,8 @@ var (
 type selectableRow struct {
 	widget.BaseWidget
 	background *canvas.Rectangle
+	chipBg     *canvas.Rectangle
+	chipText   *canvas.Text
 	label      *widget.Label
 	editBtn    *widget.Button
 	delBtn     *widget.Button
@@ -45,8 +54,16 @@ func newSelectableRow(h *HistoryTab) *selectableRow {
 		delBtn:     widget.NewButton("Delete", nil),
 	}
 	r.ExtendBaseWidget(r)
+	if h.readOnly {
+		r.editBtn.Hide()
+		r.delBtn.Hide()
+	}
+
+	var chip fyne.CanvasObject
+	chip, r.chipBg, r.chipText = resultChip()
 
 	row := container.NewHBox(
+		chip,
 		r.label,
 		layout.NewSpacer(),
 		r.editBtn,
@@ -97,6 +114,78 @@ func (r *selectableRow) MouseIn(e *desktop.MouseEvent) {
 func (r *selectableRow) MouseMoved(e *desktop.MouseEvent) {}
 func (r *selectableRow) MouseOut()                        {}
 
+// SetResult shows the round's outcome in the row's chip.
+func (r *selectableRow) SetResult(res database.Result) {
+	setResultChip(r.chipBg, r.chipText, res)
+}
+
+// resultChip creates an empty result chip, returning it along with the parts
+// setResultChip fills in.
+func resultChip() (fyne.CanvasObject, *canvas.Rectangle, *canvas.Text) {
+	bg := canvas.NewRectangle(unselectedColor)
+	bg.CornerRadius = 4
+	bg.SetMinSize(fyne.NewSize(24, 20))
+	text := canvas.NewText("", unselectedColor)
+	text.TextStyle = fyne.TextStyle{Bold: true}
+	text.Alignment = fyne.TextAlignCenter
+	return container.NewCenter(container.NewStack(bg, text)), bg, text
+}
+
+// newResultChip creates a chip showing res.
+func newResultChip(res database.Result) fyne.CanvasObject {
+	chip, bg, text := resultChip()
+	setResultChip(bg, text, res)
+	return chip
+}
+
+// setResultChip shows a round's outcome as W or L in the win/loss colours, or
+// a dash for rounds without a team.
+func setResultChip(bg *canvas.Rectangle, text *canvas.Text, res database.Result) {
+	switch res {
+	case database.ResultWin:
+		bg.FillColor = winColor()
+		text.Text = "W"
+	case database.ResultLoss:
+		bg.FillColor = lossColor()
+		text.Text = "L"
+	default:
+		bg.FillColor = chartLineColor()
+		text.Text = "–"
+	}
+	text.Color = contrastText(bg.FillColor)
+	bg.Refresh()
+	text.Refresh()
+}
+
+// roundSummary is a round's one-line description in History.
+func roundSummary(r database.Round) string {
+	teamStr := "None"
+	if r.Team != "" {
+		teamStr = string(r.Team)
+	}
+	text := fmt.Sprintf("%s | %s won [%s]",
+		r.CreatedAt.Format("2006-01-02 15:04:05"),
+		r.Winner,
+		teamStr,
+	)
+	if r.PartySize != database.PartyUnknown {
+		text += " " + r.PartySize.String()
+	}
+	if r.Mode != database.ModeUnknown {
+		text += " " + r.Mode.String()
+	}
+	if r.CTName != "" && (r.CTName != "CT" || r.TName != "T") {
+		text += " (" + r.CTName + " vs " + r.TName + ")"
+	}
+	if r.Map != "" {
+		text += " on " + r.Map
+	}
+	if r.Account != "" {
+		text += " — " + r.Account
+	}
+	return text
+}
+
 func (r *selectableRow) SetSelected(selected bool) {
 	r.isSelected = selected
 	if selected {
@@ -109,23 +198,36 @@ func (r *selectableRow) SetSelected(selected bool) {
 
 // HistoryTab shows every recorded round with edit / delete controls.
 type HistoryTab struct {
-	db             *sql.DB
-	window         fyne.Window
-	list           *widget.List
-	rounds         []database.Round
-	selected       map[int]bool
-	lastClickedIdx int
-	onUpdate       func()
-	deleteBtn      *widget.Button
-	selectAllBtn   *widget.Button
-	clearBtn       *widget.Button
+	ctx              context.Context // cancelled on app shutdown
+	db               *sql.DB
+	window           fyne.Window
+	cfg              *config.Config
+	list             *widget.List
+	rounds           []database.Round // loaded so far, newest first
+	total            int              // rounds in the database
+	countLabel       *widget.Label
+	views            *container.AppTabs
+	archiveTab       *container.TabItem
+	archiveContainer *fyne.Container
+	loadMoreBtn      *widget.Button
+	selected         map[int]bool
+	lastClickedIdx   int
+	onUpdate         func()
+	readOnly         bool // viewing a shared database: no adding, editing or deleting
+	deleteBtn        *widget.Button
+	copyBtn          *widget.Button
+	bulkEditBtn      *widget.Button
+	selectAllBtn     *widget.Button
+	clearBtn         *widget.Button
 }
 
 // NewHistoryTab creates a new history tab.
-func NewHistoryTab(db *sql.DB, window fyne.Window, onUpdate func()) *HistoryTab {
+func NewHistoryTab(ctx context.Context, db *sql.DB, window fyne.Window, cfg *config.Config, onUpdate func()) *HistoryTab {
 	h := &HistoryTab{
+		ctx:            ctx,
 		db:             db,
 		window:         window,
+		cfg:            cfg,
 		onUpdate:       onUpdate,
 		selected:       make(map[int]bool),
 		lastClickedIdx: -1,
@@ -134,6 +236,12 @@ func NewHistoryTab(db *sql.DB, window fyne.Window, onUpdate func()) *HistoryTab
 	return h
 }
 
+// SetReadOnly hides the controls that add, edit or delete rounds, for
+// viewing a database another PC records to. Call it before Container.
+func (h *HistoryTab) SetReadOnly() {
+	h.readOnly = true
+}
+
 // Container returns the tab content.
 func (h *HistoryTab) Container() fyne.CanvasObject {
 	// widget.List virtualises — only visible rows are materialised, which is
@@ -150,15 +258,8 @@ func (h *HistoryTab) Container() fyne.CanvasObject {
 			row.rowIdx = id
 			row.history = h
 
-			teamStr := "None"
-			if r.Team != "" {
-				teamStr = string(r.Team)
-			}
-			row.label.SetText(fmt.Sprintf("%s | %s won [%s]",
-				r.CreatedAt.Format("2006-01-02 15:04:05"),
-				r.Winner,
-				teamStr,
-			))
+			row.label.SetText(roundSummary(r))
+			row.SetResult(r.Result())
 			row.SetSelected(h.selected[r.ID])
 
 			if len(h.selected) > 1 {
@@ -170,10 +271,10 @@ func (h *HistoryTab) Container() fyne.CanvasObject {
 			rnd := r
 			row.editBtn.OnTapped = func() {
 				if len(h.selected) <= 1 {
-					h.showEditDialog(&rnd)
+					h.guard(func() { h.showEditDialog(&rnd) })
 				}
 			}
-			row.delBtn.OnTapped = func() { h.confirmDelete(&rnd) }
+			row.delBtn.OnTapped = func() { h.guard(func() { h.confirmDelete(&rnd) }) }
 		},
 	)
 	h.list.HideSeparators = true
@@ -185,11 +286,21 @@ func (h *HistoryTab) Container() fyne.CanvasObject {
 	addBtn.Importance = widget.HighImportance
 
 	h.deleteBtn = widget.NewButton("Delete Selected", func() {
-		h.confirmDeleteSelected()
+		h.guard(h.confirmDeleteSelected)
 	})
 	h.deleteBtn.Importance = widget.DangerImportance
 	h.deleteBtn.Hide()
 
+	h.copyBtn = widget.NewButton("Copy", func() {
+		h.copySelected()
+	})
+	h.copyBtn.Hide()
+
+	h.bulkEditBtn = widget.NewButton("Edit Selected", func() {
+		h.guard(h.showBulkEditDialog)
+	})
+	h.bulkEditBtn.Hide()
+
 	h.selectAllBtn = widget.NewButton("Select All", func() {
 		for _, r := range h.rounds {
 			h.selected[r.ID] = true
@@ -213,8 +324,122 @@ func (h *HistoryTab) Container() fyne.CanvasObject {
 		h.refresh()
 	})
 
-	toolbar := container.NewHBox(addBtn, h.deleteBtn, h.selectAllBtn, h.clearBtn, refreshBtn)
-	return container.NewBorder(toolbar, nil, nil, nil, h.list)
+	calendarBtn := widget.NewButton("Export Calendar...", func() {
+		h.exportCalendar()
+	})
+
+	cleanupBtn := widget.NewButton("Clean Up...", func() {
+		h.showCleanup()
+	})
+
+	leetifyBtn := widget.NewButton("Import Leetify...", func() {
+		h.importLeetify()
+	})
+	csstatsBtn := widget.NewButton("Import csstats.gg...", func() {
+		h.importCSStats()
+	})
+
+	toolbar := container.NewHBox(addBtn, h.deleteBtn, h.bulkEditBtn, h.copyBtn, h.selectAllBtn, h.clearBtn, refreshBtn, calendarBtn, cleanupBtn, leetifyBtn, csstatsBtn)
+	if h.readOnly {
+		addBtn.Hide()
+		cleanupBtn.Hide()
+		leetifyBtn.Hide()
+		csstatsBtn.Hide()
+	}
+
+	// Paging: the count of loaded rounds, Load More, and a jump to a date
+	// that loads everything newer than it.
+	h.countLabel = widget.NewLabel("")
+	h.loadMoreBtn = widget.NewButton("Load More", func() {
+		h.loadMore(historyPageSize)
+	})
+	dateEntry := widget.NewEntry()
+	dateEntry.SetPlaceHolder("YYYY-MM-DD")
+	jumpBtn := widget.NewButton("Go to Date", func() {
+		h.jumpToDate(dateEntry.Text)
+	})
+	dateEntry.OnSubmitted = func(text string) { h.jumpToDate(text) }
+	pager := container.NewHBox(h.countLabel, h.loadMoreBtn, layout.NewSpacer(),
+		widget.NewLabel("Go to:"), container.NewGridWrap(fyne.NewSize(120, dateEntry.MinSize().Height), dateEntry), jumpBtn)
+	h.updatePager()
+
+	list := container.NewBorder(container.NewVBox(toolbar, pager), nil, nil, nil, h.list)
+
+	h.archiveTab = container.NewTabItem("Archive", h.buildArchive())
+	h.views = container.NewAppTabs(container.NewTabItem("List", list), h.archiveTab)
+	h.views.OnSelected = func(tab *container.TabItem) {
+		if tab == h.archiveTab {
+			h.refreshArchive()
+		}
+	}
+	return h.views
+}
+
+// historyPageSize is how many rounds History loads at a time.
+const historyPageSize = 50
+
+// updatePager shows how many rounds are loaded and hides Load More once
+// they all are.
+func (h *HistoryTab) updatePager() {
+	if h.countLabel == nil {
+		return
+	}
+	h.countLabel.SetText(fmt.Sprintf("Showing %s of %s rounds",
+		formatThousands(len(h.rounds)), formatThousands(h.total)))
+	if len(h.rounds) < h.total {
+		h.loadMoreBtn.Show()
+	} else {
+		h.loadMoreBtn.Hide()
+	}
+}
+
+// loadMore appends the next n rounds to the list.
+func (h *HistoryTab) loadMore(n int) {
+	ctx, cancel := database.WithTimeout(h.ctx)
+	defer cancel()
+	rounds, err := database.GetRoundsPage(ctx, h.db, len(h.rounds), n)
+	if err != nil {
+		dialog.ShowError(err, h.window)
+		return
+	}
+	h.rounds = append(h.rounds, rounds...)
+	h.updatePager()
+	h.refreshRows()
+}
+
+// jumpToDate loads rounds down to the given YYYY-MM-DD day and scrolls to
+// the newest round played on or before it.
+func (h *HistoryTab) jumpToDate(text string) {
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(text), time.Local)
+	if err != nil {
+		dialog.ShowError(fmt.Errorf("%q is not a date like 2024-01-31", text), h.window)
+		return
+	}
+	ctx, cancel := database.WithTimeout(h.ctx)
+	defer cancel()
+	idx, err := database.CountRoundsSince(ctx, h.db, day.AddDate(0, 0, 1).UTC())
+	if err != nil {
+		dialog.ShowError(err, h.window)
+		return
+	}
+	if idx >= h.total {
+		dialog.ShowInformation("Go to Date", "No rounds on or before "+day.Format("2006-01-02")+".", h.window)
+		return
+	}
+	// Load through the target plus a page of context below it.
+	if want := idx + historyPageSize; want > len(h.rounds) {
+		h.loadMore(want - len(h.rounds))
+	}
+	h.list.ScrollTo(idx)
+}
+
+// formatThousands renders n with comma separators, e.g. 3,214.
+func formatThousands(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
 }
 
 // refreshRows redraws the currently-visible list rows.
@@ -264,27 +489,147 @@ func (h *HistoryTab) updateToolbar() {
 		return
 	}
 	count := len(h.selected)
+	if count > 0 {
+		h.copyBtn.SetText(fmt.Sprintf("Copy (%d)", count))
+		h.copyBtn.Show()
+	} else {
+		h.copyBtn.Hide()
+
+		h.bulkEditBtn = widget.NewButton("Edit Selected", func() {
+			h.guard(h.showBulkEditDialog)
+		})
+		h.bulkEditBtn.Hide()
+	}
 	if count > 1 {
 		h.deleteBtn.SetText(fmt.Sprintf("Delete Selected (%d)", count))
 		h.deleteBtn.Show()
+		h.bulkEditBtn.SetText(fmt.Sprintf("Edit Selected (%d)", count))
+		h.bulkEditBtn.Show()
 		h.clearBtn.Show()
 	} else {
 		h.deleteBtn.Hide()
+		h.bulkEditBtn.Hide()
 		h.clearBtn.Hide()
 	}
+	if h.readOnly {
+		h.deleteBtn.Hide()
+		h.bulkEditBtn.Hide()
+	}
+}
+
+// copySelected puts the selected rounds on the clipboard in the configured
+// copy format.
+func (h *HistoryTab) copySelected() {
+	var rounds []database.Round
+	for _, r := range h.rounds {
+		if h.selected[r.ID] {
+			rounds = append(rounds, r)
+		}
+	}
+	if len(rounds) == 0 {
+		return
+	}
+	fyne.CurrentApp().Clipboard().SetContent(formatRoundsForCopy(rounds, h.cfg.CopyFormat))
+}
+
+// exportCalendar saves the play sessions as an iCalendar file, from the
+// selected rounds or all of them.
+func (h *HistoryTab) exportCalendar() {
+	ctx, cancel := database.WithTimeout(h.ctx)
+	defer cancel()
+	rounds, err := database.GetAllRounds(ctx, h.db)
+	if err != nil {
+		dialog.ShowError(err, h.window)
+		return
+	}
+	if len(h.selected) == 0 {
+		h.saveCalendar(rounds, fmt.Sprintf("All %d rounds", len(rounds)))
+		return
+	}
+
+	// With rows selected, offer to export just those.
+	var selected []database.Round
+	for _, r := range rounds {
+		if h.selected[r.ID] {
+			selected = append(selected, r)
+		}
+	}
+	dialog.ShowCustomConfirm("Export Calendar",
+		fmt.Sprintf("Export Selected (%d rounds)", len(selected)),
+		fmt.Sprintf("Export All (%d rounds)", len(rounds)),
+		widget.NewLabel("Export sessions from the selected rounds only, or from all rounds?"),
+		func(onlySelected bool) {
+			if !onlySelected {
+				h.saveCalendar(rounds, fmt.Sprintf("All %d rounds", len(rounds)))
+				return
+			}
+			h.saveCalendar(selected, describeSelection(selected))
+		}, h.window)
+}
+
+// describeSelection describes selected rounds for an export's header, e.g.
+// "12 selected rounds, 2025-03-01 to 2025-03-04".
+func describeSelection(rounds []database.Round) string {
+	if len(rounds) == 0 {
+		return "No rounds"
+	}
+	first, last := rounds[0].CreatedAt, rounds[0].CreatedAt
+	for _, r := range rounds {
+		if r.CreatedAt.Before(first) {
+			first = r.CreatedAt
+		}
+		if r.CreatedAt.After(last) {
+			last = r.CreatedAt
+		}
+	}
+	return fmt.Sprintf("%d selected rounds, %s to %s", len(rounds),
+		first.Local().Format("2006-01-02"), last.Local().Format("2006-01-02"))
+}
+
+// saveCalendar asks where to save the sessions played in rounds as an .ics
+// file, with desc saying which rounds they are.
+func (h *HistoryTab) saveCalendar(rounds []database.Round, desc string) {
+	sessions := records.Sessions(rounds)
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, h.window)
+			return
+		}
+		if w == nil {
+			return // cancelled
+		}
+		defer func() { _ = w.Close() }()
+		if err := ics.Write(w, sessions, time.Now(), desc); err != nil {
+			dialog.ShowError(err, h.window)
+		}
+	}, h.window)
+	save.SetFileName("cs2-sessions.ics")
+	save.Show()
 }
 
 // Refresh reloads data from database.
 func (h *HistoryTab) Refresh() { h.refresh() }
 
 func (h *HistoryTab) refresh() {
-	ctx := context.Background()
-	rounds, err := database.GetAllRounds(ctx, h.db)
+	ctx, cancel := database.WithTimeout(h.ctx)
+	defer cancel()
+	total, err := database.CountRounds(ctx, h.db)
+	if err != nil {
+		dialog.ShowError(err, h.window)
+		return
+	}
+	// Keep as many rounds loaded as before so edits don't collapse the list.
+	rounds, err := database.GetRoundsPage(ctx, h.db, 0, max(len(h.rounds), historyPageSize))
 	if err != nil {
 		dialog.ShowError(err, h.window)
 		return
 	}
+	h.total = total
 	h.rounds = rounds
+	h.updatePager()
+	if h.views != nil && h.views.Selected() == h.archiveTab {
+		h.refreshArchive()
+	}
 	h.selected = make(map[int]bool)
 	h.lastClickedIdx = -1
 	h.updateToolbar()
@@ -296,10 +641,22 @@ func (h *HistoryTab) showAddDialog() {
 	winnerSelect.SetSelected("CT")
 	teamSelect := widget.NewSelect([]string{"None", "CT", "T"}, nil)
 	teamSelect.SetSelected("None")
+	partySelect := widget.NewSelect(partySizeNames(), nil)
+	partySelect.SetSelected(database.PartyUnknown.String())
+	modeSelect := widget.NewSelect(modeNames(), nil)
+	modeSelect.SetSelected(database.ModeUnknown.String())
+	accountSelect := widget.NewSelect(accountNames(h.cfg, "None"), nil)
+	accountSelect.SetSelected(accountOption(h.cfg.ActiveAccount, "None"))
+	mapEntry := widget.NewSelectEntry(h.mapOptions())
+	mapEntry.SetPlaceHolder("Unknown")
 
 	form := widget.NewForm(
 		widget.NewFormItem("Winner", winnerSelect),
 		widget.NewFormItem("Your Team", teamSelect),
+		widget.NewFormItem("Party", partySelect),
+		widget.NewFormItem("Mode", modeSelect),
+		widget.NewFormItem("Account", accountSelect),
+		widget.NewFormItem("Map", mapEntry),
 	)
 
 	dialog.ShowCustomConfirm("Add Round", "Save", "Cancel", form, func(save bool) {
@@ -311,7 +668,17 @@ func (h *HistoryTab) showAddDialog() {
 		if teamSelect.Selected != "None" {
 			team = database.Team(teamSelect.Selected)
 		}
-		if _, err := database.InsertRound(context.Background(), h.db, winner, team); err != nil {
+		r := database.Round{
+			Winner:    winner,
+			Team:      team,
+			PartySize: database.ParsePartySize(partySelect.Selected),
+			Mode:      database.ParseMode(modeSelect.Selected),
+			Account:   accountFromOption(accountSelect.Selected, "None"),
+			Map:       strings.TrimSpace(mapEntry.Text),
+		}
+		ctx, cancel := database.WithTimeout(h.ctx)
+		defer cancel()
+		if _, err := database.InsertRound(ctx, h.db, r); err != nil {
 			dialog.ShowError(err, h.window)
 			return
 		}
@@ -331,12 +698,25 @@ func (h *HistoryTab) showEditDialog(r *database.Round) {
 	} else {
 		teamSelect.SetSelected(string(r.Team))
 	}
+	partySelect := widget.NewSelect(partySizeNames(), nil)
+	partySelect.SetSelected(r.PartySize.String())
+	modeSelect := widget.NewSelect(modeNames(), nil)
+	modeSelect.SetSelected(r.Mode.String())
+	accountSelect := widget.NewSelect(accountNames(h.cfg, "None", r.Account), nil)
+	accountSelect.SetSelected(accountOption(r.Account, "None"))
+	mapEntry := widget.NewSelectEntry(h.mapOptions())
+	mapEntry.SetPlaceHolder("Unknown")
+	mapEntry.SetText(r.Map)
 	tsLabel := widget.NewLabel(r.CreatedAt.Format("2006-01-02 15:04:05"))
 
 	form := widget.NewForm(
 		widget.NewFormItem("Timestamp", tsLabel),
 		widget.NewFormItem("Winner", winnerSelect),
 		widget.NewFormItem("Your Team", teamSelect),
+		widget.NewFormItem("Party", partySelect),
+		widget.NewFormItem("Mode", modeSelect),
+		widget.NewFormItem("Account", accountSelect),
+		widget.NewFormItem("Map", mapEntry),
 	)
 
 	dialog.ShowCustomConfirm("Edit Round", "Save", "Cancel", form, func(save bool) {
@@ -348,7 +728,94 @@ func (h *HistoryTab) showEditDialog(r *database.Round) {
 		if teamSelect.Selected != "None" {
 			team = database.Team(teamSelect.Selected)
 		}
-		if err := database.UpdateRound(context.Background(), h.db, r.ID, winner, team); err != nil {
+		updated := *r
+		updated.Winner = winner
+		updated.Team = team
+		updated.PartySize = database.ParsePartySize(partySelect.Selected)
+		updated.Mode = database.ParseMode(modeSelect.Selected)
+		updated.Account = accountFromOption(accountSelect.Selected, "None")
+		updated.Map = strings.TrimSpace(mapEntry.Text)
+		ctx, cancel := database.WithTimeout(h.ctx)
+		defer cancel()
+		if err := database.UpdateRound(ctx, h.db, updated); err != nil {
+			dialog.ShowError(err, h.window)
+			return
+		}
+		h.refresh()
+		if h.onUpdate != nil {
+			h.onUpdate()
+		}
+	}, h.window)
+}
+
+// showBulkEditDialog changes the team, party size, mode, account or map of
+// every selected round at once. Fields left at "(unchanged)" keep each
+// round's own value.
+func (h *HistoryTab) showBulkEditDialog() {
+	const unchanged = "(unchanged)"
+	count := len(h.selected)
+	if count == 0 {
+		return
+	}
+
+	teamSelect := widget.NewSelect([]string{unchanged, "None", "CT", "T"}, nil)
+	teamSelect.SetSelected(unchanged)
+	partySelect := widget.NewSelect(append([]string{unchanged}, partySizeNames()...), nil)
+	partySelect.SetSelected(unchanged)
+	modeSelect := widget.NewSelect(append([]string{unchanged}, modeNames()...), nil)
+	modeSelect.SetSelected(unchanged)
+	accountSelect := widget.NewSelect(append([]string{unchanged}, accountNames(h.cfg, "None")...), nil)
+	accountSelect.SetSelected(unchanged)
+	// Emptied, the entry clears the rounds' map.
+	mapEntry := widget.NewSelectEntry(append([]string{unchanged}, h.mapOptions()...))
+	mapEntry.SetPlaceHolder("Unknown")
+	mapEntry.SetText(unchanged)
+
+	form := widget.NewForm(
+		widget.NewFormItem("Your Team", teamSelect),
+		widget.NewFormItem("Party", partySelect),
+		widget.NewFormItem("Mode", modeSelect),
+		widget.NewFormItem("Account", accountSelect),
+		widget.NewFormItem("Map", mapEntry),
+	)
+
+	title := fmt.Sprintf("Edit %d Rounds", count)
+	dialog.ShowCustomConfirm(title, "Save", "Cancel", form, func(save bool) {
+		if !save {
+			return
+		}
+		var change database.RoundChange
+		if teamSelect.Selected != unchanged {
+			team := database.TeamNone
+			if teamSelect.Selected != "None" {
+				team = database.Team(teamSelect.Selected)
+			}
+			change.Team = &team
+		}
+		if partySelect.Selected != unchanged {
+			party := database.ParsePartySize(partySelect.Selected)
+			change.PartySize = &party
+		}
+		if modeSelect.Selected != unchanged {
+			mode := database.ParseMode(modeSelect.Selected)
+			change.Mode = &mode
+		}
+		if accountSelect.Selected != unchanged {
+			account := accountFromOption(accountSelect.Selected, "None")
+			change.Account = &account
+		}
+		if mapEntry.Text != unchanged {
+			mapName := strings.TrimSpace(mapEntry.Text)
+			change.Map = &mapName
+		}
+
+		ids := make([]int, 0, count)
+		for id := range h.selected {
+			ids = append(ids, id)
+		}
+		ctx, cancel := database.WithTimeout(h.ctx)
+		defer cancel()
+		if err := database.UpdateRounds(ctx, h.db, ids, change); err != nil {
 			dialog.ShowError(err, h.window)
 			return
 		}
@@ -359,6 +826,26 @@ func (h *HistoryTab) showEditDialog(r *database.Round) {
 	}, h.window)
 }
 
+// mapPool is the competitive map pool, offered by the round dialogs' map
+// pickers before anything has been played on it.
+var mapPool = []string{"de_ancient", "de_anubis", "de_dust2", "de_inferno", "de_mirage", "de_nuke", "de_overpass", "de_train"}
+
+// mapOptions returns the maps the round dialogs offer: the map pool and
+// every map already in History, sorted. Any other map can be typed in.
+func (h *HistoryTab) mapOptions() []string {
+	options := slices.Clone(mapPool)
+	ctx, cancel := database.WithTimeout(h.ctx)
+	defer cancel()
+	if played, err := database.GetMapNames(ctx, h.db); err == nil {
+		options = append(options, played...)
+	}
+	slices.Sort(options)
+	return slices.Compact(options)
+}
+
+// guard runs action once the PIN, if one is set, has been entered.
+func (h *HistoryTab) guard(action func()) { unlock(h.cfg, h.window, action) }
+
 func (h *HistoryTab) confirmDelete(r *database.Round) {
 	dialog.ShowConfirm("Delete Round",
 		fmt.Sprintf("Delete round from %s?", r.CreatedAt.Format("2006-01-02 15:04:05")),
@@ -366,7 +853,9 @@ func (h *HistoryTab) confirmDelete(r *database.Round) {
 			if !confirmed {
 				return
 			}
-			if err := database.DeleteRound(context.Background(), h.db, r.ID); err != nil {
+			ctx, cancel := database.WithTimeout(h.ctx)
+			defer cancel()
+			if err := database.DeleteRound(ctx, h.db, r.ID); err != nil {
 				dialog.ShowError(err, h.window)
 				return
 			}
@@ -388,7 +877,8 @@ func (h *HistoryTab) confirmDeleteSelected() {
 			if !confirmed {
 				return
 			}
-			ctx := context.Background()
+			ctx, cancel := database.WithTimeout(h.ctx)
+			defer cancel()
 			for id := range h.selected {
 				if err := database.DeleteRound(ctx, h.db, id); err != nil {
 					dialog.ShowError(err, h.window)
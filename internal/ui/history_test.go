@@ -0,0 +1,85 @@
+package ui_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/ui"
+)
+
+func TestHistoryTabEditsRounds(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WL")...)
+	cfg := config.Default()
+
+	updates := 0
+	w := test.NewTempWindow(t, nil)
+	h := ui.NewHistoryTab(ctx, db, w, cfg, func() { updates++ })
+	w.SetContent(h.Container())
+	w.Resize(fyne.NewSize(1000, 600))
+
+	newest := func() database.Round {
+		t.Helper()
+		rounds, err := database.GetRoundsPage(ctx, db, 0, 1)
+		if err != nil || len(rounds) != 1 {
+			t.Fatalf("GetRoundsPage = %v, %v", rounds, err)
+		}
+		return rounds[0]
+	}
+	count := func() int {
+		t.Helper()
+		n, err := database.CountRounds(ctx, db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// Add a T round won by T.
+	test.Tap(button(t, w.Content(), "+ Add Round"))
+	d := topDialog(t, w)
+	winner, team := selects(d)[0], selects(d)[1]
+	winner.SetSelected("T")
+	team.SetSelected("T")
+	test.Tap(button(t, d, "Save"))
+	if n := count(); n != 3 {
+		t.Fatalf("%d rounds after adding one; want 3", n)
+	}
+	if r := newest(); r.Winner != database.TeamT || r.Team != database.TeamT {
+		t.Errorf("added round won by %q on %q; want T on T", r.Winner, r.Team)
+	}
+
+	// Edit its party size; rows are newest first.
+	test.Tap(buttons(w.Content(), "Edit")[0])
+	d = topDialog(t, w)
+	selectWith(t, d, database.PartyDuo.String()).SetSelected(database.PartyDuo.String())
+	test.Tap(button(t, d, "Save"))
+	if r := newest(); r.PartySize != database.PartyDuo || r.Winner != database.TeamT {
+		t.Errorf("edited round = %+v; want a Duo round still won by T", r)
+	}
+
+	// Cancelling a delete keeps the round; confirming removes it.
+	test.Tap(buttons(w.Content(), "Delete")[0])
+	test.Tap(button(t, topDialog(t, w), "No"))
+	if n := count(); n != 3 {
+		t.Fatalf("%d rounds after cancelling a delete; want 3", n)
+	}
+	test.Tap(buttons(w.Content(), "Delete")[0])
+	test.Tap(button(t, topDialog(t, w), "Yes"))
+	if n := count(); n != 2 {
+		t.Errorf("%d rounds after deleting one; want 2", n)
+	}
+
+	if updates != 3 {
+		t.Errorf("onUpdate called %d times; want 3 (add, edit, delete)", updates)
+	}
+}
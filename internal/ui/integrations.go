@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SetSyncFACEIT sets what the Sync Now button in the FACEIT settings runs,
+// returning how many rounds it added.
+func (s *SettingsTab) SetSyncFACEIT(sync func() (int, error)) {
+	s.syncFACEIT = sync
+	s.Reload()
+}
+
+// buildIntegrationsSection creates the Settings editor for the services
+// matches are synced from.
+func (s *SettingsTab) buildIntegrationsSection() fyne.CanvasObject {
+	f := &s.cfg.FACEIT
+
+	enabledCheck := widget.NewCheck("Sync finished FACEIT matches into History", func(enabled bool) {
+		f.Enabled = enabled
+		s.save()
+	})
+	enabledCheck.Checked = f.Enabled
+
+	nicknameEntry := widget.NewEntry()
+	nicknameEntry.SetPlaceHolder("FACEIT nickname")
+	nicknameEntry.SetText(f.Nickname)
+	nicknameEntry.OnChanged = func(text string) {
+		f.Nickname = strings.TrimSpace(text)
+		s.save()
+	}
+	apiKeyEntry := widget.NewPasswordEntry()
+	apiKeyEntry.SetText(f.APIKey)
+	apiKeyEntry.OnChanged = func(text string) {
+		f.APIKey = strings.TrimSpace(text)
+		s.save()
+	}
+	intervalEntry := NewIntEntry(f.IntervalMinutes, 5, 1440, func(n int) {
+		f.IntervalMinutes = n
+		s.save()
+	})
+
+	syncBtn := widget.NewButton("Sync Now", func() {
+		if s.syncFACEIT == nil {
+			return
+		}
+		sync := s.syncFACEIT
+		go func() {
+			added, err := sync()
+			fyne.Do(func() {
+				if err != nil {
+					dialog.ShowError(err, s.window)
+					return
+				}
+				dialog.ShowInformation("FACEIT Synced", fmt.Sprintf("Added %d rounds.", added), s.window)
+			})
+		}()
+	})
+	if s.syncFACEIT == nil {
+		syncBtn.Disable()
+	}
+
+	return container.NewVBox(
+		widget.NewLabel("Integrations"),
+		enabledCheck,
+		widget.NewForm(
+			widget.NewFormItem("Nickname", nicknameEntry),
+			widget.NewFormItem("API key", withHint(apiKeyEntry,
+				"A server-side API key, created for an app at developers.faceit.com.")),
+			widget.NewFormItem("Check every", container.NewHBox(intervalEntry, widget.NewLabel("minutes"))),
+		),
+		withHint(container.NewHBox(syncBtn),
+			"Matches are recorded against the active account. FACEIT only reports final scores, "+
+				"so each map's rounds are spread evenly through it."),
+	)
+}
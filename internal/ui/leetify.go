@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/leetify"
+)
+
+// importLeetify asks for a Leetify match history export and, once the user
+// confirms what's in it, adds its matches' rounds to History.
+func (h *HistoryTab) importLeetify() {
+	open := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, h.window)
+			return
+		}
+		if r == nil {
+			return // cancelled
+		}
+		matches, err := leetify.Parse(r)
+		_ = r.Close()
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("failed to read Leetify export: %w", err), h.window)
+			return
+		}
+		if len(matches) == 0 {
+			dialog.ShowInformation("Nothing to Import", "The export has no matches.", h.window)
+			return
+		}
+		h.confirmLeetifyImport(matches)
+	}, h.window)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".json"}))
+	open.Show()
+}
+
+// confirmLeetifyImport says what importing matches would add and adds it if
+// the user agrees.
+func (h *HistoryTab) confirmLeetifyImport(matches []leetify.Match) {
+	var rounds []database.Round
+	first, last := matches[0].FinishedAt, matches[0].FinishedAt
+	for _, m := range matches {
+		rounds = append(rounds, leetify.Rounds(m, h.cfg.ActiveAccount)...)
+		if m.FinishedAt.Before(first) {
+			first = m.FinishedAt
+		}
+		if m.FinishedAt.After(last) {
+			last = m.FinishedAt
+		}
+	}
+	account := ""
+	if h.cfg.ActiveAccount != "" {
+		account = fmt.Sprintf(" on %s", h.cfg.ActiveAccount)
+	}
+	msg := widget.NewLabel(fmt.Sprintf(
+		"%d matches played from %s to %s make %d rounds to add%s.\n\n"+
+			"Leetify only exports final scores, so each match's rounds are spread evenly through it. "+
+			"Matches already imported are skipped.",
+		len(matches), first.Local().Format("2 Jan 2006"), last.Local().Format("2 Jan 2006"), len(rounds), account))
+	msg.Wrapping = fyne.TextWrapWord
+	confirm := dialog.NewCustomConfirm("Import from Leetify", "Import", "Cancel", msg, func(ok bool) {
+		if !ok {
+			return
+		}
+		ctx, cancel := database.WithTimeout(h.ctx)
+		defer cancel()
+		added, err := database.MergeRounds(ctx, h.db, rounds)
+		if err != nil {
+			dialog.ShowError(err, h.window)
+			return
+		}
+		h.refresh()
+		if h.onUpdate != nil {
+			h.onUpdate()
+		}
+		dialog.ShowInformation("Imported", fmt.Sprintf("Added %d of %d rounds.", added, len(rounds)), h.window)
+	}, h.window)
+	confirm.Resize(fyne.NewSize(420, 0))
+	confirm.Show()
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/tracker"
+)
+
+// MatchOverPrompt asks what to do with a round recorded after the match's
+// score was already decided, which can't be a round of that match. Only one
+// prompt shows at a time.
+type MatchOverPrompt struct {
+	window fyne.Window
+	open   dialog.Dialog
+}
+
+// NewMatchOverPrompt creates a MatchOverPrompt over window.
+func NewMatchOverPrompt(window fyne.Window) *MatchOverPrompt {
+	return &MatchOverPrompt{window: window}
+}
+
+// Show asks whether the round won by side starts a new match in target, is
+// recorded in the finished match anyway, or was a mistake. ctName and tName
+// are the match's side names.
+func (p *MatchOverPrompt) Show(target *tracker.Tracker, side database.Team, ctName, tName string) {
+	if p.open != nil {
+		return
+	}
+	state := target.Match().State()
+	rules := target.Match().Rules()
+	next := state
+	if side == database.TeamCT {
+		next.CTWins++
+	} else {
+		next.TWins++
+	}
+	label := widget.NewLabel(fmt.Sprintf(
+		"%s %d – %d %s is already a finished match (MR%d), so %d–%d can't happen.\n"+
+			"Start a new match with this round, or record it anyway?",
+		ctName, state.CTWins, state.TWins, tName, rules.RegulationRounds/2, next.CTWins, next.TWins))
+	label.Wrapping = fyne.TextWrapWord
+
+	var d *dialog.CustomDialog
+	choose := func(action func()) func() {
+		return func() {
+			d.Hide()
+			if action != nil {
+				action()
+			}
+		}
+	}
+	newMatch := widget.NewButton("New Match", choose(func() { target.NewMatch(side) }))
+	newMatch.Importance = widget.HighImportance
+	buttons := container.NewHBox(
+		widget.NewButton("Cancel", choose(nil)),
+		widget.NewButton("Record Anyway", choose(func() { target.Record(side) })),
+		newMatch,
+	)
+	d = dialog.NewCustomWithoutButtons("Match Already Over", container.NewVBox(label, container.NewCenter(buttons)), p.window)
+	d.SetOnClosed(func() { p.open = nil })
+	p.open = d
+	d.Resize(fyne.NewSize(420, 0))
+	d.Show()
+}
@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// MiniMode shows content in a condensed layout while it's given less room
+// than its full layout needs, instead of clipping it, and switches back once
+// there's room again. Its minimum size is the condensed layout's, so the
+// window can be made smaller than the full one.
+type MiniMode struct {
+	widget.BaseWidget
+	content fyne.CanvasObject
+	setMini func(mini bool)
+	mini    bool
+	fullMin fyne.Size // content's minimum size in the full layout
+	miniMin fyne.Size // and in the condensed one
+}
+
+// NewMiniMode wraps content, whose layout setMini switches between full
+// (false) and condensed (true).
+func NewMiniMode(content fyne.CanvasObject, setMini func(mini bool)) *MiniMode {
+	m := &MiniMode{content: content, setMini: setMini}
+	m.ExtendBaseWidget(m)
+	m.fullMin = content.MinSize()
+	setMini(true)
+	m.miniMin = content.MinSize()
+	setMini(false)
+	return m
+}
+
+// Mini reports whether the condensed layout is showing.
+func (m *MiniMode) Mini() bool { return m.mini }
+
+func (m *MiniMode) CreateRenderer() fyne.WidgetRenderer {
+	return &miniModeRenderer{m: m}
+}
+
+type miniModeRenderer struct {
+	m *MiniMode
+}
+
+func (r *miniModeRenderer) Layout(size fyne.Size) {
+	m := r.m
+	if !m.mini {
+		m.fullMin = m.content.MinSize()
+	}
+	mini := size.Width < m.fullMin.Width || size.Height < m.fullMin.Height
+	m.content.Resize(size)
+	if mini != m.mini {
+		m.mini = mini
+		m.setMini(mini)
+		// Lay out again with the other set of widgets showing.
+		m.content.Refresh()
+	}
+}
+
+func (r *miniModeRenderer) MinSize() fyne.Size {
+	m := r.m
+	if m.mini {
+		// Whatever is open in the condensed layout, e.g. a drawer.
+		m.miniMin = m.content.MinSize()
+	}
+	return m.miniMin
+}
+
+func (r *miniModeRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.m.content}
+}
+
+func (r *miniModeRenderer) Refresh() {
+	r.m.content.Refresh()
+}
+
+func (r *miniModeRenderer) Destroy() {}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/match"
+)
+
+// osdSize is the size of the on-screen display window.
+var osdSize = fyne.NewSize(180, 64)
+
+// OSD briefly flashes the score in a small borderless window, confirming a
+// hotkey action while the main window is hidden.
+type OSD struct {
+	cfg *config.Config
+
+	mu     sync.Mutex
+	window fyne.Window
+	text   *canvas.Text
+	timer  *time.Timer
+}
+
+// NewOSD creates an OSD. Its window is only created on the first Flash.
+func NewOSD(cfg *config.Config) *OSD {
+	return &OSD{cfg: cfg}
+}
+
+// Flash shows text for the configured duration, replacing anything already
+// showing. It can be called from any goroutine.
+func (o *OSD) Flash(text string) {
+	d, ok := fyne.CurrentApp().Driver().(desktop.Driver)
+	if !ok {
+		return
+	}
+	duration := time.Duration(o.cfg.OSD.DurationMs) * time.Millisecond
+	position := o.cfg.OSD.Position
+
+	fyne.Do(func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+
+		if o.window == nil {
+			o.window = d.CreateSplashWindow()
+			o.text = canvas.NewText("", theme.Color(theme.ColorNameForeground))
+			o.text.TextSize = theme.TextSize() * 2
+			o.text.TextStyle = fyne.TextStyle{Bold: true}
+			bg := canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+			bg.CornerRadius = theme.InputRadiusSize()
+			o.window.SetContent(container.NewStack(bg, container.NewCenter(o.text)))
+			o.window.Resize(osdSize)
+		}
+		o.text.Text = text
+		o.text.Refresh()
+		showOSD(o.window)
+		placeOSD(o.window, position)
+
+		if o.timer != nil {
+			o.timer.Stop()
+		}
+		o.timer = time.AfterFunc(duration, func() {
+			fyne.Do(func() {
+				o.mu.Lock()
+				defer o.mu.Unlock()
+				o.window.Hide()
+			})
+		})
+	})
+}
+
+// OSDText formats a match for the OSD: the player's side and its score
+// first, e.g. "T 8 – 7", or both sides if none is selected.
+func OSDText(s match.State, ctName, tName string) string {
+	switch s.Team {
+	case database.TeamCT:
+		return fmt.Sprintf("%s %d – %d", ctName, s.CTWins, s.TWins)
+	case database.TeamT:
+		return fmt.Sprintf("%s %d – %d", tName, s.TWins, s.CTWins)
+	}
+	return fmt.Sprintf("%s %d – %d %s", ctName, s.CTWins, s.TWins, tName)
+}
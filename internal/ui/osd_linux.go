@@ -0,0 +1,25 @@
+//go:build linux
+
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// showOSD shows the OSD without asking for the focus, so a flash doesn't
+// take it from the game. GLFW asks the window manager to focus the windows
+// it shows unless FOCUS_ON_SHOW was cleared when they were created, which
+// Fyne never does, so it's cleared around the Show that creates the OSD's
+// window and set back for the app's other windows. The X11 window manager
+// or Wayland compositor can still focus it by its own policy, and Fyne
+// can't place it above a fullscreen game there.
+func showOSD(w fyne.Window) {
+	glfw.WindowHint(glfw.FocusOnShow, glfw.False)
+	w.Show()
+	glfw.WindowHint(glfw.FocusOnShow, glfw.True)
+}
+
+// placeOSD leaves the OSD where it is: Fyne can't move windows, and X11 has
+// no pure-Go way to, so on Linux the OSD is always centred.
+func placeOSD(w fyne.Window, position string) {}
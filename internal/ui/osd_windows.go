@@ -0,0 +1,98 @@
+//go:build windows
+
+package ui
+
+import (
+	"syscall"
+	"unsafe"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver"
+	"github.com/go-gl/glfw/v3.3/glfw"
+
+	"csstatstracker/internal/config"
+)
+
+// osdMargin is the gap in pixels between the OSD and the screen edge.
+const osdMargin = 24
+
+// showOSD shows the OSD without activating it, so a flash doesn't take the
+// focus from the game, or minimise it in exclusive fullscreen. GLFW focuses
+// the windows it shows unless FOCUS_ON_SHOW was cleared when they were
+// created, which Fyne never does, so it's cleared around the Show that
+// creates the OSD's window and set back for the app's other windows. Once
+// shown, WS_EX_NOACTIVATE keeps clicks from activating it, and it's put
+// above other topmost windows, such as a borderless game.
+func showOSD(w fyne.Window) {
+	glfw.WindowHint(glfw.FocusOnShow, glfw.False)
+	w.Show()
+	glfw.WindowHint(glfw.FocusOnShow, glfw.True)
+
+	nw, ok := w.(driver.NativeWindow)
+	if !ok {
+		return
+	}
+	nw.RunNative(func(ctx any) {
+		wc, ok := ctx.(driver.WindowsWindowContext)
+		if !ok {
+			return
+		}
+		user32 := syscall.NewLazyDLL("user32.dll")
+
+		const (
+			gwlExStyle     = ^uintptr(19) // GWL_EXSTYLE, -20
+			wsExNoActivate = 0x08000000
+			hwndTopmost    = ^uintptr(0) // HWND_TOPMOST, -1
+		)
+		style, _, _ := user32.NewProc("GetWindowLongPtrW").Call(wc.HWND, gwlExStyle)
+		_, _, _ = user32.NewProc("SetWindowLongPtrW").Call(wc.HWND, gwlExStyle, style|wsExNoActivate)
+
+		// SWP_NOSIZE | SWP_NOMOVE | SWP_NOACTIVATE
+		const flags = 0x0001 | 0x0002 | 0x0010
+		_, _, _ = user32.NewProc("SetWindowPos").Call(wc.HWND, hwndTopmost, 0, 0, 0, 0, flags)
+	})
+}
+
+// placeOSD moves the OSD to a corner of the primary monitor's work area.
+// Fyne can't position windows itself, so this goes through the native handle.
+func placeOSD(w fyne.Window, position string) {
+	if position == config.OSDCenter {
+		return // splash windows start centred
+	}
+	nw, ok := w.(driver.NativeWindow)
+	if !ok {
+		return
+	}
+	nw.RunNative(func(ctx any) {
+		wc, ok := ctx.(driver.WindowsWindowContext)
+		if !ok {
+			return
+		}
+		user32 := syscall.NewLazyDLL("user32.dll")
+
+		// SPI_GETWORKAREA: the primary monitor minus the taskbar
+		var area struct{ left, top, right, bottom int32 }
+		const spiGetWorkArea = 0x0030
+		ok1, _, _ := user32.NewProc("SystemParametersInfoW").Call(spiGetWorkArea, 0, uintptr(unsafe.Pointer(&area)), 0)
+		var rect struct{ left, top, right, bottom int32 }
+		ok2, _, _ := user32.NewProc("GetWindowRect").Call(wc.HWND, uintptr(unsafe.Pointer(&rect)))
+		if ok1 == 0 || ok2 == 0 {
+			return
+		}
+		width, height := rect.right-rect.left, rect.bottom-rect.top
+
+		x, y := area.right-width-osdMargin, area.top+osdMargin
+		switch position {
+		case config.OSDTopLeft:
+			x = area.left + osdMargin
+		case config.OSDBottomLeft:
+			x, y = area.left+osdMargin, area.bottom-height-osdMargin
+		case config.OSDBottomRight:
+			y = area.bottom - height - osdMargin
+		}
+
+		// SWP_NOSIZE | SWP_NOZORDER | SWP_NOACTIVATE
+		const flags = 0x0001 | 0x0004 | 0x0010
+		_, _, _ = user32.NewProc("SetWindowPos").Call(wc.HWND, 0, uintptr(x), uintptr(y), 0, 0, flags)
+	})
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/games"
+	"csstatstracker/internal/match"
+)
+
+// maxSlowGames caps the slow games listed on the Play Time sub-tab.
+const maxSlowGames = 3
+
+// refreshPace rebuilds games from rounds and updates the pace line, the slow
+// games and the pace chart on the Play Time sub-tab. Games are filtered to
+// the selected period by when they started.
+func (s *StatsTab) refreshPace(rounds []database.Round) {
+	if s.paceLabel == nil {
+		return
+	}
+	all := games.Rebuild(rounds, match.DefaultRules)
+	if start := database.GetWindowStart(s.currentWindow); !start.IsZero() {
+		kept := all[:0]
+		for _, g := range all {
+			if !g.Start.Before(start) {
+				kept = append(kept, g)
+			}
+		}
+		all = kept
+	}
+
+	median := games.MedianPace(all)
+	if median == 0 {
+		s.paceLabel.SetText("Pace: -- (no finished games in this period)")
+	} else {
+		var overtime int
+		for _, g := range all {
+			if g.Finished && g.Overtime(match.DefaultRules) {
+				overtime++
+			}
+		}
+		s.paceLabel.SetText(fmt.Sprintf("Pace: %s per round (median), %d overtime games", formatPace(median), overtime))
+	}
+
+	slow := games.Slow(all)
+	rows := make([]fyne.CanvasObject, 0, maxSlowGames+1)
+	if len(slow) > 0 {
+		rows = append(rows, widget.NewLabel(fmt.Sprintf("Slow games (%d, over 1.5× the median pace):", len(slow))))
+	}
+	for i, g := range slow {
+		if i == maxSlowGames {
+			break
+		}
+		note := ""
+		if g.Overtime(match.DefaultRules) {
+			note = ", overtime"
+		}
+		label := widget.NewLabel(fmt.Sprintf("  %s: %d–%d in %s, %s per round%s",
+			g.Start.Local().Format("Mon 02 Jan 15:04"), g.Wins, g.Losses,
+			formatPlayTime(int(g.End.Sub(g.Start).Minutes())), formatPace(g.Pace()), note))
+		label.Importance = widget.WarningImportance
+		rows = append(rows, label)
+	}
+	s.slowGamesContainer.Objects = rows
+	s.slowGamesContainer.Refresh()
+
+	s.paceChartContainer.Objects = []fyne.CanvasObject{s.buildPaceChart(all)}
+	s.paceChartContainer.Refresh()
+}
+
+// buildPaceChart charts the average pace of the finished games started in
+// each aggregation bucket, in seconds per round.
+func (s *StatsTab) buildPaceChart(all []games.Game) fyne.CanvasObject {
+	type bucket struct {
+		label string
+		total time.Duration
+		games int
+	}
+	var order []string
+	buckets := make(map[string]*bucket)
+	for _, g := range all {
+		if !g.Finished || g.Pace() == 0 {
+			continue
+		}
+		key := s.bucketKey(g.Start)
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{label: s.bucketLabel(g.Start)}
+			buckets[key] = b
+			order = append(order, key)
+		}
+		b.total += g.Pace()
+		b.games++
+	}
+	if len(order) == 0 {
+		noDataLabel := widget.NewLabel("No finished games for selected period")
+		noDataLabel.Alignment = fyne.TextAlignCenter
+		return container.NewCenter(noDataLabel)
+	}
+
+	labels := make([]string, len(order))
+	values := make([]int, len(order))
+	for i, key := range order {
+		b := buckets[key]
+		labels[i] = b.label
+		values[i] = int((b.total / time.Duration(b.games)).Seconds())
+	}
+
+	legendBox := canvas.NewRectangle(timeColor())
+	legendBox.SetMinSize(fyne.NewSize(12, 12))
+	legend := container.NewHBox(
+		container.NewPadded(legendBox),
+		widget.NewLabel("Average Time per Round"),
+	)
+
+	chart := newBarChart(labels, values, func(v int) string { return formatPace(time.Duration(v) * time.Second) })
+	chart.posColor = timeColor
+	return chart.withControls(legend)
+}
+
+// formatPace renders a per-round time as e.g. "1:52".
+func formatPace(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/config"
+)
+
+// unlockFor is how long entering the PIN allows guarded actions without
+// asking again.
+const unlockFor = 5 * time.Minute
+
+// unlockedUntil is when the PIN last entered stops allowing guarded actions.
+var unlockedUntil time.Time
+
+// unlock runs action, a destructive one such as deleting rounds, once the
+// PIN set in Settings has been entered. Without a PIN, or within a few
+// minutes of it being entered, action runs straight away.
+func unlock(cfg *config.Config, w fyne.Window, action func()) {
+	unlockOr(cfg, w, action, nil)
+}
+
+// unlockOr is unlock, but runs cancelled, if not nil, when the PIN dialog is
+// closed without unlocking, e.g. to put back a widget the user changed.
+func unlockOr(cfg *config.Config, w fyne.Window, action, cancelled func()) {
+	if !cfg.HasPIN() || time.Now().Before(unlockedUntil) {
+		action()
+		return
+	}
+	entry := widget.NewPasswordEntry()
+	entry.SetPlaceHolder("PIN")
+	wrong := widget.NewLabel("")
+	wrong.Hide()
+	var d dialog.Dialog
+	unlocked := false
+	submit := func() {
+		if !cfg.CheckPIN(entry.Text) {
+			wrong.SetText("Wrong PIN.")
+			wrong.Show()
+			entry.SetText("")
+			return
+		}
+		unlockedUntil = time.Now().Add(unlockFor)
+		unlocked = true
+		d.Hide()
+		action()
+	}
+	entry.OnSubmitted = func(string) { submit() }
+	d = dialog.NewCustomWithoutButtons("Enter PIN", container.NewVBox(
+		widget.NewLabel("Editing History is locked."),
+		entry,
+		wrong,
+		container.NewHBox(
+			widget.NewButton("Cancel", func() { d.Hide() }),
+			widget.NewButton("Unlock", submit),
+		),
+	), w)
+	if cancelled != nil {
+		d.SetOnClosed(func() {
+			if !unlocked {
+				cancelled()
+			}
+		})
+	}
+	d.Show()
+	w.Canvas().Focus(entry)
+}
+
+// lock makes the next guarded action ask for the PIN again.
+func lock() { unlockedUntil = time.Time{} }
+
+// buildPINSection creates the Settings editor for the PIN that guards
+// deleting and editing rounds. Changing or removing it needs the current
+// one.
+func (s *SettingsTab) buildPINSection() fyne.CanvasObject {
+	status := "No PIN is set: anyone can edit and delete rounds."
+	if s.cfg.HasPIN() {
+		status = "A PIN is needed to edit or delete rounds, and to prune old ones."
+	}
+
+	setBtn := widget.NewButton("Set PIN...", func() {
+		unlock(s.cfg, s.window, s.showSetPINDialog)
+	})
+	removeBtn := widget.NewButton("Remove PIN", func() {
+		unlock(s.cfg, s.window, func() {
+			_ = s.cfg.SetPIN("")
+			s.save()
+			s.Reload()
+		})
+	})
+	lockBtn := widget.NewButton("Lock Now", lock)
+	if !s.cfg.HasPIN() {
+		removeBtn.Disable()
+		lockBtn.Disable()
+	}
+
+	return container.NewVBox(
+		widget.NewLabel("PIN Lock"),
+		withHint(widget.NewLabel(status), "Keeps History safe on a shared PC: editing, deleting or pruning "+
+			"rounds, or renaming accounts, asks for the PIN, which then stays entered for a few minutes. "+
+			"Viewing stats stays open to everyone."),
+		container.NewHBox(setBtn, removeBtn, lockBtn),
+	)
+}
+
+// showSetPINDialog asks for a new PIN twice and saves it.
+func (s *SettingsTab) showSetPINDialog() {
+	pin := widget.NewPasswordEntry()
+	again := widget.NewPasswordEntry()
+	form := widget.NewForm(
+		widget.NewFormItem("New PIN", pin),
+		widget.NewFormItem("Repeat", again),
+	)
+	dialog.ShowCustomConfirm("Set PIN", "Save", "Cancel", form, func(ok bool) {
+		if !ok {
+			return
+		}
+		if pin.Text != again.Text {
+			dialog.ShowInformation("PIN Not Set", "The PINs don't match.", s.window)
+			return
+		}
+		if pin.Text == "" {
+			return
+		}
+		if err := s.cfg.SetPIN(pin.Text); err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		lock()
+		s.save()
+		s.Reload()
+	}, s.window)
+}
@@ -0,0 +1,102 @@
+package ui_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/ui"
+)
+
+// cancelPIN checks that the PIN is being asked for over w, and cancels.
+func cancelPIN(t *testing.T, w fyne.Window) {
+	t.Helper()
+	d := topDialog(t, w)
+	if !hasLabel(d, "Editing History is locked.") {
+		t.Fatal("the dialog showing doesn't ask for the PIN")
+	}
+	test.Tap(button(t, d, "Cancel"))
+}
+
+func TestPINGuardsAssigningDraws(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "DDD")...)
+	cfg := config.Default()
+	if err := cfg.SetPIN("1234"); err != nil {
+		t.Fatal(err)
+	}
+	w := test.NewTempWindow(t, nil)
+	s := ui.NewStatsTab(ctx, db, w, cfg, "", func() {})
+	w.SetContent(s.Container())
+	w.Resize(fyne.NewSize(1000, 700))
+
+	for _, o := range objects(w.Content()) {
+		if tabs, ok := o.(*container.AppTabs); ok {
+			for _, tab := range tabs.Items {
+				if strings.HasPrefix(tab.Text, "Draws") {
+					tabs.Select(tab)
+				}
+			}
+		}
+	}
+	test.Tap(button(t, w.Content(), "I was CT"))
+	cancelPIN(t, w)
+	rounds, err := database.GetUnassignedRounds(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != 3 {
+		t.Errorf("%d unassigned rounds left after cancelling the PIN; want all 3", len(rounds))
+	}
+}
+
+func TestPINGuardsRenamingAccount(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WLW"),
+		func(r *database.Round) { r.Account = "main" })...)
+	cfg := config.Default()
+	cfg.Accounts = []config.Account{{Name: "main"}}
+	if err := cfg.SetPIN("1234"); err != nil {
+		t.Fatal(err)
+	}
+	w := test.NewTempWindow(t, nil)
+	s := ui.NewSettingsTab(cfg, w, func(*config.Config) {})
+	s.SetDatabase(ctx, db, nil)
+	w.SetContent(s.Container())
+
+	entry := func() *ui.CommitEntry {
+		for _, o := range objects(s.Container()) {
+			if e, ok := o.(*ui.CommitEntry); ok && e.PlaceHolder == "Name" {
+				return e
+			}
+		}
+		t.Fatal("no name entry for the account")
+		return nil
+	}
+	e := entry()
+	e.SetText("smurf")
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	cancelPIN(t, w)
+
+	if cfg.Accounts[0].Name != "main" {
+		t.Errorf("account renamed to %q after cancelling the PIN; want main", cfg.Accounts[0].Name)
+	}
+	if got := entry().Text; got != "main" {
+		t.Errorf("name entry shows %q after cancelling the PIN; want main back", got)
+	}
+	if names, err := database.GetAccountNames(ctx, db); err != nil || len(names) != 1 || names[0] != "main" {
+		t.Errorf("GetAccountNames = %v, %v; want the rounds still on main", names, err)
+	}
+}
@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/plugins"
+)
+
+// SetPlugins shows m's plugins in Settings so they can be switched on and
+// off.
+func (s *SettingsTab) SetPlugins(m *plugins.Manager) {
+	s.plugins = m
+	s.Reload()
+}
+
+// buildPluginsSection creates the Settings list of plugins found in the
+// plugins directory, each with a check to enable it and its status.
+func (s *SettingsTab) buildPluginsSection() fyne.CanvasObject {
+	if s.plugins == nil {
+		return container.NewVBox()
+	}
+	title := widget.NewLabel("Plugins")
+	dir := widget.NewLabel(fmt.Sprintf("Executables in %s", s.plugins.Dir()))
+	dir.Wrapping = fyne.TextWrapBreak
+
+	infos, err := s.plugins.List()
+	if err != nil {
+		return container.NewVBox(title, dir, widget.NewLabel(err.Error()))
+	}
+	rows := container.NewVBox()
+	if len(infos) == 0 {
+		rows.Add(widget.NewLabel("No plugins installed"))
+	}
+	for _, info := range infos {
+		status := widget.NewLabel(pluginStatus(info))
+		check := widget.NewCheck(info.Name, func(enabled bool) {
+			i := slices.Index(s.cfg.Plugins, info.Name)
+			switch {
+			case enabled && i < 0:
+				s.cfg.Plugins = append(s.cfg.Plugins, info.Name)
+			case !enabled && i >= 0:
+				s.cfg.Plugins = slices.Delete(s.cfg.Plugins, i, i+1)
+			default:
+				return
+			}
+			s.save()
+			s.Reload()
+		})
+		check.Checked = slices.Contains(s.cfg.Plugins, info.Name)
+		rows.Add(container.NewBorder(nil, nil, nil, status, check))
+	}
+
+	refreshBtn := widget.NewButton("Rescan", s.Reload)
+	return container.NewVBox(title, dir, rows, container.NewHBox(refreshBtn))
+}
+
+// pluginStatus describes whether a plugin is running.
+func pluginStatus(info plugins.Info) string {
+	switch {
+	case info.Running:
+		return "Running"
+	case info.Err != nil:
+		return fmt.Sprintf("Stopped: %v", info.Err)
+	}
+	return "Stopped"
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/match"
+)
+
+// Presentation is a scoreboard for a TV or second screen: the side logos,
+// names and giant counters on black, with nothing else around them. The
+// counters are sized to fill whatever space it's given.
+type Presentation struct {
+	widget.BaseWidget
+	background *canvas.Rectangle
+	ctName     *canvas.Text
+	tName      *canvas.Text
+	ctScore    *canvas.Text
+	tScore     *canvas.Text
+	ctLogo     *canvas.Image
+	tLogo      *canvas.Image
+}
+
+// NewPresentation creates an empty presentation; Present fills it in.
+func NewPresentation() *Presentation {
+	p := &Presentation{background: canvas.NewRectangle(color.Black), ctLogo: newLogo(), tLogo: newLogo()}
+	for _, text := range []**canvas.Text{&p.ctName, &p.tName, &p.ctScore, &p.tScore} {
+		*text = canvas.NewText("", color.White)
+		(*text).Alignment = fyne.TextAlignCenter
+	}
+	p.ctScore.TextStyle.Bold = true
+	p.tScore.TextStyle.Bold = true
+	p.ExtendBaseWidget(p)
+	return p
+}
+
+// Present shows v's side names, colours and logos and its match's score.
+// Later changes to the score come in through SetScore.
+func (p *Presentation) Present(v *TrackerView) {
+	p.ctName.Text, p.tName.Text = v.SideNames()
+	setLogo(p.ctLogo, v.tracker.Config.CTLogo)
+	setLogo(p.tLogo, v.tracker.Config.TLogo)
+	ct, t := SideColors(v.tracker.Config)
+	p.ctName.Color, p.ctScore.Color = ct, ct
+	p.tName.Color, p.tScore.Color = t, t
+	p.SetScore(v.tracker.Match().State())
+}
+
+// SetScore shows the score of state.
+func (p *Presentation) SetScore(state match.State) {
+	p.ctScore.Text = fmt.Sprintf("%d", state.CTWins)
+	p.tScore.Text = fmt.Sprintf("%d", state.TWins)
+	p.Refresh()
+}
+
+func (p *Presentation) CreateRenderer() fyne.WidgetRenderer {
+	return &presentationRenderer{p: p}
+}
+
+// Shares of the height taken by the side logos, if there are any, and the
+// side names, with a margin below each; the counters get the rest.
+const (
+	presentationMargin     = 0.05
+	presentationLogoHeight = 0.2
+	presentationNameHeight = 0.15
+)
+
+type presentationRenderer struct {
+	p *Presentation
+}
+
+func (r *presentationRenderer) Layout(size fyne.Size) {
+	p := r.p
+	p.background.Resize(size)
+
+	half := size.Width / 2
+	width := half * (1 - 2*presentationMargin)
+	margin := size.Height * presentationMargin
+	logoY := margin
+	nameY := logoY
+	if p.ctLogo.Visible() || p.tLogo.Visible() {
+		nameY += size.Height*presentationLogoHeight + margin
+	}
+	nameSize := fyne.NewSize(width, size.Height*presentationNameHeight)
+	scoreY := nameY + nameSize.Height + margin
+	scoreSize := fyne.NewSize(width, size.Height-scoreY-margin)
+
+	logos := []*canvas.Image{p.ctLogo, p.tLogo}
+	for i, side := range [][2]*canvas.Text{{p.ctName, p.ctScore}, {p.tName, p.tScore}} {
+		x := float32(i)*half + half*presentationMargin
+		logos[i].Resize(fyne.NewSize(width, size.Height*presentationLogoHeight))
+		logos[i].Move(fyne.NewPos(x, logoY))
+		fitText(side[0], nameSize)
+		side[0].Move(fyne.NewPos(x, nameY))
+		fitText(side[1], scoreSize)
+		side[1].Move(fyne.NewPos(x, scoreY))
+	}
+}
+
+// fitText sizes text to the largest font that fits in size.
+func fitText(text *canvas.Text, size fyne.Size) {
+	const base = 100
+	m := fyne.MeasureText(text.Text, base, text.TextStyle)
+	scale := size.Height / m.Height
+	if m.Width > 0 {
+		scale = min(scale, size.Width/m.Width)
+	}
+	text.TextSize = base * scale
+	text.Resize(size)
+}
+
+func (r *presentationRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(0, 0)
+}
+
+func (r *presentationRenderer) Objects() []fyne.CanvasObject {
+	p := r.p
+	return []fyne.CanvasObject{p.background, p.ctLogo, p.tLogo, p.ctName, p.tName, p.ctScore, p.tScore}
+}
+
+func (r *presentationRenderer) Refresh() {
+	r.Layout(r.p.Size())
+	for _, o := range r.Objects() {
+		o.Refresh()
+	}
+}
+
+func (r *presentationRenderer) Destroy() {}
@@ -0,0 +1,58 @@
+package ui_test
+
+import (
+	"context"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+
+	"csstatstracker"
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/tracker"
+	"csstatstracker/internal/ui"
+)
+
+func TestPresentation(t *testing.T) {
+	test.NewTempApp(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+
+	w := test.NewTempWindow(t, nil)
+	ctLabel, tLabel := ui.NewCounterLabels()
+	tr := tracker.New(context.Background(), dbtest.New(t), w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	v := ui.NewTrackerView(tr, w, ctLabel, tLabel)
+	v.SetSideNames("Navi", "Vitality")
+	tr.IncrementCT()
+
+	p := ui.NewPresentation()
+	w.SetContent(p)
+	w.Resize(fyne.NewSize(800, 450))
+	p.Present(v)
+	tr.SetOnScoreChange(p.SetScore)
+	tr.IncrementT()
+	tr.IncrementT()
+
+	texts := map[string]*canvas.Text{}
+	for _, o := range objects(p) {
+		if text, ok := o.(*canvas.Text); ok {
+			texts[text.Text] = text
+		}
+	}
+	for _, want := range []string{"Navi", "Vitality", "1", "2"} {
+		if texts[want] == nil {
+			t.Fatalf("presentation shows %v; want %s among them", texts, want)
+		}
+	}
+	score := texts["1"].TextSize
+	if score < 200 || score < 2*texts["Navi"].TextSize {
+		t.Errorf("score text is %v, names %v; want giant counters", score, texts["Navi"].TextSize)
+	}
+
+	w.Resize(fyne.NewSize(1600, 900))
+	if texts["1"].TextSize < 1.5*score {
+		t.Errorf("score text is %v on a screen twice the size, %v before; want it to grow", texts["1"].TextSize, score)
+	}
+}
@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+)
+
+// premierTierColors are the in-game colours of the Premier rating tiers, see
+// database.PremierTier.
+var premierTierColors = []color.Color{
+	color.RGBA{R: 176, G: 195, B: 217, A: 255}, // grey
+	color.RGBA{R: 94, G: 152, B: 217, A: 255},  // light blue
+	color.RGBA{R: 75, G: 105, B: 255, A: 255},  // blue
+	color.RGBA{R: 136, G: 71, B: 255, A: 255},  // purple
+	color.RGBA{R: 211, G: 44, B: 230, A: 255},  // pink
+	color.RGBA{R: 235, G: 75, B: 75, A: 255},   // red
+	color.RGBA{R: 255, G: 215, B: 0, A: 255},   // gold
+}
+
+// rankColor returns the badge colour for a rating.
+func rankColor(r database.Rating) color.Color {
+	if r.Kind == database.RatingPremier {
+		return premierTierColors[database.PremierTier(r.Value)]
+	}
+	// Competitive skill groups share a colour per family.
+	switch {
+	case r.Value <= 6: // Silver
+		return premierTierColors[0]
+	case r.Value <= 10: // Gold Nova
+		return color.RGBA{R: 218, G: 165, B: 32, A: 255}
+	case r.Value <= 14: // Master Guardian
+		return premierTierColors[2]
+	case r.Value <= 16: // Legendary Eagle
+		return premierTierColors[3]
+	case r.Value == 17: // Supreme
+		return premierTierColors[4]
+	default: // Global Elite
+		return premierTierColors[5]
+	}
+}
+
+// newRankBadge draws a rating as a coloured chip.
+func newRankBadge(r database.Rating) fyne.CanvasObject {
+	bg := canvas.NewRectangle(rankColor(r))
+	bg.CornerRadius = 6
+	text := canvas.NewText(r.Label(), contrastText(rankColor(r)))
+	text.TextStyle = fyne.TextStyle{Bold: true}
+	text.TextSize = 12
+	text.Alignment = fyne.TextAlignCenter
+	return container.NewStack(bg, container.NewPadded(text))
+}
+
+// ShowRecordRankDialog asks for the player's current Premier rating or
+// competitive skill group and records it against the active account.
+func ShowRecordRankDialog(ctx context.Context, db *sql.DB, window fyne.Window, cfg *config.Config, onSaved func()) {
+	ratingEntry := widget.NewEntry()
+	ratingEntry.SetPlaceHolder("e.g. 14250")
+	rankSelect := widget.NewSelect(database.CompetitiveRanks, nil)
+	rankSelect.Hide()
+
+	kindSelect := widget.NewRadioGroup([]string{"Premier", "Competitive"}, func(selected string) {
+		if selected == "Competitive" {
+			ratingEntry.Hide()
+			rankSelect.Show()
+		} else {
+			rankSelect.Hide()
+			ratingEntry.Show()
+		}
+	})
+	kindSelect.Horizontal = true
+	kindSelect.SetSelected("Premier")
+
+	form := widget.NewForm(
+		widget.NewFormItem("Mode", kindSelect),
+		widget.NewFormItem("Rank", container.NewStack(ratingEntry, rankSelect)),
+	)
+
+	dialog.ShowCustomConfirm("Record Rank", "Save", "Cancel", form, func(save bool) {
+		if !save {
+			return
+		}
+		kind, value := database.RatingPremier, 0
+		if kindSelect.Selected == "Competitive" {
+			kind = database.RatingCompetitive
+			value = rankSelect.SelectedIndex() + 1
+			if value == 0 {
+				dialog.ShowError(fmt.Errorf("no skill group selected"), window)
+				return
+			}
+		} else {
+			n, err := strconv.Atoi(strings.ReplaceAll(strings.TrimSpace(ratingEntry.Text), ",", ""))
+			if err != nil || n < 0 {
+				dialog.ShowError(fmt.Errorf("%q is not a valid rating", ratingEntry.Text), window)
+				return
+			}
+			value = n
+		}
+		ctx, cancel := database.WithTimeout(ctx)
+		defer cancel()
+		if err := database.InsertRating(ctx, db, kind, value, cfg.ActiveAccount); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if onSaved != nil {
+			onSaved()
+		}
+	}, window)
+}
+
+// buildRankContent creates the Rank sub-tab: the rank-over-time chart for the
+// chosen mode and the recorded ranks as badges.
+func (s *StatsTab) buildRankContent() fyne.CanvasObject {
+	s.rankChartContainer = container.NewStack()
+	s.rankListContainer = container.NewVBox()
+
+	s.rankKind = database.RatingPremier
+	kindSelect := widget.NewSelect([]string{"Premier", "Competitive"}, func(selected string) {
+		if selected == "Competitive" {
+			s.rankKind = database.RatingCompetitive
+		} else {
+			s.rankKind = database.RatingPremier
+		}
+		s.refreshRanks()
+	})
+	kindSelect.Selected = "Premier"
+
+	recordBtn := widget.NewButton("Record Rank...", func() {
+		ShowRecordRankDialog(s.ctx, s.db, s.window, s.cfg, s.refreshRanks)
+	})
+	if s.readOnly {
+		recordBtn.Hide()
+	}
+
+	return container.NewBorder(
+		container.NewHBox(widget.NewLabel("Mode:"), kindSelect, recordBtn),
+		nil, nil, nil,
+		container.NewVSplit(s.rankChartContainer, container.NewVScroll(s.rankListContainer)),
+	)
+}
+
+// refreshRanks reloads the recorded ranks for the Rank sub-tab.
+func (s *StatsTab) refreshRanks() {
+	if s.rankChartContainer == nil {
+		return
+	}
+	ctx, cancel := database.WithTimeout(s.ctx)
+	defer cancel()
+	ratings, err := database.GetRatings(ctx, s.db, s.rankKind, s.cfg.StatsAccount)
+	if err != nil {
+		dialog.ShowError(err, s.window)
+		return
+	}
+
+	if len(ratings) == 0 {
+		noData := widget.NewLabel("No ranks recorded yet")
+		s.rankChartContainer.Objects = []fyne.CanvasObject{container.NewCenter(noData)}
+	} else {
+		chart := &stepChart{ratings: ratings}
+		chart.ExtendBaseWidget(chart)
+		s.rankChartContainer.Objects = []fyne.CanvasObject{chart}
+	}
+	s.rankChartContainer.Refresh()
+
+	rows := make([]fyne.CanvasObject, 0, len(ratings))
+	for i := len(ratings) - 1; i >= 0; i-- {
+		r := ratings[i]
+		when := r.CreatedAt.Local().Format("2006-01-02 15:04")
+		if r.Account != "" {
+			when += " — " + r.Account
+		}
+		deleteBtn := widget.NewButton("Delete", func() {
+			ctx, cancel := database.WithTimeout(s.ctx)
+			defer cancel()
+			if err := database.DeleteRating(ctx, s.db, r.ID); err != nil {
+				dialog.ShowError(err, s.window)
+				return
+			}
+			s.refreshRanks()
+		})
+		if s.readOnly {
+			deleteBtn.Hide()
+		}
+		rows = append(rows, container.NewHBox(
+			newRankBadge(r),
+			widget.NewLabel(when),
+			layout.NewSpacer(),
+			deleteBtn,
+		))
+	}
+	s.rankListContainer.Objects = rows
+	s.rankListContainer.Refresh()
+}
+
+// stepChart plots ratings over time as a stepped line: a rank holds until the
+// next one is recorded. Each step is drawn in its rank's colour.
+type stepChart struct {
+	widget.BaseWidget
+	ratings []database.Rating // chronological, at least one
+}
+
+func (c *stepChart) CreateRenderer() fyne.WidgetRenderer {
+	return &stepChartRenderer{chart: c}
+}
+
+func (c *stepChart) MinSize() fyne.Size {
+	return fyne.NewSize(300, 150)
+}
+
+type stepChartRenderer struct {
+	chart   *stepChart
+	objects []fyne.CanvasObject
+}
+
+func (r *stepChartRenderer) Destroy() {}
+
+func (r *stepChartRenderer) Layout(size fyne.Size) {
+	r.Refresh()
+}
+
+func (r *stepChartRenderer) MinSize() fyne.Size {
+	return r.chart.MinSize()
+}
+
+func (r *stepChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *stepChartRenderer) Refresh() {
+	c := r.chart
+	size := c.Size()
+	ratings := c.ratings
+
+	axisWidth := float32(60)
+	labelHeight := float32(15)
+	plotWidth := size.Width - axisWidth
+	plotHeight := size.Height - labelHeight
+	if plotWidth < 10 || plotHeight < 10 {
+		r.objects = nil
+		return
+	}
+
+	lo, hi := ratings[0].Value, ratings[0].Value
+	for _, rt := range ratings {
+		lo, hi = min(lo, rt.Value), max(hi, rt.Value)
+	}
+	if lo == hi {
+		lo, hi = lo-1, hi+1
+	}
+	yOf := func(v int) float32 {
+		return plotHeight - float32(v-lo)/float32(hi-lo)*plotHeight
+	}
+
+	start := ratings[0].CreatedAt
+	span := ratings[len(ratings)-1].CreatedAt.Sub(start)
+	xOf := func(i int) float32 {
+		if span <= 0 {
+			return axisWidth + float32(i)/float32(len(ratings))*plotWidth
+		}
+		return axisWidth + float32(ratings[i].CreatedAt.Sub(start))/float32(span)*plotWidth*0.95
+	}
+
+	labelColor := chartTextColor()
+	var objects []fyne.CanvasObject
+	for _, v := range []int{lo, hi} {
+		label := canvas.NewText(database.Rating{Kind: ratings[0].Kind, Value: v}.Label(), labelColor)
+		label.TextSize = 10
+		label.Move(fyne.NewPos(0, yOf(v)-6))
+		objects = append(objects, label)
+	}
+
+	for i, rt := range ratings {
+		x1, y := xOf(i), yOf(rt.Value)
+		x2 := axisWidth + plotWidth
+		if i+1 < len(ratings) {
+			x2 = xOf(i + 1)
+			// Vertical riser to the next rank.
+			riser := canvas.NewLine(labelColor)
+			riser.StrokeWidth = 1
+			riser.Position1 = fyne.NewPos(x2, y)
+			riser.Position2 = fyne.NewPos(x2, yOf(ratings[i+1].Value))
+			objects = append(objects, riser)
+		}
+		step := canvas.NewLine(rankColor(rt))
+		step.StrokeWidth = 3
+		step.Position1 = fyne.NewPos(x1, y)
+		step.Position2 = fyne.NewPos(x2, y)
+		objects = append(objects, step)
+	}
+
+	first := canvas.NewText(start.Local().Format("01/02"), labelColor)
+	first.TextSize = 10
+	first.Move(fyne.NewPos(axisWidth, plotHeight+2))
+	last := canvas.NewText(ratings[len(ratings)-1].CreatedAt.Local().Format("01/02"), labelColor)
+	last.TextSize = 10
+	last.Move(fyne.NewPos(xOf(len(ratings)-1), plotHeight+2))
+	objects = append(objects, first, last)
+
+	r.objects = objects
+}
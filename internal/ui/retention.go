@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/retention"
+)
+
+// SetDatabase gives the settings the database for pruning old rounds by
+// hand and renaming accounts. onRounds is run after rounds were pruned or
+// renamed, e.g. to refresh the stats.
+func (s *SettingsTab) SetDatabase(ctx context.Context, db *sql.DB, onRounds func()) {
+	s.ctx, s.db, s.onRounds = ctx, db, onRounds
+	s.Reload()
+}
+
+// buildRetentionSection creates the Settings editor for the retention
+// policy, with a button to preview and run it now.
+func (s *SettingsTab) buildRetentionSection() fyne.CanvasObject {
+	r := &s.cfg.Retention
+
+	// The policy prunes rounds, so changing it needs the PIN too; declining
+	// puts the widgets back.
+	enabledCheck := widget.NewCheck("At startup, prune rounds older than", func(enabled bool) {
+		unlockOr(s.cfg, s.window, func() {
+			r.Enabled = enabled
+			s.save()
+		}, s.Reload)
+	})
+	enabledCheck.Checked = r.Enabled
+	monthsEntry := NewIntEntry(r.KeepMonths, 1, math.MaxInt32, func(n int) {
+		unlockOr(s.cfg, s.window, func() {
+			r.KeepMonths = n
+			s.save()
+		}, s.Reload)
+	})
+
+	pruneBtn := widget.NewButton("Preview and Prune Now...", func() {
+		unlock(s.cfg, s.window, s.previewPrune)
+	})
+	if s.db == nil {
+		pruneBtn.Disable()
+	}
+
+	return container.NewVBox(
+		widget.NewLabel("Data Retention (rounds are archived to CSV first; totals are kept)"),
+		container.NewHBox(enabledCheck, monthsEntry, widget.NewLabel("months")),
+		container.NewHBox(pruneBtn),
+	)
+}
+
+// previewPrune shows what pruning with the current policy would remove and
+// offers to save an archive of it and prune.
+func (s *SettingsTab) previewPrune() {
+	ctx, cancel := database.WithTimeout(s.ctx)
+	defer cancel()
+	p, err := retention.NewPreview(ctx, s.db, retention.Cutoff(time.Now(), s.cfg.Retention.KeepMonths))
+	if err != nil {
+		dialog.ShowError(err, s.window)
+		return
+	}
+	if len(p.Rounds) == 0 {
+		dialog.ShowInformation("Nothing to Prune",
+			fmt.Sprintf("No rounds were recorded before %s.", p.Cutoff.Format("2 January 2006")), s.window)
+		return
+	}
+
+	first, last := p.Rounds[0].CreatedAt, p.Rounds[len(p.Rounds)-1].CreatedAt
+	msg := widget.NewLabel(fmt.Sprintf(
+		"%d rounds recorded from %s to %s will be removed.\n\n"+
+			"Their per-day win, loss and side counts are kept, so totals and charts don't change, "+
+			"but History, sessions, pace and halftime stats won't include them.\n\n"+
+			"Save an archive of the rounds first, then prune?",
+		len(p.Rounds), first.Local().Format("2 Jan 2006"), last.Local().Format("2 Jan 2006")))
+	msg.Wrapping = fyne.TextWrapWord
+	confirm := dialog.NewCustomConfirm("Prune Old Rounds", "Save Archive and Prune", "Cancel", msg, func(ok bool) {
+		if ok {
+			s.archiveAndPrune(p)
+		}
+	}, s.window)
+	confirm.Resize(fyne.NewSize(420, 0))
+	confirm.Show()
+}
+
+// archiveAndPrune asks where to save p's archive and prunes once it's
+// written.
+func (s *SettingsTab) archiveAndPrune(p *retention.Preview) {
+	save := dialog.NewFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		if w == nil {
+			return // cancelled: nothing is pruned without an archive
+		}
+		err = p.WriteCSV(w)
+		if cerr := w.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+
+		ctx, cancel := database.WithTimeout(s.ctx)
+		defer cancel()
+		n, err := p.Prune(ctx, s.db)
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		if s.onRounds != nil {
+			s.onRounds()
+		}
+		dialog.ShowInformation("Rounds Pruned", fmt.Sprintf("%d rounds were pruned.", n), s.window)
+	}, s.window)
+	save.SetFileName(p.ArchiveName())
+	save.Show()
+}
@@ -1,16 +1,30 @@
 package ui
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"path/filepath"
+	"strings"
 	"sync"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/storage"
 	"fyne.io/fyne/v2/widget"
 
 	"csstatstracker/internal/config"
+	"csstatstracker/internal/gsi"
+	"csstatstracker/internal/hotkey"
+	"csstatstracker/internal/plugins"
+	"csstatstracker/internal/sound"
 )
 
 // SettingsTab manages the settings view
@@ -18,7 +32,19 @@ type SettingsTab struct {
 	cfg       *config.Config
 	window    fyne.Window
 	onSave    func(*config.Config)
-	container fyne.CanvasObject
+	plugins   *plugins.Manager // nil until SetPlugins
+	container *fyne.Container
+
+	sendTestEmail func() error                      // nil until SetSendTestEmail
+	syncFACEIT    func() (int, error)               // nil until SetSyncFACEIT
+	testSound     func(sound.Effect) error          // nil until SetTestSound
+	testStinger   func(sound.Stinger, string) error // nil until SetTestStinger
+
+	ctx      context.Context // set with db by SetDatabase
+	db       *sql.DB         // nil until SetDatabase
+	onRounds func()
+
+	writeBugReport func(io.Writer) error // nil until SetBugReport
 }
 
 // NewSettingsTab creates a new settings tab
@@ -28,7 +54,7 @@ func NewSettingsTab(cfg *config.Config, window fyne.Window, onSave func(*config.
 		window: window,
 		onSave: onSave,
 	}
-	s.container = s.buildUI()
+	s.container = container.NewStack(s.buildUI())
 	return s
 }
 
@@ -57,6 +83,44 @@ func (s *SettingsTab) buildUI() fyne.CanvasObject {
 	}
 	volumeRow := container.NewBorder(nil, nil, volumeLabel, nil, volumeSlider)
 
+	// Keep mashed +/- corrections from piling sounds up; per-sound cooldowns
+	// are set in the config file
+	cooldownEntry := NewIntEntry(s.cfg.SoundTiming.Cooldown, 0, 5000, func(n int) {
+		s.cfg.SoundTiming.Cooldown = n
+		s.save()
+	})
+	cooldownRow := container.NewHBox(widget.NewLabel("Skip a sound repeated within"), cooldownEntry, widget.NewLabel("ms"))
+	restartCheck := widget.NewCheck("Cut off a sound when it plays again", func(enabled bool) {
+		s.cfg.SoundTiming.Restart = enabled
+		s.save()
+	})
+	restartCheck.Checked = s.cfg.SoundTiming.Restart
+
+	// Quieter effects while a voice app has the microphone
+	duckLabel := widget.NewLabel(fmt.Sprintf("While talking: %d%%", int(s.cfg.Ducking.Volume*100)))
+	duckSlider := widget.NewSlider(0, 1)
+	duckSlider.Step = 0.05
+	duckSlider.Value = s.cfg.Ducking.Volume
+	duckSlider.OnChanged = func(val float64) {
+		s.cfg.Ducking.Volume = val
+		duckLabel.SetText(fmt.Sprintf("While talking: %d%%", int(val*100)))
+		s.save()
+	}
+	duckCheck := widget.NewCheck("Lower effects while the microphone is in use", func(enabled bool) {
+		s.cfg.Ducking.Enabled = enabled
+		if enabled {
+			duckSlider.Enable()
+		} else {
+			duckSlider.Disable()
+		}
+		s.save()
+	})
+	duckCheck.Checked = s.cfg.Ducking.Enabled
+	if !s.cfg.Ducking.Enabled {
+		duckSlider.Disable()
+	}
+	duckRow := container.NewBorder(nil, nil, duckLabel, nil, duckSlider)
+
 	// Minimize to tray toggle
 	trayCheck := widget.NewCheck("Close to System Tray", func(enabled bool) {
 		s.cfg.MinimizeToTray = enabled
@@ -64,55 +128,333 @@ func (s *SettingsTab) buildUI() fyne.CanvasObject {
 	})
 	trayCheck.Checked = s.cfg.MinimizeToTray
 
-	// Create buttons for each hotkey
-	var incCTButton, decCTButton, incTButton, decTButton, selectCTButton, selectTButton, swapTeamsButton *widget.Button
+	// Big buttons and swipes for a touchscreen second device
+	touchCheck := widget.NewCheck("Touch mode (large +/- buttons, swipe counters up or down)", func(enabled bool) {
+		s.cfg.TouchMode = enabled
+		s.save()
+	})
+	touchCheck.Checked = s.cfg.TouchMode
 
-	incCTButton = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys.IncrementCT), func() {
-		CaptureHotkey(s.window, "Increment CT", &s.cfg.Hotkeys.IncrementCT, incCTButton, s.save)
+	// On-screen score for hotkey actions while the window is hidden
+	osdCheck := widget.NewCheck("Flash score on hotkeys while hidden", func(enabled bool) {
+		s.cfg.OSD.Enabled = enabled
+		s.save()
+	})
+	osdCheck.Checked = s.cfg.OSD.Enabled
+	osdDurationEntry := NewIntEntry(s.cfg.OSD.DurationMs, 1, math.MaxInt32, func(n int) {
+		s.cfg.OSD.DurationMs = n
+		s.save()
+	})
+	osdPositions := []string{config.OSDTopRight, config.OSDTopLeft, config.OSDBottomRight, config.OSDBottomLeft, config.OSDCenter}
+	osdPositionSelect := widget.NewSelect(osdPositions, func(selected string) {
+		if selected != s.cfg.OSD.Position {
+			s.cfg.OSD.Position = selected
+			s.save()
+		}
 	})
+	osdPositionSelect.Selected = s.cfg.OSD.Position
+	osdRow := container.NewHBox(
+		osdCheck,
+		widget.NewLabel("for"),
+		osdDurationEntry,
+		widget.NewLabel("ms at"),
+		osdPositionSelect,
+	)
 
-	decCTButton = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys.DecrementCT), func() {
-		CaptureHotkey(s.window, "Decrement CT", &s.cfg.Hotkeys.DecrementCT, decCTButton, s.save)
+	// Break after a run of matches, to stop "one more game" spirals
+	breakCheck := widget.NewCheck("Suggest a break after", func(enabled bool) {
+		s.cfg.BreakLimit.Enabled = enabled
+		s.save()
 	})
+	breakCheck.Checked = s.cfg.BreakLimit.Enabled
+	breakMatchesEntry := NewStepper(s.cfg.BreakLimit.Matches, 1, 20, func(n int) {
+		s.cfg.BreakLimit.Matches = n
+		s.save()
+	})
+	breakMinutesEntry := NewStepper(s.cfg.BreakLimit.BreakMinutes, 1, 240, func(n int) {
+		s.cfg.BreakLimit.BreakMinutes = n
+		s.save()
+	})
+	breakRow := container.NewHBox(
+		breakCheck,
+		breakMatchesEntry,
+		widget.NewLabel("matches in a row, holding off the next one for"),
+		breakMinutesEntry,
+		widget.NewLabel("min"),
+	)
 
-	incTButton = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys.IncrementT), func() {
-		CaptureHotkey(s.window, "Increment T", &s.cfg.Hotkeys.IncrementT, incTButton, s.save)
+	// Minimum sample size below which stats win rates are flagged
+	minSampleEntry := NewIntEntry(s.cfg.MinSampleSize, 1, math.MaxInt32, func(n int) {
+		s.cfg.MinSampleSize = n
+		s.save()
 	})
+	minSampleRow := container.NewHBox(
+		widget.NewLabel("Min. rounds for win rates:"),
+		minSampleEntry,
+	)
+
+	// Win/loss palette for colour-vision deficiencies
+	paletteLabels := make([]string, len(ColorVisionModes))
+	paletteSelected := ColorVisionModes[0].Label
+	for i, m := range ColorVisionModes {
+		paletteLabels[i] = m.Label
+		if m.Mode == s.cfg.ColorVision {
+			paletteSelected = m.Label
+		}
+	}
+	paletteSelect := widget.NewSelect(paletteLabels, func(selected string) {
+		for _, m := range ColorVisionModes {
+			if m.Label == selected && m.Mode != s.cfg.ColorVision {
+				s.cfg.ColorVision = m.Mode
+				s.save()
+			}
+		}
+	})
+	paletteSelect.Selected = paletteSelected
+	paletteRow := container.NewHBox(widget.NewLabel("Win/loss colours:"), paletteSelect)
+
+	// Format of history rows copied to the clipboard
+	copyFormatSelect := widget.NewSelect([]string{"Plain text", "Markdown table"}, func(selected string) {
+		format := CopyFormatText
+		if selected == "Markdown table" {
+			format = CopyFormatMarkdown
+		}
+		if format != s.cfg.CopyFormat {
+			s.cfg.CopyFormat = format
+			s.save()
+		}
+	})
+	copyFormatSelect.Selected = "Plain text"
+	if s.cfg.CopyFormat == CopyFormatMarkdown {
+		copyFormatSelect.Selected = "Markdown table"
+	}
+	copyFormatRow := container.NewHBox(widget.NewLabel("Copy history as:"), copyFormatSelect)
+
+	// Default display names for the sides of new matches
+	ctNameEntry := widget.NewEntry()
+	ctNameEntry.SetText(s.cfg.CTName)
+	ctNameEntry.OnChanged = func(text string) {
+		if text = strings.TrimSpace(text); text == "" {
+			return
+		}
+		s.cfg.CTName = text
+		s.save()
+	}
+	tNameEntry := widget.NewEntry()
+	tNameEntry.SetText(s.cfg.TName)
+	tNameEntry.OnChanged = func(text string) {
+		if text = strings.TrimSpace(text); text == "" {
+			return
+		}
+		s.cfg.TName = text
+		s.save()
+	}
+	ctColorEntry := newColorEntry(s.cfg.CTColor, func(hex string) {
+		s.cfg.CTColor = hex
+		s.save()
+	})
+	tColorEntry := newColorEntry(s.cfg.TColor, func(hex string) {
+		s.cfg.TColor = hex
+		s.save()
+	})
+	ctLogoPicker := s.newLogoPicker(s.cfg.CTLogo, func(path string) {
+		s.cfg.CTLogo = path
+		s.save()
+	})
+	tLogoPicker := s.newLogoPicker(s.cfg.TLogo, func(path string) {
+		s.cfg.TLogo = path
+		s.save()
+	})
+
+	// Game state integration listener
+	gsiCheck := widget.NewCheck("Enable CS2 Game State Integration", func(enabled bool) {
+		s.cfg.GSI.Enabled = enabled
+		s.save()
+	})
+	gsiCheck.Checked = s.cfg.GSI.Enabled
+	gsiPortEntry := NewIntEntry(s.cfg.GSI.Port, 1, 65535, func(n int) {
+		s.cfg.GSI.Port = n
+		s.save()
+	})
+	gsiTokenEntry := widget.NewPasswordEntry()
+	gsiTokenEntry.SetText(s.cfg.GSI.Token)
+	gsiTokenEntry.OnChanged = func(text string) {
+		s.cfg.GSI.Token = text
+		s.save()
+	}
 
-	decTButton = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys.DecrementT), func() {
-		CaptureHotkey(s.window, "Decrement T", &s.cfg.Hotkeys.DecrementT, decTButton, s.save)
+	// One capture button per hotkey action
+	hotkeyForm := widget.NewForm()
+	for _, action := range config.HotkeyActions {
+		var button *widget.Button
+		button = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys[action.Key]), func() {
+			CaptureHotkey(s.window, action.Label, s.cfg.Hotkeys[action.Key], button, func(combo []string) {
+				s.cfg.Hotkeys[action.Key] = combo
+				s.save()
+			})
+		})
+		hotkeyForm.Append(action.Label, withHint(button, action.Help))
+	}
+
+	// What holding down a hotkey does; per-action cooldowns are set in the
+	// config file
+	repeatSelect := widget.NewSelect([]string{"Fire once", "Repeat after delay"}, func(selected string) {
+		repeat := config.RepeatIgnore
+		if selected == "Repeat after delay" {
+			repeat = config.RepeatDelay
+		}
+		if repeat != s.cfg.HotkeyTiming.Repeat {
+			s.cfg.HotkeyTiming.Repeat = repeat
+			s.save()
+		}
 	})
+	repeatSelect.Selected = "Fire once"
+	if s.cfg.HotkeyTiming.Repeat == config.RepeatDelay {
+		repeatSelect.Selected = "Repeat after delay"
+	}
 
-	selectCTButton = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys.SelectCT), func() {
-		CaptureHotkey(s.window, "Select CT Team", &s.cfg.Hotkeys.SelectCT, selectCTButton, s.save)
+	// What other keys held down alongside a hotkey do
+	matchOptions := []string{"Block the hotkey", "Are ignored", "Are ignored, longest combo wins"}
+	matchValues := []string{config.MatchExact, config.MatchSuperset, config.MatchLongest}
+	matchSelect := widget.NewSelect(matchOptions, func(selected string) {
+		for i, o := range matchOptions {
+			if o == selected && matchValues[i] != s.cfg.HotkeyMatch {
+				s.cfg.HotkeyMatch = matchValues[i]
+				s.save()
+			}
+		}
 	})
+	matchSelect.Selected = matchOptions[0]
+	for i, v := range matchValues {
+		if v == s.cfg.HotkeyMatch {
+			matchSelect.Selected = matchOptions[i]
+		}
+	}
 
-	selectTButton = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys.SelectT), func() {
-		CaptureHotkey(s.window, "Select T Team", &s.cfg.Hotkeys.SelectT, selectTButton, s.save)
+	focusCheck := widget.NewCheck("Only while CS2 or the tracker has the focus", func(on bool) {
+		s.cfg.HotkeyFocus = on
+		s.save()
 	})
+	focusCheck.Checked = s.cfg.HotkeyFocus
 
-	swapTeamsButton = widget.NewButton(FormatHotkeys(s.cfg.Hotkeys.SwapTeams), func() {
-		CaptureHotkey(s.window, "Swap Teams", &s.cfg.Hotkeys.SwapTeams, swapTeamsButton, s.save)
+	exportButton := widget.NewButton("Export Settings...", s.exportSettings)
+	// Imported settings can drop the PIN, so importing needs it too.
+	importButton := widget.NewButton("Import Settings...", func() {
+		unlock(s.cfg, s.window, s.importSettings)
 	})
+	bugReportButton := widget.NewButton("Save Bug Report...", s.saveBugReport)
+	if s.writeBugReport == nil {
+		bugReportButton.Disable()
+	}
 
 	form := container.NewVBox(
 		soundCheck,
 		volumeRow,
+		cooldownRow,
+		restartCheck,
+		duckCheck,
+		duckRow,
+		s.buildTestSoundsSection(),
+		s.buildAnnouncerSection(),
+		s.buildStingersSection(),
 		trayCheck,
+		touchCheck,
+		osdRow,
+		breakRow,
+		s.buildDailyGoalSection(),
+		minSampleRow,
+		paletteRow,
+		copyFormatRow,
+		widget.NewSeparator(),
+		widget.NewLabel("Sides (names apply to new matches)"),
+		widget.NewForm(
+			widget.NewFormItem("CT side", container.NewGridWithColumns(2, ctNameEntry, ctColorEntry)),
+			widget.NewFormItem("T side", container.NewGridWithColumns(2, tNameEntry, tColorEntry)),
+			widget.NewFormItem("CT logo", ctLogoPicker),
+			widget.NewFormItem("T logo", tLogoPicker),
+		),
+		widget.NewSeparator(),
+		s.buildAccountsSection(),
+		widget.NewSeparator(),
+		widget.NewLabel("Game State Integration (detects map and side)"),
+		gsiCheck,
+		widget.NewForm(
+			widget.NewFormItem("Port", container.NewHBox(gsiPortEntry)),
+			widget.NewFormItem("Auth token", gsiTokenEntry),
+		),
+		withHint(container.NewHBox(widget.NewButton("Write CS2 Config File...", s.writeGSIConfig)),
+			"Saves "+gsi.ConfigFileName+" with this port and token into CS2's cfg folder."),
+		s.buildToastsSection(),
+		widget.NewSeparator(),
+		s.buildIntegrationsSection(),
 		widget.NewSeparator(),
 		widget.NewLabel("Hotkey Configuration (click to change)"),
+		hotkeyForm,
 		widget.NewForm(
-			widget.NewFormItem("Increment CT", incCTButton),
-			widget.NewFormItem("Decrement CT", decCTButton),
-			widget.NewFormItem("Increment T", incTButton),
-			widget.NewFormItem("Decrement T", decTButton),
-			widget.NewFormItem("Select CT Team", selectCTButton),
-			widget.NewFormItem("Select T Team", selectTButton),
-			widget.NewFormItem("Swap Teams", swapTeamsButton),
+			widget.NewFormItem("Held hotkey", repeatSelect),
+			widget.NewFormItem("Extra held keys", matchSelect),
 		),
+		withHint(focusCheck, "Keeps keys typed in other apps from changing the score. Under Wayland, "+
+			"where the focused app can't be told, hotkeys always fire."),
+		widget.NewSeparator(),
+		s.buildRetentionSection(),
+		widget.NewSeparator(),
+		s.buildPINSection(),
+		widget.NewSeparator(),
+		s.buildEmailSection(),
+		widget.NewSeparator(),
+		s.buildPluginsSection(),
+		widget.NewSeparator(),
+		withHint(container.NewHBox(exportButton, importButton, bugReportButton), helpSaving),
 	)
 
-	return form
+	return container.NewVScroll(form)
+}
+
+// Reload recreates the form so every widget reflects the current config,
+// e.g. after it was replaced by an import or an external edit.
+func (s *SettingsTab) Reload() {
+	s.container.Objects = []fyne.CanvasObject{s.buildUI()}
+	s.container.Refresh()
+}
+
+func (s *SettingsTab) exportSettings() {
+	dialog.ShowFileSave(func(w fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		if w == nil {
+			return // cancelled
+		}
+		defer func() { _ = w.Close() }()
+		if err := config.Export(s.cfg, w); err != nil {
+			dialog.ShowError(err, s.window)
+		}
+	}, s.window)
+}
+
+func (s *SettingsTab) importSettings() {
+	dialog.ShowFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		if r == nil {
+			return // cancelled
+		}
+		defer func() { _ = r.Close() }()
+		imported, err := config.Import(r, s.cfg)
+		if err != nil {
+			dialog.ShowError(err, s.window)
+			return
+		}
+		// Copy into the shared config so every tab holding the pointer sees
+		// the imported values.
+		*s.cfg = *imported
+		s.Reload()
+		s.save()
+	}, s.window)
 }
 
 func (s *SettingsTab) save() {
@@ -121,6 +463,88 @@ func (s *SettingsTab) save() {
 	}
 }
 
+// newColorEntry creates an entry for a #RRGGBB colour with a swatch
+// previewing it. onChange is only called with valid colours.
+func newColorEntry(hex string, onChange func(hex string)) fyne.CanvasObject {
+	swatch := canvas.NewRectangle(color.Transparent)
+	swatch.SetMinSize(fyne.NewSize(24, 24))
+	swatch.CornerRadius = 4
+	if c, err := parseHexColor(hex); err == nil {
+		swatch.FillColor = c
+	}
+
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("#RRGGBB")
+	entry.SetText(hex)
+	entry.Validator = func(text string) error {
+		_, err := parseHexColor(text)
+		return err
+	}
+	entry.OnChanged = func(text string) {
+		c, err := parseHexColor(text)
+		if err != nil {
+			return
+		}
+		swatch.FillColor = c
+		swatch.Refresh()
+		onChange(strings.ToUpper(strings.TrimSpace(text)))
+	}
+	return container.NewBorder(nil, nil, nil, container.NewCenter(swatch), entry)
+}
+
+// newLogoPicker creates a row for choosing the image file of a side logo,
+// previewing it, with a button to go back to none. onChange is called with
+// the file's path, or "" once cleared.
+func (s *SettingsTab) newLogoPicker(path string, onChange func(path string)) fyne.CanvasObject {
+	preview := newLogo()
+	preview.SetMinSize(fyne.NewSquareSize(24))
+	setLogo(preview, path)
+	return s.newFilePicker(path, "None", []string{".png", ".jpg", ".jpeg", ".svg"}, preview, func(path string) {
+		setLogo(preview, path)
+		onChange(path)
+	})
+}
+
+// newFilePicker creates a row showing the name of the file at path, or
+// none when it's empty, with buttons to choose a file with one of
+// extensions and to clear it. leading, if not nil, goes before the name.
+// onChange is called with the chosen file's path, or "" once cleared.
+func (s *SettingsTab) newFilePicker(path, none string, extensions []string, leading fyne.CanvasObject, onChange func(path string)) fyne.CanvasObject {
+	name := widget.NewLabel("")
+	var clearButton *widget.Button
+	set := func(p string) {
+		if p == "" {
+			name.SetText(none)
+			clearButton.Disable()
+		} else {
+			name.SetText(filepath.Base(p))
+			clearButton.Enable()
+		}
+	}
+	clearButton = widget.NewButton("Clear", func() {
+		set("")
+		onChange("")
+	})
+	choose := widget.NewButton("Choose...", func() {
+		open := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, s.window)
+				return
+			}
+			if r == nil {
+				return // cancelled
+			}
+			_ = r.Close()
+			set(r.URI().Path())
+			onChange(r.URI().Path())
+		}, s.window)
+		open.SetFilter(storage.NewExtensionFileFilter(extensions))
+		open.Show()
+	})
+	set(path)
+	return container.NewBorder(nil, nil, leading, container.NewHBox(choose, clearButton), name)
+}
+
 // FormatHotkeys formats a slice of key names as a display string
 func FormatHotkeys(keys []string) string {
 	if len(keys) == 0 {
@@ -136,8 +560,9 @@ func FormatHotkeys(keys []string) string {
 	return result
 }
 
-// CaptureHotkey opens a dialog to capture a key combination
-func CaptureHotkey(w fyne.Window, action string, target *[]string, button *widget.Button, onSave func()) {
+// CaptureHotkey opens a dialog to capture a key combination, replacing
+// current. onSave is called with the new combo if the user confirms one.
+func CaptureHotkey(w fyne.Window, action string, current []string, button *widget.Button, onSave func([]string)) {
 	tempWindow := fyne.CurrentApp().NewWindow("Key Capture")
 	tempWindow.Resize(fyne.Size{Width: 400, Height: 200})
 	tempWindow.CenterOnScreen()
@@ -146,7 +571,7 @@ func CaptureHotkey(w fyne.Window, action string, target *[]string, button *widge
 	label.Alignment = fyne.TextAlignCenter
 	label.Wrapping = fyne.TextWrapWord
 
-	statusLabel := widget.NewLabel("Waiting for keys...")
+	statusLabel := widget.NewLabel(fmt.Sprintf("Currently %s. Waiting for keys...", FormatHotkeys(current)))
 	statusLabel.Alignment = fyne.TextAlignCenter
 
 	var capturedCombo []string
@@ -157,10 +582,9 @@ func CaptureHotkey(w fyne.Window, action string, target *[]string, button *widge
 		defer captureMutex.Unlock()
 
 		if len(capturedCombo) > 0 {
-			*target = capturedCombo
 			button.SetText(FormatHotkeys(capturedCombo))
 			if onSave != nil {
-				onSave()
+				onSave(capturedCombo)
 			}
 		}
 		tempWindow.Close()
@@ -202,8 +626,12 @@ func CaptureHotkey(w fyne.Window, action string, target *[]string, button *widge
 
 	if deskCanvas, ok := tempWindow.Canvas().(desktop.Canvas); ok {
 		deskCanvas.SetOnKeyDown(func(key *fyne.KeyEvent) {
+			keyStr, ok := hotkeyName(key.Name)
+			if !ok {
+				statusLabel.SetText(fmt.Sprintf("%s can't be used in a hotkey", key.Name))
+				return
+			}
 			captureMutex.Lock()
-			keyStr := string(key.Name)
 
 			// Track this key as held
 			heldKeys[keyStr] = true
@@ -219,16 +647,20 @@ func CaptureHotkey(w fyne.Window, action string, target *[]string, button *widge
 		})
 
 		deskCanvas.SetOnKeyUp(func(key *fyne.KeyEvent) {
+			keyStr, _ := hotkeyName(key.Name)
 			captureMutex.Lock()
-			keyStr := string(key.Name)
 			delete(heldKeys, keyStr)
 			captureMutex.Unlock()
 		})
 	} else {
 		// Fallback for non-desktop canvas
 		tempWindow.Canvas().SetOnTypedKey(func(key *fyne.KeyEvent) {
+			keyStr, ok := hotkeyName(key.Name)
+			if !ok {
+				statusLabel.SetText(fmt.Sprintf("%s can't be used in a hotkey", key.Name))
+				return
+			}
 			captureMutex.Lock()
-			keyStr := string(key.Name)
 
 			if !containsKey(capturedCombo, keyStr) {
 				capturedCombo = append(capturedCombo, keyStr)
@@ -244,6 +676,17 @@ func CaptureHotkey(w fyne.Window, action string, target *[]string, button *widge
 	tempWindow.Show()
 }
 
+// hotkeyName translates a key captured by Fyne to the canonical name stored
+// in the config. It reports false for keys the global hook can't report, which
+// could never fire.
+func hotkeyName(name fyne.KeyName) (string, bool) {
+	canonical := hotkey.CanonicalKey(string(name))
+	if canonical == "" || !hotkey.IsKnownKey(canonical) {
+		return "", false
+	}
+	return canonical, true
+}
+
 // containsKey checks if a key is already in the slice
 func containsKey(keys []string, key string) bool {
 	for _, k := range keys {
@@ -0,0 +1,143 @@
+package ui_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/ui"
+)
+
+func TestSettingsCapturesHotkey(t *testing.T) {
+	a := test.NewTempApp(t)
+	cfg := config.Default()
+	action := config.HotkeyActions[0]
+	cfg.Hotkeys[action.Key] = []string{"F1"}
+
+	var saved *config.Config
+	w := test.NewTempWindow(t, nil)
+	s := ui.NewSettingsTab(cfg, w, func(c *config.Config) { saved = c })
+	w.SetContent(s.Container())
+
+	test.Tap(button(t, s.Container(), "F1"))
+	windows := a.Driver().AllWindows()
+	capture := windows[len(windows)-1]
+	if capture == w {
+		t.Fatal("no key capture window opened")
+	}
+	ok := button(t, capture.Content(), "OK")
+	if !ok.Disabled() {
+		t.Error("OK is enabled before any key is pressed")
+	}
+
+	press := capture.Canvas().OnTypedKey()
+	// A key the global hook can't report is refused.
+	press(&fyne.KeyEvent{Name: "NotAKey"})
+	if !ok.Disabled() {
+		t.Error("OK is enabled after an unusable key")
+	}
+	press(&fyne.KeyEvent{Name: fyne.KeyF5})
+	press(&fyne.KeyEvent{Name: fyne.KeyF6})
+	press(&fyne.KeyEvent{Name: fyne.KeyF5})
+	test.Tap(ok)
+
+	want := []string{"F5", "F6"}
+	if got := cfg.Hotkeys[action.Key]; !slices.Equal(got, want) {
+		t.Errorf("%s hotkey = %v; want %v", action.Key, got, want)
+	}
+	if saved != cfg {
+		t.Error("capturing a hotkey didn't save the config")
+	}
+	if len(buttons(s.Container(), "F5+F6")) != 1 {
+		t.Error("the hotkey's button doesn't show the new combo")
+	}
+}
+
+func TestSettingsAnnouncerStreaks(t *testing.T) {
+	test.NewTempApp(t)
+	cfg := config.Default()
+	w := test.NewTempWindow(t, nil)
+	s := ui.NewSettingsTab(cfg, w, func(*config.Config) {})
+	w.SetContent(s.Container())
+
+	var entry *widget.Entry
+	for _, o := range objects(s.Container()) {
+		if e, ok := o.(*widget.Entry); ok && e.PlaceHolder == "e.g. 3, 5, 10" {
+			entry = e
+		}
+	}
+	if entry == nil {
+		t.Fatal("no win streaks entry")
+	}
+	if entry.Text != "3, 5" {
+		t.Errorf("win streaks entry shows %q; want the defaults 3, 5", entry.Text)
+	}
+
+	entry.SetText("10, 3,3 ,5")
+	if want := []int{3, 5, 10}; !slices.Equal(cfg.Announcer.Streaks, want) {
+		t.Errorf("streaks = %v; want %v", cfg.Announcer.Streaks, want)
+	}
+	entry.SetText("3, 1")
+	if entry.Validate() == nil || !slices.Equal(cfg.Announcer.Streaks, []int{3, 5, 10}) {
+		t.Errorf("a streak of 1 is accepted, streaks = %v", cfg.Announcer.Streaks)
+	}
+	entry.SetText("")
+	if cfg.Announcer.Streaks == nil || len(cfg.Announcer.Streaks) != 0 {
+		t.Errorf("streaks = %#v after clearing; want none, not the defaults", cfg.Announcer.Streaks)
+	}
+}
+
+func TestSettingsRenamesAccount(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	dbtest.Insert(t, db, dbtest.With(dbtest.Series(time.Now().Add(-time.Hour), time.Minute, "WLW"),
+		func(r *database.Round) { r.Account = "main" })...)
+	cfg := config.Default()
+	cfg.Accounts = []config.Account{{Name: "main"}}
+	cfg.ActiveAccount = "main"
+	w := test.NewTempWindow(t, nil)
+	s := ui.NewSettingsTab(cfg, w, func(*config.Config) {})
+	s.SetDatabase(ctx, db, nil)
+	w.SetContent(s.Container())
+
+	var entry *ui.CommitEntry
+	for _, o := range objects(s.Container()) {
+		if e, ok := o.(*ui.CommitEntry); ok && e.Text == "main" {
+			entry = e
+		}
+	}
+	if entry == nil {
+		t.Fatal("no name entry for the account")
+	}
+	accountRounds := func(name string) int {
+		stats, err := database.GetStats(ctx, db, database.Filter{Window: database.WindowAll, Account: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stats.TotalRounds
+	}
+
+	// Nothing is renamed while the name is being typed.
+	entry.SetText("sm")
+	if cfg.Accounts[0].Name != "main" || accountRounds("main") != 3 {
+		t.Fatalf("account renamed to %q with %d rounds left on main while typing; want neither",
+			cfg.Accounts[0].Name, accountRounds("main"))
+	}
+	entry.SetText("smurf")
+	entry.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	if cfg.Accounts[0].Name != "smurf" || cfg.ActiveAccount != "smurf" {
+		t.Errorf("account %q, active %q after renaming; want smurf", cfg.Accounts[0].Name, cfg.ActiveAccount)
+	}
+	if n := accountRounds("smurf"); n != 3 {
+		t.Errorf("smurf has %d rounds after renaming; want main's 3", n)
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/sound"
+)
+
+// SetTestSound sets what the sound test buttons run, e.g. the tracker's
+// player's Test.
+func (s *SettingsTab) SetTestSound(test func(sound.Effect) error) {
+	s.testSound = test
+	s.Reload()
+}
+
+// buildTestSoundsSection creates a button for each built-in sound effect
+// that plays it, showing why if it can't be played.
+func (s *SettingsTab) buildTestSoundsSection() fyne.CanvasObject {
+	grid := container.NewGridWithColumns(2)
+	for _, effect := range sound.Effects {
+		btn := widget.NewButtonWithIcon(effect.Label, theme.MediaPlayIcon(), func() {
+			if s.testSound == nil {
+				return
+			}
+			test := s.testSound
+			go func() {
+				if err := test(effect); err != nil {
+					fyne.Do(func() {
+						dialog.ShowError(fmt.Errorf("failed to play %q: %w", effect.Label, err), s.window)
+					})
+				}
+			}()
+		})
+		btn.Alignment = widget.ButtonAlignLeading
+		if s.testSound == nil {
+			btn.Disable()
+		}
+		grid.Add(btn)
+	}
+	return container.NewVBox(widget.NewLabel("Test Sounds (plays even while sound is off)"), grid)
+}
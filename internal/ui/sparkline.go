@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"context"
+	"database/sql"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+)
+
+// sparklineRounds is how many recent rounds the sparkline shows.
+const sparklineRounds = 10
+
+// Sparkline draws a compact strip of the most recent round results: green
+// ticks above the midline for wins, red below for losses and a short grey
+// tick for draws. Oldest round is on the left.
+type Sparkline struct {
+	widget.BaseWidget
+	ctx      context.Context
+	db       *sql.DB
+	results  []database.Result
+	onTapped func()
+}
+
+// NewSparkline creates a sparkline over the rounds in db. onTapped is called
+// when the user clicks it. Loads are abandoned once ctx is cancelled.
+func NewSparkline(ctx context.Context, db *sql.DB, onTapped func()) *Sparkline {
+	s := &Sparkline{ctx: ctx, db: db, onTapped: onTapped}
+	s.ExtendBaseWidget(s)
+	s.Reload()
+	return s
+}
+
+// Reload re-reads the recent rounds from the database and redraws.
+func (s *Sparkline) Reload() {
+	ctx, cancel := database.WithTimeout(s.ctx)
+	defer cancel()
+	rounds, err := database.GetRecentRounds(ctx, s.db, sparklineRounds)
+	if err != nil {
+		fyne.LogError("failed to load recent rounds", err)
+		return
+	}
+	results := make([]database.Result, len(rounds))
+	for i, r := range rounds {
+		// Rounds come newest first; draw them oldest first.
+		results[len(rounds)-1-i] = r.Result()
+	}
+	s.results = results
+	s.Refresh()
+}
+
+// Tapped implements fyne.Tappable.
+func (s *Sparkline) Tapped(*fyne.PointEvent) {
+	if s.onTapped != nil {
+		s.onTapped()
+	}
+}
+
+// Cursor implements desktop.Cursorable so the strip reads as clickable.
+func (s *Sparkline) Cursor() desktop.Cursor {
+	return desktop.PointerCursor
+}
+
+func (s *Sparkline) CreateRenderer() fyne.WidgetRenderer {
+	return &sparklineRenderer{sparkline: s}
+}
+
+func (s *Sparkline) MinSize() fyne.Size {
+	return fyne.NewSize(sparklineRounds*(sparklineTickWidth+sparklineTickGap), 24)
+}
+
+const (
+	sparklineTickWidth = float32(6)
+	sparklineTickGap   = float32(4)
+)
+
+type sparklineRenderer struct {
+	sparkline *Sparkline
+	objects   []fyne.CanvasObject
+}
+
+func (r *sparklineRenderer) Destroy() {}
+
+func (r *sparklineRenderer) Layout(size fyne.Size) {
+	r.Refresh()
+}
+
+func (r *sparklineRenderer) MinSize() fyne.Size {
+	return r.sparkline.MinSize()
+}
+
+func (r *sparklineRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *sparklineRenderer) Refresh() {
+	s := r.sparkline
+	size := s.Size()
+	mid := size.Height / 2
+
+	win := winColor()
+	loss := lossColor()
+	drawColor := chartTextColor()
+
+	// Centre the strip horizontally within whatever space we're given.
+	stripWidth := float32(len(s.results)) * (sparklineTickWidth + sparklineTickGap)
+	x := (size.Width - stripWidth) / 2
+
+	var objects []fyne.CanvasObject
+	for _, res := range s.results {
+		var tick *canvas.Rectangle
+		switch res {
+		case database.ResultWin:
+			tick = canvas.NewRectangle(win)
+			tick.Resize(fyne.NewSize(sparklineTickWidth, mid))
+			tick.Move(fyne.NewPos(x, 0))
+		case database.ResultLoss:
+			tick = canvas.NewRectangle(loss)
+			tick.Resize(fyne.NewSize(sparklineTickWidth, mid))
+			tick.Move(fyne.NewPos(x, mid))
+		default:
+			tick = canvas.NewRectangle(drawColor)
+			tick.Resize(fyne.NewSize(sparklineTickWidth, 4))
+			tick.Move(fyne.NewPos(x, mid-2))
+		}
+		objects = append(objects, tick)
+		x += sparklineTickWidth + sparklineTickGap
+	}
+	r.objects = objects
+}
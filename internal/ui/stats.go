@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"image/color"
+	"slices"
+	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -13,6 +15,9 @@ import (
 
 	"csstatstracker/internal/config"
 	"csstatstracker/internal/database"
+	"csstatstracker/internal/records"
+	"csstatstracker/internal/snapshot"
+	"csstatstracker/internal/statsmath"
 )
 
 // AggregationInterval defines how to group stats in the chart
@@ -27,13 +32,19 @@ const (
 
 // StatsTab manages the statistics view
 type StatsTab struct {
+	ctx           context.Context // cancelled on app shutdown
 	db            *sql.DB
 	window        fyne.Window
 	cfg           *config.Config
 	onSave        func()
+	readOnly      bool // viewing a shared database: no editing rounds or ranks
 	currentWindow database.TimeWindow
 	aggregation   AggregationInterval
 	container     *fyne.Container
+	accountSelect *widget.Select
+	mapSelect     *widget.Select
+	windowSelect  *widget.Select
+	periodButtons *fyne.Container
 
 	// Sub-tabs
 	subTabs *container.AppTabs
@@ -52,19 +63,53 @@ type StatsTab struct {
 	tTimeLabel         *widget.Label
 	timeChartLabel     *widget.Label
 	timeChartContainer *fyne.Container
+	paceLabel          *widget.Label
+	slowGamesContainer *fyne.Container
+	paceChartLabel     *widget.Label
+	paceChartContainer *fyne.Container
+
+	// Party Size sub-tab
+	partyContainer *fyne.Container
+
+	// Moments sub-tab
+	momentsContainer *fyne.Container
+
+	// Halftime sub-tab
+	halftimeLabels         *fyne.Container
+	halftimeChartContainer *fyne.Container
+
+	// Draws sub-tab
+	drawsTab       *container.TabItem
+	drawsContainer *fyne.Container
+	onRoundsEdited func()
+
+	// Rank sub-tab
+	rankKind           database.RatingKind
+	rankChartContainer *fyne.Container
+	rankListContainer  *fyne.Container
+
+	// Compare sub-tab
+	keyPath          string
+	friend           *snapshot.Verified
+	compareContainer *fyne.Container
+	lastStats        *database.Stats
+	lastParties      []database.PartyStats
 }
 
 // secondsPerRound is our rough estimate for play-time calculations.
 // 1 minute 45 seconds per round.
 const secondsPerRound = 105
 
-// NewStatsTab creates a new statistics tab
-func NewStatsTab(db *sql.DB, window fyne.Window, cfg *config.Config, onSave func()) *StatsTab {
+// NewStatsTab creates a new statistics tab. keyPath is where the key used to
+// sign shared snapshots is kept.
+func NewStatsTab(ctx context.Context, db *sql.DB, window fyne.Window, cfg *config.Config, keyPath string, onSave func()) *StatsTab {
 	s := &StatsTab{
-		db:     db,
-		window: window,
-		cfg:    cfg,
-		onSave: onSave,
+		ctx:     ctx,
+		db:      db,
+		window:  window,
+		cfg:     cfg,
+		keyPath: keyPath,
+		onSave:  onSave,
 	}
 
 	s.currentWindow = s.periodToWindow(cfg.StatsPeriod)
@@ -73,17 +118,34 @@ func NewStatsTab(db *sql.DB, window fyne.Window, cfg *config.Config, onSave func
 	return s
 }
 
+// SetReadOnly hides the controls that record, edit or delete rounds and
+// ranks, for viewing a database another PC records to. Call it before
+// Container.
+func (s *StatsTab) SetReadOnly() {
+	s.readOnly = true
+}
+
 // periodToWindow converts a period string to TimeWindow
 func (s *StatsTab) periodToWindow(period string) database.TimeWindow {
 	switch period {
+	case "Today":
+		return database.WindowToday
 	case "Day":
 		return database.WindowDay
 	case "Week":
 		return database.WindowWeek
 	case "Month":
 		return database.WindowMonth
+	case "Season":
+		return database.WindowSeason
 	case "Year":
 		return database.WindowYear
+	case "Last 5 Rounds":
+		return database.WindowLast5
+	case "Last 10 Rounds":
+		return database.WindowLast10
+	case "Last 20 Rounds":
+		return database.WindowLast20
 	default:
 		return database.WindowAll
 	}
@@ -119,20 +181,56 @@ func (s *StatsTab) Container() fyne.CanvasObject {
 	s.tTimeLabel = widget.NewLabel("T Play Time: --")
 	s.timeChartLabel = widget.NewLabel("Play Time by Day:")
 	s.timeChartContainer = container.NewStack()
+	s.paceLabel = widget.NewLabel("Pace: --")
+	s.slowGamesContainer = container.NewVBox()
+	s.paceChartLabel = widget.NewLabel("Pace by Day:")
+	s.paceChartContainer = container.NewStack()
+
+	// Party Size sub-tab rows are rebuilt on every refresh
+	s.partyContainer = container.NewVBox()
+	s.momentsContainer = container.NewVBox()
+
+	// Account filter; options follow the configured accounts (see refresh).
+	// Created first: setting the other selectors below triggers a refresh.
+	s.accountSelect = widget.NewSelect(nil, func(selected string) {
+		account := accountFromOption(selected, allAccounts)
+		if account == s.cfg.StatsAccount {
+			return
+		}
+		s.cfg.StatsAccount = account
+		if s.onSave != nil {
+			s.onSave()
+		}
+		s.refresh()
+	})
+	// Map filter; options are the maps in History (see refresh).
+	s.mapSelect = widget.NewSelect(nil, func(selected string) {
+		mapName := selected
+		if selected == allMaps {
+			mapName = ""
+		}
+		if mapName == s.cfg.StatsMap {
+			return
+		}
+		s.cfg.StatsMap = mapName
+		if s.onSave != nil {
+			s.onSave()
+		}
+		s.refresh()
+	})
 
-	// Time window selector
-	windowSelect := widget.NewSelect(
-		[]string{"Day", "Week", "Month", "Year", "All Time"},
-		func(selected string) {
-			s.currentWindow = s.periodToWindow(selected)
-			s.cfg.StatsPeriod = selected
-			if s.onSave != nil {
-				s.onSave()
-			}
-			s.refresh()
-		},
+	// Time window selector, with one-click buttons for the common periods
+	s.windowSelect = widget.NewSelect(
+		[]string{"Today", "Day", "Week", "Month", "Season", "Year", "All Time", "Last 5 Rounds", "Last 10 Rounds", "Last 20 Rounds"},
+		s.setPeriod,
 	)
-	windowSelect.SetSelected(s.cfg.StatsPeriod)
+	s.windowSelect.Selected = s.cfg.StatsPeriod
+	s.periodButtons = container.NewHBox()
+	for _, q := range quickPeriods {
+		button := widget.NewButton(q.label, func() { s.setPeriod(q.period) })
+		s.periodButtons.Add(button)
+	}
+	s.updatePeriodButtons()
 
 	// Aggregation selector
 	aggregationSelect := widget.NewSelect(
@@ -149,20 +247,25 @@ func (s *StatsTab) Container() fyne.CanvasObject {
 	)
 	aggregationSelect.SetSelected(s.cfg.StatsGroup)
 
-	// Shared controls (Period and Group)
+	// Shared controls (Period, Group, Account and Map)
 	controlsPanel := container.NewHBox(
 		widget.NewLabel("Period:"),
-		windowSelect,
+		s.periodButtons,
+		s.windowSelect,
 		widget.NewLabel("Group:"),
 		aggregationSelect,
+		widget.NewLabel("Account:"),
+		s.accountSelect,
+		widget.NewLabel("Map:"),
+		s.mapSelect,
 	)
 
 	// Win Rate sub-tab content
 	winRateContent := container.NewBorder(
 		container.NewVBox(
 			widget.NewSeparator(),
-			s.countLabel,
-			s.winRateLabel,
+			withHint(s.countLabel, helpDraws),
+			withHint(s.winRateLabel, helpWinRate),
 			widget.NewSeparator(),
 			widget.NewLabel("Win Rate by Team:"),
 			s.ctWinRateLabel,
@@ -184,17 +287,47 @@ func (s *StatsTab) Container() fyne.CanvasObject {
 			s.ctTimeLabel,
 			s.tTimeLabel,
 			widget.NewSeparator(),
-			s.timeChartLabel,
+			s.paceLabel,
+			s.slowGamesContainer,
+			widget.NewSeparator(),
 		),
 		nil, nil, nil,
-		s.timeChartContainer,
+		container.NewGridWithRows(2,
+			container.NewBorder(s.timeChartLabel, nil, nil, nil, s.timeChartContainer),
+			container.NewBorder(s.paceChartLabel, nil, nil, nil, s.paceChartContainer),
+		),
 	)
 
+	// Party Size sub-tab content
+	partyContent := container.NewVScroll(container.NewVBox(
+		widget.NewSeparator(),
+		widget.NewLabel("Win Rate by Party Size:"),
+		s.partyContainer,
+	))
+
+	// Moments sub-tab content
+	momentsContent := container.NewVScroll(container.NewVBox(
+		widget.NewSeparator(),
+		widget.NewLabel("Moments from Game State Integration:"),
+		s.momentsContainer,
+	))
+
 	// Create sub-tabs
 	s.subTabs = container.NewAppTabs(
 		container.NewTabItem("Win Rate", winRateContent),
 		container.NewTabItem("Play Time", playTimeContent),
+		container.NewTabItem("Party Size", partyContent),
+		container.NewTabItem("Moments", momentsContent),
+		container.NewTabItem("Halftime", s.buildHalftimeContent()),
+		s.buildDrawsTab(),
+		container.NewTabItem("Rank", s.buildRankContent()),
+		container.NewTabItem("Compare", s.buildCompareContent()),
 	)
+	s.subTabs.OnSelected = func(tab *container.TabItem) {
+		if period, ok := s.cfg.StatsPeriods[tabName(tab)]; ok {
+			s.setPeriod(period)
+		}
+	}
 
 	// Main container with controls at top and sub-tabs below
 	s.container = container.NewBorder(
@@ -207,6 +340,64 @@ func (s *StatsTab) Container() fyne.CanvasObject {
 	return s.container
 }
 
+// quickPeriods are the periods offered as buttons next to the period
+// dropdown.
+var quickPeriods = []struct{ label, period string }{
+	{"Today", "Today"},
+	{"7d", "Week"},
+	{"30d", "Month"},
+	{"Season", "Season"},
+	{"All", "All Time"},
+}
+
+// setPeriod switches the stats to period and remembers it as the choice for
+// the selected sub-tab, so switching back to that sub-tab restores it.
+func (s *StatsTab) setPeriod(period string) {
+	changed := period != s.cfg.StatsPeriod
+	if tab := s.subTabs.Selected(); tab != nil && s.cfg.StatsPeriods[tabName(tab)] != period {
+		if s.cfg.StatsPeriods == nil {
+			s.cfg.StatsPeriods = make(map[string]string)
+		}
+		s.cfg.StatsPeriods[tabName(tab)] = period
+		changed = true
+	}
+	if !changed {
+		return
+	}
+	if s.onSave != nil {
+		s.onSave()
+	}
+	if period == s.cfg.StatsPeriod {
+		return
+	}
+	s.currentWindow = s.periodToWindow(period)
+	s.cfg.StatsPeriod = period
+	s.windowSelect.SetSelected(period)
+	s.updatePeriodButtons()
+	s.refresh()
+}
+
+// tabName is a sub-tab's title without the count some carry, e.g. "Draws"
+// for "Draws (12)".
+func tabName(tab *container.TabItem) string {
+	name, _, _ := strings.Cut(tab.Text, " (")
+	return name
+}
+
+// updatePeriodButtons highlights the button of the current period, if it has
+// one.
+func (s *StatsTab) updatePeriodButtons() {
+	for i, obj := range s.periodButtons.Objects {
+		button := obj.(*widget.Button)
+		if quickPeriods[i].period == s.cfg.StatsPeriod {
+			button.Importance = widget.HighImportance
+		} else {
+			button.Importance = widget.MediumImportance
+		}
+		button.Refresh()
+	}
+}
+
 func (s *StatsTab) updateChartLabels() {
 	bucket := "Day"
 	switch s.aggregation {
@@ -219,6 +410,7 @@ func (s *StatsTab) updateChartLabels() {
 	}
 	s.chartLabel.SetText(fmt.Sprintf("Net Wins/Losses by %s:", bucket))
 	s.timeChartLabel.SetText(fmt.Sprintf("Play Time by %s:", bucket))
+	s.paceChartLabel.SetText(fmt.Sprintf("Pace by %s:", bucket))
 }
 
 // Refresh reloads statistics from database
@@ -226,16 +418,49 @@ func (s *StatsTab) Refresh() {
 	s.refresh()
 }
 
+// allAccounts is the account filter option that disables filtering.
+const allAccounts = "All Accounts"
+
+// allMaps is the map filter option that disables filtering.
+const allMaps = "All Maps"
+
 func (s *StatsTab) refresh() {
-	ctx := context.Background()
+	ctx, cancel := database.WithTimeout(s.ctx)
+	defer cancel()
+
+	s.accountSelect.Options = accountNames(s.cfg, allAccounts, s.cfg.StatsAccount)
+	s.accountSelect.Selected = accountOption(s.cfg.StatsAccount, allAccounts)
+	s.accountSelect.Refresh()
 
-	stats, err := database.GetStats(ctx, s.db, s.currentWindow)
+	maps, _ := database.GetMapNames(ctx, s.db) // without them only All Maps is offered
+	if s.cfg.StatsMap != "" && !slices.Contains(maps, s.cfg.StatsMap) {
+		maps = append(maps, s.cfg.StatsMap)
+	}
+	s.mapSelect.Options = append([]string{allMaps}, maps...)
+	s.mapSelect.Selected = allMaps
+	if s.cfg.StatsMap != "" {
+		s.mapSelect.Selected = s.cfg.StatsMap
+	}
+	s.mapSelect.Refresh()
+
+	filter := database.Filter{Window: s.currentWindow, Account: s.cfg.StatsAccount, Map: s.cfg.StatsMap}
+	stats, err := database.GetStats(ctx, s.db, filter)
 	if err != nil {
 		s.winRateLabel.SetText("Error loading stats")
 		s.totalTimeLabel.SetText("Error loading stats")
 		return
 	}
-	daily, err := database.GetDailyStats(ctx, s.db, s.currentWindow)
+	daily, err := database.GetDailyStats(ctx, s.db, filter)
+	if err != nil {
+		s.winRateLabel.SetText("Error loading stats")
+		return
+	}
+	parties, err := database.GetPartyStats(ctx, s.db, filter)
+	if err != nil {
+		s.winRateLabel.SetText("Error loading stats")
+		return
+	}
+	moments, err := database.GetMomentCounts(ctx, s.db, filter)
 	if err != nil {
 		s.winRateLabel.SetText("Error loading stats")
 		return
@@ -244,11 +469,14 @@ func (s *StatsTab) refresh() {
 	// Win Rate labels — everything is round-scoped now.
 	s.countLabel.SetText(fmt.Sprintf("Rounds: %d (W:%d L:%d D:%d)",
 		stats.TotalRounds, stats.Wins, stats.Losses, stats.Draws))
-	s.winRateLabel.SetText(fmt.Sprintf("Win Rate: %.1f%%", stats.WinRate))
-	s.ctWinRateLabel.SetText(fmt.Sprintf("CT: %.1f%% (%d/%d rounds)",
-		stats.CTWinRate, stats.CTWins, stats.CTRounds))
-	s.tWinRateLabel.SetText(fmt.Sprintf("T: %.1f%% (%d/%d rounds)",
-		stats.TWinRate, stats.TWins, stats.TRounds))
+	s.setRate(s.winRateLabel, fmt.Sprintf("Win Rate: %.1f%%%s",
+		stats.WinRate, formatMargin(stats.Wins, stats.TotalRounds)), stats.TotalRounds)
+	s.setRate(s.ctWinRateLabel, fmt.Sprintf("CT: %.1f%%%s (%d/%d rounds)",
+		stats.CTWinRate, formatMargin(stats.CTWins, stats.CTRounds),
+		stats.CTWins, stats.CTRounds), stats.CTRounds)
+	s.setRate(s.tWinRateLabel, fmt.Sprintf("T: %.1f%%%s (%d/%d rounds)",
+		stats.TWinRate, formatMargin(stats.TWins, stats.TRounds),
+		stats.TWins, stats.TRounds), stats.TRounds)
 
 	// Play Time: estimated at secondsPerRound per round.
 	totalMinutes := stats.TotalRounds * secondsPerRound / 60
@@ -261,7 +489,47 @@ func (s *StatsTab) refresh() {
 	s.tTimeLabel.SetText(fmt.Sprintf("T: %s (%d rounds)",
 		formatPlayTime(tMinutes), stats.TRounds))
 
+	// Party Size: one win-rate line per party size that has rounds.
+	partyRows := make([]fyne.CanvasObject, 0, len(parties))
+	for _, p := range parties {
+		label := widget.NewLabel("")
+		s.setRate(label, fmt.Sprintf("%s: %.1f%%%s (W:%d L:%d D:%d)",
+			p.Party, p.WinRate, formatMargin(p.Wins, p.TotalRounds),
+			p.Wins, p.Losses, p.Draws), p.TotalRounds)
+		partyRows = append(partyRows, label)
+	}
+	if len(partyRows) == 0 {
+		partyRows = append(partyRows, widget.NewLabel("No rounds in this period"))
+	}
+	s.partyContainer.Objects = partyRows
+	s.partyContainer.Refresh()
+
+	// Moments: one count per kind.
+	momentRows := make([]fyne.CanvasObject, 0, len(database.MomentKinds))
+	for _, kind := range database.MomentKinds {
+		momentRows = append(momentRows, widget.NewLabel(fmt.Sprintf("%s: %d", kind.Label(), moments[kind])))
+	}
+	s.momentsContainer.Objects = momentRows
+	s.momentsContainer.Refresh()
+
+	s.refreshRanks()
+	s.refreshDraws()
+
+	s.lastStats, s.lastParties = stats, parties
+	s.refreshCompare()
+
 	aggregated := s.aggregateStats(daily)
+	if rounds, err := database.GetAllRounds(ctx, s.db); err == nil {
+		if s.cfg.StatsAccount != "" || s.cfg.StatsMap != "" {
+			rounds = slices.DeleteFunc(rounds, func(r database.Round) bool {
+				return (s.cfg.StatsAccount != "" && r.Account != s.cfg.StatsAccount) ||
+					(s.cfg.StatsMap != "" && r.Map != s.cfg.StatsMap)
+			})
+		}
+		s.annotate(aggregated, records.Compute(rounds, s.cfg.MinSampleSize))
+		s.refreshHalftime(rounds)
+		s.refreshPace(rounds)
+	}
 	chart := s.buildChart(aggregated)
 	s.chartContainer.Objects = []fyne.CanvasObject{chart}
 	s.chartContainer.Refresh()
@@ -271,6 +539,28 @@ func (s *StatsTab) refresh() {
 	s.timeChartContainer.Refresh()
 }
 
+// setRate shows a win-rate line, greying it out and flagging it when it's
+// based on fewer rounds than the configured minimum sample size so a single
+// won round doesn't read as a convincing 100%.
+func (s *StatsTab) setRate(label *widget.Label, text string, sample int) {
+	if sample > 0 && sample < s.cfg.MinSampleSize {
+		label.Importance = widget.LowImportance
+		text += fmt.Sprintf(" — low sample (<%d)", s.cfg.MinSampleSize)
+	} else {
+		label.Importance = widget.MediumImportance
+	}
+	label.SetText(text)
+}
+
+// formatMargin renders the 95% Wilson interval half-width as " ±6.1", or
+// nothing when there are no rounds to estimate from.
+func formatMargin(wins, rounds int) string {
+	if rounds == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" ±%.1f", statsmath.WilsonMargin(wins, rounds, statsmath.Z95))
+}
+
 // formatPlayTime converts minutes to a readable format (hours and minutes, or days/hours for large values)
 func formatPlayTime(minutes int) string {
 	if minutes < 60 {
@@ -298,9 +588,82 @@ func formatPlayTime(minutes int) string {
 
 // AggregatedStats holds aggregated win/loss data for a period
 type AggregatedStats struct {
+	Key    string // bucket identity, see bucketKey
 	Label  string
 	Wins   int
 	Losses int
+
+	// Personal-best annotations drawn above the bucket's bar in the win/loss
+	// and play-time charts respectively.
+	Marks     []string
+	TimeMarks []string
+}
+
+// bucketKey returns the key of the aggregation bucket t falls in. Buckets
+// are cut in UTC, like the dates GetDailyStats returns.
+func (s *StatsTab) bucketKey(t time.Time) string {
+	t = t.UTC()
+	switch s.aggregation {
+	case AggregateByWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	case AggregateByMonth:
+		return t.Format("2006-01")
+	case AggregateByYear:
+		return t.Format("2006")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+// bucketLabel returns the chart label of the aggregation bucket t falls in,
+// matching the labels of the win/loss chart's buckets.
+func (s *StatsTab) bucketLabel(t time.Time) string {
+	t = t.UTC()
+	switch s.aggregation {
+	case AggregateByWeek:
+		_, week := t.ISOWeek()
+		return fmt.Sprintf("W%02d", week)
+	case AggregateByMonth:
+		return t.Format("Jan")
+	case AggregateByYear:
+		return t.Format("2006")
+	default:
+		return t.Format("01/02")
+	}
+}
+
+// annotate marks the buckets holding personal bests.
+func (s *StatsTab) annotate(buckets []AggregatedStats, recs records.Records) {
+	// A week's mark goes on the first of its buckets, which By Day is the
+	// Monday's only if a round was played then.
+	markWeek := func(w *records.Week, text string) {
+		first, last := s.bucketKey(w.Start), s.bucketKey(w.Start.AddDate(0, 0, 6))
+		at := -1
+		for i, b := range buckets {
+			if b.Key >= first && b.Key <= last && (at < 0 || b.Key < buckets[at].Key) {
+				at = i
+			}
+		}
+		if at >= 0 {
+			buckets[at].Marks = append(buckets[at].Marks, text)
+		}
+	}
+	if w := recs.BestWeek; w != nil {
+		markWeek(w, fmt.Sprintf("Best week %+d", w.Wins-w.Losses))
+	}
+	if w := recs.BestWinRateWeek; w != nil {
+		markWeek(w, fmt.Sprintf("Best week %.0f%%", w.WinRate))
+	}
+	if l := recs.LongestSession; l != nil {
+		key := s.bucketKey(l.Start)
+		for i := range buckets {
+			if buckets[i].Key == key {
+				buckets[i].TimeMarks = append(buckets[i].TimeMarks,
+					"Longest session "+formatPlayTime(int(l.Duration().Minutes())))
+			}
+		}
+	}
 }
 
 func (s *StatsTab) aggregateStats(dailyStats []database.DailyStats) []AggregatedStats {
@@ -324,6 +687,7 @@ func (s *StatsTab) aggregateByDay(dailyStats []database.DailyStats) []Aggregated
 	result := make([]AggregatedStats, len(dailyStats))
 	for i, ds := range dailyStats {
 		result[i] = AggregatedStats{
+			Key:    s.bucketKey(ds.Date),
 			Label:  ds.Date.Format("01/02"),
 			Wins:   ds.Wins,
 			Losses: ds.Losses,
@@ -337,11 +701,11 @@ func (s *StatsTab) aggregateByWeek(dailyStats []database.DailyStats) []Aggregate
 	var weekOrder []string
 
 	for _, ds := range dailyStats {
-		year, week := ds.Date.ISOWeek()
-		key := fmt.Sprintf("%d-W%02d", year, week)
+		_, week := ds.Date.ISOWeek()
+		key := s.bucketKey(ds.Date)
 
 		if _, exists := weekMap[key]; !exists {
-			weekMap[key] = &AggregatedStats{Label: fmt.Sprintf("W%02d", week)}
+			weekMap[key] = &AggregatedStats{Key: key, Label: fmt.Sprintf("W%02d", week)}
 			weekOrder = append(weekOrder, key)
 		}
 		weekMap[key].Wins += ds.Wins
@@ -360,11 +724,11 @@ func (s *StatsTab) aggregateByMonth(dailyStats []database.DailyStats) []Aggregat
 	var monthOrder []string
 
 	for _, ds := range dailyStats {
-		key := ds.Date.Format("2006-01")
+		key := s.bucketKey(ds.Date)
 		label := ds.Date.Format("Jan")
 
 		if _, exists := monthMap[key]; !exists {
-			monthMap[key] = &AggregatedStats{Label: label}
+			monthMap[key] = &AggregatedStats{Key: key, Label: label}
 			monthOrder = append(monthOrder, key)
 		}
 		monthMap[key].Wins += ds.Wins
@@ -383,10 +747,10 @@ func (s *StatsTab) aggregateByYear(dailyStats []database.DailyStats) []Aggregate
 	var yearOrder []string
 
 	for _, ds := range dailyStats {
-		key := ds.Date.Format("2006")
+		key := s.bucketKey(ds.Date)
 
 		if _, exists := yearMap[key]; !exists {
-			yearMap[key] = &AggregatedStats{Label: key}
+			yearMap[key] = &AggregatedStats{Key: key, Label: key}
 			yearOrder = append(yearOrder, key)
 		}
 		yearMap[key].Wins += ds.Wins
@@ -407,29 +771,19 @@ func (s *StatsTab) buildChart(stats []AggregatedStats) fyne.CanvasObject {
 		return container.NewCenter(noDataLabel)
 	}
 
-	// Calculate net values and find max absolute value for scaling
+	labels := make([]string, len(stats))
 	netValues := make([]int, len(stats))
-	maxAbs := 1
+	marks := make([][]string, len(stats))
 	for i, st := range stats {
+		labels[i] = st.Label
 		netValues[i] = st.Wins - st.Losses
-		abs := netValues[i]
-		if abs < 0 {
-			abs = -abs
-		}
-		if abs > maxAbs {
-			maxAbs = abs
-		}
+		marks[i] = st.Marks
 	}
 
-	// Colors
-	winColor := color.RGBA{R: 76, G: 175, B: 80, A: 255}  // Green
-	lossColor := color.RGBA{R: 244, G: 67, B: 54, A: 255} // Red
-	zeroLineColor := color.Gray{Y: 100}
-
 	// Legend
-	legendWinBox := canvas.NewRectangle(winColor)
+	legendWinBox := canvas.NewRectangle(winColor())
 	legendWinBox.SetMinSize(fyne.NewSize(12, 12))
-	legendLossBox := canvas.NewRectangle(lossColor)
+	legendLossBox := canvas.NewRectangle(lossColor())
 	legendLossBox.SetMinSize(fyne.NewSize(12, 12))
 
 	legend := container.NewHBox(
@@ -440,20 +794,13 @@ func (s *StatsTab) buildChart(stats []AggregatedStats) fyne.CanvasObject {
 		widget.NewLabel("Net Losses"),
 	)
 
-	// Create a custom scalable chart widget
-	chart := &scalableChart{
-		stats:         stats,
-		netValues:     netValues,
-		maxAbs:        maxAbs,
-		winColor:      winColor,
-		lossColor:     lossColor,
-		zeroLineColor: zeroLineColor,
-	}
-	chart.ExtendBaseWidget(chart)
-
-	scrollable := container.NewHScroll(chart)
+	chart := newBarChart(labels, netValues, formatNet)
+	chart.diverging = true
+	chart.posColor = winColor
+	chart.negColor = lossColor
+	chart.marks = marks
 
-	return container.NewBorder(nil, legend, nil, nil, scrollable)
+	return chart.withControls(legend)
 }
 
 func (s *StatsTab) buildTimeChart(stats []AggregatedStats) fyne.CanvasObject {
@@ -465,21 +812,18 @@ func (s *StatsTab) buildTimeChart(stats []AggregatedStats) fyne.CanvasObject {
 
 	// Play time per bucket is derived from the number of rounds played that
 	// day (wins + losses — draws are rounds with no team).
+	labels := make([]string, len(stats))
 	timeValues := make([]int, len(stats))
-	maxTime := 1
+	marks := make([][]string, len(stats))
 	for i, st := range stats {
+		labels[i] = st.Label
 		totalRounds := st.Wins + st.Losses
 		timeValues[i] = totalRounds * secondsPerRound / 60
-		if timeValues[i] > maxTime {
-			maxTime = timeValues[i]
-		}
+		marks[i] = st.TimeMarks
 	}
 
-	// Color for time bars
-	timeColor := color.RGBA{R: 33, G: 150, B: 243, A: 255} // Blue
-
 	// Legend
-	legendBox := canvas.NewRectangle(timeColor)
+	legendBox := canvas.NewRectangle(timeColor())
 	legendBox.SetMinSize(fyne.NewSize(12, 12))
 
 	legend := container.NewHBox(
@@ -487,249 +831,9 @@ func (s *StatsTab) buildTimeChart(stats []AggregatedStats) fyne.CanvasObject {
 		widget.NewLabel("Play Time"),
 	)
 
-	// Create a custom scalable time chart widget
-	chart := &scalableTimeChart{
-		stats:      stats,
-		timeValues: timeValues,
-		maxTime:    maxTime,
-		timeColor:  timeColor,
-	}
-	chart.ExtendBaseWidget(chart)
-
-	scrollable := container.NewHScroll(chart)
-
-	return container.NewBorder(nil, legend, nil, nil, scrollable)
-}
-
-// scalableChart is a custom widget that scales with available space
-type scalableChart struct {
-	widget.BaseWidget
-	stats         []AggregatedStats
-	netValues     []int
-	maxAbs        int
-	winColor      color.Color
-	lossColor     color.Color
-	zeroLineColor color.Color
-}
-
-func (c *scalableChart) CreateRenderer() fyne.WidgetRenderer {
-	return &scalableChartRenderer{chart: c}
-}
-
-func (c *scalableChart) MinSize() fyne.Size {
-	barWidth := float32(40)
-	spacing := float32(10)
-	totalWidth := float32(len(c.stats)) * (barWidth + spacing)
-	if totalWidth < 300 {
-		totalWidth = 300
-	}
-	return fyne.NewSize(totalWidth, 150)
-}
-
-type scalableChartRenderer struct {
-	chart   *scalableChart
-	objects []fyne.CanvasObject
-}
-
-func (r *scalableChartRenderer) Destroy() {}
-
-func (r *scalableChartRenderer) Layout(size fyne.Size) {
-	r.Refresh()
-}
-
-func (r *scalableChartRenderer) MinSize() fyne.Size {
-	return r.chart.MinSize()
-}
-
-func (r *scalableChartRenderer) Objects() []fyne.CanvasObject {
-	return r.objects
-}
-
-func (r *scalableChartRenderer) Refresh() {
-	c := r.chart
-	size := c.Size()
-
-	// Chart dimensions - scale with available height
-	labelHeight := float32(15)
-	chartHeight := size.Height - labelHeight
-	if chartHeight < 60 {
-		chartHeight = 60
-	}
-	halfHeight := chartHeight / 2
-	barWidth := float32(40)
-	spacing := float32(10)
-
-	var bars []fyne.CanvasObject
-
-	// Draw zero line
-	totalWidth := float32(len(c.stats)) * (barWidth + spacing)
-	if totalWidth < size.Width {
-		totalWidth = size.Width
-	}
-	zeroLine := canvas.NewLine(c.zeroLineColor)
-	zeroLine.Position1 = fyne.NewPos(0, halfHeight)
-	zeroLine.Position2 = fyne.NewPos(totalWidth, halfHeight)
-	zeroLine.StrokeWidth = 1
-	bars = append(bars, zeroLine)
-
-	for i, st := range c.stats {
-		xOffset := float32(i) * (barWidth + spacing)
-		net := c.netValues[i]
-
-		// Track the bottom of the bar for label positioning
-		barBottom := halfHeight // Default: at zero line
-
-		if net != 0 {
-			// Calculate bar height proportional to max value
-			barHeight := float32(net) / float32(c.maxAbs) * halfHeight
-			if barHeight < 0 {
-				barHeight = -barHeight
-			}
-			// Minimum visible height
-			if barHeight < 3 {
-				barHeight = 3
-			}
-
-			var bar *canvas.Rectangle
-			var yPos float32
-
-			if net > 0 {
-				bar = canvas.NewRectangle(c.winColor)
-				yPos = halfHeight - barHeight
-				barBottom = halfHeight // Bar ends at zero line
-			} else {
-				bar = canvas.NewRectangle(c.lossColor)
-				yPos = halfHeight
-				barBottom = halfHeight + barHeight // Bar ends below zero line
-			}
-
-			bar.Resize(fyne.NewSize(barWidth, barHeight))
-			bar.Move(fyne.NewPos(xOffset, yPos))
-			bars = append(bars, bar)
-
-			// Net value label on bar
-			labelText := fmt.Sprintf("%+d", net)
-			netLabel := canvas.NewText(labelText, color.White)
-			netLabel.TextSize = 10
-			netLabel.Alignment = fyne.TextAlignCenter
-
-			// Set text size to bar width and center it
-			textSize := netLabel.MinSize()
-			netLabel.Resize(fyne.NewSize(barWidth, textSize.Height))
-			labelX := xOffset
-			labelY := yPos + (barHeight-textSize.Height)/2
-			netLabel.Move(fyne.NewPos(labelX, labelY))
-			bars = append(bars, netLabel)
-		}
-
-		// Period label directly below the bar
-		dateLabel := canvas.NewText(st.Label, color.Gray{Y: 150})
-		dateLabel.TextSize = 10
-		dateLabel.Move(fyne.NewPos(xOffset, barBottom+2))
-		bars = append(bars, dateLabel)
-	}
-
-	r.objects = bars
-}
-
-// scalableTimeChart is a custom widget for displaying play time
-type scalableTimeChart struct {
-	widget.BaseWidget
-	stats      []AggregatedStats
-	timeValues []int
-	maxTime    int
-	timeColor  color.Color
-}
-
-func (c *scalableTimeChart) CreateRenderer() fyne.WidgetRenderer {
-	return &scalableTimeChartRenderer{chart: c}
-}
-
-func (c *scalableTimeChart) MinSize() fyne.Size {
-	barWidth := float32(40)
-	spacing := float32(10)
-	totalWidth := float32(len(c.stats)) * (barWidth + spacing)
-	if totalWidth < 300 {
-		totalWidth = 300
-	}
-	return fyne.NewSize(totalWidth, 150)
-}
-
-type scalableTimeChartRenderer struct {
-	chart   *scalableTimeChart
-	objects []fyne.CanvasObject
-}
-
-func (r *scalableTimeChartRenderer) Destroy() {}
-
-func (r *scalableTimeChartRenderer) Layout(size fyne.Size) {
-	r.Refresh()
-}
-
-func (r *scalableTimeChartRenderer) MinSize() fyne.Size {
-	return r.chart.MinSize()
-}
-
-func (r *scalableTimeChartRenderer) Objects() []fyne.CanvasObject {
-	return r.objects
-}
-
-func (r *scalableTimeChartRenderer) Refresh() {
-	c := r.chart
-	size := c.Size()
-
-	// Chart dimensions
-	labelHeight := float32(15)
-	chartHeight := size.Height - labelHeight
-	if chartHeight < 60 {
-		chartHeight = 60
-	}
-	barWidth := float32(40)
-	spacing := float32(10)
-
-	var bars []fyne.CanvasObject
-
-	for i, st := range c.stats {
-		xOffset := float32(i) * (barWidth + spacing)
-		timeMinutes := c.timeValues[i]
-
-		if timeMinutes > 0 {
-			// Calculate bar height proportional to max value
-			barHeight := float32(timeMinutes) / float32(c.maxTime) * chartHeight
-			// Minimum visible height
-			if barHeight < 3 {
-				barHeight = 3
-			}
-
-			// Bar grows upward from bottom
-			yPos := chartHeight - barHeight
-
-			bar := canvas.NewRectangle(c.timeColor)
-			bar.Resize(fyne.NewSize(barWidth, barHeight))
-			bar.Move(fyne.NewPos(xOffset, yPos))
-			bars = append(bars, bar)
-
-			// Time label on bar
-			labelText := formatPlayTime(timeMinutes)
-			timeLabel := canvas.NewText(labelText, color.White)
-			timeLabel.TextSize = 10
-			timeLabel.Alignment = fyne.TextAlignCenter
-
-			// Set text size to bar width and center it
-			textSize := timeLabel.MinSize()
-			timeLabel.Resize(fyne.NewSize(barWidth, textSize.Height))
-			labelX := xOffset
-			labelY := yPos + (barHeight-textSize.Height)/2
-			timeLabel.Move(fyne.NewPos(labelX, labelY))
-			bars = append(bars, timeLabel)
-		}
-
-		// Period label below the bar
-		dateLabel := canvas.NewText(st.Label, color.Gray{Y: 150})
-		dateLabel.TextSize = 10
-		dateLabel.Move(fyne.NewPos(xOffset, chartHeight+2))
-		bars = append(bars, dateLabel)
-	}
+	chart := newBarChart(labels, timeValues, formatPlayTime)
+	chart.posColor = timeColor
+	chart.marks = marks
 
-	r.objects = bars
+	return chart.withControls(legend)
 }
@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/sound"
+)
+
+// SetTestStinger sets what the stinger play buttons run, e.g. the tracker's
+// player's TestStinger.
+func (s *SettingsTab) SetTestStinger(test func(sound.Stinger, string) error) {
+	s.testStinger = test
+	s.Reload()
+}
+
+// buildStingersSection creates the Settings editor for the music played
+// when a match is won or lost.
+func (s *SettingsTab) buildStingersSection() fyne.CanvasObject {
+	st := &s.cfg.Stingers
+
+	volumeLabel := widget.NewLabel(fmt.Sprintf("Music volume: %d%%", int(st.Volume*100)))
+	volumeSlider := widget.NewSlider(0, 1)
+	volumeSlider.Step = 0.05
+	volumeSlider.Value = st.Volume
+	volumeSlider.OnChanged = func(val float64) {
+		st.Volume = val
+		volumeLabel.SetText(fmt.Sprintf("Music volume: %d%%", int(val*100)))
+		s.save()
+	}
+
+	winRow := s.stingerRow(sound.WinStingers, &st.Win, &st.WinFile)
+	loseRow := s.stingerRow(sound.LoseStingers, &st.Lose, &st.LoseFile)
+	form := widget.NewForm(
+		widget.NewFormItem("Match won", winRow),
+		widget.NewFormItem("Match lost", loseRow),
+	)
+
+	enabledCheck := widget.NewCheck("Play music when a match is won or lost", func(enabled bool) {
+		st.Enabled = enabled
+		s.save()
+	})
+	enabledCheck.Checked = st.Enabled
+
+	return container.NewVBox(
+		withHint(enabledCheck, "Recording the next round, taking one back or starting a new match cuts it short."),
+		container.NewBorder(nil, nil, volumeLabel, nil, volumeSlider),
+		form,
+	)
+}
+
+// stingerRow creates the picker for one stinger: a bundled one from
+// stingers, saved to key, or a sound file saved to file, and a button that
+// plays whichever is picked.
+func (s *SettingsTab) stingerRow(stingers []sound.Stinger, key, file *string) fyne.CanvasObject {
+	labels := make([]string, len(stingers))
+	for i, st := range stingers {
+		labels[i] = st.Label
+	}
+	bundled := widget.NewSelect(labels, func(label string) {
+		for _, st := range stingers {
+			if st.Label == label && st.Key != *key {
+				*key = st.Key
+				s.save()
+			}
+		}
+	})
+	bundled.Selected = sound.FindStinger(stingers, *key).Label
+
+	custom := s.newFilePicker(*file, "Bundled", sound.Extensions, nil, func(path string) {
+		*file = path
+		s.save()
+	})
+
+	play := widget.NewButtonWithIcon("", theme.MediaPlayIcon(), func() {
+		test := s.testStinger
+		stinger, path := sound.FindStinger(stingers, *key), *file
+		go func() {
+			if err := test(stinger, path); err != nil {
+				fyne.Do(func() {
+					dialog.ShowError(fmt.Errorf("failed to play stinger: %w", err), s.window)
+				})
+			}
+		}()
+	})
+	if s.testStinger == nil {
+		play.Disable()
+	}
+	return container.NewBorder(nil, nil, nil, play, container.NewGridWithColumns(2, bundled, custom))
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/toast"
+)
+
+// buildToastsSection creates the Settings editor for the notifications that
+// ask who won a round. It's empty where they aren't supported.
+func (s *SettingsTab) buildToastsSection() fyne.CanvasObject {
+	if !toast.Supported {
+		return container.NewVBox()
+	}
+	n := &s.cfg.Toasts
+
+	roundEndCheck := widget.NewCheck("Ask when game state reports a round over", func(enabled bool) {
+		n.Enabled = enabled
+		s.save()
+	})
+	roundEndCheck.Checked = n.Enabled
+
+	intervalEntry := NewIntEntry(n.IntervalMinutes, 0, math.MaxInt32, func(v int) {
+		n.IntervalMinutes = v
+		s.save()
+	})
+
+	return container.NewVBox(
+		widget.NewSeparator(),
+		widget.NewLabel("Round Notifications (record a round from its buttons)"),
+		roundEndCheck,
+		container.NewHBox(
+			widget.NewLabel("Also ask every"),
+			intervalEntry,
+			widget.NewLabel("min (0 for never)"),
+		),
+	)
+}
@@ -0,0 +1,498 @@
+package ui
+
+import (
+	"image/color"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/tracker"
+)
+
+// NewCounterLabels creates the big CT and T score labels a tracker draws into.
+func NewCounterLabels() (ctLabel, tLabel *canvas.Text) {
+	ctLabel = canvas.NewText("0", ctColor)
+	ctLabel.TextSize = 72
+	ctLabel.Alignment = fyne.TextAlignCenter
+
+	tLabel = canvas.NewText("0", tColor)
+	tLabel.TextSize = 72
+	tLabel.Alignment = fyne.TextAlignCenter
+	return ctLabel, tLabel
+}
+
+// TrackerView shows one tracker's counters with their +/- buttons, the team
+// selector and the swap button.
+type TrackerView struct {
+	tracker   *tracker.Tracker
+	window    fyne.Window
+	container fyne.CanvasObject
+	ctTitle   *canvas.Text
+	tTitle    *canvas.Text
+	ctLogo    *canvas.Image
+	tLogo     *canvas.Image
+	ctLabel   *canvas.Text
+	tLabel    *canvas.Text
+
+	// Touch mode: spacers that make the +/- buttons tall, and the counters,
+	// which take swipes.
+	buttonSpacers []*canvas.Rectangle
+	counters      []*counterArea
+
+	// Mini mode: what's hidden to leave just the counters, and the button
+	// that opens the controls as a drawer.
+	fullOnly     []fyne.CanvasObject
+	controls     fyne.CanvasObject
+	drawerButton *widget.Button
+
+	// OnRenamed is called after the user renames the sides.
+	OnRenamed func(ctName, tName string)
+}
+
+// NewTrackerView builds the view for t, whose counters draw into ctLabel and
+// tLabel (see NewCounterLabels). The sides start out with the default names
+// and accent colours from the tracker's config.
+func NewTrackerView(t *tracker.Tracker, window fyne.Window, ctLabel, tLabel *canvas.Text) *TrackerView {
+	v := &TrackerView{tracker: t, window: window, ctLabel: ctLabel, tLabel: tLabel}
+	v.container = v.buildUI(ctLabel, tLabel)
+	t.SetSideNames(v.SideNames())
+	v.ApplySideColors()
+	v.ApplySideLogos()
+	v.ApplyTouchMode()
+	return v
+}
+
+// touchButtonHeight is how tall the +/- buttons are in touch mode, about a
+// fingertip and a half.
+const touchButtonHeight = 96
+
+// ApplyTouchMode switches the large +/- buttons and counter swipes on or off
+// to match the tracker's config.
+func (v *TrackerView) ApplyTouchMode() {
+	touch := v.tracker.Config.TouchMode
+	height := float32(0)
+	if touch {
+		height = touchButtonHeight
+	}
+	for _, spacer := range v.buttonSpacers {
+		spacer.SetMinSize(fyne.NewSize(0, height))
+	}
+	for _, c := range v.counters {
+		c.swipes = touch
+	}
+	if v.container != nil {
+		v.container.Refresh()
+	}
+}
+
+// SetMini switches the view to a condensed layout with only the counters,
+// which still take clicks, scrolls and swipes, and the team, party and swap
+// controls behind a drawer button. SetMini(false) restores the full layout.
+func (v *TrackerView) SetMini(mini bool) {
+	for _, o := range v.fullOnly {
+		setVisible(o, !mini)
+	}
+	setVisible(v.drawerButton, mini)
+	setVisible(v.controls, !mini)
+	v.drawerButton.SetIcon(theme.MenuExpandIcon())
+}
+
+func (v *TrackerView) toggleDrawer() {
+	open := !v.controls.Visible()
+	setVisible(v.controls, open)
+	if open {
+		v.drawerButton.SetIcon(theme.MenuDropDownIcon())
+	} else {
+		v.drawerButton.SetIcon(theme.MenuExpandIcon())
+	}
+}
+
+func setVisible(o fyne.CanvasObject, visible bool) {
+	if visible {
+		o.Show()
+	} else {
+		o.Hide()
+	}
+}
+
+// ApplySideColors recolours the side titles and counters with the accent
+// colours from the tracker's config.
+func (v *TrackerView) ApplySideColors() {
+	ct, t := SideColors(v.tracker.Config)
+	for _, text := range []*canvas.Text{v.ctTitle, v.ctLabel} {
+		text.Color = ct
+		text.Refresh()
+	}
+	for _, text := range []*canvas.Text{v.tTitle, v.tLabel} {
+		text.Color = t
+		text.Refresh()
+	}
+}
+
+// ApplySideLogos shows the logos from the tracker's config above the
+// counters, or none for a side without one.
+func (v *TrackerView) ApplySideLogos() {
+	setLogo(v.ctLogo, v.tracker.Config.CTLogo)
+	setLogo(v.tLogo, v.tracker.Config.TLogo)
+}
+
+// logoSize is how big side logos are drawn above the counters.
+const logoSize = 64
+
+// newLogo creates an image for a side logo, hidden until setLogo gives it a
+// file.
+func newLogo() *canvas.Image {
+	img := &canvas.Image{FillMode: canvas.ImageFillContain}
+	img.SetMinSize(fyne.NewSquareSize(logoSize))
+	img.Hide()
+	return img
+}
+
+// setLogo shows the image file at path in img, or hides img if path is
+// empty or the file is gone.
+func setLogo(img *canvas.Image, path string) {
+	if _, err := os.Stat(path); path == "" || err != nil {
+		img.File = ""
+		img.Hide()
+		return
+	}
+	img.File = path
+	img.Show()
+	img.Refresh()
+}
+
+// SideNames returns the display names of the CT and T sides.
+func (v *TrackerView) SideNames() (ctName, tName string) {
+	return v.ctTitle.Text, v.tTitle.Text
+}
+
+// SetSideNames changes the display names of the CT and T sides, which are
+// stored with the rounds recorded under them. Rounds are still recorded
+// against the underlying CT/T side.
+func (v *TrackerView) SetSideNames(ctName, tName string) {
+	v.tracker.SetSideNames(ctName, tName)
+	v.ctTitle.Text = ctName
+	v.tTitle.Text = tName
+	v.ctTitle.Refresh()
+	v.tTitle.Refresh()
+}
+
+func (v *TrackerView) showRenameDialog() {
+	ctName, tName := v.SideNames()
+	ctEntry := widget.NewEntry()
+	ctEntry.SetText(ctName)
+	tEntry := widget.NewEntry()
+	tEntry.SetText(tName)
+
+	form := widget.NewForm(
+		widget.NewFormItem("CT side", ctEntry),
+		widget.NewFormItem("T side", tEntry),
+	)
+
+	dialog.ShowCustomConfirm("Rename Sides", "Save", "Cancel", form, func(save bool) {
+		if !save {
+			return
+		}
+		ctName, tName := strings.TrimSpace(ctEntry.Text), strings.TrimSpace(tEntry.Text)
+		if ctName == "" {
+			ctName = "CT"
+		}
+		if tName == "" {
+			tName = "T"
+		}
+		v.SetSideNames(ctName, tName)
+		if v.OnRenamed != nil {
+			v.OnRenamed(ctName, tName)
+		}
+	}, v.window)
+}
+
+// Container returns the view content.
+func (v *TrackerView) Container() fyne.CanvasObject {
+	return v.container
+}
+
+// Tracker returns the tracker this view drives.
+func (v *TrackerView) Tracker() *tracker.Tracker {
+	return v.tracker
+}
+
+func (v *TrackerView) buildUI(ctLabel, tLabel *canvas.Text) fyne.CanvasObject {
+	t := v.tracker
+
+	// Create CT side (left)
+	ctTitle := canvas.NewText(t.Config.CTName, ctColor)
+	ctTitle.TextSize = 32
+	ctTitle.Alignment = fyne.TextAlignCenter
+	v.ctTitle = ctTitle
+
+	ctPlusButton := widget.NewButton("+", func() {
+		t.IncrementCT()
+	})
+	ctPlusButton.Importance = widget.HighImportance
+
+	ctMinusButton := widget.NewButton("-", func() {
+		t.DecrementCT()
+	})
+	ctMinusButton.Importance = widget.WarningImportance
+
+	ctButtonsContainer := container.NewGridWithColumns(2,
+		v.touchSized(ctPlusButton),
+		v.touchSized(ctMinusButton),
+	)
+
+	v.ctLogo = newLogo()
+	ctHeader := container.NewVBox(ctTitle, v.ctLogo)
+	ctContainer := container.NewBorder(
+		ctHeader,
+		ctButtonsContainer,
+		nil,
+		nil,
+		v.newCounterArea(container.NewCenter(ctLabel), t.IncrementCT, t.DecrementCT),
+	)
+
+	// Create T side (right)
+	tTitle := canvas.NewText(t.Config.TName, tColor)
+	tTitle.TextSize = 32
+	tTitle.Alignment = fyne.TextAlignCenter
+	v.tTitle = tTitle
+
+	tPlusButton := widget.NewButton("+", func() {
+		t.IncrementT()
+	})
+	tPlusButton.Importance = widget.HighImportance
+
+	tMinusButton := widget.NewButton("-", func() {
+		t.DecrementT()
+	})
+	tMinusButton.Importance = widget.WarningImportance
+
+	tButtonsContainer := container.NewGridWithColumns(2,
+		v.touchSized(tPlusButton),
+		v.touchSized(tMinusButton),
+	)
+
+	v.tLogo = newLogo()
+	tHeader := container.NewVBox(tTitle, v.tLogo)
+	tContainer := container.NewBorder(
+		tHeader,
+		tButtonsContainer,
+		nil,
+		nil,
+		v.newCounterArea(container.NewCenter(tLabel), t.IncrementT, t.DecrementT),
+	)
+
+	// Create side-by-side layout
+	countersContainer := container.NewGridWithColumns(2,
+		ctContainer,
+		tContainer,
+	)
+
+	// Create team selection
+	teamSelect := widget.NewSelect([]string{"None", "CT", "T"}, func(selected string) {
+		if selected == "None" {
+			t.SetTeam(database.TeamNone)
+		} else {
+			t.SetTeam(database.Team(selected))
+		}
+	})
+	teamSelect.SetSelected("None")
+
+	// Wire up hotkey team selection to update UI
+	t.SetOnTeamChange(func(team database.Team) {
+		switch team {
+		case database.TeamCT:
+			teamSelect.SetSelected("CT")
+		case database.TeamT:
+			teamSelect.SetSelected("T")
+		default:
+			teamSelect.SetSelected("None")
+		}
+	})
+
+	// Party size is recorded with every round so stats can compare solo
+	// queue against playing with friends.
+	partySelect := widget.NewSelect(partySizeNames(), func(selected string) {
+		t.SetPartySize(database.ParsePartySize(selected))
+	})
+	partySelect.SetSelected(t.PartySize().String())
+
+	// Game mode is recorded with every round and sets the match format:
+	// MR12 in Premier and Competitive, first to 8 in Wingman and Casual.
+	// Game state integration picks it when a match starts.
+	modeSelect := widget.NewSelect(modeNames(), func(selected string) {
+		t.SetMode(database.ParseMode(selected))
+	})
+	modeSelect.SetSelected(t.Mode().String())
+	t.SetOnModeChange(func(mode database.Mode) {
+		modeSelect.SetSelected(mode.String())
+	})
+
+	// Map reported by game state integration, hidden until one arrives.
+	mapLabel := widget.NewLabel("")
+	mapLabel.Hide()
+	setMap := func(name string) {
+		mapLabel.SetText("Map: " + name)
+		mapLabel.Show()
+	}
+	if name := t.MapName(); name != "" {
+		setMap(name)
+	}
+	t.SetOnMapChange(setMap)
+
+	// Team selector row.
+	teamRow := container.NewHBox(
+		layout.NewSpacer(),
+		widget.NewLabel("Team:"),
+		teamSelect,
+		widget.NewLabel("Party:"),
+		partySelect,
+		widget.NewLabel("Mode:"),
+		modeSelect,
+		mapLabel,
+		layout.NewSpacer(),
+	)
+
+	// Action buttons row.
+	swapButton := widget.NewButton("Swap Teams", func() {
+		t.SwapTeams()
+	})
+	renameButton := widget.NewButton("Rename Sides", v.showRenameDialog)
+	actionButtonsContainer := container.NewHBox(
+		layout.NewSpacer(),
+		swapButton,
+		renameButton,
+		layout.NewSpacer(),
+	)
+
+	v.fullOnly = []fyne.CanvasObject{ctHeader, ctButtonsContainer, tHeader, tButtonsContainer}
+	v.controls = container.NewVBox(
+		teamRow,
+		actionButtonsContainer,
+	)
+	v.drawerButton = widget.NewButtonWithIcon("Controls", theme.MenuExpandIcon(), v.toggleDrawer)
+	v.drawerButton.Importance = widget.LowImportance
+	v.drawerButton.Hide()
+
+	return container.NewBorder(
+		nil,
+		container.NewVBox(
+			v.drawerButton,
+			v.controls,
+		),
+		nil,
+		nil,
+		countersContainer,
+	)
+}
+
+// partySizeNames returns the party size options for a select widget.
+func partySizeNames() []string {
+	names := make([]string, len(database.PartySizes))
+	for i, p := range database.PartySizes {
+		names[i] = p.String()
+	}
+	return names
+}
+
+// modeNames returns the game mode options for a select widget.
+func modeNames() []string {
+	names := make([]string, len(database.Modes))
+	for i, m := range database.Modes {
+		names[i] = m.String()
+	}
+	return names
+}
+
+// touchSized stacks button on a spacer that ApplyTouchMode makes tall.
+func (v *TrackerView) touchSized(button *widget.Button) fyne.CanvasObject {
+	spacer := canvas.NewRectangle(color.Transparent)
+	v.buttonSpacers = append(v.buttonSpacers, spacer)
+	return container.NewStack(spacer, button)
+}
+
+// newCounterArea makes a counter a control of its own, so the +/- buttons
+// are optional: clicking it or scrolling up runs up, right-clicking it or
+// scrolling down runs down, and in touch mode so do swipes up and down.
+func (v *TrackerView) newCounterArea(counter fyne.CanvasObject, up, down func()) *counterArea {
+	c := &counterArea{content: counter, onUp: up, onDown: down}
+	c.ExtendBaseWidget(c)
+	v.counters = append(v.counters, c)
+	return c
+}
+
+// swipeDistance is how far a drag has to go up or down to count as a swipe.
+const swipeDistance = 40
+
+// scrollStep is how far a scroll has to go for one round, about a notch of a
+// mouse wheel; a touchpad's stream of small scrolls adds up to it.
+const scrollStep = 10
+
+// counterArea turns clicks, scrolls and, when swipes is set, vertical
+// swipes over a counter into onUp and onDown.
+type counterArea struct {
+	widget.BaseWidget
+	content      fyne.CanvasObject
+	swipes       bool
+	dx, dy       float32 // distance dragged so far
+	scrolled     float32 // scroll distance not yet turned into rounds
+	onUp, onDown func()
+}
+
+func (c *counterArea) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.content)
+}
+
+func (c *counterArea) Tapped(*fyne.PointEvent) { c.onUp() }
+
+func (c *counterArea) TappedSecondary(*fyne.PointEvent) { c.onDown() }
+
+// Cursor shows the counter can be clicked.
+func (c *counterArea) Cursor() desktop.Cursor { return desktop.PointerCursor }
+
+func (c *counterArea) Scrolled(e *fyne.ScrollEvent) {
+	// Scrolling up gives a positive DY.
+	c.scrolled += e.Scrolled.DY
+	for ; c.scrolled >= scrollStep; c.scrolled -= scrollStep {
+		c.onUp()
+	}
+	for ; c.scrolled <= -scrollStep; c.scrolled += scrollStep {
+		c.onDown()
+	}
+}
+
+func (c *counterArea) Dragged(e *fyne.DragEvent) {
+	c.dx += e.Dragged.DX
+	c.dy += e.Dragged.DY
+}
+
+func (c *counterArea) DragEnd() {
+	dx, dy := c.dx, c.dy
+	c.dx, c.dy = 0, 0
+	// Mostly sideways isn't a swipe up or down.
+	if !c.swipes || abs(dx) > abs(dy) {
+		return
+	}
+	switch {
+	case dy <= -swipeDistance:
+		c.onUp()
+	case dy >= swipeDistance:
+		c.onDown()
+	}
+}
+
+func abs(f float32) float32 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
@@ -0,0 +1,354 @@
+package ui_test
+
+import (
+	"context"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+
+	"csstatstracker"
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/gsi"
+	"csstatstracker/internal/tracker"
+	"csstatstracker/internal/ui"
+)
+
+func TestTrackerViewRecordsRounds(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+
+	w := test.NewTempWindow(t, nil)
+	ctLabel, tLabel := ui.NewCounterLabels()
+	tr := tracker.New(ctx, db, w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	v := ui.NewTrackerView(tr, w, ctLabel, tLabel)
+	w.SetContent(v.Container())
+	w.Resize(fyne.NewSize(600, 400))
+
+	selectWith(t, v.Container(), "None").SetSelected("T")
+	if got := tr.Team(); got != database.TeamT {
+		t.Fatalf("team = %q after selecting T; want T", got)
+	}
+	v.SetSideNames("Us", "Them")
+
+	plus, minus := buttons(v.Container(), "+"), buttons(v.Container(), "-")
+	if len(plus) != 2 || len(minus) != 2 {
+		t.Fatalf("found %d + and %d - buttons; want 2 of each", len(plus), len(minus))
+	}
+	ctPlus, tPlus, ctMinus := plus[0], plus[1], minus[0]
+	test.Tap(ctPlus)
+	test.Tap(ctPlus)
+	test.Tap(tPlus)
+	// A CT round synced in meanwhile is the newest row, but wasn't recorded
+	// here, so undo leaves it alone.
+	synced := database.Round{UUID: "synced", Winner: database.TeamCT, Team: database.TeamT, CreatedAt: time.Now().Add(-time.Hour)}
+	if _, err := database.MergeRounds(ctx, db, []database.Round{synced}); err != nil {
+		t.Fatal(err)
+	}
+	test.Tap(ctMinus)
+
+	if ctLabel.Text != "1" || tLabel.Text != "1" {
+		t.Errorf("counters = %s–%s; want 1–1", ctLabel.Text, tLabel.Text)
+	}
+	if _, err := database.GetRoundByUUID(ctx, db, synced.UUID); err != nil {
+		t.Errorf("the synced round is gone after undoing a CT round: %v", err)
+	}
+	rounds, err := database.GetRoundsPage(ctx, db, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rounds = slices.DeleteFunc(rounds, func(r database.Round) bool { return r.UUID == synced.UUID })
+	if len(rounds) != 2 {
+		t.Fatalf("recorded %d rounds; want 2 (the undone one deleted)", len(rounds))
+	}
+	for _, r := range rounds {
+		if r.Team != database.TeamT {
+			t.Errorf("round %+v recorded on %q; want T", r, r.Team)
+		}
+		if r.CTName != "Us" || r.TName != "Them" {
+			t.Errorf("round %+v recorded with sides %q vs %q; want Us vs Them", r, r.CTName, r.TName)
+		}
+	}
+	if rounds[0].Winner != database.TeamT || rounds[1].Winner != database.TeamCT {
+		t.Errorf("winners newest first = %s, %s; want T, CT", rounds[0].Winner, rounds[1].Winner)
+	}
+}
+
+func TestTrackerViewTouchMode(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+
+	w := test.NewTempWindow(t, nil)
+	ctLabel, tLabel := ui.NewCounterLabels()
+	tr := tracker.New(ctx, db, w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	v := ui.NewTrackerView(tr, w, ctLabel, tLabel)
+	w.SetContent(v.Container())
+
+	var counters []fyne.Draggable
+	for _, o := range objects(v.Container()) {
+		if d, ok := o.(fyne.Draggable); ok {
+			counters = append(counters, d)
+		}
+	}
+	if len(counters) != 2 {
+		t.Fatalf("found %d swipeable counters; want 2", len(counters))
+	}
+	swipe := func(d fyne.Draggable, dx, dy float32) {
+		d.Dragged(&fyne.DragEvent{Dragged: fyne.Delta{DX: dx / 2, DY: dy / 2}})
+		d.Dragged(&fyne.DragEvent{Dragged: fyne.Delta{DX: dx / 2, DY: dy / 2}})
+		d.DragEnd()
+	}
+	buttonHeight := buttons(v.Container(), "+")[0].MinSize().Height
+	viewHeight := v.Container().MinSize().Height
+
+	// Off by default.
+	swipe(counters[0], 0, -100)
+	if ctLabel.Text != "0" {
+		t.Errorf("CT = %s after a swipe outside touch mode; want 0", ctLabel.Text)
+	}
+
+	cfg.TouchMode = true
+	v.ApplyTouchMode()
+	swipe(counters[0], 0, -100) // up: CT +1
+	swipe(counters[0], 0, -100) // CT +1
+	swipe(counters[0], 0, 100)  // down: CT -1
+	swipe(counters[1], 0, -100) // T +1
+	swipe(counters[1], 80, -50) // mostly sideways: nothing
+	swipe(counters[1], 0, -10)  // too short: nothing
+	if ctLabel.Text != "1" || tLabel.Text != "1" {
+		t.Errorf("counters = %s–%s after swiping; want 1–1", ctLabel.Text, tLabel.Text)
+	}
+	if n, err := database.CountRounds(ctx, db); err != nil || n != 2 {
+		t.Errorf("CountRounds = %d, %v; want 2", n, err)
+	}
+
+	// The +/- buttons' row grows to the touch height.
+	if h := v.Container().MinSize().Height; h < viewHeight+96-buttonHeight {
+		t.Errorf("view is %v tall in touch mode, %v before; want the buttons 96 tall", h, viewHeight)
+	}
+}
+
+func TestTrackerViewCounterGestures(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+
+	w := test.NewTempWindow(t, nil)
+	ctLabel, tLabel := ui.NewCounterLabels()
+	tr := tracker.New(ctx, db, w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	v := ui.NewTrackerView(tr, w, ctLabel, tLabel)
+	w.SetContent(v.Container())
+
+	type counter interface {
+		fyne.Tappable
+		fyne.SecondaryTappable
+		fyne.Scrollable
+	}
+	var counters []counter
+	for _, o := range objects(v.Container()) {
+		if c, ok := o.(counter); ok {
+			counters = append(counters, c)
+		}
+	}
+	if len(counters) != 2 {
+		t.Fatalf("found %d clickable counters; want 2", len(counters))
+	}
+	scroll := func(c counter, dy float32) {
+		c.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.Delta{DY: dy}})
+	}
+
+	test.Tap(counters[0])          // CT 1
+	test.Tap(counters[0])          // CT 2
+	test.TapSecondary(counters[0]) // CT 1
+	scroll(counters[1], 10)        // T 1: a notch up
+	for range 4 {
+		scroll(counters[1], 5) // T 3: a touchpad's small scrolls add up
+	}
+	scroll(counters[1], -10) // T 2
+	scroll(counters[1], -4)  // not a whole notch: nothing
+	if ctLabel.Text != "1" || tLabel.Text != "2" {
+		t.Errorf("counters = %s–%s; want 1–2", ctLabel.Text, tLabel.Text)
+	}
+	if n, err := database.CountRounds(ctx, db); err != nil || n != 3 {
+		t.Errorf("CountRounds = %d, %v; want 3", n, err)
+	}
+
+	// Clicks go through the same match-over check as the buttons.
+	var overSide database.Team
+	tr.SetOnMatchOver(func(_ *tracker.Tracker, side database.Team) { overSide = side })
+	for range 12 {
+		test.Tap(counters[0])
+	}
+	if ctLabel.Text != "13" || overSide != "" {
+		t.Fatalf("CT = %s, match over for %q; want 13 and not over yet", ctLabel.Text, overSide)
+	}
+	test.Tap(counters[0])
+	if ctLabel.Text != "13" || overSide != database.TeamCT {
+		t.Errorf("clicking after 13–2 gives CT %s, match over for %q; want 13 kept and CT asked about", ctLabel.Text, overSide)
+	}
+}
+
+func TestTrackerViewMiniMode(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+
+	w := test.NewTempWindow(t, nil)
+	ctLabel, tLabel := ui.NewCounterLabels()
+	tr := tracker.New(ctx, db, w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	v := ui.NewTrackerView(tr, w, ctLabel, tLabel)
+	full := v.Container().MinSize()
+	m := ui.NewMiniMode(v.Container(), v.SetMini)
+	w.SetContent(m)
+	if m.MinSize().Height >= full.Height {
+		t.Fatalf("mini mode is %v at least, the full view %v; want it shorter", m.MinSize(), full)
+	}
+
+	visible := func(label string) bool { return len(buttons(v.Container(), label)) > 0 }
+	teamShown := func() bool { return len(selects(v.Container())) > 0 }
+
+	w.Resize(full.AddWidthHeight(20, 20))
+	if m.Mini() || !visible("+") || !teamShown() || visible("Controls") {
+		t.Fatal("the view isn't full with room for it")
+	}
+
+	w.Resize(fyne.NewSize(full.Width, full.Height/2))
+	if !m.Mini() || visible("+") || teamShown() || !visible("Controls") {
+		t.Fatal("the view isn't condensed to the counters without room for it")
+	}
+	test.Tap(button(t, v.Container(), "Controls"))
+	if !teamShown() {
+		t.Error("opening the drawer doesn't show the team selector")
+	}
+
+	w.Resize(full.AddWidthHeight(20, 20))
+	if m.Mini() || !visible("+") || !teamShown() || visible("Controls") {
+		t.Error("the full view isn't back after enlarging the window")
+	}
+}
+
+func TestTrackerViewFollowsGameState(t *testing.T) {
+	test.NewTempApp(t)
+	ctx := context.Background()
+	db := dbtest.New(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+
+	w := test.NewTempWindow(t, nil)
+	ctLabel, tLabel := ui.NewCounterLabels()
+	tr := tracker.New(ctx, db, w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	v := ui.NewTrackerView(tr, w, ctLabel, tLabel)
+	w.SetContent(v.Container())
+	teamSelect := selectWith(t, v.Container(), "None")
+
+	update := func(player, team string) {
+		tr.HandleGSI(&gsi.State{
+			Provider: gsi.Provider{SteamID: "1"},
+			Map:      gsi.Map{Name: "de_inferno"},
+			Player:   gsi.Player{SteamID: player, Team: team},
+		})
+	}
+	update("1", "CT")
+	if tr.Team() != database.TeamCT || teamSelect.Selected != "CT" {
+		t.Errorf("team = %q with %q selected; want CT", tr.Team(), teamSelect.Selected)
+	}
+	update("2", "T") // following another player while spectating
+	if teamSelect.Selected != "CT" {
+		t.Errorf("following a player on T selects %q; want CT kept", teamSelect.Selected)
+	}
+	update("1", "T") // halftime
+	if tr.Team() != database.TeamT || teamSelect.Selected != "T" {
+		t.Errorf("after halftime team = %q with %q selected; want T", tr.Team(), teamSelect.Selected)
+	}
+
+	if !hasLabel(v.Container(), "Map: de_inferno") {
+		t.Error("the Tracker tab doesn't show the map")
+	}
+	test.Tap(buttons(v.Container(), "+")[1])
+	rounds, err := database.GetRecentRounds(ctx, db, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != 1 || rounds[0].Team != database.TeamT || rounds[0].Map != "de_inferno" {
+		t.Errorf("recorded %+v; want a round on T on de_inferno", rounds)
+	}
+}
+
+func TestTrackerViewLogos(t *testing.T) {
+	test.NewTempApp(t)
+	cfg := config.Default()
+	cfg.SoundEnabled = false
+	cfg.CTLogo = writePNG(t)
+	cfg.TLogo = filepath.Join(t.TempDir(), "missing.png")
+
+	w := test.NewTempWindow(t, nil)
+	ctLabel, tLabel := ui.NewCounterLabels()
+	tr := tracker.New(context.Background(), dbtest.New(t), w, cfg, ctLabel, tLabel, csstatstracker.SoundFS)
+	v := ui.NewTrackerView(tr, w, ctLabel, tLabel)
+	w.SetContent(v.Container())
+
+	logos := func(o fyne.CanvasObject) []string {
+		var files []string
+		for _, obj := range objects(o) {
+			if img, ok := obj.(*canvas.Image); ok && img.File != "" {
+				files = append(files, img.File)
+			}
+		}
+		return files
+	}
+	// A logo whose file is gone isn't shown.
+	if got := logos(v.Container()); !slices.Equal(got, []string{cfg.CTLogo}) {
+		t.Errorf("logos shown = %v; want just the CT one", got)
+	}
+	v.SetMini(true)
+	if got := logos(v.Container()); len(got) != 0 {
+		t.Errorf("mini mode shows logos %v; want just the counters", got)
+	}
+	v.SetMini(false)
+
+	p := ui.NewPresentation()
+	p.Present(v)
+	if got := logos(p); !slices.Equal(got, []string{cfg.CTLogo}) {
+		t.Errorf("presentation shows logos %v; want just the CT one", got)
+	}
+
+	cfg.CTLogo = ""
+	v.ApplySideLogos()
+	if got := logos(v.Container()); len(got) != 0 {
+		t.Errorf("logos shown after clearing = %v; want none", got)
+	}
+}
+
+// writePNG writes a small image file and returns its path.
+func writePNG(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "logo.png")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = f.Close() }()
+	if err := png.Encode(f, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,96 @@
+package ui_test
+
+import (
+	"slices"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// objects returns o and everything drawn inside it, descending into
+// containers and the widgets' renderers. Hidden objects aren't drawn, so
+// neither they nor anything inside them is returned.
+func objects(o fyne.CanvasObject) []fyne.CanvasObject {
+	if !o.Visible() {
+		return nil
+	}
+	all := []fyne.CanvasObject{o}
+	switch o := o.(type) {
+	case *fyne.Container:
+		for _, child := range o.Objects {
+			all = append(all, objects(child)...)
+		}
+	case fyne.Widget:
+		for _, child := range test.WidgetRenderer(o).Objects() {
+			all = append(all, objects(child)...)
+		}
+	}
+	return all
+}
+
+// buttons returns the visible buttons labelled text inside o, in layout
+// order.
+func buttons(o fyne.CanvasObject, text string) []*widget.Button {
+	var found []*widget.Button
+	for _, obj := range objects(o) {
+		if b, ok := obj.(*widget.Button); ok && b.Visible() && b.Text == text {
+			found = append(found, b)
+		}
+	}
+	return found
+}
+
+// button returns the only visible button labelled text inside o.
+func button(t *testing.T, o fyne.CanvasObject, text string) *widget.Button {
+	t.Helper()
+	found := buttons(o, text)
+	if len(found) != 1 {
+		t.Fatalf("found %d %q buttons; want 1", len(found), text)
+	}
+	return found[0]
+}
+
+// hasLabel reports whether a label reading text is drawn inside o.
+func hasLabel(o fyne.CanvasObject, text string) bool {
+	for _, obj := range objects(o) {
+		if l, ok := obj.(*widget.Label); ok && l.Text == text {
+			return true
+		}
+	}
+	return false
+}
+
+// selects returns the selects inside o, in layout order.
+func selects(o fyne.CanvasObject) []*widget.Select {
+	var found []*widget.Select
+	for _, obj := range objects(o) {
+		if s, ok := obj.(*widget.Select); ok {
+			found = append(found, s)
+		}
+	}
+	return found
+}
+
+// selectWith returns the select inside o that offers option.
+func selectWith(t *testing.T, o fyne.CanvasObject, option string) *widget.Select {
+	t.Helper()
+	for _, obj := range objects(o) {
+		if s, ok := obj.(*widget.Select); ok && slices.Contains(s.Options, option) {
+			return s
+		}
+	}
+	t.Fatalf("no select offering %q", option)
+	return nil
+}
+
+// topDialog returns the dialog showing over w's content.
+func topDialog(t *testing.T, w fyne.Window) fyne.CanvasObject {
+	t.Helper()
+	top := w.Canvas().Overlays().Top()
+	if top == nil {
+		t.Fatal("no dialog is showing")
+	}
+	return top
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/database"
+)
+
+// ShowUpgradeSplash shows a small window saying the database is being
+// upgraded, e.g. "Upgrading database (v3→v5)…", while the main window can't
+// be set up yet. Close it once the upgrade is done.
+func ShowUpgradeSplash(a fyne.App, up database.Upgrade) fyne.Window {
+	var w fyne.Window
+	if d, ok := a.Driver().(desktop.Driver); ok {
+		w = d.CreateSplashWindow()
+	} else {
+		w = a.NewWindow("CS Stats Tracker")
+	}
+	label := widget.NewLabel("Upgrading database (" + up.String() + ")…")
+	label.Alignment = fyne.TextAlignCenter
+	w.SetContent(container.NewPadded(container.NewVBox(label, widget.NewProgressBarInfinite())))
+	w.Resize(fyne.NewSize(280, 0))
+	w.CenterOnScreen()
+	w.Show()
+	return w
+}
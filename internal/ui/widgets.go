@@ -1,13 +1,26 @@
 package ui
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
-// AutoSizeEntry is an entry widget that sizes itself based on content
+// AutoSizeEntry is an entry that's as wide as its text, for short values in
+// a row of labels. It's measured with the current theme's font, so it fits
+// at any text size or scale.
 type AutoSizeEntry struct {
 	widget.Entry
+
+	// MinChars is how many digits' width the entry keeps at least, so it
+	// doesn't jump around as a number is typed. 0 means one.
+	MinChars int
 }
 
 // NewAutoSizeEntry creates a new auto-sizing entry widget
@@ -17,15 +30,207 @@ func NewAutoSizeEntry() *AutoSizeEntry {
 	return e
 }
 
-// MinSize returns the minimum size based on text content
+// MinSize returns the width of the text, or the placeholder if there's none,
+// plus the entry's padding and room for its validation icon.
 func (e *AutoSizeEntry) MinSize() fyne.Size {
-	textLen := len(e.Text)
-	if textLen < 1 {
-		textLen = 1
+	th := e.Theme()
+	textSize := th.Size(theme.SizeNameText)
+	text := e.Text
+	if text == "" {
+		text = e.PlaceHolder
 	}
-	width := float32(textLen*10 + 16) // 10px per char + 16px padding
-	if width < 26 {
-		width = 26 // minimum width
+	width := max(
+		fyne.MeasureText(text, textSize, e.TextStyle).Width,
+		fyne.MeasureText(strings.Repeat("0", max(e.MinChars, 1)), textSize, e.TextStyle).Width,
+	)
+	// As widget.Entry pads its text, with room for the cursor.
+	width += th.Size(theme.SizeNameInnerPadding)*3 + th.Size(theme.SizeNameInputBorder)*2
+	if e.Validator != nil {
+		width += th.Size(theme.SizeNameInlineIcon) + th.Size(theme.SizeNameLineSpacing)
 	}
 	return fyne.NewSize(width, e.Entry.MinSize().Height)
 }
+
+// CommitEntry is an entry whose text is applied once editing is done, on
+// Enter or when it loses focus, rather than on every keystroke.
+type CommitEntry struct {
+	widget.Entry
+	committed string
+	commit    func(string)
+}
+
+// NewCommitEntry creates an entry showing text. commit is called with the
+// new text once it's been edited.
+func NewCommitEntry(text string, commit func(string)) *CommitEntry {
+	e := &CommitEntry{committed: text, commit: commit}
+	e.ExtendBaseWidget(e)
+	e.SetText(text)
+	e.OnSubmitted = func(string) { e.apply() }
+	return e
+}
+
+// FocusLost applies the text when the entry loses focus.
+func (e *CommitEntry) FocusLost() {
+	e.Entry.FocusLost()
+	e.apply()
+}
+
+func (e *CommitEntry) apply() {
+	if e.Text == e.committed {
+		return
+	}
+	e.committed = e.Text
+	e.commit(e.Text)
+}
+
+// intEntryDelay is how long an IntEntry waits after the last keystroke
+// before applying its value, so typing "12" doesn't apply a transient 1.
+const intEntryDelay = 400 * time.Millisecond
+
+// IntEntry is an entry for a whole number in a range. It only accepts
+// digits, applies the number once typing pauses, and marks text that's out
+// of range as invalid instead of applying it.
+type IntEntry struct {
+	AutoSizeEntry
+	lo, hi   int
+	apply    func(int)
+	timer    *time.Timer
+	onChange func(n int, valid bool) // for Stepper, on every keystroke
+}
+
+// NewIntEntry creates an entry for a whole number from lo to hi, showing
+// value. apply is called with the new number once typing pauses, or straight
+// away on Enter.
+func NewIntEntry(value, lo, hi int, apply func(int)) *IntEntry {
+	e := &IntEntry{lo: lo, hi: hi, apply: apply}
+	e.ExtendBaseWidget(e)
+	e.MinChars = min(len(strconv.Itoa(hi)), 5)
+	e.SetText(strconv.Itoa(value))
+	e.Validator = func(text string) error {
+		_, err := e.parse(text)
+		return err
+	}
+	e.OnChanged = e.changed
+	e.OnSubmitted = func(text string) {
+		if e.timer != nil {
+			e.timer.Stop()
+		}
+		if n, err := e.parse(text); err == nil {
+			e.apply(n)
+		}
+	}
+	return e
+}
+
+// TypedRune drops anything that can't be part of a number.
+func (e *IntEntry) TypedRune(r rune) {
+	if (r >= '0' && r <= '9') || (r == '-' && e.lo < 0) {
+		e.AutoSizeEntry.TypedRune(r)
+	}
+}
+
+// Value returns the number shown, or the nearest one in range if the text
+// isn't valid.
+func (e *IntEntry) Value() int {
+	n, err := strconv.Atoi(e.Text)
+	if err != nil {
+		return e.lo
+	}
+	return min(max(n, e.lo), e.hi)
+}
+
+// SetRange changes the numbers allowed. A value now out of range is moved
+// to the nearest one allowed, and applied.
+func (e *IntEntry) SetRange(lo, hi int) {
+	e.lo, e.hi = lo, hi
+	if n := e.Value(); strconv.Itoa(n) != e.Text {
+		e.SetText(strconv.Itoa(n))
+		e.OnSubmitted(e.Text)
+	}
+	_ = e.Validate()
+}
+
+func (e *IntEntry) parse(text string) (int, error) {
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("enter a whole number")
+	}
+	if n < e.lo || n > e.hi {
+		return 0, fmt.Errorf("enter a number from %d to %d", e.lo, e.hi)
+	}
+	return n, nil
+}
+
+func (e *IntEntry) changed(text string) {
+	if e.timer != nil {
+		e.timer.Stop()
+	}
+	n, err := e.parse(text)
+	if e.onChange != nil {
+		e.onChange(n, err == nil)
+	}
+	if err != nil {
+		return
+	}
+	e.timer = time.AfterFunc(intEntryDelay, func() {
+		fyne.Do(func() {
+			// Typing since may have raced the timer.
+			if e.Text == text {
+				e.apply(n)
+			}
+		})
+	})
+}
+
+// Stepper is an IntEntry between - and + buttons, for small numbers that
+// are usually nudged rather than typed. The buttons stop at the ends of the
+// range.
+type Stepper struct {
+	widget.BaseWidget
+	entry       *IntEntry
+	minus, plus *widget.Button
+}
+
+// NewStepper creates a stepper for a whole number from lo to hi, showing
+// value. apply is called as for NewIntEntry; a run of clicks applies once.
+func NewStepper(value, lo, hi int, apply func(int)) *Stepper {
+	s := &Stepper{entry: NewIntEntry(value, lo, hi, apply)}
+	s.ExtendBaseWidget(s)
+	step := func(by int) {
+		n := min(max(s.entry.Value()+by, s.entry.lo), s.entry.hi)
+		s.entry.SetText(strconv.Itoa(n))
+	}
+	s.minus = widget.NewButtonWithIcon("", theme.ContentRemoveIcon(), func() { step(-1) })
+	s.plus = widget.NewButtonWithIcon("", theme.ContentAddIcon(), func() { step(1) })
+	s.entry.onChange = func(int, bool) { s.updateButtons() }
+	s.updateButtons()
+	return s
+}
+
+// Value returns the number shown, as for IntEntry.Value.
+func (s *Stepper) Value() int { return s.entry.Value() }
+
+// SetRange changes the numbers allowed, as for IntEntry.SetRange.
+func (s *Stepper) SetRange(lo, hi int) {
+	s.entry.SetRange(lo, hi)
+	s.updateButtons()
+}
+
+func (s *Stepper) updateButtons() {
+	n := s.entry.Value()
+	for _, b := range []struct {
+		button *widget.Button
+		atEnd  bool
+	}{{s.minus, n <= s.entry.lo}, {s.plus, n >= s.entry.hi}} {
+		if b.atEnd {
+			b.button.Disable()
+		} else {
+			b.button.Enable()
+		}
+	}
+}
+
+// CreateRenderer lays the stepper out as -, the entry, +.
+func (s *Stepper) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewHBox(s.minus, s.entry, s.plus))
+}
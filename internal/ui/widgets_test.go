@@ -0,0 +1,149 @@
+package ui_test
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"csstatstracker/internal/ui"
+)
+
+func TestIntEntry(t *testing.T) {
+	test.NewTempApp(t)
+	applied := make(chan int, 10)
+	e := ui.NewIntEntry(5, 1, 30, func(n int) { applied <- n })
+	test.NewTempWindow(t, e)
+
+	// Only the settled value is applied, not the 1 typed on the way to 12,
+	// and letters are dropped.
+	e.SetText("")
+	test.Type(e, "1a2")
+	if e.Text != "12" {
+		t.Errorf("typing 1a2 shows %q; want 12", e.Text)
+	}
+	select {
+	case n := <-applied:
+		if n != 12 {
+			t.Errorf("applied %d; want 12", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("typing 12 wasn't applied")
+	}
+
+	// Out of range and non-numbers are marked invalid and not applied.
+	for _, text := range []string{"31", "0", "x"} {
+		e.SetText(text)
+		if e.Validate() == nil {
+			t.Errorf("%q is valid; want it marked invalid", text)
+		}
+	}
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+
+	// Enter applies straight away.
+	e.SetText("7")
+	e.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	select {
+	case n := <-applied:
+		if n != 7 {
+			t.Errorf("applied %d on Enter; want 7", n)
+		}
+	default:
+		t.Fatal("Enter didn't apply 7")
+	}
+
+	time.Sleep(time.Second)
+	select {
+	case n := <-applied:
+		t.Errorf("applied %d afterwards; want nothing more", n)
+	default:
+	}
+}
+
+func TestStepper(t *testing.T) {
+	test.NewTempApp(t)
+	applied := make(chan int, 10)
+	s := ui.NewStepper(2, 1, 3, func(n int) { applied <- n })
+	test.NewTempWindow(t, s)
+	minus, plus := buttonsOf(s)
+
+	// A run of clicks applies once, and + stops at the top of the range.
+	test.Tap(plus)
+	test.Tap(plus)
+	if s.Value() != 3 || !plus.Disabled() {
+		t.Errorf("after two clicks value = %d, + disabled %v; want 3, disabled", s.Value(), plus.Disabled())
+	}
+	select {
+	case n := <-applied:
+		if n != 3 {
+			t.Errorf("applied %d; want 3", n)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("clicking + wasn't applied")
+	}
+	select {
+	case n := <-applied:
+		t.Errorf("applied %d as well; want one apply per run of clicks", n)
+	case <-time.After(time.Second):
+	}
+
+	// Narrowing the range moves the value into it straight away.
+	s.SetRange(1, 2)
+	if n := <-applied; n != 2 || s.Value() != 2 {
+		t.Errorf("after narrowing, applied %d with value %d; want 2", n, s.Value())
+	}
+	test.Tap(minus)
+	test.Tap(minus)
+	if s.Value() != 1 || !minus.Disabled() || plus.Disabled() {
+		t.Errorf("value = %d, - disabled %v, + disabled %v; want 1 with only - disabled", s.Value(), minus.Disabled(), plus.Disabled())
+	}
+}
+
+// buttonsOf returns a stepper's - and + buttons.
+func buttonsOf(s *ui.Stepper) (minus, plus *widget.Button) {
+	var found []*widget.Button
+	for _, o := range objects(s) {
+		if b, ok := o.(*widget.Button); ok {
+			found = append(found, b)
+		}
+	}
+	return found[0], found[1]
+}
+
+// bigText is the test theme with text twice the size.
+type bigText struct{ fyne.Theme }
+
+func (t bigText) Size(name fyne.ThemeSizeName) float32 {
+	if name == theme.SizeNameText {
+		return 2 * t.Theme.Size(name)
+	}
+	return t.Theme.Size(name)
+}
+
+func TestAutoSizeEntryMinSize(t *testing.T) {
+	a := test.NewTempApp(t)
+	e := ui.NewAutoSizeEntry()
+	test.NewTempWindow(t, e)
+
+	e.SetText("1234")
+	short := e.MinSize().Width
+	e.SetText("12345678")
+	long := e.MinSize().Width
+	if long <= short {
+		t.Errorf("8 digits are %v wide, 4 are %v; want 8 wider", long, short)
+	}
+
+	e.MinChars = 10
+	if w := e.MinSize().Width; w <= long {
+		t.Errorf("with MinChars 10, 8 digits are %v wide; want wider than %v", w, long)
+	}
+	e.MinChars = 0
+
+	a.Settings().SetTheme(bigText{test.Theme()})
+	if w := e.MinSize().Width; w < 1.5*long {
+		t.Errorf("at twice the text size 8 digits are %v wide, %v before; want them to grow with it", w, long)
+	}
+}
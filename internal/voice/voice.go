@@ -0,0 +1,32 @@
+// Package voice detects whether a voice app is using the microphone, so sound
+// effects can be turned down instead of playing over voice chat.
+package voice
+
+import (
+	"context"
+	"time"
+)
+
+// PollInterval is how often Watch checks the microphone.
+const PollInterval = 2 * time.Second
+
+// Watch calls onChange with whether the microphone is in use whenever that
+// changes, checking every PollInterval while enabled reports true, until ctx
+// is cancelled. It reports false as soon as enabled stops reporting true.
+func Watch(ctx context.Context, enabled func() bool, onChange func(active bool)) {
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+	var last bool
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			active := enabled() && Active()
+			if active != last {
+				last = active
+				onChange(active)
+			}
+		}
+	}
+}
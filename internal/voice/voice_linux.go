@@ -0,0 +1,57 @@
+//go:build linux
+
+package voice
+
+import (
+	"bufio"
+	"bytes"
+	"os/exec"
+	"strings"
+)
+
+// Active reports whether any app is recording from a microphone, going by
+// the recording streams PulseAudio lists; PipeWire's Pulse server lists them
+// too. Streams recording a monitor source, i.e. what's playing rather than a
+// microphone, don't count. Without pactl it always reports false.
+func Active() bool {
+	outputs, err := exec.Command("pactl", "list", "short", "source-outputs").Output()
+	if err != nil || len(bytes.TrimSpace(outputs)) == 0 {
+		return false
+	}
+	sources, err := exec.Command("pactl", "list", "short", "sources").Output()
+	if err != nil {
+		return false
+	}
+	return recording(outputs, sources)
+}
+
+// recording reports whether any of the source outputs, as listed by pactl
+// list short source-outputs, records from a source in sources (pactl list
+// short sources) that isn't a monitor.
+func recording(outputs, sources []byte) bool {
+	monitors := map[string]bool{}
+	for _, fields := range columns(sources) {
+		if len(fields) >= 2 && strings.HasSuffix(fields[1], ".monitor") {
+			monitors[fields[0]] = true
+		}
+	}
+	for _, fields := range columns(outputs) {
+		if len(fields) >= 2 && !monitors[fields[1]] {
+			return true
+		}
+	}
+	return false
+}
+
+// columns splits pactl's short listing into the tab-separated fields of each
+// line.
+func columns(out []byte) [][]string {
+	var lines [][]string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, strings.Split(line, "\t"))
+		}
+	}
+	return lines
+}
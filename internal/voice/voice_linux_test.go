@@ -0,0 +1,25 @@
+//go:build linux
+
+package voice
+
+import "testing"
+
+func TestRecording(t *testing.T) {
+	sources := []byte("0\talsa_output.pci-0000_00_1f.3.analog-stereo.monitor\tPipeWire\ts32le 2ch 48000Hz\tSUSPENDED\n" +
+		"1\talsa_input.pci-0000_00_1f.3.analog-stereo\tPipeWire\ts32le 2ch 48000Hz\tRUNNING\n")
+	tests := []struct {
+		name    string
+		outputs string
+		want    bool
+	}{
+		{"nothing recording", "", false},
+		{"microphone", "42\t1\t57\tfloat32le 1ch 48000Hz\n", true},
+		{"monitor only", "43\t0\t60\tfloat32le 2ch 48000Hz\n", false},
+		{"monitor and microphone", "43\t0\t60\tfloat32le 2ch 48000Hz\n42\t1\t57\tfloat32le 1ch 48000Hz\n", true},
+	}
+	for _, tt := range tests {
+		if got := recording([]byte(tt.outputs), sources); got != tt.want {
+			t.Errorf("%s: recording() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
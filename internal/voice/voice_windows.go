@@ -0,0 +1,45 @@
+//go:build windows
+
+package voice
+
+import (
+	"golang.org/x/sys/windows/registry"
+)
+
+// consentStore is where Windows records which apps use the microphone, the
+// same record behind the microphone icon in the taskbar. Packaged apps have
+// a key each under it; desktop apps have one each under NonPackaged.
+const consentStore = `Software\Microsoft\Windows\CurrentVersion\CapabilityAccessManager\ConsentStore\microphone`
+
+// Active reports whether any app is capturing from the microphone: one with
+// a capture session that has started but not stopped.
+func Active() bool {
+	return anyCapturing(consentStore) || anyCapturing(consentStore+`\NonPackaged`)
+}
+
+// anyCapturing reports whether any app key under path has a capture session
+// open, i.e. a start time and a stop time of 0.
+func anyCapturing(path string) bool {
+	key, err := registry.OpenKey(registry.CURRENT_USER, path, registry.ENUMERATE_SUB_KEYS)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = key.Close() }()
+	apps, err := key.ReadSubKeyNames(-1)
+	if err != nil {
+		return false
+	}
+	for _, app := range apps {
+		appKey, err := registry.OpenKey(key, app, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		start, _, errStart := appKey.GetIntegerValue("LastUsedTimeStart")
+		stop, _, errStop := appKey.GetIntegerValue("LastUsedTimeStop")
+		_ = appKey.Close()
+		if errStart == nil && errStop == nil && start > 0 && stop == 0 {
+			return true
+		}
+	}
+	return false
+}
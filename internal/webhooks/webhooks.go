@@ -0,0 +1,208 @@
+// Package webhooks posts tracker events to user-configured URLs.
+//
+// Events are queued in the database before they're sent, so a delivery
+// survives the receiving service being down, or the app being restarted,
+// and is retried with exponential backoff until it succeeds or runs out of
+// attempts.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/hooks"
+)
+
+// Request headers sent with every delivery.
+const (
+	HeaderEvent     = "X-CSST-Event"     // the event, e.g. "round_recorded"
+	HeaderDelivery  = "X-CSST-Delivery"  // the queue ID, the same across retries
+	HeaderSignature = "X-CSST-Signature" // "sha256=" and the hex HMAC of the body, if the webhook has a secret
+)
+
+const (
+	maxAttempts    = 10               // a delivery is dropped after this many failures
+	baseBackoff    = 30 * time.Second // wait after the first failure, doubled after each one
+	maxBackoff     = time.Hour
+	pollInterval   = 30 * time.Second // how often the queue is checked for retries
+	requestTimeout = 10 * time.Second
+	batchSize      = 20
+)
+
+// Dispatcher queues events for the configured webhooks and delivers them.
+type Dispatcher struct {
+	ctx    context.Context // cancelled on app shutdown
+	db     *sql.DB
+	cfg    *config.Config
+	client *http.Client
+	wake   chan struct{}
+	now    func() time.Time
+}
+
+// NewDispatcher creates a Dispatcher for cfg's webhooks, which it reads on
+// every event so edits apply immediately. Deliveries are only sent once Run
+// is started.
+func NewDispatcher(ctx context.Context, db *sql.DB, cfg *config.Config) *Dispatcher {
+	return &Dispatcher{
+		ctx:    ctx,
+		db:     db,
+		cfg:    cfg,
+		client: &http.Client{Timeout: requestTimeout},
+		wake:   make(chan struct{}, 1),
+		now:    time.Now,
+	}
+}
+
+// Enqueue queues data for every webhook subscribed to its event.
+func (d *Dispatcher) Enqueue(data hooks.Data) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("webhooks: failed to encode event: %v", err)
+		return
+	}
+	queued := false
+	for _, w := range d.cfg.Webhooks {
+		if len(w.Events) > 0 && !slices.Contains(w.Events, data.Event) {
+			continue
+		}
+		ctx, cancel := database.WithTimeout(d.ctx)
+		err := database.EnqueueDelivery(ctx, d.db, w.URL, data.Event, payload, d.now())
+		cancel()
+		if err != nil {
+			log.Printf("webhook %s: %v", w.URL, err)
+			continue
+		}
+		queued = true
+	}
+	if queued {
+		select {
+		case d.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run delivers queued events until the context is cancelled: new ones right
+// away, failed ones as their retries come due.
+func (d *Dispatcher) Run() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		d.deliverDue()
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-d.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue sends every delivery that's due, rescheduling the ones that
+// fail.
+func (d *Dispatcher) deliverDue() {
+	for d.ctx.Err() == nil {
+		ctx, cancel := database.WithTimeout(d.ctx)
+		due, err := database.DueDeliveries(ctx, d.db, d.now(), batchSize)
+		cancel()
+		if err != nil {
+			log.Printf("webhooks: %v", err)
+			return
+		}
+		for _, delivery := range due {
+			d.deliver(delivery)
+		}
+		if len(due) < batchSize {
+			return
+		}
+	}
+}
+
+// deliver sends one delivery and updates the queue with the outcome.
+func (d *Dispatcher) deliver(delivery database.Delivery) {
+	i := slices.IndexFunc(d.cfg.Webhooks, func(w config.Webhook) bool { return w.URL == delivery.URL })
+	var err error
+	if i >= 0 {
+		err = d.send(delivery, d.cfg.Webhooks[i].Secret)
+	}
+
+	ctx, cancel := database.WithTimeout(d.ctx)
+	defer cancel()
+	switch {
+	case i < 0:
+		// The webhook was removed from the config since this was queued.
+		err = database.DeleteDelivery(ctx, d.db, delivery.ID)
+	case err == nil:
+		err = database.DeleteDelivery(ctx, d.db, delivery.ID)
+	case delivery.Attempts+1 >= maxAttempts:
+		log.Printf("webhook %s: giving up on %s after %d attempts: %v", delivery.URL, delivery.Event, maxAttempts, err)
+		err = database.DeleteDelivery(ctx, d.db, delivery.ID)
+	default:
+		log.Printf("webhook %s: %v", delivery.URL, err)
+		err = database.RetryDelivery(ctx, d.db, delivery.ID, d.now().Add(backoff(delivery.Attempts+1)), err.Error())
+	}
+	if err != nil {
+		log.Printf("webhooks: %v", err)
+	}
+}
+
+// send posts a delivery. Any response other than 2xx is an error.
+func (d *Dispatcher) send(delivery database.Delivery, secret string) error {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "csstatstracker")
+	req.Header.Set(HeaderEvent, delivery.Event)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(delivery.ID))
+	if secret != "" {
+		req.Header.Set(HeaderSignature, Signature(secret, delivery.Payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Signature returns the HeaderSignature value for body: "sha256=" and the
+// hex HMAC-SHA256 of body keyed with secret.
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns how long to wait before the next attempt after the given
+// number of failures.
+func backoff(failures int) time.Duration {
+	if failures < 1 {
+		return 0
+	}
+	d := baseBackoff
+	for i := 1; i < failures && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"csstatstracker/internal/config"
+	"csstatstracker/internal/database"
+	"csstatstracker/internal/database/dbtest"
+	"csstatstracker/internal/hooks"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{4, 4 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, time.Hour},
+		{50, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := backoff(tt.failures); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestSignature(t *testing.T) {
+	// printf '%s' '{"event":"round_recorded"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=2ee9ef73041743a736e67322fa64a0f2437aeddc3959abe504eb45233cbc0295"
+	if got := Signature("secret", []byte(`{"event":"round_recorded"}`)); got != want {
+		t.Errorf("Signature() = %q, want %q", got, want)
+	}
+}
+
+// receiver is a webhook endpoint that fails its first failures requests.
+type receiver struct {
+	mu       sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   []string
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, string(body))
+	if r.failures > 0 {
+		r.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	rcv := &receiver{failures: 1}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	cfg := config.Default()
+	cfg.Webhooks = []config.Webhook{
+		{URL: server.URL + "/all", Secret: "secret"},
+		{URL: server.URL + "/streaks", Events: []string{hooks.StreakReached}},
+	}
+	now := time.Unix(1_700_000_000, 0)
+	d := NewDispatcher(ctx, db, cfg)
+	d.now = func() time.Time { return now }
+
+	d.Enqueue(hooks.Data{Event: hooks.RoundRecorded, Winner: "CT", CTScore: 1})
+	d.deliverDue()
+
+	// The only delivery failed and is waiting for its retry.
+	if len(rcv.requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(rcv.requests))
+	}
+	req := rcv.requests[0]
+	if req.URL.Path != "/all" || req.Header.Get(HeaderEvent) != hooks.RoundRecorded {
+		t.Errorf("request to %s for %q, want /all for round_recorded", req.URL.Path, req.Header.Get(HeaderEvent))
+	}
+	if got, want := req.Header.Get(HeaderSignature), Signature("secret", []byte(rcv.bodies[0])); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+	if !strings.Contains(rcv.bodies[0], `"winner":"CT"`) {
+		t.Errorf("body = %s, want the event", rcv.bodies[0])
+	}
+	d.deliverDue()
+	if len(rcv.requests) != 1 {
+		t.Fatalf("retried before the backoff")
+	}
+
+	now = now.Add(backoff(1))
+	d.deliverDue()
+	if len(rcv.requests) != 2 || rcv.requests[1].Header.Get(HeaderDelivery) != req.Header.Get(HeaderDelivery) {
+		t.Fatalf("got %d requests, want the retry of the same delivery", len(rcv.requests))
+	}
+	if n, _ := database.CountDeliveries(ctx, db); n != 0 {
+		t.Errorf("%d deliveries left in the queue, want 0", n)
+	}
+
+	// A delivery for a webhook that's since been removed is dropped.
+	d.Enqueue(hooks.Data{Event: hooks.StreakReached, Streak: 3})
+	cfg.Webhooks = cfg.Webhooks[:1]
+	d.deliverDue()
+	if len(rcv.requests) != 3 || rcv.requests[2].URL.Path != "/all" {
+		t.Errorf("got %d requests, want only the one to /all", len(rcv.requests))
+	}
+	if n, _ := database.CountDeliveries(ctx, db); n != 0 {
+		t.Errorf("%d deliveries left in the queue, want 0", n)
+	}
+}
+
+func TestDispatcherGivesUp(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.New(t)
+	rcv := &receiver{failures: maxAttempts}
+	server := httptest.NewServer(rcv)
+	defer server.Close()
+
+	cfg := config.Default()
+	cfg.Webhooks = []config.Webhook{{URL: server.URL}}
+	now := time.Unix(1_700_000_000, 0)
+	d := NewDispatcher(ctx, db, cfg)
+	d.now = func() time.Time { return now }
+
+	d.Enqueue(hooks.Data{Event: hooks.RoundRecorded})
+	for range maxAttempts {
+		d.deliverDue()
+		now = now.Add(maxBackoff)
+	}
+	if len(rcv.requests) != maxAttempts {
+		t.Errorf("got %d requests, want %d", len(rcv.requests), maxAttempts)
+	}
+	if n, _ := database.CountDeliveries(ctx, db); n != 0 {
+		t.Errorf("%d deliveries left in the queue, want 0", n)
+	}
+}
@@ -0,0 +1,3 @@
+-- Party size the player queued with: 0 = not recorded, 1 = solo ... 5 = full
+-- stack. Existing rounds predate the field and stay unrecorded.
+ALTER TABLE rounds ADD COLUMN party_size INTEGER NOT NULL DEFAULT 0;
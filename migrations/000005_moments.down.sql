@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_moments_created_at;
+DROP TABLE IF EXISTS moments;
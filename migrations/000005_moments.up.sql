@@ -0,0 +1,12 @@
+-- Notable in-round events reported by game state integration (bomb plants,
+-- aces, MVPs). They aren't tied to a round row: rounds are recorded by hand
+-- and may be edited or deleted independently.
+CREATE TABLE moments (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    map TEXT NOT NULL DEFAULT '',
+    round INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_moments_created_at ON moments(created_at);
@@ -0,0 +1,4 @@
+-- Name of the Steam account (from the config's account list) a round was
+-- played on. Empty for rounds recorded before accounts were set up.
+ALTER TABLE rounds ADD COLUMN account TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS idx_rounds_account ON rounds(account);
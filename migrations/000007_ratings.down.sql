@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ratings_created_at;
+DROP TABLE IF EXISTS ratings;
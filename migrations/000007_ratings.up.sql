@@ -0,0 +1,12 @@
+-- Rank/rating snapshots entered by the player, e.g. after a match. kind is
+-- 'premier' (value is the CS Rating) or 'competitive' (value is the skill
+-- group, 1 = Silver I ... 18 = Global Elite).
+CREATE TABLE ratings (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    kind TEXT NOT NULL,
+    value INTEGER NOT NULL,
+    account TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ratings_created_at ON ratings(created_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_next_attempt;
+DROP TABLE IF EXISTS webhook_deliveries;
@@ -0,0 +1,12 @@
+-- Per-day round counts left behind when the retention policy prunes old
+-- rounds, so totals and charts still include them. created_at is midnight
+-- UTC of the day; rounds is how many rounds the row stands for.
+CREATE TABLE round_summaries (
+    created_at DATETIME NOT NULL,
+    winner TEXT NOT NULL,
+    team TEXT NOT NULL DEFAULT '',
+    party_size INTEGER NOT NULL DEFAULT 0,
+    account TEXT NOT NULL DEFAULT '',
+    rounds INTEGER NOT NULL,
+    PRIMARY KEY (created_at, winner, team, party_size, account)
+);
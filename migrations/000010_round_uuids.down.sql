@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_rounds_uuid;
+ALTER TABLE rounds DROP COLUMN uuid;
@@ -0,0 +1,32 @@
+-- A random (version 4) UUID per round, so rounds recorded on different PCs
+-- can be merged without their ids colliding. The integer id stays the key
+-- used locally. SQLite can't add a column with an expression default, so the
+-- table is rebuilt; existing rounds get a fresh UUID each.
+CREATE TABLE rounds_new (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        substr('89ab', 1 + abs(random() % 4), 1) ||
+        substr(lower(hex(randomblob(2))), 2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    winner TEXT NOT NULL,
+    team TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+    party_size INTEGER NOT NULL DEFAULT 0,
+    account TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO rounds_new (id, winner, team, created_at, party_size, account)
+SELECT id, winner, team, created_at, party_size, account FROM rounds;
+
+DROP INDEX IF EXISTS idx_rounds_created_at;
+DROP INDEX IF EXISTS idx_rounds_account;
+DROP TABLE rounds;
+ALTER TABLE rounds_new RENAME TO rounds;
+
+CREATE INDEX IF NOT EXISTS idx_rounds_created_at ON rounds(created_at);
+CREATE INDEX IF NOT EXISTS idx_rounds_account ON rounds(account);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_rounds_uuid ON rounds(uuid);
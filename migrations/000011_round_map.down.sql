@@ -0,0 +1 @@
+ALTER TABLE rounds DROP COLUMN map;
@@ -0,0 +1,3 @@
+-- Map the round was played on, as reported by game state integration.
+-- Empty for rounds recorded without it.
+ALTER TABLE rounds ADD COLUMN map TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_sessions_started_at;
+DROP TABLE IF EXISTS sessions;
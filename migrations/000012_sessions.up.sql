@@ -0,0 +1,10 @@
+-- Stretches of time the game was running, recorded by the process watcher.
+-- ended_at is moved forward while the game runs, so a session cut short by
+-- the tracker exiting ends when it was last seen.
+CREATE TABLE sessions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_sessions_started_at ON sessions(started_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_round_outcomes_created_at;
+DROP TABLE IF EXISTS round_outcomes;
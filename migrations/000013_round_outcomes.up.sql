@@ -0,0 +1,19 @@
+-- How each round ended, as reported by game state integration: why it was
+-- won, what happened to the bomb, the side the player was on and what they
+-- had to spend. Rows are tied to a match by map and round number, like
+-- moments, since rounds aren't grouped into games.
+CREATE TABLE round_outcomes (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    map TEXT NOT NULL DEFAULT '',
+    round INTEGER NOT NULL,
+    winner TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    bomb TEXT NOT NULL DEFAULT '',
+    side TEXT NOT NULL DEFAULT '',
+    money INTEGER NOT NULL DEFAULT 0,
+    equip_value INTEGER NOT NULL DEFAULT 0,
+    account TEXT NOT NULL DEFAULT '',
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_round_outcomes_created_at ON round_outcomes(created_at);
@@ -0,0 +1,3 @@
+-- Game mode the round was played in, e.g. "premier" or "wingman". Empty for
+-- rounds recorded before modes were, which count as the default format.
+ALTER TABLE rounds ADD COLUMN mode TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE rounds DROP COLUMN t_name;
+ALTER TABLE rounds DROP COLUMN ct_name;
@@ -0,0 +1,4 @@
+-- Names the CT and T sides had when the round was recorded, e.g. "Us" and
+-- "Them". Empty for rounds recorded before they were stored.
+ALTER TABLE rounds ADD COLUMN ct_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE rounds ADD COLUMN t_name TEXT NOT NULL DEFAULT '';
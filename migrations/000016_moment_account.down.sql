@@ -0,0 +1 @@
+ALTER TABLE moments DROP COLUMN account;
@@ -0,0 +1,3 @@
+-- Name of the Steam account (from the config's account list) the moment
+-- happened on. Empty for moments recorded before it was stored.
+ALTER TABLE moments ADD COLUMN account TEXT NOT NULL DEFAULT '';